@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 )
@@ -41,6 +42,39 @@ func TestLoadAWSConfigWithProfile(t *testing.T) {
 	}
 }
 
+func TestLoadAWSConfigProfileAndRegionOverrideEnvironment(t *testing.T) {
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "env-profile")
+	t.Setenv("AWS_REGION", "us-east-1")
+	cfgDir := t.TempDir()
+
+	configPath := filepath.Join(cfgDir, "config")
+	credentialsPath := filepath.Join(cfgDir, "credentials")
+
+	writeFile(t, configPath, "[profile env-profile]\nregion = us-east-1\n[profile flag-profile]\nregion = us-west-2\n")
+	writeFile(t, credentialsPath, "[env-profile]\naws_access_key_id = ENV\naws_secret_access_key = env\n[flag-profile]\naws_access_key_id = FLAG\naws_secret_access_key = flag\n")
+
+	t.Setenv("AWS_CONFIG_FILE", configPath)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsPath)
+
+	cfg, err := LoadAWSConfig("flag-profile", "eu-west-1")
+	if err != nil {
+		t.Fatalf("LoadAWSConfig() error = %v", err)
+	}
+	if cfg.Region != "eu-west-1" {
+		t.Fatalf("expected --region to override AWS_REGION, got %q", cfg.Region)
+	}
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	if err != nil {
+		t.Fatalf("retrieve credentials: %v", err)
+	}
+	if creds.AccessKeyID != "FLAG" {
+		t.Fatalf("expected --profile to override AWS_PROFILE, got access key %q", creds.AccessKeyID)
+	}
+}
+
 func TestLoadAWSConfigMissingProfileReturnsError(t *testing.T) {
 	clearRegionEnv(t)
 	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
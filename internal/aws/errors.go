@@ -83,8 +83,14 @@ func ClassifyError(err error) ClassifiedError {
 	}
 }
 
-// FormatUserError returns a human-friendly AWS error string.
+// FormatUserError returns a human-friendly AWS error string. Errors that
+// already carry one from WrapUserError are returned as they are.
 func FormatUserError(err error) string {
+	var formatted userError
+	if errors.As(err, &formatted) {
+		return err.Error()
+	}
+
 	classified := ClassifyError(err)
 	if classified.Code != "" {
 		return fmt.Sprintf("%s (%s)", classified.Message, classified.Code)
@@ -107,3 +113,26 @@ func classifyByCode(code string) ErrorKind {
 		return ErrorKindUnknown
 	}
 }
+
+// userError is an error whose message is already FormatUserError output.
+type userError struct {
+	err error
+}
+
+func (e userError) Error() string {
+	return FormatUserError(e.err)
+}
+
+func (e userError) Unwrap() error {
+	return e.err
+}
+
+// WrapUserError returns err with the FormatUserError message, keeping err in
+// the chain so that wrapping it with %w still lets ClassifyError find the AWS
+// error code. It returns nil for a nil err.
+func WrapUserError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return userError{err: err}
+}
@@ -63,6 +63,23 @@ func TestFormatUserError(t *testing.T) {
 	}
 }
 
+func TestWrapUserErrorKeepsCodeInChain(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "ThrottlingException", Message: "slow down"}
+	err := fmt.Errorf("list stacks: %w", WrapUserError(apiErr))
+	if err.Error() != "list stacks: slow down (ThrottlingException)" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+	if code := ClassifyError(err).Code; code != "ThrottlingException" {
+		t.Fatalf("expected code to survive wrapping, got %q", code)
+	}
+	if message := FormatUserError(err); message != err.Error() {
+		t.Fatalf("expected already formatted error to be kept, got %q", message)
+	}
+	if WrapUserError(nil) != nil {
+		t.Fatal("expected nil for nil error")
+	}
+}
+
 func TestClassifiedErrorMethods(t *testing.T) {
 	baseErr := errors.New("boom")
 	withMessage := ClassifiedError{
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// configPathEnv overrides the default config file location.
+const configPathEnv = "AWSTBX_CONFIG"
+
+// aliasLinePattern matches `alias "name" = "command args"` config lines.
+var aliasLinePattern = regexp.MustCompile(`^alias\s+"([^"\s]+)"\s*=\s*"([^"]*)"$`)
+
+func configPath() string {
+	if path := strings.TrimSpace(os.Getenv(configPathEnv)); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "awstbx", "config")
+}
+
+// loadAliases reads command aliases from the config file. A missing file means
+// no aliases. Blank lines and lines starting with # are ignored. Problems with
+// the file or a single line are written to warnings and skipped, so a broken
+// config never keeps the CLI itself from running.
+func loadAliases(path string, warnings io.Writer) map[string][]string {
+	aliases := make(map[string][]string)
+	if path == "" {
+		return aliases
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return aliases
+	}
+	if err != nil {
+		fmt.Fprintf(warnings, "warning: ignoring aliases: read config %s: %v\n", path, err)
+		return aliases
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		match := aliasLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			fmt.Fprintf(warnings, "warning: ignoring config %s line %d: expected alias \"name\" = \"command\"\n", path, lineNumber)
+			continue
+		}
+		expansion, splitErr := splitShellWords(match[2])
+		if splitErr != nil {
+			fmt.Fprintf(warnings, "warning: ignoring config %s line %d: alias %q: %v\n", path, lineNumber, match[1], splitErr)
+			continue
+		}
+		if len(expansion) == 0 {
+			fmt.Fprintf(warnings, "warning: ignoring config %s line %d: alias %q has an empty command\n", path, lineNumber, match[1])
+			continue
+		}
+		aliases[match[1]] = expansion
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(warnings, "warning: ignoring aliases: read config %s: %v\n", path, err)
+		return make(map[string][]string)
+	}
+
+	return aliases
+}
+
+// dropShadowingAliases removes every alias named like a built-in command or
+// command alias of root, warning about each one. Checking all aliases up front
+// reports a bad entry as soon as the config is loaded rather than only when
+// the alias is typed.
+func dropShadowingAliases(root *cobra.Command, aliases map[string][]string, warnings io.Writer) {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if builtin, ok := builtinCommandName(root, name); ok {
+			fmt.Fprintf(warnings, "warning: ignoring alias %q: it shadows the built-in %q command\n", name, builtin)
+			delete(aliases, name)
+		}
+	}
+}
+
+// builtinCommandName returns the top-level command that name resolves to,
+// including cobra's help and completion commands that are only added when the
+// root command executes.
+func builtinCommandName(root *cobra.Command, name string) (string, bool) {
+	if name == "help" || name == "completion" {
+		return name, true
+	}
+	for _, sub := range root.Commands() {
+		if sub.Name() == name || sub.HasAlias(name) {
+			return sub.Name(), true
+		}
+	}
+	return "", false
+}
+
+// expandAlias replaces the first command word in args with its alias
+// expansion, keeping any global flags before it and arguments after it.
+func expandAlias(root *cobra.Command, args []string, aliases map[string][]string) []string {
+	if len(aliases) == 0 {
+		return args
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return args
+		}
+		if strings.HasPrefix(arg, "-") {
+			if !strings.Contains(arg, "=") && flagTakesValue(root, arg) {
+				i++
+			}
+			continue
+		}
+
+		expansion, ok := aliases[arg]
+		if !ok {
+			return args
+		}
+
+		expanded := make([]string, 0, len(args)+len(expansion)-1)
+		expanded = append(expanded, args[:i]...)
+		expanded = append(expanded, expansion...)
+		return append(expanded, args[i+1:]...)
+	}
+
+	return args
+}
+
+// splitShellWords splits an alias command the way a POSIX shell splits words:
+// on unquoted whitespace, with single quotes kept literal and backslashes
+// escaping the next character outside single quotes.
+func splitShellWords(value string) ([]string, error) {
+	words := make([]string, 0)
+	var word strings.Builder
+	inWord, inSingle, inDouble, escaped := false, false, false, false
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case inSingle:
+			if r == '\'' {
+				inSingle = false
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case inDouble:
+			if r == '"' {
+				inDouble = false
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'':
+			inSingle, inWord = true, true
+		case r == '"':
+			inDouble, inWord = true, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	switch {
+	case escaped:
+		return nil, fmt.Errorf("trailing backslash")
+	case inSingle || inDouble:
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
+
+// flagTakesValue reports whether a root persistent flag given without "="
+// consumes the next argument as its value.
+func flagTakesValue(root *cobra.Command, arg string) bool {
+	flags := root.PersistentFlags()
+	name := strings.TrimLeft(arg, "-")
+	flag := flags.Lookup(name)
+	if flag == nil && !strings.HasPrefix(arg, "--") && len(name) == 1 {
+		flag = flags.ShorthandLookup(name)
+	}
+	return flag != nil && flag.NoOptDefVal == ""
+}
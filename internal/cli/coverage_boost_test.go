@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -16,6 +17,7 @@ func TestCoverageBoostRootAndHelpDefaults(t *testing.T) {
 	oldArgs := os.Args
 	os.Args = []string{"awstbx", "--version"}
 	t.Cleanup(func() { os.Args = oldArgs })
+	t.Setenv(configPathEnv, filepath.Join(t.TempDir(), "config"))
 	if err := Execute(); err != nil {
 		t.Fatalf("Execute --version: %v", err)
 	}
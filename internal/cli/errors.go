@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+	"gopkg.in/yaml.v3"
+)
+
+type commandError struct {
+	Error commandErrorDetail `json:"error" yaml:"error"`
+}
+
+type commandErrorDetail struct {
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code" yaml:"code"`
+}
+
+// writeCommandError reports a failed command. Under --output json or yaml it
+// writes a structured error object so consumers parsing the output can parse
+// failures too; other formats keep the plain "Error: ..." line.
+func writeCommandError(w io.Writer, outputFormat string, err error) {
+	var marshal func(any) ([]byte, error)
+	switch outputFormat {
+	case "json":
+		marshal = json.Marshal
+	case "yaml":
+		marshal = yaml.Marshal
+	default:
+		fmt.Fprintf(w, "Error: %s\n", err)
+		return
+	}
+
+	code := awstbxaws.ClassifyError(err).Code
+	var partialErr *cliutil.PartialFailureError
+	if errors.As(err, &partialErr) {
+		code = "PartialFailure"
+	}
+	payload, marshalErr := marshal(commandError{Error: commandErrorDetail{
+		Message: err.Error(),
+		Code:    code,
+	}})
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %s\n", err)
+		return
+	}
+	fmt.Fprintln(w, strings.TrimRight(string(payload), "\n"))
+}
+
+// ExitCode maps a command error to the process exit code: 0 on success,
+// cliutil.ExitCodePartialFailure when --strict found failed rows, 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var partialErr *cliutil.PartialFailureError
+	if errors.As(err, &partialErr) {
+		return cliutil.ExitCodePartialFailure
+	}
+	return 1
+}
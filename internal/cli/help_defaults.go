@@ -17,6 +17,9 @@ awstbx ssm import-parameters --input-file params.json --no-confirm`),
 awstbx completion zsh > "${fpath[1]}/_awstbx"
 # macOS:
 awstbx completion zsh > $(brew --prefix)/share/zsh/site-functions/_awstbx`),
+	"awstbx profiles": strings.TrimSpace(`
+awstbx profiles
+awstbx profiles --output json`),
 	"awstbx version": strings.TrimSpace(`
 awstbx version
 awstbx --version`),
@@ -29,12 +32,45 @@ awstbx appstream delete-image --image-name image-name --no-confirm`),
 	"awstbx cloudformation": strings.TrimSpace(`
 awstbx cloudformation delete-stackset --stackset-name my-stackset --dry-run
 awstbx cloudformation find-stack-by-resource --resource i-0123456789abcdef0`),
+	"awstbx cloudformation delete-stack": strings.TrimSpace(`
+awstbx cloudformation delete-stack --stack-name my-stack --dry-run
+awstbx cloudformation delete-stack --stack-name my-stack --empty-buckets --no-confirm`),
 	"awstbx cloudformation delete-stackset": strings.TrimSpace(`
 awstbx cloudformation delete-stackset --stackset-name my-stackset --dry-run
-awstbx cloudformation delete-stackset --stackset-name my-stackset --no-confirm`),
+awstbx cloudformation delete-stackset --stackset-name my-stackset --no-confirm
+awstbx cloudformation delete-stackset --stackset-name my-stackset --no-confirm --verbose
+awstbx cloudformation delete-stackset --stackset-name my-stackset --retain-stacks --dry-run
+awstbx cloudformation delete-stackset --stackset-name my-stackset --no-confirm --poll-interval 30s --timeout 45m`),
+	"awstbx cloudformation detect-drift": strings.TrimSpace(`
+awstbx cloudformation detect-drift --stack-name my-stack
+awstbx cloudformation detect-drift --stack-name my-stack --drifted-only`),
+	"awstbx cloudformation export-template": strings.TrimSpace(`
+awstbx cloudformation export-template --stack-name my-stack
+awstbx cloudformation export-template --stack-name my-stack --out backups/my-stack
+awstbx cloudformation export-template --stack-name my-stack --stage Processed --out my-stack-processed.json`),
 	"awstbx cloudformation find-stack-by-resource": strings.TrimSpace(`
 awstbx cloudformation find-stack-by-resource --resource i-0123456789abcdef0
-awstbx cloudformation find-stack-by-resource --resource AWS::S3::Bucket --include-nested`),
+awstbx cloudformation find-stack-by-resource --resource AWS::S3::Bucket --include-nested
+awstbx cloudformation find-stack-by-resource --tag-key team --tag-value payments --exact
+awstbx cloudformation find-stack-by-resource --resource my-bucket --concurrency 10`),
+	"awstbx cloudformation generate-import": strings.TrimSpace(`
+awstbx cloudformation generate-import --stack-name my-stack --resource-type AWS::S3::Bucket --identifier my-bucket > import.json
+awstbx cloudformation generate-import --stack-name my-stack --resource-type AWS::S3::Bucket --identifier my-bucket --output-file template.json`),
+	"awstbx cloudformation get-stack-policy": strings.TrimSpace(`
+awstbx cloudformation get-stack-policy --stack-name app`),
+	"awstbx cloudformation list-stacks": strings.TrimSpace(`
+awstbx cloudformation list-stacks
+awstbx cloudformation list-stacks --status ROLLBACK_COMPLETE --status UPDATE_ROLLBACK_COMPLETE
+awstbx cloudformation list-stacks --name-contains sandbox --older-than-days 90 --include-nested`),
+	"awstbx cloudformation set-stack-policy": strings.TrimSpace(`
+awstbx cloudformation set-stack-policy --stack-name app --protect-types 'AWS::RDS::*' --dry-run
+awstbx cloudformation set-stack-policy --stack-name app --policy-file stack-policy.json`),
+	"awstbx cloudformation stack-io": strings.TrimSpace(`
+awstbx cloudformation stack-io --stack-name my-stack
+awstbx cloudformation stack-io --stack-name my-stack --outputs-only --output json`),
+	"awstbx cloudformation stack-tree": strings.TrimSpace(`
+awstbx cloudformation stack-tree --stack-name my-root-stack
+awstbx cloudformation stack-tree --stack-name my-root-stack --format mermaid`),
 	"awstbx cloudwatch": strings.TrimSpace(`
 awstbx cloudwatch count-log-groups
 awstbx cloudwatch delete-log-groups --retention-days 30 --filter-name-contains /aws/lambda --dry-run`),
@@ -53,27 +89,79 @@ awstbx cloudwatch set-retention --print-retention-counts`),
 	"awstbx ec2": strings.TrimSpace(`
 awstbx ec2 list-eips
 awstbx ec2 delete-volumes --dry-run`),
+	"awstbx ec2 audit-backup-coverage": strings.TrimSpace(`
+awstbx ec2 audit-backup-coverage --backup-tag Backup=true
+awstbx ec2 audit-backup-coverage --backup-tag Backup=daily --apply --no-confirm`),
+	"awstbx ec2 audit-requester-managed-enis": strings.TrimSpace(`
+awstbx ec2 audit-requester-managed-enis
+awstbx ec2 audit-requester-managed-enis --delete --dry-run`),
+	"awstbx ec2 cancel-spot-requests": strings.TrimSpace(`
+awstbx ec2 cancel-spot-requests --dry-run
+awstbx ec2 cancel-spot-requests --older-than 7d --no-confirm`),
+	"awstbx ec2 coverage-forecast": strings.TrimSpace(`
+awstbx ec2 coverage-forecast
+awstbx ec2 coverage-forecast --within-days 90 --output json`),
+	"awstbx ec2 create-image": strings.TrimSpace(`
+awstbx ec2 create-image --instance-id i-0123456789abcdef0 --name backup-1 --dry-run
+awstbx ec2 create-image --instance-id i-0123456789abcdef0 --name backup-1 --no-reboot --wait --no-confirm`),
 	"awstbx ec2 delete-amis": strings.TrimSpace(`
 awstbx ec2 delete-amis --retention-days 90 --dry-run
-awstbx ec2 delete-amis --unused --no-confirm`),
+awstbx ec2 delete-amis --unused --all-regions --no-confirm
+awstbx ec2 delete-amis --retention-days 90 --name-contains nightly --delete-snapshots --no-confirm`),
 	"awstbx ec2 delete-eips": strings.TrimSpace(`
 awstbx ec2 delete-eips --dry-run
-awstbx ec2 delete-eips --no-confirm`),
+awstbx ec2 delete-eips --no-confirm
+awstbx ec2 delete-eips --all-regions --dry-run`),
 	"awstbx ec2 delete-keypairs": strings.TrimSpace(`
 awstbx ec2 delete-keypairs --dry-run
 awstbx ec2 delete-keypairs --all-regions --no-confirm`),
 	"awstbx ec2 delete-security-groups": strings.TrimSpace(`
 awstbx ec2 delete-security-groups --unused --type ec2 --dry-run
-awstbx ec2 delete-security-groups --ssh-rules --no-confirm`),
+awstbx ec2 delete-security-groups --ssh-rules --no-confirm
+awstbx ec2 delete-unused-security-groups --all-regions --dry-run`),
 	"awstbx ec2 delete-snapshots": strings.TrimSpace(`
 awstbx ec2 delete-snapshots --retention-days 60 --dry-run
-awstbx ec2 delete-snapshots --no-confirm`),
+awstbx ec2 delete-snapshots --all-regions --no-confirm
+awstbx ec2 delete-snapshots --retention-days 90 --dangling=false --dry-run`),
 	"awstbx ec2 delete-volumes": strings.TrimSpace(`
 awstbx ec2 delete-volumes --dry-run
-awstbx ec2 delete-volumes --no-confirm`),
+awstbx ec2 delete-volumes --snapshot-first --no-confirm
+awstbx ec2 delete-volumes --older-than-days 30 --exclude-tag keep=true --dry-run
+awstbx ec2 delete-volumes --interactive`),
+	"awstbx ec2 find-ami-copies": strings.TrimSpace(`
+awstbx ec2 find-ami-copies --source-ami ami-0123456789abcdef0
+awstbx ec2 find-ami-copies --source-ami ami-0123456789abcdef0 --deregister-copies --dry-run`),
+	"awstbx ec2 find-ephemeral-public-ips": strings.TrimSpace(`
+awstbx ec2 find-ephemeral-public-ips
+awstbx ec2 find-ephemeral-public-ips --output json`),
+	"awstbx ec2 find-orphaned-enis": strings.TrimSpace(`
+awstbx ec2 find-orphaned-enis
+awstbx ec2 find-orphaned-enis --delete --dry-run`),
+	"awstbx ec2 find-unused-capacity-reservations": strings.TrimSpace(`
+awstbx ec2 find-unused-capacity-reservations --max-utilization 25
+awstbx ec2 find-unused-capacity-reservations --cancel --dry-run`),
 	"awstbx ec2 list-eips": strings.TrimSpace(`
 awstbx ec2 list-eips
 awstbx ec2 list-eips --output json`),
+	"awstbx ec2 list-instance-type-offerings": strings.TrimSpace(`
+awstbx ec2 list-instance-type-offerings
+awstbx ec2 list-instance-type-offerings --location-type availability-zone --location us-east-1a --type-filter 'm6i.*'`),
+	"awstbx ec2 list-instances": strings.TrimSpace(`
+awstbx ec2 list-instances
+awstbx ec2 list-instances --include-tags Owner,CostCenter --output json`),
+	"awstbx ec2 resize-instance": strings.TrimSpace(`
+awstbx ec2 resize-instance --instance-id i-0123456789abcdef0 --type m6i.large --dry-run
+awstbx ec2 resize-instance --instance-id i-0123456789abcdef0 --type m6i.large`),
+	"awstbx ec2 revoke-open-ingress": strings.TrimSpace(`
+awstbx ec2 revoke-open-ingress --dry-run
+awstbx ec2 revoke-open-ingress --ports 22,3389,5432 --no-confirm
+awstbx ec2 revoke-open-ingress --ports 22 --include-broader --dry-run`),
+	"awstbx ec2 tag-from-csv": strings.TrimSpace(`
+awstbx ec2 tag-from-csv --file tags.csv --dry-run
+awstbx ec2 tag-from-csv --file tags.csv --no-confirm --output json`),
+	"awstbx ec2 volume-report": strings.TrimSpace(`
+awstbx ec2 volume-report
+awstbx ec2 volume-report --output json`),
 	"awstbx ecs": strings.TrimSpace(`
 awstbx ecs delete-task-definitions --dry-run
 awstbx ecs publish-image --ecr-url 123456789012.dkr.ecr.us-east-1.amazonaws.com/app`),
@@ -112,10 +200,34 @@ awstbx org list-accounts --output json
 awstbx org generate-diagram --max-accounts-per-ou 8`),
 	"awstbx org assign-sso-access": strings.TrimSpace(`
 awstbx org assign-sso-access --principal-name Engineering --principal-type GROUP --permission-set-name AdministratorAccess --ou-name Sandbox
-awstbx org assign-sso-access --principal-name jane@example.com --principal-type USER --permission-set-name ReadOnlyAccess --ou-name Dev`),
+awstbx org assign-sso-access --principal-name jane@example.com --principal-type USER --permission-set-name ReadOnlyAccess --ou-name Dev
+awstbx org assign-sso-access --principal-name Auditors --permission-set-name ReadOnlyAccess --all-accounts --dry-run
+awstbx org assign-sso-access --principal-name Engineering --permission-set-name PowerUserAccess --account-ids 111111111111,222222222222`),
+	"awstbx org attach-scp": strings.TrimSpace(`
+awstbx org attach-scp --policy-name DenyLeaveOrganization --target Sandbox --dry-run
+awstbx org attach-scp --policy-name DenyLeaveOrganization --target root --no-confirm
+awstbx org attach-scp --policy-name RegionLock --target 123456789012
+awstbx org attach-scp --policy-name RegionLock --target ou-ab12-cdef3456`),
+	"awstbx org audit-root-usage": strings.TrimSpace(`
+awstbx org audit-root-usage
+awstbx org audit-root-usage --output json`),
+	"awstbx org close-account": strings.TrimSpace(`
+awstbx org close-account --account-id 123456789012 --dry-run
+awstbx org close-account --account-id 123456789012`),
+	"awstbx org create-ou": strings.TrimSpace(`
+awstbx org create-ou --name Sandbox --dry-run
+awstbx org create-ou --name Staging --parent Workloads --no-confirm`),
+	"awstbx org delete-ou": strings.TrimSpace(`
+awstbx org delete-ou --name Sandbox --dry-run
+awstbx org delete-ou --name Sandbox --no-confirm`),
+	"awstbx org detach-scp": strings.TrimSpace(`
+awstbx org detach-scp --policy-name RegionLock --target Sandbox --dry-run
+awstbx org detach-scp --policy-name RegionLock --target 123456789012 --no-confirm`),
 	"awstbx org generate-diagram": strings.TrimSpace(`
 awstbx org generate-diagram > org.mmd
-awstbx org generate-diagram --max-accounts-per-ou 10`),
+awstbx org generate-diagram --max-accounts-per-ou 10
+awstbx org generate-diagram --format dot | dot -Tsvg > org.svg
+awstbx org generate-diagram --format json > org.json`),
 	"awstbx org get-account": strings.TrimSpace(`
 awstbx org get-account --account-id 123456789012
 awstbx org get-account --account-id 123456789012 --output json`),
@@ -124,16 +236,35 @@ awstbx org import-sso-users --input-file users.csv --dry-run
 awstbx org import-sso-users --input-file users.csv --no-confirm`),
 	"awstbx org list-accounts": strings.TrimSpace(`
 awstbx org list-accounts
-awstbx org list-accounts --ou-name Sandbox,Production --output json`),
+awstbx org list-accounts --ou-name Sandbox,Production --output json
+awstbx org list-accounts --orphans
+awstbx org list-accounts --status SUSPENDED --status PENDING_CLOSURE
+awstbx org list-accounts --include-tags Owner,Environment`),
+	"awstbx org list-delegated-admins": strings.TrimSpace(`
+awstbx org list-delegated-admins
+awstbx org list-delegated-admins --service-principal guardduty.amazonaws.com --output json`),
+	"awstbx org list-scps": strings.TrimSpace(`
+awstbx org list-scps
+awstbx org list-scps --output json`),
 	"awstbx org list-sso-assignments": strings.TrimSpace(`
 awstbx org list-sso-assignments
-awstbx org list-sso-assignments --account-id 123456789012`),
+awstbx org list-sso-assignments --account-id 123456789012
+awstbx org list-sso-assignments --resolve-names=false --output json`),
+	"awstbx org move-account": strings.TrimSpace(`
+awstbx org move-account --account-id 123456789012 --dest-ou-name Sandbox --dry-run
+awstbx org move-account --account-id 123456789012 --dest-ou-name Production --no-confirm`),
 	"awstbx org remove-sso-access": strings.TrimSpace(`
 awstbx org remove-sso-access --principal-name Engineering --principal-type GROUP --permission-set-name AdministratorAccess --ou-name Sandbox --dry-run
 awstbx org remove-sso-access --principal-name Engineering --principal-type GROUP --permission-set-name AdministratorAccess --ou-name Sandbox --no-confirm`),
+	"awstbx org security-baseline-report": strings.TrimSpace(`
+awstbx org security-baseline-report
+awstbx org security-baseline-report --output json`),
 	"awstbx org set-alternate-contact": strings.TrimSpace(`
 awstbx org set-alternate-contact --input-file contacts.json --dry-run
 awstbx org set-alternate-contact --input-file contacts.json --no-confirm`),
+	"awstbx org tag-compliance": strings.TrimSpace(`
+awstbx org tag-compliance --output table
+awstbx org tag-compliance --policy-id p-0123abcd --output json`),
 	"awstbx r53": strings.TrimSpace(`
 awstbx r53 create-health-checks --domains example.com,www.example.com --dry-run
 awstbx r53 create-health-checks --domains api.example.com --no-confirm`),
@@ -143,36 +274,96 @@ awstbx r53 create-health-checks --domains api.example.com --no-confirm`),
 	"awstbx s3": strings.TrimSpace(`
 awstbx s3 search-objects --bucket-name my-bucket --keys invoice.csv,report.json
 awstbx s3 delete-buckets --empty --dry-run`),
+	"awstbx s3 audit-cors": strings.TrimSpace(`
+awstbx s3 audit-cors
+awstbx s3 audit-cors --bucket-name my-bucket --output json`),
+	"awstbx s3 audit-mfa-delete": strings.TrimSpace(`
+awstbx s3 audit-mfa-delete
+awstbx s3 audit-mfa-delete --bucket-name my-bucket --output json`),
+	"awstbx s3 bucket-size": strings.TrimSpace(`
+awstbx s3 bucket-size --bucket-name my-bucket
+awstbx s3 bucket-size --bucket-name my-bucket --prefix logs/ --group-by-prefix-depth 2`),
 	"awstbx s3 delete-buckets": strings.TrimSpace(`
 awstbx s3 delete-buckets --empty --dry-run
-awstbx s3 delete-buckets --filter-name-contains my-bucket --no-confirm`),
+awstbx s3 delete-buckets --filter-name-contains my-bucket --no-confirm
+awstbx s3 delete-buckets --filter-name-contains my-bucket --abort-uploads
+awstbx s3 delete-buckets --empty --force-versioned --dry-run
+awstbx s3 delete-buckets --empty --all-regions --dry-run
+awstbx s3 delete-buckets --filter-name-contains test --interactive`),
+	"awstbx s3 delete-objects": strings.TrimSpace(`
+awstbx s3 delete-objects --bucket-name my-bucket --keys-file keys.txt --dry-run
+awstbx s3 delete-objects --bucket-name my-bucket --keys-file keys.json --version-ids-file versions.json --no-confirm`),
+	"awstbx s3 diff-listing": strings.TrimSpace(`
+awstbx s3 diff-listing --bucket-name my-bucket --save-listing baseline.json
+awstbx s3 diff-listing --bucket-name my-bucket --baseline baseline.json --save-listing today.json`),
 	"awstbx s3 download-bucket": strings.TrimSpace(`
 awstbx s3 download-bucket --bucket-name my-bucket --prefix exports/ --output-dir ./downloads
-awstbx s3 download-bucket --bucket-name my-bucket --prefix logs/`),
+awstbx s3 download-bucket --bucket-name my-bucket --prefix logs/ --concurrency 20
+awstbx s3 download-bucket --bucket-name my-bucket --prefix logs/ --skip-existing`),
+	"awstbx s3 largest-objects": strings.TrimSpace(`
+awstbx s3 largest-objects --bucket-name my-bucket --top 20
+awstbx s3 largest-objects --all-buckets --top 5 --output json`),
+	"awstbx s3 list-buckets": strings.TrimSpace(`
+awstbx s3 list-buckets
+awstbx s3 list-buckets --with-size --concurrency 16 --output json`),
 	"awstbx s3 list-old-files": strings.TrimSpace(`
 awstbx s3 list-old-files --bucket-name my-bucket --older-than-days 90
-awstbx s3 list-old-files --bucket-name my-bucket --prefix archive/ --output json`),
+awstbx s3 list-old-files --bucket-name my-bucket --prefix archive/ --older-than 2024-01-01 --output json
+awstbx s3 list-old-files --bucket-name my-bucket --min-size-bytes 104857600 --storage-class STANDARD`),
+	"awstbx s3 restore-versions": strings.TrimSpace(`
+awstbx s3 restore-versions --bucket-name my-bucket --prefix reports/ --dry-run
+awstbx s3 restore-versions --bucket-name my-bucket --prefix reports/ --no-confirm`),
 	"awstbx s3 search-objects": strings.TrimSpace(`
 awstbx s3 search-objects --bucket-name my-bucket --keys foo.txt,bar.txt
-awstbx s3 search-objects --bucket-name my-bucket --prefix logs/ --output json`),
+awstbx s3 search-objects --bucket-name my-bucket --prefix logs/ --output json
+awstbx s3 search-objects --bucket-name my-bucket --keys 'exports/*.csv' --match-mode glob
+awstbx s3 search-objects --bucket-name my-bucket --prefix logs/ --stream > keys.ndjson`),
+	"awstbx s3 set-cors": strings.TrimSpace(`
+awstbx s3 set-cors --bucket-name my-bucket --config cors.json --dry-run
+awstbx s3 set-cors --bucket-name my-bucket --config cors.json --no-confirm`),
+	"awstbx s3 setup-replication": strings.TrimSpace(`
+awstbx s3 setup-replication --source my-bucket --dest my-bucket-dr --role-arn arn:aws:iam::123456789012:role/s3-replication --dry-run
+awstbx s3 setup-replication --source my-bucket --dest my-bucket-dr --role-arn arn:aws:iam::123456789012:role/s3-replication --no-confirm
+awstbx s3 setup-replication --source my-bucket --dest my-bucket-dr --role-arn arn:aws:iam::123456789012:role/s3-replication-v2 --force --dry-run`),
+	"awstbx s3 tiering-report": strings.TrimSpace(`
+awstbx s3 tiering-report --bucket-name my-bucket --older-than-days 30
+awstbx s3 tiering-report --bucket-name my-bucket --prefix logs/ --output json`),
 	"awstbx sagemaker": strings.TrimSpace(`
 awstbx sagemaker cleanup-spaces --domain-id d-abc123 --dry-run
 awstbx sagemaker delete-user-profile --domain-id d-abc123 --user-profile data-scientist`),
 	"awstbx sagemaker cleanup-spaces": strings.TrimSpace(`
 awstbx sagemaker cleanup-spaces --domain-id d-abc123 --spaces studio-default --dry-run
-awstbx sagemaker cleanup-spaces --domain-id d-abc123 --no-confirm`),
+awstbx sagemaker cleanup-spaces --domain-id d-abc123 --no-confirm
+awstbx sagemaker cleanup-spaces --older-than-days 30 --dry-run
+awstbx sagemaker cleanup-spaces --domain-id d-abc123 --no-confirm --wait --timeout 20m`),
+	"awstbx sagemaker delete-domain": strings.TrimSpace(`
+awstbx sagemaker delete-domain --domain-id d-abc123 --dry-run
+awstbx sagemaker delete-domain --domain-id d-abc123 --no-confirm`),
 	"awstbx sagemaker delete-user-profile": strings.TrimSpace(`
 awstbx sagemaker delete-user-profile --domain-id d-abc123 --user-profile data-scientist --dry-run
-awstbx sagemaker delete-user-profile --domain-id d-abc123 --user-profile data-scientist --no-confirm`),
+awstbx sagemaker delete-user-profile --domain-id d-abc123 --user-profile alice,bob --no-confirm
+awstbx sagemaker delete-user-profile --domain-id d-abc123 --all --dry-run`),
+	"awstbx sagemaker list-apps": strings.TrimSpace(`
+awstbx sagemaker list-apps
+awstbx sagemaker list-apps --domain-id d-abc123 --user-profile alice --output json`),
 	"awstbx ssm": strings.TrimSpace(`
 awstbx ssm import-parameters --input-file params.json --dry-run
 awstbx ssm delete-parameters --input-file params.json --no-confirm`),
 	"awstbx ssm delete-parameters": strings.TrimSpace(`
 awstbx ssm delete-parameters --input-file params.json --dry-run
-awstbx ssm delete-parameters --input-file params.json --no-confirm`),
+awstbx ssm delete-parameters --input-file params.json --no-confirm
+awstbx ssm delete-parameters --path /app/legacy --recursive --dry-run`),
+	"awstbx ssm export-parameters": strings.TrimSpace(`
+awstbx ssm export-parameters --path /app/prod --recursive
+awstbx ssm export-parameters --path /app/prod --with-decryption --format env > .env
+awstbx ssm export-parameters --path /app/prod --recursive --envelope --out params.json`),
 	"awstbx ssm import-parameters": strings.TrimSpace(`
 awstbx ssm import-parameters --input-file params.json --dry-run
-awstbx ssm import-parameters --input-file params.json --no-confirm`),
+awstbx ssm import-parameters --input-file params.json --no-confirm
+awstbx ssm import-parameters --input-file params.json --overwrite-all --no-confirm`),
+	"awstbx ssm start-session": strings.TrimSpace(`
+awstbx ssm start-session --target i-0123456789abcdef0
+awstbx ssm start-session --tag Name=bastion --dry-run`),
 }
 
 func applyCommandHelpDefaults(root *cobra.Command) {
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
@@ -21,8 +22,34 @@ import (
 	"github.com/towardsthecloud/aws-toolbox/internal/version"
 )
 
+// Execute runs the CLI with the process arguments. Errors are reported on
+// stderr before being returned, so callers only need to set the exit code.
 func Execute() error {
-	return NewRootCommand().Execute()
+	return run(NewRootCommand(), os.Args[1:])
+}
+
+func run(root *cobra.Command, args []string) error {
+	root.SilenceErrors = true
+
+	err := executeWithAliases(root, args)
+	if err != nil {
+		outputFormat, _ := root.PersistentFlags().GetString("output")
+		writeCommandError(root.ErrOrStderr(), outputFormat, err)
+	}
+	return err
+}
+
+func executeWithAliases(root *cobra.Command, args []string) error {
+	// Shell completion requests never expand aliases, and warnings written
+	// while completing would garble the shell's prompt.
+	if len(args) == 0 || (args[0] != cobra.ShellCompRequestCmd && args[0] != cobra.ShellCompNoDescRequestCmd) {
+		aliases := loadAliases(configPath(), root.ErrOrStderr())
+		dropShadowingAliases(root, aliases, root.ErrOrStderr())
+		args = expandAlias(root, args, aliases)
+	}
+	root.SetArgs(args)
+
+	return root.Execute()
 }
 
 func NewRootCommand() *cobra.Command {
@@ -34,7 +61,10 @@ func NewRootCommand() *cobra.Command {
 		Long:  "awstbx unifies AWS automation commands behind a consistent CLI and safety defaults.",
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			if _, ok := cliutil.ValidOutputFormats[opts.OutputFormat]; !ok {
-				return fmt.Errorf("invalid --output %q (valid: table, json, text)", opts.OutputFormat)
+				return fmt.Errorf("invalid --output %q (valid: table, json, yaml, text, csv)", opts.OutputFormat)
+			}
+			if opts.Limit < 0 {
+				return fmt.Errorf("--limit must be >= 0")
 			}
 			return nil
 		},
@@ -53,11 +83,16 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().StringVarP(&opts.Profile, "profile", "p", "", "AWS CLI profile name")
 	rootCmd.PersistentFlags().StringVarP(&opts.Region, "region", "r", "", "AWS region override")
 	rootCmd.PersistentFlags().BoolVar(&opts.DryRun, "dry-run", false, "Preview changes without executing")
-	rootCmd.PersistentFlags().StringVarP(&opts.OutputFormat, "output", "o", "table", "Output format: table, json, text")
+	rootCmd.PersistentFlags().StringVarP(&opts.OutputFormat, "output", "o", "table", "Output format: table, json, yaml, text, csv")
 	rootCmd.PersistentFlags().BoolVar(&opts.NoConfirm, "no-confirm", false, "Skip confirmation prompts")
 	rootCmd.PersistentFlags().BoolVar(&opts.ShowVersion, "version", false, "Print build metadata and exit")
+	rootCmd.PersistentFlags().StringSliceVar(&opts.IncludeTags, "include-tags", nil, "Comma-separated tag keys to append as columns where supported")
+	rootCmd.PersistentFlags().IntVar(&opts.Limit, "limit", 0, "Maximum number of rows to render on supported list commands (0 = no limit)")
+	rootCmd.PersistentFlags().BoolVar(&opts.Quiet, "quiet", false, "Write prompts to stderr and skip informational notes so stdout holds only results")
+	rootCmd.PersistentFlags().BoolVar(&opts.Strict, "strict", false, "Exit with code 2 when a result row's action failed or a region scan errored (tabular results only; not --stream)")
 
 	rootCmd.AddCommand(newCompletionCommand())
+	rootCmd.AddCommand(cliutil.NewProfilesCommand())
 	rootCmd.AddCommand(newVersionCommand())
 
 	rootCmd.AddCommand(appstream.NewCommand())
@@ -2,11 +2,21 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/aws/smithy-go"
 	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 	"github.com/towardsthecloud/aws-toolbox/internal/version"
+	"gopkg.in/yaml.v3"
 )
 
 func executeCommand(t *testing.T, args ...string) (string, error) {
@@ -85,6 +95,132 @@ func TestCompletionBashGeneratesScript(t *testing.T) {
 	}
 }
 
+func TestCompletionGeneratesScriptForEachShell(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		output, err := executeCommand(t, "completion", shell)
+		if err != nil {
+			t.Fatalf("execute completion %s: %v", shell, err)
+		}
+		if strings.TrimSpace(output) == "" {
+			t.Fatalf("completion %s produced no output", shell)
+		}
+	}
+}
+
+func TestExecuteExpandsConfigAlias(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config")
+	config := "# awstbx aliases\nalias \"v\" = \"version\"\nalias \"clean-test\" = \"s3 delete-buckets --filter-name-contains test\"\n"
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv(configPathEnv, configFile)
+
+	var warnings bytes.Buffer
+	aliases := loadAliases(configPath(), &warnings)
+	if warnings.Len() != 0 {
+		t.Fatalf("unexpected warnings: %s", warnings.String())
+	}
+	root := NewRootCommand()
+	expanded := expandAlias(root, []string{"--profile", "dev", "clean-test", "--dry-run"}, aliases)
+	if got := strings.Join(expanded, " "); got != "--profile dev s3 delete-buckets --filter-name-contains test --dry-run" {
+		t.Fatalf("unexpected expansion: %s", got)
+	}
+
+	// Execute resolves the alias to the underlying command.
+	oldArgs := os.Args
+	os.Args = []string{"awstbx", "v"}
+	t.Cleanup(func() { os.Args = oldArgs })
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = writer
+	execErr := Execute()
+	os.Stdout = stdout
+	_ = writer.Close()
+	output, _ := io.ReadAll(reader)
+	if execErr != nil {
+		t.Fatalf("execute alias: %v", execErr)
+	}
+	if !strings.Contains(string(output), "version:") {
+		t.Fatalf("expected version output from alias, got %q", output)
+	}
+}
+
+func TestShadowingAliasesAreDroppedWithWarning(t *testing.T) {
+	aliases := map[string][]string{"s3": {"version"}, "completion": {"version"}, "v": {"version"}}
+	var warnings bytes.Buffer
+	dropShadowingAliases(NewRootCommand(), aliases, &warnings)
+	if _, ok := aliases["v"]; !ok || len(aliases) != 1 {
+		t.Fatalf("expected only the v alias to remain, got %v", aliases)
+	}
+	if !strings.Contains(warnings.String(), `ignoring alias "completion": it shadows the built-in "completion" command`) ||
+		!strings.Contains(warnings.String(), `ignoring alias "s3": it shadows the built-in "s3" command`) {
+		t.Fatalf("unexpected warnings: %s", warnings.String())
+	}
+}
+
+func TestLoadAliasesSkipsMalformedLines(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config")
+	config := "alias clean = s3 delete-buckets\nalias \"q\" = \"s3 search-objects --key-contains 'my report' --dry-run\"\nalias \"bad\" = \"version 'open\"\n"
+	if err := os.WriteFile(configFile, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	var warnings bytes.Buffer
+	aliases := loadAliases(configFile, &warnings)
+	if got := strings.Join(aliases["q"], "|"); got != "s3|search-objects|--key-contains|my report|--dry-run" || len(aliases) != 1 {
+		t.Fatalf("unexpected aliases: %v", aliases)
+	}
+	if !strings.Contains(warnings.String(), "line 1: expected alias") || !strings.Contains(warnings.String(), `line 3: alias "bad": unterminated quote`) {
+		t.Fatalf("unexpected warnings: %s", warnings.String())
+	}
+}
+
+func TestMalformedConfigStillRunsVersionAndCompletion(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configFile, []byte("not an alias line\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv(configPathEnv, configFile)
+
+	for _, args := range [][]string{{"--version"}, {"completion", "bash"}} {
+		root := NewRootCommand()
+		var stdout, stderr bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(&stderr)
+		if err := run(root, args); err != nil {
+			t.Fatalf("run %v: %v", args, err)
+		}
+		if stdout.Len() == 0 || !strings.Contains(stderr.String(), "warning: ignoring config") {
+			t.Fatalf("expected output and a warning for %v, got stdout %q stderr %q", args, stdout.String(), stderr.String())
+		}
+	}
+}
+
+func TestSplitShellWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "s3  list-buckets", want: "s3|list-buckets"},
+		{in: `ec2 list --tag 'Name=my app'`, want: "ec2|list|--tag|Name=my app"},
+		{in: `a\ b 'it''s' ""`, want: "a b|its|"},
+	}
+	for _, tc := range tests {
+		words, err := splitShellWords(tc.in)
+		if err != nil {
+			t.Fatalf("split %q: %v", tc.in, err)
+		}
+		if got := strings.Join(words, "|"); got != tc.want {
+			t.Fatalf("split %q: want %q, got %q", tc.in, tc.want, got)
+		}
+	}
+	if _, err := splitShellWords(`trailing\`); err == nil {
+		t.Fatal("expected trailing backslash error")
+	}
+}
+
 func TestVersionCommandPrintsBuildMetadata(t *testing.T) {
 	output, err := executeCommand(t, "version")
 	if err != nil {
@@ -124,3 +260,82 @@ func walkCommandsForTest(root *cobra.Command, visit func(cmdPath string, longTex
 		walkCommandsForTest(child, visit)
 	}
 }
+
+func TestRunReportsErrorsAsJSONUnderJSONOutput(t *testing.T) {
+	t.Setenv(configPathEnv, filepath.Join(t.TempDir(), "config"))
+
+	root := NewRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(stderr)
+	if err := run(root, []string{"--output", "json", "cloudformation", "delete-stackset"}); err == nil {
+		t.Fatal("expected command error")
+	}
+
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+			Code    string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(stderr.Bytes(), &payload); err != nil {
+		t.Fatalf("expected JSON error on stderr, got %q: %v", stderr.String(), err)
+	}
+	if payload.Error.Message != "--stackset-name is required" || payload.Error.Code == "" {
+		t.Fatalf("unexpected error payload: %+v", payload)
+	}
+
+	root = NewRootCommand()
+	stderr.Reset()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(stderr)
+	if err := run(root, []string{"cloudformation", "delete-stackset"}); err == nil {
+		t.Fatal("expected command error")
+	}
+	if stderr.String() != "Error: --stackset-name is required\n" {
+		t.Fatalf("expected plain text error for table output, got %q", stderr.String())
+	}
+}
+
+func TestWriteCommandErrorKeepsAWSErrorCode(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}
+	err := fmt.Errorf("list stacks: %w", awstbxaws.WrapUserError(apiErr))
+	if err.Error() != "list stacks: not authorized (AccessDenied)" {
+		t.Fatalf("unexpected error message: %q", err.Error())
+	}
+
+	stderr := &bytes.Buffer{}
+	writeCommandError(stderr, "json", err)
+	if stderr.String() != `{"error":{"message":"list stacks: not authorized (AccessDenied)","code":"AccessDenied"}}`+"\n" {
+		t.Fatalf("unexpected JSON error: %q", stderr.String())
+	}
+
+	stderr.Reset()
+	writeCommandError(stderr, "yaml", err)
+	var payload commandError
+	if unmarshalErr := yaml.Unmarshal(stderr.Bytes(), &payload); unmarshalErr != nil {
+		t.Fatalf("expected YAML error, got %q: %v", stderr.String(), unmarshalErr)
+	}
+	if payload.Error.Code != "AccessDenied" || payload.Error.Message != "list stacks: not authorized (AccessDenied)" {
+		t.Fatalf("unexpected YAML error payload: %+v", payload)
+	}
+}
+
+func TestExitCodeMarksStrictPartialFailures(t *testing.T) {
+	partialErr := fmt.Errorf("delete buckets: %w", &cliutil.PartialFailureError{Failed: 1, Total: 4})
+	if got := ExitCode(partialErr); got != cliutil.ExitCodePartialFailure {
+		t.Fatalf("expected exit code %d for partial failure, got %d", cliutil.ExitCodePartialFailure, got)
+	}
+	if got := ExitCode(errors.New("boom")); got != 1 {
+		t.Fatalf("expected exit code 1 for other errors, got %d", got)
+	}
+	if got := ExitCode(nil); got != 0 {
+		t.Fatalf("expected exit code 0 without error, got %d", got)
+	}
+
+	stderr := &bytes.Buffer{}
+	writeCommandError(stderr, "json", partialErr)
+	if !strings.Contains(stderr.String(), `"code":"PartialFailure"`) || !strings.Contains(stderr.String(), "1 of 4 result row(s) failed") {
+		t.Fatalf("unexpected JSON error: %s", stderr.String())
+	}
+}
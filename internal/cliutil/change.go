@@ -0,0 +1,19 @@
+package cliutil
+
+// ChangeHeaders returns the columns set-commands use to show a planned change
+// to field next to the action column, so plans can be reviewed before they
+// run: current_<field>, target_<field> and <field>_change.
+func ChangeHeaders(field string) []string {
+	return []string{"current_" + field, "target_" + field, field + "_change"}
+}
+
+// ChangeColumns returns the current value, the target value, and a
+// "current -> target" diff in the order of ChangeHeaders. Values that
+// already match render as "unchanged".
+func ChangeColumns(current, target string) []string {
+	change := current + " -> " + target
+	if current == target {
+		change = "unchanged"
+	}
+	return []string{current, target, change}
+}
@@ -0,0 +1,21 @@
+package cliutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeColumns(t *testing.T) {
+	if got := ChangeColumns("7", "30"); !reflect.DeepEqual(got, []string{"7", "30", "7 -> 30"}) {
+		t.Fatalf("unexpected change columns: %v", got)
+	}
+	if got := ChangeColumns("30", "30"); got[2] != "unchanged" {
+		t.Fatalf("expected unchanged, got %v", got)
+	}
+	if got := ChangeHeaders("retention_days"); !reflect.DeepEqual(got, []string{"current_retention_days", "target_retention_days", "retention_days_change"}) {
+		t.Fatalf("unexpected change headers: %v", got)
+	}
+	if len(ChangeHeaders("x")) != len(ChangeColumns("a", "b")) {
+		t.Fatal("ChangeHeaders and ChangeColumns must stay aligned")
+	}
+}
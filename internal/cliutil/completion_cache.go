@@ -0,0 +1,86 @@
+package cliutil
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionCacheDir returns the directory holding cached completion values.
+// It is a variable so tests can point it at a temporary directory.
+var CompletionCacheDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "awstbx", "completion"), nil
+}
+
+// RememberCompletionValues merges values into the named completion cache.
+// Shell completion must stay fast and offline, so commands record names they
+// have already fetched and completion functions only read them back. Errors
+// are ignored because a missing cache only degrades completion.
+func RememberCompletionValues(name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	dir, err := CompletionCacheDir()
+	if err != nil {
+		return
+	}
+
+	merged := make(map[string]struct{})
+	for _, value := range append(readCompletionCache(dir, name), values...) {
+		if strings.TrimSpace(value) != "" {
+			merged[value] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(merged))
+	for value := range merged {
+		sorted = append(sorted, value)
+	}
+	sort.Strings(sorted)
+
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, name+".json"), data, 0o644)
+}
+
+// CachedCompletion returns a cobra completion function that offers the values
+// stored under name which start with the text being completed.
+func CachedCompletion(name string) cobra.CompletionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		dir, err := CompletionCacheDir()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		matches := make([]string, 0)
+		for _, value := range readCompletionCache(dir, name) {
+			if strings.HasPrefix(strings.ToLower(value), strings.ToLower(toComplete)) {
+				matches = append(matches, value)
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func readCompletionCache(dir, name string) []string {
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+	return values
+}
@@ -25,12 +25,24 @@ type DestructiveActionPlan struct {
 }
 
 // RunDestructiveActionPlan implements the 3-phase safety pattern:
-// empty/dry-run/confirm+execute.
+// empty/dry-run/confirm+execute. Commands registered with AddInteractiveFlag
+// first let the user narrow the rows when --interactive is set.
 func RunDestructiveActionPlan(cmd *cobra.Command, runtime CommandRuntime, plan DestructiveActionPlan) error {
 	if len(plan.Rows) == 0 {
 		return WriteDataset(cmd, runtime, plan.Headers, plan.Rows)
 	}
 
+	if interactiveRequested(cmd) {
+		selected, err := selectPlanRows(runtime, plan)
+		if err != nil {
+			return err
+		}
+		plan = selected
+		if len(plan.Rows) == 0 {
+			return WriteDataset(cmd, runtime, plan.Headers, plan.Rows)
+		}
+	}
+
 	if runtime.Options.DryRun {
 		return WriteDataset(cmd, runtime, plan.Headers, plan.Rows)
 	}
@@ -40,7 +52,12 @@ func RunDestructiveActionPlan(cmd *cobra.Command, runtime CommandRuntime, plan D
 		return err
 	}
 	if !ok {
-		SetActionForAllRows(plan.Rows, plan.ActionColumn, ActionCancelled)
+		// Rows the command already skipped keep their reason.
+		for i := range plan.Rows {
+			if !strings.HasPrefix(plan.Rows[i][plan.ActionColumn], "skipped:") {
+				plan.Rows[i][plan.ActionColumn] = ActionCancelled
+			}
+		}
 		return WriteDataset(cmd, runtime, plan.Headers, plan.Rows)
 	}
 
@@ -2,7 +2,9 @@ package cliutil
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -122,6 +124,43 @@ func TestRunDestructiveActionPlanDryRun(t *testing.T) {
 	}
 }
 
+func TestRunDestructiveActionPlanQuietKeepsPromptOffStdout(t *testing.T) {
+	root, stdout := newTestRuntimeCmd(t, "json")
+	stderr := &bytes.Buffer{}
+	root.SetErr(stderr)
+	root.SetIn(strings.NewReader("y\n"))
+	if err := root.PersistentFlags().Set("quiet", "true"); err != nil {
+		t.Fatalf("set quiet: %v", err)
+	}
+
+	runtime, err := NewCommandRuntime(root)
+	if err != nil {
+		t.Fatalf("NewCommandRuntime: %v", err)
+	}
+
+	plan := DestructiveActionPlan{
+		Headers:       []string{"id", "action"},
+		Rows:          [][]string{{"item-1", ActionPending}},
+		ActionColumn:  1,
+		ConfirmPrompt: "Delete 1 item",
+		Execute:       func(int) string { return ActionDeleted },
+	}
+
+	if err := RunDestructiveActionPlan(root, runtime, plan); err != nil {
+		t.Fatalf("RunDestructiveActionPlan: %v", err)
+	}
+	var records []map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &records); err != nil {
+		t.Fatalf("expected stdout to be only JSON: %v\n%s", err, stdout.String())
+	}
+	if len(records) != 1 || records[0]["action"] != ActionDeleted {
+		t.Fatalf("unexpected records: %#v", records)
+	}
+	if !strings.Contains(stderr.String(), "Delete 1 item [y/N]") {
+		t.Fatalf("expected prompt on stderr, got %q", stderr.String())
+	}
+}
+
 func TestRunDestructiveActionPlanEmptyRows(t *testing.T) {
 	root, _ := newTestRuntimeCmd(t, "json")
 
@@ -342,3 +381,58 @@ func TestRunDestructiveActionPlanMultipleRows(t *testing.T) {
 		t.Fatalf("expected failed:error: %s", output)
 	}
 }
+
+func TestRunDestructiveActionPlanInteractiveSelection(t *testing.T) {
+	original := StdinIsTerminal
+	t.Cleanup(func() { StdinIsTerminal = original })
+
+	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
+	AddInteractiveFlag(dummy)
+	root := NewTestRootCommand(dummy)
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader("2-3\ny\n"))
+	if err := root.PersistentFlags().Set("output", "text"); err != nil {
+		t.Fatalf("set output: %v", err)
+	}
+	if err := dummy.Flags().Set("interactive", "true"); err != nil {
+		t.Fatalf("set interactive: %v", err)
+	}
+
+	runtime, err := NewCommandRuntime(dummy)
+	if err != nil {
+		t.Fatalf("NewCommandRuntime: %v", err)
+	}
+	plan := DestructiveActionPlan{
+		Headers:       []string{"id", "action"},
+		Rows:          [][]string{{"item-1", ActionPending}, {"item-2", ActionPending}, {"item-3", ActionPending}},
+		ActionColumn:  1,
+		ConfirmPrompt: "Delete?",
+	}
+
+	StdinIsTerminal = func(io.Reader) bool { return false }
+	if err := RunDestructiveActionPlan(dummy, runtime, plan); err == nil || !strings.Contains(err.Error(), "interactive terminal") {
+		t.Fatalf("expected terminal error, got %v", err)
+	}
+
+	StdinIsTerminal = func(io.Reader) bool { return true }
+	executed := make([]int, 0)
+	plan.Execute = func(rowIndex int) string {
+		executed = append(executed, rowIndex)
+		return ActionDeleted
+	}
+	if err := RunDestructiveActionPlan(dummy, runtime, plan); err != nil {
+		t.Fatalf("RunDestructiveActionPlan: %v", err)
+	}
+	if len(executed) != 2 || executed[0] != 1 || executed[1] != 2 {
+		t.Fatalf("expected rows 1 and 2 executed, got %v", executed)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "  1) id=item-1") || strings.Contains(output, "id=item-1 action") {
+		t.Fatalf("expected item-1 listed but not acted on: %s", output)
+	}
+	if !strings.Contains(output, "id=item-3 action=deleted") {
+		t.Fatalf("expected item-3 deleted: %s", output)
+	}
+}
@@ -0,0 +1,78 @@
+package cliutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitCodePartialFailure is the process exit code for a command that ran but
+// reported failed rows or errored regions under --strict.
+const ExitCodePartialFailure = 2
+
+// actionHeader names the dataset column whose failed: values --strict counts.
+// Other columns hold resource data and are never inspected.
+const actionHeader = "action"
+
+// erroredRegionsAnnotation records on the running command how many regions
+// WriteRegionSummary reported as errored, for WriteDataset to pick up.
+const erroredRegionsAnnotation = "awstbx.errored-regions"
+
+// PartialFailureError reports that a command finished with some result rows
+// marked failed or some regions that could not be scanned. It is only
+// returned when --strict is set.
+type PartialFailureError struct {
+	Failed         int
+	Total          int
+	ErroredRegions int
+}
+
+func (e *PartialFailureError) Error() string {
+	message := fmt.Sprintf("%d of %d result row(s) failed", e.Failed, e.Total)
+	if e.ErroredRegions > 0 {
+		message += fmt.Sprintf(", %d region(s) errored", e.ErroredRegions)
+	}
+	return message
+}
+
+// countFailedRows counts rows whose action column was written by FailedAction
+// or FailedActionMessage. Datasets without an action column never fail.
+func countFailedRows(headers []string, rows [][]string) int {
+	column := -1
+	for i, header := range headers {
+		if header == actionHeader {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		return 0
+	}
+
+	failed := 0
+	for _, row := range rows {
+		if column < len(row) && strings.HasPrefix(row[column], "failed:") {
+			failed++
+		}
+	}
+	return failed
+}
+
+// recordErroredRegions adds count to the errored regions noted on cmd.
+func recordErroredRegions(cmd *cobra.Command, count int) {
+	if count == 0 {
+		return
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[erroredRegionsAnnotation] = strconv.Itoa(erroredRegions(cmd) + count)
+}
+
+// erroredRegions returns the errored regions noted on cmd.
+func erroredRegions(cmd *cobra.Command) int {
+	count, _ := strconv.Atoi(cmd.Annotations[erroredRegionsAnnotation])
+	return count
+}
@@ -0,0 +1,72 @@
+package cliutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const interactiveFlag = "interactive"
+
+// StdinIsTerminal reports whether the command input is an interactive
+// terminal. Tests replace it to drive selection from a scripted reader.
+var StdinIsTerminal = func(in io.Reader) bool {
+	file, ok := in.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// AddInteractiveFlag registers --interactive on a destructive command. When
+// set, RunDestructiveActionPlan asks which candidate rows to act on instead of
+// acting on all of them.
+func AddInteractiveFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(interactiveFlag, false, "Pick which candidates to act on from a numbered list (requires a terminal)")
+}
+
+func interactiveRequested(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup(interactiveFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// selectPlanRows narrows the plan to the rows the user picks. Execute keeps
+// receiving indexes into the original rows, so callers can index their own
+// candidate slices as usual.
+func selectPlanRows(runtime CommandRuntime, plan DestructiveActionPlan) (DestructiveActionPlan, error) {
+	if !StdinIsTerminal(runtime.Prompter.In) {
+		return plan, errors.New("--interactive requires an interactive terminal on stdin")
+	}
+
+	items := make([]string, 0, len(plan.Rows))
+	for _, row := range plan.Rows {
+		fields := make([]string, 0, len(row))
+		for i, value := range row {
+			if i != plan.ActionColumn && i < len(plan.Headers) {
+				fields = append(fields, plan.Headers[i]+"="+value)
+			}
+		}
+		items = append(items, strings.Join(fields, " "))
+	}
+
+	selected, err := runtime.Prompter.Select("Select items to act on", items)
+	if err != nil {
+		return plan, err
+	}
+
+	rows := make([][]string, 0, len(selected))
+	for _, index := range selected {
+		rows = append(rows, plan.Rows[index])
+	}
+	plan.Rows = rows
+	if execute := plan.Execute; execute != nil {
+		plan.Execute = func(rowIndex int) string {
+			return execute(selected[rowIndex])
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,173 @@
+package cliutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Credential sources reported by ListProfiles.
+const (
+	CredentialSourceStatic  = "static"
+	CredentialSourceSSO     = "sso"
+	CredentialSourceRole    = "role"
+	CredentialSourceProcess = "process"
+	CredentialSourceNone    = "none"
+)
+
+// Profile is a named profile from the shared AWS config and credentials files.
+type Profile struct {
+	Name             string
+	Region           string
+	CredentialSource string
+}
+
+// SharedConfigPaths returns the shared config and credentials file paths,
+// honouring AWS_CONFIG_FILE and AWS_SHARED_CREDENTIALS_FILE like the SDK does.
+func SharedConfigPaths() (string, string) {
+	home, _ := os.UserHomeDir()
+	configPath := os.Getenv("AWS_CONFIG_FILE")
+	if configPath == "" {
+		configPath = filepath.Join(home, ".aws", "config")
+	}
+	credentialsPath := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsPath == "" {
+		credentialsPath = filepath.Join(home, ".aws", "credentials")
+	}
+	return configPath, credentialsPath
+}
+
+// ListProfiles merges the profiles of both files, sorted by name. A missing
+// file is treated as empty.
+func ListProfiles(configPath, credentialsPath string) ([]Profile, error) {
+	configSections, err := readINISections(configPath)
+	if err != nil {
+		return nil, err
+	}
+	credentialSections, err := readINISections(credentialsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Config sections are named "profile <name>", except for "default";
+	// other section kinds such as sso-session are not profiles.
+	merged := make(map[string]map[string]string)
+	for section, values := range configSections {
+		name := section
+		if rest, ok := strings.CutPrefix(section, "profile "); ok {
+			name = strings.TrimSpace(rest)
+		} else if section != "default" {
+			continue
+		}
+		merged[name] = values
+	}
+	for name, values := range credentialSections {
+		if merged[name] == nil {
+			merged[name] = make(map[string]string)
+		}
+		for key, value := range values {
+			if _, ok := merged[name][key]; !ok {
+				merged[name][key] = value
+			}
+		}
+	}
+
+	profiles := make([]Profile, 0, len(merged))
+	for name, values := range merged {
+		profiles = append(profiles, Profile{Name: name, Region: values["region"], CredentialSource: credentialSource(values)})
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles, nil
+}
+
+// NewProfilesCommand returns the command that lists the profiles usable with
+// --profile.
+func NewProfilesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List the named profiles in the shared AWS config and credentials files",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			runtime, err := NewCommandRuntime(cmd)
+			if err != nil {
+				return err
+			}
+			profiles, err := ListProfiles(SharedConfigPaths())
+			if err != nil {
+				return err
+			}
+
+			rows := make([][]string, 0, len(profiles))
+			for _, profile := range profiles {
+				rows = append(rows, []string{profile.Name, profile.Region, profile.CredentialSource})
+			}
+			return WriteDataset(cmd, runtime, []string{"profile", "region", "credential_source"}, rows)
+		},
+		SilenceUsage: true,
+	}
+}
+
+func credentialSource(values map[string]string) string {
+	switch {
+	case values["role_arn"] != "":
+		return CredentialSourceRole
+	case values["sso_session"] != "" || values["sso_start_url"] != "":
+		return CredentialSourceSSO
+	case values["credential_process"] != "":
+		return CredentialSourceProcess
+	case values["aws_access_key_id"] != "":
+		return CredentialSourceStatic
+	}
+	return CredentialSourceNone
+}
+
+// readINISections parses the INI dialect of the shared AWS files into
+// lower-cased keys per section. Indented lines continue a nested block such
+// as s3 settings and are skipped.
+func readINISections(path string) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return sections, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var current map[string]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.Join(strings.Fields(line[1:len(line)-1]), " ")
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			current = sections[name]
+			continue
+		}
+		if current == nil || raw[0] == ' ' || raw[0] == '\t' {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			current[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return sections, nil
+}
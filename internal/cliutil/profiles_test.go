@@ -0,0 +1,78 @@
+package cliutil
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfilesListsConfigAndCredentialsProfiles(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config")
+	credentialsPath := filepath.Join(dir, "credentials")
+	config := `[default]
+region = us-east-1
+
+[profile prod]
+region = eu-west-1
+sso_session = corp
+sso_account_id = 111111111111
+
+[profile deploy]
+region = eu-central-1
+role_arn = arn:aws:iam::222222222222:role/deploy
+source_profile = default
+s3 =
+    max_concurrent_requests = 20
+
+[sso-session corp]
+sso_region = eu-west-1
+`
+	credentials := `[default]
+aws_access_key_id = AKIAEXAMPLE
+aws_secret_access_key = secret
+
+[legacy]
+aws_access_key_id = AKIAOTHER
+`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(credentialsPath, []byte(credentials), 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+	t.Setenv("AWS_CONFIG_FILE", configPath)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsPath)
+
+	root := NewTestRootCommand(NewProfilesCommand())
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"--output", "text", "profiles"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute profiles: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"profile=default region=us-east-1 credential_source=static",
+		"profile=deploy region=eu-central-1 credential_source=role",
+		"profile=legacy region= credential_source=static",
+		"profile=prod region=eu-west-1 credential_source=sso",
+	}, "\n")
+	if strings.TrimSpace(out.String()) != want {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestListProfilesIgnoresMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	profiles, err := ListProfiles(filepath.Join(dir, "config"), filepath.Join(dir, "credentials"))
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Fatalf("expected no profiles, got %v", profiles)
+	}
+}
@@ -0,0 +1,99 @@
+package cliutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/towardsthecloud/aws-toolbox/internal/output"
+)
+
+const (
+	RegionStatusSucceeded = "succeeded"
+	RegionStatusSkipped   = "skipped"
+	RegionStatusErrored   = "errored"
+)
+
+// maxRegionScanConcurrency bounds how many regions are scanned at once.
+const maxRegionScanConcurrency = 8
+
+// RegionStatus records the outcome of scanning a single region.
+type RegionStatus struct {
+	Region string
+	Status string
+	Detail string
+}
+
+// ScanRegions runs scan for every region concurrently and collects the items
+// each region returns. A region that errors is recorded as errored with the
+// error text as detail and does not abort the others. Skipped regions (e.g. not
+// opted in) are reported without being scanned.
+func ScanRegions[T any](
+	ctx context.Context,
+	regions []string,
+	skipped []string,
+	scan func(ctx context.Context, region string) ([]T, error),
+) ([]T, []RegionStatus) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		items    []T
+		statuses = make([]RegionStatus, 0, len(regions)+len(skipped))
+		limit    = make(chan struct{}, maxRegionScanConcurrency)
+	)
+
+	for _, region := range skipped {
+		statuses = append(statuses, RegionStatus{Region: region, Status: RegionStatusSkipped, Detail: "not enabled for this account"})
+	}
+
+	for _, region := range regions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			regionItems, err := scan(ctx, region)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				statuses = append(statuses, RegionStatus{Region: region, Status: RegionStatusErrored, Detail: err.Error()})
+				return
+			}
+			items = append(items, regionItems...)
+			statuses = append(statuses, RegionStatus{Region: region, Status: RegionStatusSucceeded})
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Region < statuses[j].Region
+	})
+
+	return items, statuses
+}
+
+// WriteRegionSummary writes the per-region scan outcome to stderr so that the
+// primary dataset on stdout stays machine-readable. With --quiet only errored
+// regions are reported. Errored regions make the command's next WriteDataset
+// fail under --strict.
+func WriteRegionSummary(cmd *cobra.Command, runtime CommandRuntime, statuses []RegionStatus) error {
+	rows := make([][]string, 0, len(statuses))
+	errored := 0
+	for _, status := range statuses {
+		if status.Status == RegionStatusErrored {
+			errored++
+		}
+		if runtime.Options.Quiet && status.Status != RegionStatusErrored {
+			continue
+		}
+		rows = append(rows, []string{status.Region, status.Status, status.Detail})
+	}
+	recordErroredRegions(cmd, errored)
+	if runtime.Options.Quiet && len(rows) == 0 {
+		return nil
+	}
+	return runtime.Formatter.Format(cmd.ErrOrStderr(), output.Dataset{Headers: []string{"region", "status", "detail"}, Rows: rows})
+}
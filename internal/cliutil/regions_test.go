@@ -0,0 +1,38 @@
+package cliutil
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestScanRegionsContinuesPastErrors(t *testing.T) {
+	items, statuses := ScanRegions(context.Background(), []string{"us-east-1", "eu-west-1", "ap-south-1"}, []string{"af-south-1"},
+		func(_ context.Context, region string) ([]string, error) {
+			if region == "eu-west-1" {
+				return nil, errors.New("access denied")
+			}
+			return []string{"item-" + region}, nil
+		})
+
+	sort.Strings(items)
+	if len(items) != 2 || items[0] != "item-ap-south-1" || items[1] != "item-us-east-1" {
+		t.Fatalf("unexpected items: %v", items)
+	}
+
+	want := []RegionStatus{
+		{Region: "af-south-1", Status: RegionStatusSkipped, Detail: "not enabled for this account"},
+		{Region: "ap-south-1", Status: RegionStatusSucceeded},
+		{Region: "eu-west-1", Status: RegionStatusErrored, Detail: "access denied"},
+		{Region: "us-east-1", Status: RegionStatusSucceeded},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("unexpected statuses: %#v", statuses)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("status %d = %#v, want %#v", i, statuses[i], want[i])
+		}
+	}
+}
@@ -16,13 +16,19 @@ type GlobalOptions struct {
 	OutputFormat string
 	NoConfirm    bool
 	ShowVersion  bool
+	IncludeTags  []string
+	Limit        int
+	Quiet        bool
+	Strict       bool
 }
 
 // ValidOutputFormats enumerates the allowed --output values.
 var ValidOutputFormats = map[string]struct{}{
 	"table": {},
 	"json":  {},
+	"yaml":  {},
 	"text":  {},
+	"csv":   {},
 }
 
 // CommandRuntime bundles the parsed options, formatter, and prompter for a single command invocation.
@@ -44,10 +50,16 @@ func NewCommandRuntime(cmd *cobra.Command) (CommandRuntime, error) {
 		return CommandRuntime{}, err
 	}
 
+	// With --quiet, prompts move to stderr so stdout carries only the dataset.
+	promptOut := cmd.OutOrStdout()
+	if opts.Quiet {
+		promptOut = cmd.ErrOrStderr()
+	}
+
 	return CommandRuntime{
 		Options:   opts,
 		Formatter: formatter,
-		Prompter:  confirm.NewPrompter(cmd.InOrStdin(), cmd.OutOrStdout()),
+		Prompter:  confirm.NewPrompter(cmd.InOrStdin(), promptOut),
 	}, nil
 }
 
@@ -86,6 +98,26 @@ func GlobalOptionsFromCommand(cmd *cobra.Command) (GlobalOptions, error) {
 		return GlobalOptions{}, fmt.Errorf("read --version: %w", err)
 	}
 
+	includeTags, err := pf.GetStringSlice("include-tags")
+	if err != nil {
+		return GlobalOptions{}, fmt.Errorf("read --include-tags: %w", err)
+	}
+
+	limit, err := pf.GetInt("limit")
+	if err != nil {
+		return GlobalOptions{}, fmt.Errorf("read --limit: %w", err)
+	}
+
+	quiet, err := pf.GetBool("quiet")
+	if err != nil {
+		return GlobalOptions{}, fmt.Errorf("read --quiet: %w", err)
+	}
+
+	strict, err := pf.GetBool("strict")
+	if err != nil {
+		return GlobalOptions{}, fmt.Errorf("read --strict: %w", err)
+	}
+
 	return GlobalOptions{
 		Profile:      profile,
 		Region:       region,
@@ -93,10 +125,49 @@ func GlobalOptionsFromCommand(cmd *cobra.Command) (GlobalOptions, error) {
 		OutputFormat: outputFormat,
 		NoConfirm:    noConfirm,
 		ShowVersion:  showVersion,
+		IncludeTags:  includeTags,
+		Limit:        limit,
+		Quiet:        quiet,
+		Strict:       strict,
 	}, nil
 }
 
-// WriteDataset formats a tabular dataset to the command's output.
+// WriteDataset formats a tabular dataset to the command's output. With
+// --strict, a dataset whose action column holds failed rows, or that follows
+// a WriteRegionSummary with errored regions, is still written in full and
+// then reported as a PartialFailureError.
 func WriteDataset(cmd *cobra.Command, runtime CommandRuntime, headers []string, rows [][]string) error {
-	return runtime.Formatter.Format(cmd.OutOrStdout(), output.Dataset{Headers: headers, Rows: rows})
+	if err := runtime.Formatter.Format(cmd.OutOrStdout(), output.Dataset{Headers: headers, Rows: rows}); err != nil {
+		return err
+	}
+	if runtime.Options.Strict {
+		failed, regions := countFailedRows(headers, rows), erroredRegions(cmd)
+		if failed > 0 || regions > 0 {
+			return &PartialFailureError{Failed: failed, Total: len(rows), ErroredRegions: regions}
+		}
+	}
+	return nil
+}
+
+// LimitRows caps rows at --limit. A limit of zero or less keeps every row.
+func LimitRows(runtime CommandRuntime, rows [][]string) [][]string {
+	if runtime.Options.Limit <= 0 || len(rows) <= runtime.Options.Limit {
+		return rows
+	}
+	return rows[:runtime.Options.Limit]
+}
+
+// WriteLimitedDataset writes rows capped at --limit, where total is the row
+// count before any limiting. When rows were dropped, a "showing N of M" note
+// goes to stderr so the dataset on stdout stays machine-readable; --quiet
+// drops the note.
+func WriteLimitedDataset(cmd *cobra.Command, runtime CommandRuntime, headers []string, rows [][]string, total int) error {
+	rows = LimitRows(runtime, rows)
+	if err := WriteDataset(cmd, runtime, headers, rows); err != nil {
+		return err
+	}
+	if total > len(rows) && !runtime.Options.Quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "showing %d of %d rows (--limit %d)\n", len(rows), total, runtime.Options.Limit)
+	}
+	return nil
 }
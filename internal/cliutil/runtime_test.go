@@ -57,7 +57,7 @@ func TestNewCommandRuntimeInvalidOutputFormat(t *testing.T) {
 	root.SetOut(&bytes.Buffer{})
 	root.SetErr(&bytes.Buffer{})
 
-	if err := root.PersistentFlags().Set("output", "yaml"); err != nil {
+	if err := root.PersistentFlags().Set("output", "xml"); err != nil {
 		t.Fatalf("set output: %v", err)
 	}
 
@@ -111,6 +111,135 @@ func TestWriteDataset(t *testing.T) {
 	}
 }
 
+func TestWriteLimitedDatasetCapsRows(t *testing.T) {
+	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
+	root := NewTestRootCommand(dummy)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	root.SetIn(strings.NewReader(""))
+
+	if err := root.PersistentFlags().Set("output", "text"); err != nil {
+		t.Fatalf("set output: %v", err)
+	}
+	if err := root.PersistentFlags().Set("limit", "2"); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+
+	runtime, err := NewCommandRuntime(root)
+	if err != nil {
+		t.Fatalf("NewCommandRuntime: %v", err)
+	}
+
+	rows := [][]string{{"a"}, {"b"}, {"c"}}
+	if err := WriteLimitedDataset(root, runtime, []string{"id"}, rows, len(rows)); err != nil {
+		t.Fatalf("WriteLimitedDataset: %v", err)
+	}
+	if stdout.String() != "a\nb\n" {
+		t.Fatalf("expected only the first two rows: %s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "showing 2 of 3 rows") {
+		t.Fatalf("expected limit summary, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := WriteLimitedDataset(root, runtime, []string{"id"}, rows[:2], 2); err != nil {
+		t.Fatalf("WriteLimitedDataset under limit: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no summary when nothing was dropped, got %q", stderr.String())
+	}
+
+	runtime.Options.Quiet = true
+	stdout.Reset()
+	if err := WriteLimitedDataset(root, runtime, []string{"id"}, rows, len(rows)); err != nil {
+		t.Fatalf("WriteLimitedDataset quiet: %v", err)
+	}
+	if stdout.String() != "a\nb\n" || stderr.Len() != 0 {
+		t.Fatalf("expected --quiet to drop the limit summary, got stdout %q stderr %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestWriteDatasetStrictReportsFailedRows(t *testing.T) {
+	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
+	root := NewTestRootCommand(dummy)
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader(""))
+	if err := root.PersistentFlags().Set("output", "text"); err != nil {
+		t.Fatalf("set output: %v", err)
+	}
+
+	runtime, err := NewCommandRuntime(root)
+	if err != nil {
+		t.Fatalf("NewCommandRuntime: %v", err)
+	}
+	rows := [][]string{{"a", ActionDeleted}, {"b", FailedActionMessage("AccessDenied")}, {"c", SkippedActionMessage("in-use")}}
+	if err := WriteDataset(root, runtime, []string{"id", "action"}, rows); err != nil {
+		t.Fatalf("expected failed rows to be ignored without --strict, got %v", err)
+	}
+
+	runtime.Options.Strict = true
+	stdout.Reset()
+	err = WriteDataset(root, runtime, []string{"id", "action"}, rows)
+	var partialErr *PartialFailureError
+	if !errors.As(err, &partialErr) || partialErr.Failed != 1 || partialErr.Total != 3 {
+		t.Fatalf("expected 1 of 3 rows failed, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "id=c action=skipped:in-use") {
+		t.Fatalf("expected the full dataset to be written before the error: %s", stdout.String())
+	}
+
+	if err := WriteDataset(root, runtime, []string{"id", "action"}, rows[:1]); err != nil {
+		t.Fatalf("expected no error without failed rows, got %v", err)
+	}
+
+	// Only the action column is inspected, so data that happens to start
+	// with "failed:" does not fail the command.
+	dataRows := [][]string{{"failed:report.csv", ActionDeleted}}
+	if err := WriteDataset(root, runtime, []string{"key", "action"}, dataRows); err != nil {
+		t.Fatalf("expected non-action cells to be ignored, got %v", err)
+	}
+	if err := WriteDataset(root, runtime, []string{"key", "finding"}, [][]string{{"a", "failed:denied"}}); err != nil {
+		t.Fatalf("expected datasets without an action column to be ignored, got %v", err)
+	}
+}
+
+func TestWriteDatasetStrictReportsErroredRegions(t *testing.T) {
+	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
+	root := NewTestRootCommand(dummy)
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	if err := root.PersistentFlags().Set("output", "text"); err != nil {
+		t.Fatalf("set output: %v", err)
+	}
+
+	runtime, err := NewCommandRuntime(root)
+	if err != nil {
+		t.Fatalf("NewCommandRuntime: %v", err)
+	}
+	runtime.Options.Strict = true
+	statuses := []RegionStatus{
+		{Region: "eu-west-1", Status: RegionStatusErrored, Detail: "access denied"},
+		{Region: "us-east-1", Status: RegionStatusSucceeded},
+	}
+	if err := WriteRegionSummary(root, runtime, statuses); err != nil {
+		t.Fatalf("WriteRegionSummary: %v", err)
+	}
+
+	err = WriteDataset(root, runtime, []string{"id", "action"}, [][]string{{"a", ActionDeleted}})
+	var partialErr *PartialFailureError
+	if !errors.As(err, &partialErr) || partialErr.ErroredRegions != 1 || partialErr.Failed != 0 {
+		t.Fatalf("expected one errored region, got %v", err)
+	}
+	if err.Error() != "0 of 1 result row(s) failed, 1 region(s) errored" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+}
+
 func TestWriteDatasetEmptyRows(t *testing.T) {
 	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
 	root := NewTestRootCommand(dummy)
@@ -218,6 +347,32 @@ func TestNewServiceRuntime(t *testing.T) {
 	}
 }
 
+func TestNewServiceRuntimePassesProfileAndRegionToLoader(t *testing.T) {
+	dummy := &cobra.Command{Use: "dummy", RunE: func(cmd *cobra.Command, _ []string) error {
+		var gotProfile, gotRegion string
+		_, _, err := NewServiceConfigRuntime(cmd, func(profile, region string) (awssdk.Config, error) {
+			gotProfile, gotRegion = profile, region
+			return awssdk.Config{Region: region}, nil
+		})
+		if err != nil {
+			return err
+		}
+		if gotProfile != "prod-admin" || gotRegion != "eu-central-1" {
+			t.Fatalf("expected loader to receive prod-admin/eu-central-1, got %q/%q", gotProfile, gotRegion)
+		}
+		return nil
+	}}
+	root := NewTestRootCommand(dummy)
+	root.SetIn(strings.NewReader(""))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"dummy", "--profile", "prod-admin", "--region", "eu-central-1"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+}
+
 func TestNewServiceRuntimeConfigLoadError(t *testing.T) {
 	dummy := &cobra.Command{Use: "dummy", RunE: func(*cobra.Command, []string) error { return nil }}
 	root := NewTestRootCommand(dummy)
@@ -354,4 +509,7 @@ func TestGlobalOptionsDefaultValues(t *testing.T) {
 	if opts.ShowVersion {
 		t.Fatal("expected ShowVersion to be false by default")
 	}
+	if opts.Quiet {
+		t.Fatal("expected Quiet to be false by default")
+	}
 }
@@ -0,0 +1,21 @@
+package cliutil
+
+import (
+	"context"
+	"time"
+)
+
+// SleepContext waits for d or until ctx is done, whichever comes first, and
+// returns ctx.Err() when the wait was cut short. Poll loops use it so Ctrl-C
+// or a --timeout deadline ends the wait instead of the next poll.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,10 +1,24 @@
 package cliutil
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
+// TagResolver looks up the tags attached to a single resource.
+type TagResolver interface {
+	ResourceTags(ctx context.Context, resourceID string) (map[string]string, error)
+}
+
+// TagResolverFunc adapts a plain function to the TagResolver interface.
+type TagResolverFunc func(ctx context.Context, resourceID string) (map[string]string, error)
+
+// ResourceTags calls f(ctx, resourceID).
+func (f TagResolverFunc) ResourceTags(ctx context.Context, resourceID string) (map[string]string, error) {
+	return f(ctx, resourceID)
+}
+
 // ParseTagFilter parses a "KEY=VALUE" string into its key and value parts.
 // Returns empty strings without error when raw is empty.
 func ParseTagFilter(raw string) (string, string, error) {
@@ -19,3 +33,65 @@ func ParseTagFilter(raw string) (string, string, error) {
 
 	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
 }
+
+// AppendTagColumns adds one "tag:<key>" column per requested tag key, filled by
+// resolving the tags of the resource identified in idColumn of each row.
+// Headers and rows are returned unchanged when no tag keys are requested.
+func AppendTagColumns(
+	ctx context.Context,
+	resolver TagResolver,
+	tagKeys []string,
+	headers []string,
+	rows [][]string,
+	idColumn int,
+) ([]string, [][]string, error) {
+	keys := normalizeTagKeys(tagKeys)
+	if len(keys) == 0 || resolver == nil {
+		return headers, rows, nil
+	}
+
+	extended := make([]string, 0, len(headers)+len(keys))
+	extended = append(extended, headers...)
+	for _, key := range keys {
+		extended = append(extended, "tag:"+key)
+	}
+
+	for i, row := range rows {
+		if idColumn < 0 || idColumn >= len(row) {
+			return nil, nil, fmt.Errorf("tag id column out of bounds: %d", idColumn)
+		}
+
+		var tags map[string]string
+		if resourceID := strings.TrimSpace(row[idColumn]); resourceID != "" {
+			resolved, err := resolver.ResourceTags(ctx, resourceID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("resolve tags for %s: %w", resourceID, err)
+			}
+			tags = resolved
+		}
+
+		for _, key := range keys {
+			row = append(row, tags[key])
+		}
+		rows[i] = row
+	}
+
+	return extended, rows, nil
+}
+
+func normalizeTagKeys(raw []string) []string {
+	keys := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		key := strings.TrimSpace(item)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys
+}
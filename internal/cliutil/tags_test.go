@@ -1,6 +1,11 @@
 package cliutil
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestParseTagFilter(t *testing.T) {
 	key, value, err := ParseTagFilter("")
@@ -23,3 +28,35 @@ func TestParseTagFilter(t *testing.T) {
 		t.Fatal("expected error for empty key")
 	}
 }
+
+func TestAppendTagColumns(t *testing.T) {
+	resolver := TagResolverFunc(func(_ context.Context, resourceID string) (map[string]string, error) {
+		if resourceID == "bad" {
+			return nil, errors.New("boom")
+		}
+		return map[string]string{"team": "platform-" + resourceID}, nil
+	})
+
+	headers, rows, err := AppendTagColumns(context.Background(), resolver, []string{"team", " env ", "team"}, []string{"id"}, [][]string{{"a"}, {""}}, 0)
+	if err != nil {
+		t.Fatalf("AppendTagColumns: %v", err)
+	}
+	if strings.Join(headers, ",") != "id,tag:team,tag:env" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+	if strings.Join(rows[0], ",") != "a,platform-a," || strings.Join(rows[1], ",") != ",," {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+
+	headers, _, err = AppendTagColumns(context.Background(), resolver, nil, []string{"id"}, [][]string{{"a"}}, 0)
+	if err != nil || len(headers) != 1 {
+		t.Fatalf("expected unchanged headers without tag keys: %v %v", headers, err)
+	}
+
+	if _, _, err := AppendTagColumns(context.Background(), resolver, []string{"team"}, []string{"id"}, [][]string{{"bad"}}, 0); err == nil {
+		t.Fatal("expected resolver error")
+	}
+	if _, _, err := AppendTagColumns(context.Background(), resolver, []string{"team"}, []string{"id"}, [][]string{{"a"}}, 3); err == nil {
+		t.Fatal("expected out-of-bounds error")
+	}
+}
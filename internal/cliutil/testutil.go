@@ -13,9 +13,13 @@ func NewTestRootCommand(serviceCmd *cobra.Command) *cobra.Command {
 	root.PersistentFlags().StringP("profile", "p", "", "AWS CLI profile name")
 	root.PersistentFlags().StringP("region", "r", "", "AWS region override")
 	root.PersistentFlags().Bool("dry-run", false, "Preview changes without executing")
-	root.PersistentFlags().StringP("output", "o", "table", "Output format: table, json, text")
+	root.PersistentFlags().StringP("output", "o", "table", "Output format: table, json, yaml, text, csv")
 	root.PersistentFlags().Bool("no-confirm", false, "Skip confirmation prompts")
 	root.PersistentFlags().Bool("version", false, "Print build metadata and exit")
+	root.PersistentFlags().StringSlice("include-tags", nil, "Comma-separated tag keys to append as columns where supported")
+	root.PersistentFlags().Int("limit", 0, "Maximum number of rows to render on supported list commands (0 = no limit)")
+	root.PersistentFlags().Bool("quiet", false, "Write prompts to stderr and skip informational notes so stdout holds only results")
+	root.PersistentFlags().Bool("strict", false, "Exit with code 2 when a result row's action failed or a region scan errored (tabular results only; not --stream)")
 
 	root.AddCommand(serviceCmd)
 
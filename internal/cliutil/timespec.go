@@ -0,0 +1,54 @@
+package cliutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeSpecUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseTimeSpec resolves a time flag value against the current time. See
+// ParseTimeSpecAt for the accepted forms.
+func ParseTimeSpec(spec string) (time.Time, error) {
+	return ParseTimeSpecAt(spec, time.Now().UTC())
+}
+
+// ParseTimeSpecAt resolves spec to a concrete UTC time. It accepts RFC3339
+// timestamps, plain dates (2006-01-02, midnight UTC), and relative specs such
+// as 90d, 12h, 2w, 30m, or 45s, which are subtracted from now.
+func ParseTimeSpecAt(spec string, now time.Time) (time.Time, error) {
+	value := strings.TrimSpace(spec)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time value is empty")
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.UTC(), nil
+	}
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed.UTC(), nil
+	}
+
+	unit, ok := timeSpecUnits[value[len(value)-1]]
+	if ok && len(value) > 1 {
+		amount, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && amount >= 0 {
+			// Larger amounts overflow time.Duration and wrap into the future.
+			if int64(amount) > math.MaxInt64/int64(unit) {
+				return time.Time{}, fmt.Errorf("invalid time %q: relative values can reach back at most %d%c", spec, math.MaxInt64/int64(unit), value[len(value)-1])
+			}
+			return now.UTC().Add(-time.Duration(amount) * unit), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC3339 (2006-01-02T15:04:05Z), a date (2006-01-02), or a relative value like 30d, 12h, 2w", spec)
+}
@@ -0,0 +1,84 @@
+package cliutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeSpecAtAcceptedForms(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{spec: "2024-01-02T03:04:05Z", want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{spec: "2024-01-02T03:04:05+02:00", want: time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC)},
+		{spec: "2024-01-02", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{spec: "30d", want: now.AddDate(0, 0, -30)},
+		{spec: "12h", want: now.Add(-12 * time.Hour)},
+		{spec: "2w", want: now.AddDate(0, 0, -14)},
+		{spec: "45m", want: now.Add(-45 * time.Minute)},
+		{spec: "90s", want: now.Add(-90 * time.Second)},
+		{spec: "0d", want: now},
+		{spec: "  7d  ", want: now.AddDate(0, 0, -7)},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseTimeSpecAt(tc.spec, now)
+		if err != nil {
+			t.Fatalf("ParseTimeSpecAt(%q): %v", tc.spec, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("ParseTimeSpecAt(%q) = %s, want %s", tc.spec, got, tc.want)
+		}
+		if got.Location() != time.UTC {
+			t.Fatalf("ParseTimeSpecAt(%q) returned non-UTC time %s", tc.spec, got)
+		}
+	}
+}
+
+func TestParseTimeSpecAtRejectsGarbage(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, spec := range []string{"", "   ", "d", "30", "30x", "-5d", "abc", "2024-13-01", "1.5d", "30days"} {
+		_, err := ParseTimeSpecAt(spec, now)
+		if err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+		if strings.TrimSpace(spec) != "" && !strings.Contains(err.Error(), "invalid time") {
+			t.Fatalf("unexpected error for %q: %v", spec, err)
+		}
+	}
+}
+
+func TestParseTimeSpecAtRejectsOverflowingAmounts(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, spec := range []string{"999999999w", "9999999999d", "2562048h"} {
+		_, err := ParseTimeSpecAt(spec, now)
+		if err == nil || !strings.Contains(err.Error(), "at most") {
+			t.Fatalf("expected overflow error for %q, got %v", spec, err)
+		}
+	}
+
+	got, err := ParseTimeSpecAt("15250w", now)
+	if err != nil {
+		t.Fatalf("ParseTimeSpecAt(15250w): %v", err)
+	}
+	if !got.Before(now) {
+		t.Fatalf("ParseTimeSpecAt(15250w) = %s, expected a past time", got)
+	}
+}
+
+func TestParseTimeSpecUsesCurrentTime(t *testing.T) {
+	before := time.Now().UTC().Add(-time.Hour)
+	got, err := ParseTimeSpec("1h")
+	if err != nil {
+		t.Fatalf("ParseTimeSpec: %v", err)
+	}
+	if got.Before(before.Add(-time.Second)) || got.After(time.Now().UTC()) {
+		t.Fatalf("ParseTimeSpec(1h) = %s, expected about one hour ago", got)
+	}
+}
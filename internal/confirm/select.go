@@ -0,0 +1,116 @@
+package confirm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Select shows items as a numbered list and asks which of them to act on. The
+// answer is a comma-separated list of numbers and ranges such as "1,3-5", or
+// "all". An empty answer or end of input selects nothing. Indexes are returned
+// zero-based in list order.
+func (p Prompter) Select(action string, items []string) ([]int, error) {
+	for i, item := range items {
+		if _, err := fmt.Fprintf(p.Out, "%3d) %s\n", i+1, item); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		if _, err := fmt.Fprintf(p.Out, "%s (e.g. 1,3-5 or all; empty for none): ", action); err != nil {
+			return nil, err
+		}
+
+		answer, err := readLine(p.In)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if err != nil && answer == "" {
+			return nil, nil
+		}
+
+		selected, ok := parseSelection(answer, len(items))
+		if ok {
+			return selected, nil
+		}
+		if _, err := fmt.Fprintf(p.Out, "Please enter numbers or ranges between 1 and %d, or all.\n", len(items)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseSelection resolves an answer to zero-based indexes, reporting false
+// when it names something outside 1..count or is not a number list.
+func parseSelection(answer string, count int) ([]int, bool) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "" || answer == "none" {
+		return nil, true
+	}
+	if answer == "all" {
+		selected := make([]int, count)
+		for i := range selected {
+			selected[i] = i
+		}
+		return selected, true
+	}
+
+	chosen := make([]bool, count)
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, ok := parseItemNumber(first, count)
+		if !ok {
+			return nil, false
+		}
+		end := start
+		if isRange {
+			if end, ok = parseItemNumber(last, count); !ok || end < start {
+				return nil, false
+			}
+		}
+		for i := start; i <= end; i++ {
+			chosen[i-1] = true
+		}
+	}
+
+	selected := make([]int, 0, count)
+	for i, ok := range chosen {
+		if ok {
+			selected = append(selected, i)
+		}
+	}
+	return selected, true
+}
+
+func parseItemNumber(value string, count int) (int, bool) {
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || number < 1 || number > count {
+		return 0, false
+	}
+	return number, true
+}
+
+// readLine reads one line a byte at a time so that nothing after it is
+// buffered away from a later Confirm on the same input.
+func readLine(in io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return string(line), nil
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
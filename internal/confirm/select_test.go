@@ -0,0 +1,57 @@
+package confirm
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectParsesNumbersAndRanges(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewPrompter(strings.NewReader("4, 1-2\ny\n"), &out)
+	selected, err := prompter.Select("pick", []string{"a", "b", "c", "d"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	if !reflect.DeepEqual(selected, []int{0, 1, 3}) {
+		t.Fatalf("unexpected selection: %v", selected)
+	}
+	if !strings.Contains(out.String(), "  3) c") {
+		t.Fatalf("expected numbered list in output, got %q", out.String())
+	}
+
+	// The rest of the input must stay available for a follow-up Confirm.
+	ok, err := prompter.Confirm("run action", false)
+	if err != nil || !ok {
+		t.Fatalf("expected confirm after select to read yes, got %v, %v", ok, err)
+	}
+}
+
+func TestSelectAllNoneAndReprompt(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewPrompter(strings.NewReader("7\n2-1\nall\n"), &out)
+	selected, err := prompter.Select("pick", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	if !reflect.DeepEqual(selected, []int{0, 1}) {
+		t.Fatalf("unexpected selection: %v", selected)
+	}
+	if strings.Count(out.String(), "Please enter numbers or ranges between 1 and 2") != 2 {
+		t.Fatalf("expected two reprompts, got %q", out.String())
+	}
+
+	for _, input := range []string{"\n", "none\n", ""} {
+		selected, err = NewPrompter(strings.NewReader(input), &bytes.Buffer{}).Select("pick", []string{"a"})
+		if err != nil || len(selected) != 0 {
+			t.Fatalf("expected empty selection for %q, got %v, %v", input, selected, err)
+		}
+	}
+}
+
+func TestSelectReturnsReadError(t *testing.T) {
+	if _, err := NewPrompter(&alwaysErrorReader{}, &bytes.Buffer{}).Select("pick", []string{"a"}); err == nil {
+		t.Fatal("expected read error")
+	}
+}
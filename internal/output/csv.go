@@ -0,0 +1,26 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVFormatter emits RFC 4180 CSV with a header row, quoting values that
+// contain commas, quotes, or newlines.
+type CSVFormatter struct{}
+
+func (CSVFormatter) Format(w io.Writer, data Dataset) error {
+	headers := normalizeHeaders(data.Headers, data.Rows)
+	if len(headers) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(normalizeRows(data.Rows, len(headers))); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -17,15 +17,19 @@ type Formatter interface {
 	Format(w io.Writer, data Dataset) error
 }
 
-// NewFormatter returns a formatter for table, json, or text output.
+// NewFormatter returns a formatter for table, json, yaml, text, or csv output.
 func NewFormatter(format string) (Formatter, error) {
 	switch strings.ToLower(format) {
 	case "", "table":
 		return TableFormatter{}, nil
 	case "json":
 		return JSONFormatter{}, nil
+	case "yaml":
+		return YAMLFormatter{}, nil
 	case "text":
 		return TextFormatter{}, nil
+	case "csv":
+		return CSVFormatter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported output format %q", format)
 	}
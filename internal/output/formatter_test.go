@@ -6,6 +6,8 @@ import (
 	"errors"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -17,7 +19,9 @@ func TestNewFormatter(t *testing.T) {
 		{name: "default", format: "", ok: true},
 		{name: "table", format: "table", ok: true},
 		{name: "json", format: "json", ok: true},
+		{name: "yaml", format: "yaml", ok: true},
 		{name: "text", format: "text", ok: true},
+		{name: "csv", format: "csv", ok: true},
 		{name: "invalid", format: "xml", ok: false},
 	}
 
@@ -175,3 +179,71 @@ type writeErrorWriter struct{}
 func (*writeErrorWriter) Write(_ []byte) (int, error) {
 	return 0, errors.New("write failed")
 }
+
+func TestCSVFormatterQuotesSpecialValues(t *testing.T) {
+	var buf bytes.Buffer
+	data := Dataset{
+		Headers: []string{"name", "description", "action"},
+		Rows: [][]string{
+			{"sg-1", "web, api", "deleted"},
+			{"sg-2", "line one\nline two", `failed: "quoted"`},
+			{"sg-3"},
+		},
+	}
+
+	if err := (CSVFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	expected := "name,description,action\n" +
+		"sg-1,\"web, api\",deleted\n" +
+		"sg-2,\"line one\nline two\",\"failed: \"\"quoted\"\"\"\n" +
+		"sg-3,,\n"
+	if buf.String() != expected {
+		t.Fatalf("unexpected CSV output:\n%q\nwant:\n%q", buf.String(), expected)
+	}
+}
+
+func TestYAMLFormatterRoundTripsRecords(t *testing.T) {
+	data := Dataset{
+		Headers: []string{"account_id", "name", "enabled", "note"},
+		Rows: [][]string{
+			{"012345678901", "prod: main", "true", ""},
+			{"123456789012", "sandbox", "false", "line one\nline two"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (YAMLFormatter{}).Format(&buf, data); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	var records []map[string]string
+	if err := yaml.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("YAML unmarshal error = %v\n%s", err, buf.String())
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := (JSONFormatter{}).Format(&jsonBuf, data); err != nil {
+		t.Fatalf("JSON Format() error = %v", err)
+	}
+	var jsonRecords []map[string]string
+	if err := json.Unmarshal(jsonBuf.Bytes(), &jsonRecords); err != nil {
+		t.Fatalf("JSON unmarshal error = %v", err)
+	}
+
+	if len(records) != 2 || len(records) != len(jsonRecords) {
+		t.Fatalf("unexpected records: %#v", records)
+	}
+	for i := range records {
+		for key, want := range jsonRecords[i] {
+			if records[i][key] != want {
+				t.Fatalf("record %d key %q: got %q, want %q\n%s", i, key, records[i][key], want, buf.String())
+			}
+		}
+		if len(records[i]) != len(jsonRecords[i]) {
+			t.Fatalf("record %d has keys %v, want %v", i, records[i], jsonRecords[i])
+		}
+	}
+	if !strings.Contains(buf.String(), `account_id: "012345678901"`) {
+		t.Fatalf("expected numeric-looking IDs to stay quoted strings:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter emits the same records as JSONFormatter as a YAML list of
+// maps. Values that YAML would read as numbers or booleans are quoted so
+// they stay strings.
+type YAMLFormatter struct{}
+
+func (YAMLFormatter) Format(w io.Writer, data Dataset) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(rowsAsRecords(data)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
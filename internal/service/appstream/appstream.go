@@ -63,7 +63,7 @@ func runDeleteImage(cmd *cobra.Command, name string) error {
 
 	permissions, err := listImagePermissions(cmd.Context(), client, imageName)
 	if err != nil {
-		return fmt.Errorf("list image permissions: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list image permissions: %w", awstbxaws.WrapUserError(err))
 	}
 
 	accounts := uniqueSharedAccountIDs(permissions)
@@ -2,9 +2,12 @@ package cloudformation
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
@@ -16,12 +19,21 @@ import (
 )
 
 type API interface {
+	DeleteStack(context.Context, *cloudformation.DeleteStackInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error)
 	DeleteStackInstances(context.Context, *cloudformation.DeleteStackInstancesInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error)
 	DeleteStackSet(context.Context, *cloudformation.DeleteStackSetInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackSetOutput, error)
+	DescribeStackDriftDetectionStatus(context.Context, *cloudformation.DescribeStackDriftDetectionStatusInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+	DescribeStackResourceDrifts(context.Context, *cloudformation.DescribeStackResourceDriftsInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error)
 	DescribeStackSetOperation(context.Context, *cloudformation.DescribeStackSetOperationInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error)
 	DescribeStacks(context.Context, *cloudformation.DescribeStacksInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
+	DetectStackDrift(context.Context, *cloudformation.DetectStackDriftInput, ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error)
+	GetStackPolicy(context.Context, *cloudformation.GetStackPolicyInput, ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error)
+	GetTemplate(context.Context, *cloudformation.GetTemplateInput, ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error)
+	GetTemplateSummary(context.Context, *cloudformation.GetTemplateSummaryInput, ...func(*cloudformation.Options)) (*cloudformation.GetTemplateSummaryOutput, error)
 	ListStackInstances(context.Context, *cloudformation.ListStackInstancesInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error)
 	ListStackResources(context.Context, *cloudformation.ListStackResourcesInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+	ListStackSetOperationResults(context.Context, *cloudformation.ListStackSetOperationResultsInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackSetOperationResultsOutput, error)
+	SetStackPolicy(context.Context, *cloudformation.SetStackPolicyInput, ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error)
 }
 
 type stackInstanceTarget struct {
@@ -33,29 +45,104 @@ var loadAWSConfig = awstbxaws.LoadAWSConfig
 var newClient = func(cfg awssdk.Config) API {
 	return cloudformation.NewFromConfig(cfg)
 }
-var sleep = time.Sleep
+var sleep = cliutil.SleepContext
+
+const defaultStackSetPollInterval = 5 * time.Second
 
 func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("cloudformation", "Manage CloudFormation resources")
 
+	cmd.AddCommand(newDeleteStackCommand())
 	cmd.AddCommand(newDeleteStackSetCommand())
+	cmd.AddCommand(newDetectDriftCommand())
+	cmd.AddCommand(newExportTemplateCommand())
 	cmd.AddCommand(newFindStackByResourceCommand())
+	cmd.AddCommand(newGenerateImportCommand())
+	cmd.AddCommand(newGetStackPolicyCommand())
+	cmd.AddCommand(newListStacksCommand())
+	cmd.AddCommand(newSetStackPolicyCommand())
+	cmd.AddCommand(newStackIOCommand())
+	cmd.AddCommand(newStackTreeCommand())
+
+	return cmd
+}
+
+func newDeleteStackCommand() *cobra.Command {
+	var stackName string
+	var emptyBuckets bool
+
+	cmd := &cobra.Command{
+		Use:   "delete-stack",
+		Short: "Delete a stack and wait for it to finish, optionally emptying its S3 buckets first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteStack(cmd, stackName, emptyBuckets)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+	cmd.Flags().BoolVar(&emptyBuckets, "empty-buckets", false, "Delete all objects and versions from the stack's S3 buckets before deleting the stack")
 
 	return cmd
 }
 
 func newDeleteStackSetCommand() *cobra.Command {
 	var stackSetName string
+	var verbose bool
+	var retainStacks bool
+	var pollInterval time.Duration
+	var timeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "delete-stackset",
 		Short: "Delete a stack set after removing all stack instances",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteStackSet(cmd, stackSetName)
+			return runDeleteStackSet(cmd, stackSetName, verbose, retainStacks, pollInterval, timeout)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&stackSetName, "stackset-name", "", "CloudFormation stack set name")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "Report per-target operation status on stderr while waiting")
+	cmd.Flags().BoolVar(&retainStacks, "retain-stacks", false, "Keep the stacks of each instance and only remove them from the stack set")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", defaultStackSetPollInterval, "How often to check a stack instance deletion")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up waiting on the stack instance deletions after this long in total, e.g. 30m (0 waits for the default number of polls per instance)")
+
+	return cmd
+}
+
+func newDetectDriftCommand() *cobra.Command {
+	var stackName string
+	var driftedOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "detect-drift",
+		Short: "Run drift detection on a stack and list each resource's drift status",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDetectDrift(cmd, stackName, driftedOnly)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+	cmd.Flags().BoolVar(&driftedOnly, "drifted-only", false, "Only show MODIFIED and DELETED resources")
+
+	return cmd
+}
+
+func newExportTemplateCommand() *cobra.Command {
+	var stackName string
+	var outPath string
+	var stage string
+
+	cmd := &cobra.Command{
+		Use:   "export-template",
+		Short: "Write a stack's template to stdout or a file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runExportTemplate(cmd, stackName, outPath, stage)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the template to this file instead of stdout; .json or .yaml is added when it has no extension")
+	cmd.Flags().StringVar(&stage, "stage", "Original", "Template stage: Original|Processed (Processed has transforms such as SAM expanded)")
 
 	return cmd
 }
@@ -64,27 +151,164 @@ func newFindStackByResourceCommand() *cobra.Command {
 	var resource string
 	var exact bool
 	var includeNested bool
+	var tagFilter resourceTagFilter
+	var concurrency int
 
 	cmd := &cobra.Command{
 		Use:   "find-stack-by-resource",
 		Short: "Find stacks that contain a matching resource",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runFindStackByResource(cmd, resource, exact, includeNested)
+			return runFindStackByResource(cmd, resource, exact, includeNested, tagFilter, concurrency)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&resource, "resource", "", "Resource identifier, logical ID, physical ID, or type to match")
 	cmd.Flags().BoolVar(&exact, "exact", false, "Require an exact match")
 	cmd.Flags().BoolVar(&includeNested, "include-nested", false, "Include nested stacks in the search")
+	cmd.Flags().StringVar(&tagFilter.Key, "tag-key", "", "Only match resources carrying this tag key (slower; reads every stack template)")
+	cmd.Flags().StringVar(&tagFilter.Value, "tag-value", "", "Only match resources whose --tag-key value matches; honours --exact")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 5, "Number of stacks to search in parallel")
+
+	return cmd
+}
+
+func newGenerateImportCommand() *cobra.Command {
+	var stackName string
+	var resourceType string
+	var identifier string
+	var identifierKey string
+	var logicalID string
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "generate-import",
+		Short: "Generate the resources-to-import mapping and template for importing an existing resource",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runGenerateImport(cmd, stackName, resourceType, identifier, identifierKey, logicalID, outputFile)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack to import the resource into")
+	cmd.Flags().StringVar(&resourceType, "resource-type", "", "CloudFormation resource type, e.g. AWS::S3::Bucket")
+	cmd.Flags().StringVar(&identifier, "identifier", "", "Physical identifier of the existing resource")
+	cmd.Flags().StringVar(&identifierKey, "identifier-key", "", "Import identifier property (defaults to the known key for --resource-type)")
+	cmd.Flags().StringVar(&logicalID, "logical-id", "", "Logical ID for the resource in the template (derived from --identifier by default)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the merged template to this file and print only the resources-to-import mapping")
+
+	return cmd
+}
+
+func newGetStackPolicyCommand() *cobra.Command {
+	var stackName string
+
+	cmd := &cobra.Command{
+		Use:   "get-stack-policy",
+		Short: "Print the stack policy that guards a stack's resources during updates",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runGetStackPolicy(cmd, stackName)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+
+	return cmd
+}
+
+func newListStacksCommand() *cobra.Command {
+	var statuses []string
+	var nameContains string
+	var createdBefore string
+	var olderThanDays int
+	var includeNested bool
+
+	cmd := &cobra.Command{
+		Use:   "list-stacks",
+		Short: "List stacks filtered by status, name, and age",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListStacks(cmd, statuses, nameContains, createdBefore, olderThanDays, includeNested)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only show stacks in these statuses, e.g. ROLLBACK_COMPLETE (repeatable; DELETE_COMPLETE is not supported)")
+	cmd.Flags().StringVar(&nameContains, "name-contains", "", "Only show stacks whose name contains this text")
+	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Only show stacks created before this time (RFC3339, 2006-01-02, or relative like 90d, 12h, 2w)")
+	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 0, "Only show stacks created more than this many days ago")
+	cmd.Flags().BoolVar(&includeNested, "include-nested", false, "Include nested stacks")
+	cmd.MarkFlagsMutuallyExclusive("created-before", "older-than-days")
+
+	return cmd
+}
+
+func newSetStackPolicyCommand() *cobra.Command {
+	var stackName string
+	var policyFile string
+	var protectTypes []string
+
+	cmd := &cobra.Command{
+		Use:   "set-stack-policy",
+		Short: "Set a stack policy to prevent accidental replacement or deletion of resources",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSetStackPolicy(cmd, stackName, policyFile, protectTypes)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+	cmd.Flags().StringVar(&policyFile, "policy-file", "", "Stack policy JSON file to apply")
+	cmd.Flags().StringSliceVar(&protectTypes, "protect-types", nil, "Deny replacement and deletion of resources matching these type patterns, e.g. AWS::RDS::*")
+	cmd.MarkFlagsMutuallyExclusive("policy-file", "protect-types")
+
+	return cmd
+}
+
+func newStackIOCommand() *cobra.Command {
+	var stackName string
+	var parametersOnly bool
+	var outputsOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "stack-io",
+		Short: "Show a stack's input parameters and outputs, masking NoEcho values",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStackIO(cmd, stackName, parametersOnly, outputsOnly)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Stack name or stack ID")
+	cmd.Flags().BoolVar(&parametersOnly, "parameters-only", false, "Only show parameters")
+	cmd.Flags().BoolVar(&outputsOnly, "outputs-only", false, "Only show outputs")
 
 	return cmd
 }
 
-func runDeleteStackSet(cmd *cobra.Command, name string) error {
+func newStackTreeCommand() *cobra.Command {
+	var stackName string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "stack-tree",
+		Short: "Show the nested-stack hierarchy below a root stack",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStackTree(cmd, stackName, format)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&stackName, "stack-name", "", "Root stack name or stack ID")
+	cmd.Flags().StringVar(&format, "format", "tree", "Rendering format: tree|mermaid")
+
+	return cmd
+}
+
+func runDeleteStackSet(cmd *cobra.Command, name string, verbose, retainStacks bool, pollInterval, timeout time.Duration) error {
 	stackSetName := strings.TrimSpace(name)
 	if stackSetName == "" {
 		return fmt.Errorf("--stackset-name is required")
 	}
+	if pollInterval <= 0 {
+		return fmt.Errorf("--poll-interval must be greater than 0")
+	}
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must be >= 0")
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
@@ -93,12 +317,23 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 
 	targets, err := listStackInstanceTargets(cmd.Context(), client, stackSetName)
 	if err != nil {
-		return fmt.Errorf("list stack set instances: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list stack set instances: %w", awstbxaws.WrapUserError(err))
+	}
+
+	// With --retain-stacks the instance rows say so, because the stacks
+	// outlive the stack set instead of being deleted with it.
+	instanceWouldDelete := cliutil.ActionWouldDelete
+	instanceDeleted := cliutil.ActionDeleted
+	confirmPrompt := fmt.Sprintf("Delete stack set %q and %d stack instance(s)", stackSetName, len(targets))
+	if retainStacks {
+		instanceWouldDelete += " (retaining stacks)"
+		instanceDeleted += " (stack retained)"
+		confirmPrompt = fmt.Sprintf("Delete stack set %q and %d stack instance(s), retaining their stacks", stackSetName, len(targets))
 	}
 
 	rows := make([][]string, 0, len(targets)+1)
 	for _, target := range targets {
-		action := cliutil.ActionWouldDelete
+		action := instanceWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
@@ -116,10 +351,7 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 		return cliutil.WriteDataset(cmd, runtime, []string{"stackset_name", "account", "region", "resource", "action"}, rows)
 	}
 
-	ok, err := runtime.Prompter.Confirm(
-		fmt.Sprintf("Delete stack set %q and %d stack instance(s)", stackSetName, len(targets)),
-		runtime.Options.NoConfirm,
-	)
+	ok, err := runtime.Prompter.Confirm(confirmPrompt, runtime.Options.NoConfirm)
 	if err != nil {
 		return err
 	}
@@ -130,9 +362,23 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 		return cliutil.WriteDataset(cmd, runtime, []string{"stackset_name", "account", "region", "resource", "action"}, rows)
 	}
 
+	// One deadline covers every instance operation, so --timeout bounds the
+	// whole teardown rather than each target.
+	waitCtx, cancel := cmd.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		waitCtx, cancel = context.WithTimeout(cmd.Context(), timeout)
+	}
+	defer cancel()
+
 	instanceFailure := false
 	for i, target := range targets {
-		opID, deleteErr := deleteStackSetInstanceTarget(cmd.Context(), client, stackSetName, target)
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			rows[i][4] = cliutil.SkippedActionMessage("timed out")
+			instanceFailure = true
+			continue
+		}
+
+		opID, deleteErr := deleteStackSetInstanceTarget(cmd.Context(), client, stackSetName, target, retainStacks)
 		if deleteErr != nil {
 			rows[i][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
 			instanceFailure = true
@@ -140,7 +386,18 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 		}
 
 		if opID != "" {
-			waitErr := waitForStackSetOperation(cmd.Context(), client, stackSetName, opID)
+			var progress func()
+			if verbose {
+				progress = func() {
+					reportStackSetOperationResults(cmd.Context(), cmd.ErrOrStderr(), client, stackSetName, opID)
+				}
+			}
+			waitErr := waitForStackSetOperation(waitCtx, client, stackSetName, opID, pollInterval, progress)
+			if errors.Is(waitErr, context.DeadlineExceeded) {
+				rows[i][4] = cliutil.FailedActionMessage("timed out")
+				instanceFailure = true
+				continue
+			}
 			if waitErr != nil {
 				rows[i][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
 				instanceFailure = true
@@ -148,7 +405,7 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 			}
 		}
 
-		rows[i][4] = cliutil.ActionDeleted
+		rows[i][4] = instanceDeleted
 	}
 
 	if instanceFailure {
@@ -166,10 +423,18 @@ func runDeleteStackSet(cmd *cobra.Command, name string) error {
 	return cliutil.WriteDataset(cmd, runtime, []string{"stackset_name", "account", "region", "resource", "action"}, rows)
 }
 
-func runFindStackByResource(cmd *cobra.Command, resource string, exact, includeNested bool) error {
+func runFindStackByResource(cmd *cobra.Command, resource string, exact, includeNested bool, tagFilter resourceTagFilter, concurrency int) error {
 	query := strings.TrimSpace(resource)
-	if query == "" {
-		return fmt.Errorf("--resource is required")
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	tagFilter.Key = strings.TrimSpace(tagFilter.Key)
+	tagFilter.Value = strings.TrimSpace(tagFilter.Value)
+	if tagFilter.Value != "" && !tagFilter.enabled() {
+		return fmt.Errorf("--tag-value requires --tag-key")
+	}
+	if query == "" && !tagFilter.enabled() {
+		return fmt.Errorf("--resource is required unless --tag-key is set")
 	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
@@ -179,28 +444,34 @@ func runFindStackByResource(cmd *cobra.Command, resource string, exact, includeN
 
 	stacks, err := listStacksForSearch(cmd.Context(), client, includeNested)
 	if err != nil {
-		return fmt.Errorf("list stacks: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list stacks: %w", awstbxaws.WrapUserError(err))
 	}
 
-	rows := make([][]string, 0)
-	for _, stack := range stacks {
-		resources, listErr := listStackResources(cmd.Context(), client, cliutil.PointerToString(stack.StackName))
-		if listErr != nil {
-			return fmt.Errorf("list resources for stack %s: %s", cliutil.PointerToString(stack.StackName), awstbxaws.FormatUserError(listErr))
-		}
+	// Stacks are searched concurrently; each worker fills only its own slot,
+	// and the first failure in stack-name order is reported so the error is
+	// deterministic.
+	matches := make([][][]string, len(stacks))
+	errs := make([]error, len(stacks))
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, concurrency)
+	for i, stack := range stacks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			matches[i], errs[i] = findStackMatches(cmd.Context(), client, stack, query, exact, tagFilter)
+		}()
+	}
+	wg.Wait()
 
-		for _, item := range resources {
-			if !stackResourceMatches(item, query, exact) {
-				continue
-			}
-			rows = append(rows, []string{
-				cliutil.PointerToString(stack.StackName),
-				cliutil.PointerToString(item.LogicalResourceId),
-				cliutil.PointerToString(item.PhysicalResourceId),
-				cliutil.PointerToString(item.ResourceType),
-				string(item.ResourceStatus),
-			})
+	rows := make([][]string, 0)
+	for i := range stacks {
+		if errs[i] != nil {
+			return errs[i]
 		}
+		rows = append(rows, matches[i]...)
 	}
 
 	sort.Slice(rows, func(i, j int) bool {
@@ -213,6 +484,48 @@ func runFindStackByResource(cmd *cobra.Command, resource string, exact, includeN
 	return cliutil.WriteDataset(cmd, runtime, []string{"stack_name", "logical_id", "physical_id", "resource_type", "status"}, rows)
 }
 
+// findStackMatches returns a row for every resource in the stack that matches
+// the query and, when set, the tag filter.
+func findStackMatches(ctx context.Context, client API, stack cloudformationtypes.Stack, query string, exact bool, tagFilter resourceTagFilter) ([][]string, error) {
+	stackName := cliutil.PointerToString(stack.StackName)
+	resources, err := listStackResources(ctx, client, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("list resources for stack %s: %w", stackName, awstbxaws.WrapUserError(err))
+	}
+
+	// Tags cost a template read per stack, so they are only fetched when a
+	// tag filter is set.
+	var stackTags map[string]string
+	var templateTags map[string]map[string]string
+	if tagFilter.enabled() {
+		stackTags, templateTags, err = stackResourceTags(ctx, client, stack)
+		if err != nil {
+			return nil, fmt.Errorf("read tags for stack %s: %w", stackName, awstbxaws.WrapUserError(err))
+		}
+	}
+
+	rows := make([][]string, 0)
+	for _, item := range resources {
+		if query != "" && !stackResourceMatches(item, query, exact) {
+			continue
+		}
+		if tagFilter.enabled() {
+			tags := mergedResourceTags(stackTags, templateTags[cliutil.PointerToString(item.LogicalResourceId)])
+			if !resourceTagsMatch(tags, tagFilter, exact) {
+				continue
+			}
+		}
+		rows = append(rows, []string{
+			stackName,
+			cliutil.PointerToString(item.LogicalResourceId),
+			cliutil.PointerToString(item.PhysicalResourceId),
+			cliutil.PointerToString(item.ResourceType),
+			string(item.ResourceStatus),
+		})
+	}
+	return rows, nil
+}
+
 func listStackInstanceTargets(ctx context.Context, client API, stackSetName string) ([]stackInstanceTarget, error) {
 	targets := make([]stackInstanceTarget, 0)
 	seen := make(map[string]struct{})
@@ -258,12 +571,12 @@ func listStackInstanceTargets(ctx context.Context, client API, stackSetName stri
 	return targets, nil
 }
 
-func deleteStackSetInstanceTarget(ctx context.Context, client API, stackSetName string, target stackInstanceTarget) (string, error) {
+func deleteStackSetInstanceTarget(ctx context.Context, client API, stackSetName string, target stackInstanceTarget, retainStacks bool) (string, error) {
 	resp, err := client.DeleteStackInstances(ctx, &cloudformation.DeleteStackInstancesInput{
 		StackSetName: cliutil.Ptr(stackSetName),
 		Accounts:     []string{target.Account},
 		Regions:      []string{target.Region},
-		RetainStacks: cliutil.Ptr(false),
+		RetainStacks: cliutil.Ptr(retainStacks),
 	})
 	if err != nil {
 		return "", err
@@ -272,10 +585,13 @@ func deleteStackSetInstanceTarget(ctx context.Context, client API, stackSetName
 	return strings.TrimSpace(cliutil.PointerToString(resp.OperationId)), nil
 }
 
-func waitForStackSetOperation(ctx context.Context, client API, stackSetName, operationID string) error {
+// waitForStackSetOperation polls an operation until it finishes. progress, when
+// set, runs after every poll, including the final one. A context deadline
+// replaces the attempt limit, so --timeout alone decides how long to wait.
+func waitForStackSetOperation(ctx context.Context, client API, stackSetName, operationID string, pollInterval time.Duration, progress func()) error {
 	const maxAttempts = 360
-	const pollInterval = 5 * time.Second
-	for range maxAttempts {
+	_, hasDeadline := ctx.Deadline()
+	for attempt := 0; hasDeadline || attempt < maxAttempts; attempt++ {
 		resp, err := client.DescribeStackSetOperation(ctx, &cloudformation.DescribeStackSetOperationInput{
 			StackSetName: cliutil.Ptr(stackSetName),
 			OperationId:  cliutil.Ptr(operationID),
@@ -283,6 +599,9 @@ func waitForStackSetOperation(ctx context.Context, client API, stackSetName, ope
 		if err != nil {
 			return err
 		}
+		if progress != nil {
+			progress()
+		}
 
 		status := resp.StackSetOperation.Status
 		switch status {
@@ -296,17 +615,53 @@ func waitForStackSetOperation(ctx context.Context, client API, stackSetName, ope
 			return fmt.Errorf("stack set operation %s", status)
 		}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			sleep(pollInterval)
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
 		}
 	}
 
 	return fmt.Errorf("timed out waiting for stack set operation %s", operationID)
 }
 
+// reportStackSetOperationResults writes one progress line per account/region
+// target of an operation, so a slow or failing target in a large teardown is
+// visible while waiting. Listing errors are reported rather than returned
+// because progress is informational.
+func reportStackSetOperationResults(ctx context.Context, w io.Writer, client API, stackSetName, operationID string) {
+	results, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[cloudformationtypes.StackSetOperationResultSummary], error) {
+		page, listErr := client.ListStackSetOperationResults(callCtx, &cloudformation.ListStackSetOperationResultsInput{
+			StackSetName: cliutil.Ptr(stackSetName),
+			OperationId:  cliutil.Ptr(operationID),
+			NextToken:    nextToken,
+		})
+		if listErr != nil {
+			return awstbxaws.PageResult[cloudformationtypes.StackSetOperationResultSummary]{}, listErr
+		}
+		return awstbxaws.PageResult[cloudformationtypes.StackSetOperationResultSummary]{
+			Items:     page.Summaries,
+			NextToken: page.NextToken,
+		}, nil
+	})
+	if err != nil {
+		fmt.Fprintf(w, "progress operation=%s results unavailable: %s\n", operationID, awstbxaws.FormatUserError(err))
+		return
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		left := cliutil.PointerToString(results[i].Account) + "/" + cliutil.PointerToString(results[i].Region)
+		right := cliutil.PointerToString(results[j].Account) + "/" + cliutil.PointerToString(results[j].Region)
+		return left < right
+	})
+	for _, result := range results {
+		line := fmt.Sprintf("progress operation=%s account=%s region=%s status=%s",
+			operationID, cliutil.PointerToString(result.Account), cliutil.PointerToString(result.Region), result.Status)
+		if reason := strings.TrimSpace(cliutil.PointerToString(result.StatusReason)); reason != "" {
+			line += fmt.Sprintf(" reason=%q", reason)
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
 func listStacksForSearch(ctx context.Context, client API, includeNested bool) ([]cloudformationtypes.Stack, error) {
 	allStacks, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[cloudformationtypes.Stack], error) {
 		page, listErr := client.DescribeStacks(callCtx, &cloudformation.DescribeStacksInput{NextToken: nextToken})
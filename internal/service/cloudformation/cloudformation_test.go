@@ -3,8 +3,14 @@ package cloudformation
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,12 +21,28 @@ import (
 )
 
 type mockClient struct {
+	deleteStackFn             func(context.Context, *cloudformation.DeleteStackInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error)
 	deleteStackInstancesFn    func(context.Context, *cloudformation.DeleteStackInstancesInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error)
 	deleteStackSetFn          func(context.Context, *cloudformation.DeleteStackSetInput, ...func(*cloudformation.Options)) (*cloudformation.DeleteStackSetOutput, error)
+	describeDriftStatusFn     func(context.Context, *cloudformation.DescribeStackDriftDetectionStatusInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error)
+	describeResourceDriftsFn  func(context.Context, *cloudformation.DescribeStackResourceDriftsInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error)
 	describeStackSetOperation func(context.Context, *cloudformation.DescribeStackSetOperationInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error)
 	describeStacksFn          func(context.Context, *cloudformation.DescribeStacksInput, ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
+	detectStackDriftFn        func(context.Context, *cloudformation.DetectStackDriftInput, ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error)
+	getStackPolicyFn          func(context.Context, *cloudformation.GetStackPolicyInput, ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error)
+	getTemplateFn             func(context.Context, *cloudformation.GetTemplateInput, ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error)
+	getTemplateSummaryFn      func(context.Context, *cloudformation.GetTemplateSummaryInput, ...func(*cloudformation.Options)) (*cloudformation.GetTemplateSummaryOutput, error)
 	listStackInstancesFn      func(context.Context, *cloudformation.ListStackInstancesInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error)
 	listStackResourcesFn      func(context.Context, *cloudformation.ListStackResourcesInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+	listOperationResultsFn    func(context.Context, *cloudformation.ListStackSetOperationResultsInput, ...func(*cloudformation.Options)) (*cloudformation.ListStackSetOperationResultsOutput, error)
+	setStackPolicyFn          func(context.Context, *cloudformation.SetStackPolicyInput, ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error)
+}
+
+func (m *mockClient) DeleteStack(ctx context.Context, in *cloudformation.DeleteStackInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error) {
+	if m.deleteStackFn == nil {
+		return nil, errors.New("DeleteStack not mocked")
+	}
+	return m.deleteStackFn(ctx, in, optFns...)
 }
 
 func (m *mockClient) DeleteStackInstances(ctx context.Context, in *cloudformation.DeleteStackInstancesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error) {
@@ -37,6 +59,20 @@ func (m *mockClient) DeleteStackSet(ctx context.Context, in *cloudformation.Dele
 	return m.deleteStackSetFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeStackDriftDetectionStatus(ctx context.Context, in *cloudformation.DescribeStackDriftDetectionStatusInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+	if m.describeDriftStatusFn == nil {
+		return nil, errors.New("DescribeStackDriftDetectionStatus not mocked")
+	}
+	return m.describeDriftStatusFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) DescribeStackResourceDrifts(ctx context.Context, in *cloudformation.DescribeStackResourceDriftsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+	if m.describeResourceDriftsFn == nil {
+		return nil, errors.New("DescribeStackResourceDrifts not mocked")
+	}
+	return m.describeResourceDriftsFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeStackSetOperation(ctx context.Context, in *cloudformation.DescribeStackSetOperationInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error) {
 	if m.describeStackSetOperation == nil {
 		return nil, errors.New("DescribeStackSetOperation not mocked")
@@ -51,6 +87,34 @@ func (m *mockClient) DescribeStacks(ctx context.Context, in *cloudformation.Desc
 	return m.describeStacksFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DetectStackDrift(ctx context.Context, in *cloudformation.DetectStackDriftInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error) {
+	if m.detectStackDriftFn == nil {
+		return nil, errors.New("DetectStackDrift not mocked")
+	}
+	return m.detectStackDriftFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetStackPolicy(ctx context.Context, in *cloudformation.GetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error) {
+	if m.getStackPolicyFn == nil {
+		return nil, errors.New("GetStackPolicy not mocked")
+	}
+	return m.getStackPolicyFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetTemplate(ctx context.Context, in *cloudformation.GetTemplateInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+	if m.getTemplateFn == nil {
+		return nil, errors.New("GetTemplate not mocked")
+	}
+	return m.getTemplateFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetTemplateSummary(ctx context.Context, in *cloudformation.GetTemplateSummaryInput, optFns ...func(*cloudformation.Options)) (*cloudformation.GetTemplateSummaryOutput, error) {
+	if m.getTemplateSummaryFn == nil {
+		return nil, errors.New("GetTemplateSummary not mocked")
+	}
+	return m.getTemplateSummaryFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) ListStackInstances(ctx context.Context, in *cloudformation.ListStackInstancesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error) {
 	if m.listStackInstancesFn == nil {
 		return nil, errors.New("ListStackInstances not mocked")
@@ -65,6 +129,20 @@ func (m *mockClient) ListStackResources(ctx context.Context, in *cloudformation.
 	return m.listStackResourcesFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) ListStackSetOperationResults(ctx context.Context, in *cloudformation.ListStackSetOperationResultsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetOperationResultsOutput, error) {
+	if m.listOperationResultsFn == nil {
+		return nil, errors.New("ListStackSetOperationResults not mocked")
+	}
+	return m.listOperationResultsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) SetStackPolicy(ctx context.Context, in *cloudformation.SetStackPolicyInput, optFns ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error) {
+	if m.setStackPolicyFn == nil {
+		return nil, errors.New("SetStackPolicy not mocked")
+	}
+	return m.setStackPolicyFn(ctx, in, optFns...)
+}
+
 func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, error), nc func(awssdk.Config) API) {
 	t.Helper()
 
@@ -74,7 +152,7 @@ func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, erro
 
 	loadAWSConfig = loader
 	newClient = nc
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 
 	t.Cleanup(func() {
 		loadAWSConfig = oldLoader
@@ -413,6 +491,79 @@ func TestDeleteStackSetWaitOperationFailure(t *testing.T) {
 	}
 }
 
+// --- runDeleteStackSet: verbose per-target progress ---
+
+func TestDeleteStackSetVerboseReportsPerTargetStatus(t *testing.T) {
+	polls := 0
+	client := &mockClient{
+		listStackInstancesFn: func(_ context.Context, _ *cloudformation.ListStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error) {
+			return &cloudformation.ListStackInstancesOutput{
+				Summaries: []cloudformationtypes.StackInstanceSummary{
+					{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("us-east-1")},
+				},
+			}, nil
+		},
+		deleteStackInstancesFn: func(_ context.Context, _ *cloudformation.DeleteStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error) {
+			return &cloudformation.DeleteStackInstancesOutput{OperationId: cliutil.Ptr("op-1")}, nil
+		},
+		describeStackSetOperation: func(_ context.Context, _ *cloudformation.DescribeStackSetOperationInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error) {
+			polls++
+			status := cloudformationtypes.StackSetOperationStatusRunning
+			if polls > 1 {
+				status = cloudformationtypes.StackSetOperationStatusSucceeded
+			}
+			return &cloudformation.DescribeStackSetOperationOutput{
+				StackSetOperation: &cloudformationtypes.StackSetOperation{Status: status},
+			}, nil
+		},
+		listOperationResultsFn: func(_ context.Context, in *cloudformation.ListStackSetOperationResultsInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackSetOperationResultsOutput, error) {
+			if cliutil.PointerToString(in.OperationId) != "op-1" {
+				t.Fatalf("unexpected operation id %q", cliutil.PointerToString(in.OperationId))
+			}
+			if polls == 1 {
+				return &cloudformation.ListStackSetOperationResultsOutput{Summaries: []cloudformationtypes.StackSetOperationResultSummary{
+					{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("us-east-1"), Status: cloudformationtypes.StackSetOperationResultStatusSucceeded},
+					{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("eu-west-1"), Status: cloudformationtypes.StackSetOperationResultStatusRunning},
+					{Account: cliutil.Ptr("222222222222"), Region: cliutil.Ptr("us-east-1"), Status: cloudformationtypes.StackSetOperationResultStatusFailed, StatusReason: cliutil.Ptr("stack is protected")},
+				}}, nil
+			}
+			return &cloudformation.ListStackSetOperationResultsOutput{Summaries: []cloudformationtypes.StackSetOperationResultSummary{
+				{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("eu-west-1"), Status: cloudformationtypes.StackSetOperationResultStatusSucceeded},
+			}}, nil
+		},
+		deleteStackSetFn: func(_ context.Context, _ *cloudformation.DeleteStackSetInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackSetOutput, error) {
+			return &cloudformation.DeleteStackSetOutput{}, nil
+		},
+	}
+
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stackset", "--stackset-name", "my-stackset", "--verbose")
+	if err != nil {
+		t.Fatalf("execute delete-stackset --verbose: %v", err)
+	}
+	for _, expected := range []string{
+		"progress operation=op-1 account=111111111111 region=eu-west-1 status=RUNNING\nprogress operation=op-1 account=111111111111 region=us-east-1 status=SUCCEEDED",
+		`account=222222222222 region=us-east-1 status=FAILED reason="stack is protected"`,
+		"progress operation=op-1 account=111111111111 region=eu-west-1 status=SUCCEEDED",
+		"resource=stackset action=deleted",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+
+	polls = 0
+	client.listOperationResultsFn = nil
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stackset", "--stackset-name", "my-stackset")
+	if err != nil {
+		t.Fatalf("execute delete-stackset: %v", err)
+	}
+	if strings.Contains(output, "progress") {
+		t.Fatalf("expected no progress lines without --verbose: %s", output)
+	}
+}
+
 // --- runDeleteStackSet: DeleteStackSet API error ---
 
 func TestDeleteStackSetDeleteStackSetError(t *testing.T) {
@@ -546,7 +697,7 @@ func TestDeleteStackSetEmptyOperationID(t *testing.T) {
 
 func TestWaitForStackSetOperationFailedWithReason(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	client := &mockClient{
@@ -560,7 +711,7 @@ func TestWaitForStackSetOperationFailedWithReason(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
 		t.Fatalf("expected error with reason, got %v", err)
 	}
@@ -571,7 +722,7 @@ func TestWaitForStackSetOperationFailedWithReason(t *testing.T) {
 
 func TestWaitForStackSetOperationFailedWithoutReason(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	client := &mockClient{
@@ -584,7 +735,7 @@ func TestWaitForStackSetOperationFailedWithoutReason(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err == nil || !strings.Contains(err.Error(), "FAILED") {
 		t.Fatalf("expected error with FAILED status, got %v", err)
 	}
@@ -592,7 +743,7 @@ func TestWaitForStackSetOperationFailedWithoutReason(t *testing.T) {
 
 func TestWaitForStackSetOperationStopped(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	client := &mockClient{
@@ -606,7 +757,7 @@ func TestWaitForStackSetOperationStopped(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err == nil || !strings.Contains(err.Error(), "STOPPED") {
 		t.Fatalf("expected error with STOPPED status, got %v", err)
 	}
@@ -614,7 +765,7 @@ func TestWaitForStackSetOperationStopped(t *testing.T) {
 
 func TestWaitForStackSetOperationDescribeError(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	client := &mockClient{
@@ -623,7 +774,7 @@ func TestWaitForStackSetOperationDescribeError(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err == nil || !strings.Contains(err.Error(), "throttled") {
 		t.Fatalf("expected throttled error, got %v", err)
 	}
@@ -631,7 +782,7 @@ func TestWaitForStackSetOperationDescribeError(t *testing.T) {
 
 func TestWaitForStackSetOperationContextCancelled(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -650,7 +801,7 @@ func TestWaitForStackSetOperationContextCancelled(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(ctx, client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(ctx, client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err == nil {
 		t.Fatalf("expected context cancelled error, got nil")
 	}
@@ -658,7 +809,7 @@ func TestWaitForStackSetOperationContextCancelled(t *testing.T) {
 
 func TestWaitForStackSetOperationSuccess(t *testing.T) {
 	oldSleep := sleep
-	sleep = func(_ time.Duration) {}
+	sleep = func(context.Context, time.Duration) error { return nil }
 	defer func() { sleep = oldSleep }()
 
 	calls := 0
@@ -675,7 +826,7 @@ func TestWaitForStackSetOperationSuccess(t *testing.T) {
 		},
 	}
 
-	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1")
+	err := waitForStackSetOperation(context.Background(), client, "my-stackset", "op-1", defaultStackSetPollInterval, nil)
 	if err != nil {
 		t.Fatalf("expected success, got %v", err)
 	}
@@ -887,7 +1038,7 @@ func TestDeleteStackSetInstanceTargetError(t *testing.T) {
 		},
 	}
 
-	_, err := deleteStackSetInstanceTarget(context.Background(), client, "my-stackset", stackInstanceTarget{Account: "111111111111", Region: "us-east-1"})
+	_, err := deleteStackSetInstanceTarget(context.Background(), client, "my-stackset", stackInstanceTarget{Account: "111111111111", Region: "us-east-1"}, false)
 	if err == nil || !strings.Contains(err.Error(), "permission denied") {
 		t.Fatalf("expected error, got %v", err)
 	}
@@ -906,7 +1057,7 @@ func TestDeleteStackSetInstanceTargetSuccess(t *testing.T) {
 		},
 	}
 
-	opID, err := deleteStackSetInstanceTarget(context.Background(), client, "my-stackset", stackInstanceTarget{Account: "111111111111", Region: "us-east-1"})
+	opID, err := deleteStackSetInstanceTarget(context.Background(), client, "my-stackset", stackInstanceTarget{Account: "111111111111", Region: "us-east-1"}, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1267,3 +1418,949 @@ func TestDeleteStackSetMultipleInstancesPartialFailure(t *testing.T) {
 		t.Fatalf("expected skipped for stackset, got: %s", output)
 	}
 }
+
+func nestedStacksFixture() []cloudformationtypes.Stack {
+	return []cloudformationtypes.Stack{
+		{StackName: cliutil.Ptr("root"), StackId: cliutil.Ptr("arn:root"), StackStatus: cloudformationtypes.StackStatusCreateComplete},
+		{StackName: cliutil.Ptr("root-network"), StackId: cliutil.Ptr("arn:network"), ParentId: cliutil.Ptr("arn:root"), StackStatus: cloudformationtypes.StackStatusUpdateComplete},
+		{StackName: cliutil.Ptr("root-network-subnets"), StackId: cliutil.Ptr("arn:subnets"), ParentId: cliutil.Ptr("arn:network"), StackStatus: cloudformationtypes.StackStatusCreateComplete},
+		{StackName: cliutil.Ptr("root-app"), StackId: cliutil.Ptr("arn:app"), ParentId: cliutil.Ptr("arn:root"), StackStatus: cloudformationtypes.StackStatusCreateComplete},
+		{StackName: cliutil.Ptr("unrelated"), StackId: cliutil.Ptr("arn:other"), StackStatus: cloudformationtypes.StackStatusCreateComplete},
+	}
+}
+
+func TestBuildStackTreeTwoLevels(t *testing.T) {
+	root, err := buildStackTree(nestedStacksFixture(), "root")
+	if err != nil {
+		t.Fatalf("buildStackTree: %v", err)
+	}
+
+	got := make([]string, 0)
+	walkStackTree(root, func(node *stackTreeNode) {
+		got = append(got, strings.Repeat("-", node.depth)+cliutil.PointerToString(node.stack.StackName))
+	})
+	want := []string{"root", "-root-app", "-root-network", "--root-network-subnets"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected tree: got %v want %v", got, want)
+	}
+}
+
+func TestBuildStackTreeUnknownRoot(t *testing.T) {
+	if _, err := buildStackTree(nestedStacksFixture(), "missing"); err == nil {
+		t.Fatal("expected error for unknown root stack")
+	}
+}
+
+func TestStackTreeMermaidKeepsSanitizedIDsUnique(t *testing.T) {
+	root := &stackTreeNode{stack: cloudformationtypes.Stack{StackName: cliutil.Ptr("app")}}
+	root.children = []*stackTreeNode{
+		{stack: cloudformationtypes.Stack{StackName: cliutil.Ptr("app.db")}},
+		{stack: cloudformationtypes.Stack{StackName: cliutil.Ptr("app-db")}},
+	}
+
+	lines := renderStackTreeMermaid(root)
+	for _, expected := range []string{"    app_0 --> app_db_1", "    app_0 --> app_db_2"} {
+		if !slices.Contains(lines, expected) {
+			t.Fatalf("expected %q in %v", expected, lines)
+		}
+	}
+}
+
+func TestStackTreeMermaid(t *testing.T) {
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: nestedStacksFixture()}, nil
+		},
+	}
+	withMockDeps(t, func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil }, func(awssdk.Config) API { return client })
+
+	output, err := executeCommand(t, "cloudformation", "stack-tree", "--stack-name", "root", "--format", "mermaid")
+	if err != nil {
+		t.Fatalf("execute stack-tree: %v", err)
+	}
+	for _, expected := range []string{"graph TB", "root_0 --> root_network_2", "root_network_2 --> root_network_subnets_3", "root_app_1[\"root-app<br/>CREATE_COMPLETE\"]"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "unrelated") {
+		t.Fatalf("unrelated stack should not appear: %s", output)
+	}
+}
+
+func TestStackTreeRequiresStackName(t *testing.T) {
+	_, err := executeCommand(t, "cloudformation", "stack-tree")
+	if err == nil || !strings.Contains(err.Error(), "--stack-name is required") {
+		t.Fatalf("expected stack name validation error, got %v", err)
+	}
+}
+
+func TestGenerateImportMergesIntoJSONTemplate(t *testing.T) {
+	client := &mockClient{
+		getTemplateFn: func(_ context.Context, in *cloudformation.GetTemplateInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+			if cliutil.PointerToString(in.StackName) != "app" {
+				t.Fatalf("unexpected stack name: %s", cliutil.PointerToString(in.StackName))
+			}
+			return &cloudformation.GetTemplateOutput{TemplateBody: cliutil.Ptr(`{"Resources":{"Queue":{"Type":"AWS::SQS::Queue"}}}`)}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	templatePath := filepath.Join(t.TempDir(), "template.json")
+	output, err := executeCommand(t, "cloudformation", "generate-import", "--stack-name", "app", "--resource-type", "AWS::S3::Bucket", "--identifier", "my-bucket", "--output-file", templatePath)
+	if err != nil {
+		t.Fatalf("execute generate-import: %v", err)
+	}
+	for _, expected := range []string{`"LogicalResourceId": "ImportedMyBucket"`, `"BucketName": "my-bucket"`, "--change-set-type IMPORT", "file://" + templatePath} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+
+	template, err := os.ReadFile(templatePath)
+	if err != nil {
+		t.Fatalf("read template: %v", err)
+	}
+	for _, expected := range []string{`"Queue"`, `"ImportedMyBucket"`, `"DeletionPolicy": "Retain"`} {
+		if !strings.Contains(string(template), expected) {
+			t.Fatalf("expected %q in template: %s", expected, template)
+		}
+	}
+}
+
+func TestMergeImportResourceKeepsReadOnlyIdentifierOutOfProperties(t *testing.T) {
+	merged, err := mergeImportResource(`{"Resources":{}}`, "Network", "AWS::EC2::VPC", "VpcId", "vpc-123")
+	if err != nil {
+		t.Fatalf("merge VPC: %v", err)
+	}
+	var template struct {
+		Resources map[string]map[string]any
+	}
+	if err := json.Unmarshal(merged, &template); err != nil {
+		t.Fatalf("decode merged template: %v", err)
+	}
+	network := template.Resources["Network"]
+	if network["Type"] != "AWS::EC2::VPC" || network["DeletionPolicy"] != "Retain" {
+		t.Fatalf("unexpected VPC resource: %v", network)
+	}
+	if _, ok := network["Properties"]; ok || strings.Contains(string(merged), "vpc-123") {
+		t.Fatalf("expected VpcId to stay out of the template:\n%s", merged)
+	}
+
+	merged, err = mergeImportResource("Resources: {}\n", "Bucket", "AWS::S3::Bucket", "BucketName", "b")
+	if err != nil {
+		t.Fatalf("merge bucket: %v", err)
+	}
+	if !strings.Contains(string(merged), "BucketName: b") {
+		t.Fatalf("expected the writable BucketName property to be set:\n%s", merged)
+	}
+}
+
+func TestGenerateImportValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing stack", args: []string{"--resource-type", "AWS::S3::Bucket", "--identifier", "b"}, want: "--stack-name is required"},
+		{name: "unknown type", args: []string{"--stack-name", "app", "--resource-type", "AWS::Foo::Bar", "--identifier", "x"}, want: "set --identifier-key"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := executeCommand(t, append([]string{"cloudformation", "generate-import"}, tc.args...)...)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected %q error, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestMergeImportResourceRejectsDuplicateLogicalID(t *testing.T) {
+	_, err := mergeImportResource(`{"Resources":{"Bucket":{}}}`, "Bucket", "AWS::S3::Bucket", "BucketName", "b")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate logical ID error, got %v", err)
+	}
+
+	_, err = mergeImportResource("Resources:\n  Bucket:\n    Type: AWS::S3::Bucket\n", "Bucket", "AWS::S3::Bucket", "BucketName", "b")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate logical ID error for YAML template, got %v", err)
+	}
+}
+
+func TestMergeImportResourceKeepsFullYAMLTemplate(t *testing.T) {
+	body := strings.Join([]string{
+		"AWSTemplateFormatVersion: '2010-09-09'",
+		"Resources:",
+		"  Queue:",
+		"    Type: AWS::SQS::Queue",
+		"  Topic:",
+		"    Type: AWS::SNS::Topic",
+		"    Properties:",
+		"      DisplayName: !Ref AWS::StackName",
+		"Outputs:",
+		"  QueueUrl:",
+		"    Value: !Ref Queue",
+	}, "\n")
+
+	merged, err := mergeImportResource(body, "Bucket", "AWS::S3::Bucket", "BucketName", "b")
+	if err != nil {
+		t.Fatalf("merge YAML template: %v", err)
+	}
+	expected := strings.Join([]string{
+		"AWSTemplateFormatVersion: '2010-09-09'",
+		"Resources:",
+		"  Queue:",
+		"    Type: AWS::SQS::Queue",
+		"  Topic:",
+		"    Type: AWS::SNS::Topic",
+		"    Properties:",
+		"      DisplayName: !Ref AWS::StackName",
+		"  Bucket:",
+		"    Type: AWS::S3::Bucket",
+		"    DeletionPolicy: Retain",
+		"    Properties:",
+		"      BucketName: b",
+		"Outputs:",
+		"  QueueUrl:",
+		"    Value: !Ref Queue",
+	}, "\n")
+	if string(merged) != expected {
+		t.Fatalf("unexpected merged template:\n%s", merged)
+	}
+}
+
+func TestGenerateImportWritesSingleChangeSetDocument(t *testing.T) {
+	client := &mockClient{
+		getTemplateFn: func(_ context.Context, _ *cloudformation.GetTemplateInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+			return &cloudformation.GetTemplateOutput{TemplateBody: cliutil.Ptr("Resources:\n  Queue:\n    Type: AWS::SQS::Queue\n")}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	root := cliutil.NewTestRootCommand(NewCommand())
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"cloudformation", "generate-import", "--stack-name", "app", "--resource-type", "AWS::S3::Bucket", "--identifier", "my-bucket"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute generate-import: %v", err)
+	}
+
+	var input struct {
+		StackName         string
+		ChangeSetType     string
+		ResourcesToImport []map[string]any
+		TemplateBody      string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &input); err != nil {
+		t.Fatalf("expected one JSON document on stdout, got %q: %v", stdout.String(), err)
+	}
+	if input.StackName != "app" || input.ChangeSetType != "IMPORT" || len(input.ResourcesToImport) != 1 {
+		t.Fatalf("unexpected change set input: %+v", input)
+	}
+	if !strings.Contains(input.TemplateBody, "Queue:") || !strings.Contains(input.TemplateBody, "ImportedMyBucket:") {
+		t.Fatalf("expected merged template body, got %s", input.TemplateBody)
+	}
+}
+
+func TestSetStackPolicyProtectTypes(t *testing.T) {
+	var applied string
+	client := &mockClient{
+		listStackResourcesFn: func(_ context.Context, _ *cloudformation.ListStackResourcesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+			return &cloudformation.ListStackResourcesOutput{StackResourceSummaries: []cloudformationtypes.StackResourceSummary{
+				{LogicalResourceId: cliutil.Ptr("Database"), ResourceType: cliutil.Ptr("AWS::RDS::DBInstance")},
+				{LogicalResourceId: cliutil.Ptr("Cluster"), ResourceType: cliutil.Ptr("AWS::RDS::DBCluster")},
+				{LogicalResourceId: cliutil.Ptr("Queue"), ResourceType: cliutil.Ptr("AWS::SQS::Queue")},
+			}}, nil
+		},
+		setStackPolicyFn: func(_ context.Context, in *cloudformation.SetStackPolicyInput, _ ...func(*cloudformation.Options)) (*cloudformation.SetStackPolicyOutput, error) {
+			applied = cliutil.PointerToString(in.StackPolicyBody)
+			return &cloudformation.SetStackPolicyOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--dry-run", "cloudformation", "set-stack-policy", "--stack-name", "app", "--protect-types", "AWS::RDS::*")
+	if err != nil {
+		t.Fatalf("execute set-stack-policy dry-run: %v", err)
+	}
+	for _, expected := range []string{`"LogicalResourceId/Cluster"`, `"LogicalResourceId/Database"`, `"Update:Replace"`, "dry-run: stack policy not applied"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "LogicalResourceId/Queue") || applied != "" {
+		t.Fatalf("dry-run should not protect Queue or apply the policy: %s", output)
+	}
+
+	if _, err := executeCommand(t, "--no-confirm", "cloudformation", "set-stack-policy", "--stack-name", "app", "--protect-types", "AWS::RDS::*"); err != nil {
+		t.Fatalf("execute set-stack-policy: %v", err)
+	}
+	var policy stackPolicy
+	if err := json.Unmarshal([]byte(applied), &policy); err != nil {
+		t.Fatalf("applied policy is not JSON: %v: %s", err, applied)
+	}
+	if len(policy.Statement) != 2 || policy.Statement[1].Effect != "Deny" {
+		t.Fatalf("unexpected applied policy: %s", applied)
+	}
+
+	if _, err := executeCommand(t, "cloudformation", "set-stack-policy", "--stack-name", "app", "--protect-types", "AWS::DynamoDB::*"); err == nil || !strings.Contains(err.Error(), "no resources in stack app match") {
+		t.Fatalf("expected no-match error, got %v", err)
+	}
+}
+
+func TestGetStackPolicy(t *testing.T) {
+	body := ""
+	client := &mockClient{
+		getStackPolicyFn: func(_ context.Context, _ *cloudformation.GetStackPolicyInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetStackPolicyOutput, error) {
+			return &cloudformation.GetStackPolicyOutput{StackPolicyBody: cliutil.Ptr(body)}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "cloudformation", "get-stack-policy", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute get-stack-policy: %v", err)
+	}
+	if !strings.Contains(output, "has no stack policy") {
+		t.Fatalf("expected no-policy notice: %s", output)
+	}
+
+	body = `{"Statement":[{"Effect":"Allow","Action":"Update:*","Principal":"*","Resource":"*"}]}`
+	output, err = executeCommand(t, "cloudformation", "get-stack-policy", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute get-stack-policy: %v", err)
+	}
+	if !strings.Contains(output, `"Effect": "Allow"`) {
+		t.Fatalf("expected indented policy: %s", output)
+	}
+}
+
+func TestStackIOMasksNoEchoAndShowsExports(t *testing.T) {
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, in *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{{
+				StackName: in.StackName,
+				Parameters: []cloudformationtypes.Parameter{
+					{ParameterKey: cliutil.Ptr("Environment"), ParameterValue: cliutil.Ptr("prod")},
+					{ParameterKey: cliutil.Ptr("DbPassword"), ParameterValue: cliutil.Ptr("hunter2")},
+				},
+				Outputs: []cloudformationtypes.Output{
+					{OutputKey: cliutil.Ptr("VpcId"), OutputValue: cliutil.Ptr("vpc-123"), ExportName: cliutil.Ptr("network-VpcId"), Description: cliutil.Ptr("Shared VPC")},
+				},
+			}}}, nil
+		},
+		getTemplateSummaryFn: func(_ context.Context, _ *cloudformation.GetTemplateSummaryInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateSummaryOutput, error) {
+			return &cloudformation.GetTemplateSummaryOutput{Parameters: []cloudformationtypes.ParameterDeclaration{
+				{ParameterKey: cliutil.Ptr("Environment")},
+				{ParameterKey: cliutil.Ptr("DbPassword"), NoEcho: cliutil.Ptr(true)},
+			}}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "stack-io", "--stack-name", "network")
+	if err != nil {
+		t.Fatalf("execute stack-io: %v", err)
+	}
+	expected := strings.Join([]string{
+		"kind=parameter key=DbPassword value=**** export_name= description=",
+		"kind=parameter key=Environment value=prod export_name= description=",
+		"kind=output key=VpcId value=vpc-123 export_name=network-VpcId description=Shared VPC",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "stack-io", "--stack-name", "network", "--outputs-only")
+	if err != nil {
+		t.Fatalf("execute stack-io --outputs-only: %v", err)
+	}
+	if strings.Contains(output, "kind=parameter") || !strings.Contains(output, "kind=output key=VpcId") {
+		t.Fatalf("expected only outputs: %s", output)
+	}
+}
+
+func TestDetectDriftPollsAndFiltersDrifted(t *testing.T) {
+	polls := 0
+	client := &mockClient{
+		detectStackDriftFn: func(_ context.Context, _ *cloudformation.DetectStackDriftInput, _ ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error) {
+			return &cloudformation.DetectStackDriftOutput{StackDriftDetectionId: cliutil.Ptr("detect-1")}, nil
+		},
+		describeDriftStatusFn: func(_ context.Context, in *cloudformation.DescribeStackDriftDetectionStatusInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+			if cliutil.PointerToString(in.StackDriftDetectionId) != "detect-1" {
+				t.Fatalf("unexpected detection id: %s", cliutil.PointerToString(in.StackDriftDetectionId))
+			}
+			polls++
+			status := cloudformationtypes.StackDriftDetectionStatusDetectionInProgress
+			if polls > 1 {
+				status = cloudformationtypes.StackDriftDetectionStatusDetectionComplete
+			}
+			return &cloudformation.DescribeStackDriftDetectionStatusOutput{DetectionStatus: status}, nil
+		},
+		describeResourceDriftsFn: func(_ context.Context, in *cloudformation.DescribeStackResourceDriftsInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackResourceDriftsOutput, error) {
+			if in.NextToken == nil {
+				return &cloudformation.DescribeStackResourceDriftsOutput{
+					StackResourceDrifts: []cloudformationtypes.StackResourceDrift{
+						{LogicalResourceId: cliutil.Ptr("Queue"), ResourceType: cliutil.Ptr("AWS::SQS::Queue"), PhysicalResourceId: cliutil.Ptr("queue-url"), StackResourceDriftStatus: cloudformationtypes.StackResourceDriftStatusInSync},
+						{LogicalResourceId: cliutil.Ptr("Bucket"), ResourceType: cliutil.Ptr("AWS::S3::Bucket"), PhysicalResourceId: cliutil.Ptr("my-bucket"), StackResourceDriftStatus: cloudformationtypes.StackResourceDriftStatusModified},
+					},
+					NextToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &cloudformation.DescribeStackResourceDriftsOutput{StackResourceDrifts: []cloudformationtypes.StackResourceDrift{
+				{LogicalResourceId: cliutil.Ptr("Role"), ResourceType: cliutil.Ptr("AWS::IAM::Role"), PhysicalResourceId: cliutil.Ptr("app-role"), StackResourceDriftStatus: cloudformationtypes.StackResourceDriftStatusDeleted},
+			}}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "detect-drift", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute detect-drift: %v", err)
+	}
+	if polls != 2 {
+		t.Fatalf("expected 2 status polls, got %d", polls)
+	}
+	expected := strings.Join([]string{
+		"logical_id=Bucket resource_type=AWS::S3::Bucket physical_id=my-bucket drift_status=MODIFIED",
+		"logical_id=Queue resource_type=AWS::SQS::Queue physical_id=queue-url drift_status=IN_SYNC",
+		"logical_id=Role resource_type=AWS::IAM::Role physical_id=app-role drift_status=DELETED",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "detect-drift", "--stack-name", "app", "--drifted-only")
+	if err != nil {
+		t.Fatalf("execute detect-drift --drifted-only: %v", err)
+	}
+	if strings.Contains(output, "IN_SYNC") || !strings.Contains(output, "drift_status=MODIFIED") || !strings.Contains(output, "drift_status=DELETED") {
+		t.Fatalf("expected only drifted resources: %s", output)
+	}
+}
+
+func TestDetectDriftReportsFailedDetection(t *testing.T) {
+	client := &mockClient{
+		detectStackDriftFn: func(_ context.Context, _ *cloudformation.DetectStackDriftInput, _ ...func(*cloudformation.Options)) (*cloudformation.DetectStackDriftOutput, error) {
+			return &cloudformation.DetectStackDriftOutput{StackDriftDetectionId: cliutil.Ptr("detect-1")}, nil
+		},
+		describeDriftStatusFn: func(_ context.Context, _ *cloudformation.DescribeStackDriftDetectionStatusInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackDriftDetectionStatusOutput, error) {
+			return &cloudformation.DescribeStackDriftDetectionStatusOutput{
+				DetectionStatus:       cloudformationtypes.StackDriftDetectionStatusDetectionFailed,
+				DetectionStatusReason: cliutil.Ptr("resource not supported"),
+			}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	_, err := executeCommand(t, "cloudformation", "detect-drift", "--stack-name", "app")
+	if err == nil || !strings.Contains(err.Error(), "DETECTION_FAILED: resource not supported") {
+		t.Fatalf("expected detection failure, got %v", err)
+	}
+}
+
+func TestFindStackByResourceMatchesTags(t *testing.T) {
+	var templateCalls atomic.Int32
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{
+				{StackName: cliutil.Ptr("payments"), Tags: []cloudformationtypes.Tag{{Key: cliutil.Ptr("team"), Value: cliutil.Ptr("payments")}}},
+				{StackName: cliutil.Ptr("search"), Tags: []cloudformationtypes.Tag{{Key: cliutil.Ptr("team"), Value: cliutil.Ptr("search")}}},
+			}}, nil
+		},
+		listStackResourcesFn: func(_ context.Context, _ *cloudformation.ListStackResourcesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+			return &cloudformation.ListStackResourcesOutput{StackResourceSummaries: []cloudformationtypes.StackResourceSummary{
+				{LogicalResourceId: cliutil.Ptr("Bucket"), ResourceType: cliutil.Ptr("AWS::S3::Bucket")},
+				{LogicalResourceId: cliutil.Ptr("Queue"), ResourceType: cliutil.Ptr("AWS::SQS::Queue")},
+			}}, nil
+		},
+		getTemplateFn: func(_ context.Context, in *cloudformation.GetTemplateInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+			templateCalls.Add(1)
+			if cliutil.PointerToString(in.StackName) == "payments" {
+				return &cloudformation.GetTemplateOutput{TemplateBody: cliutil.Ptr(`{"Resources":{
+					"Bucket":{"Type":"AWS::S3::Bucket","Properties":{"Tags":[{"Key":"team","Value":"payments-platform"},{"Key":"cost","Value":{"Ref":"Cost"}}]}},
+					"Queue":{"Type":"AWS::SQS::Queue"}}}`)}, nil
+			}
+			return &cloudformation.GetTemplateOutput{TemplateBody: cliutil.Ptr(strings.Join([]string{
+				"Resources:",
+				"  Bucket:",
+				"    Type: AWS::S3::Bucket",
+				"    Properties:",
+				"      Tags:",
+				"        - Key: team",
+				"          Value: payments",
+				"        - Key: cost",
+				"          Value: !Ref Cost",
+				"  Queue:",
+				"    Type: AWS::SQS::Queue",
+			}, "\n"))}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "find-stack-by-resource", "--tag-key", "team", "--tag-value", "payments")
+	if err != nil {
+		t.Fatalf("execute find-stack-by-resource --tag-key: %v", err)
+	}
+	if templateCalls.Load() != 2 {
+		t.Fatalf("expected one template read per stack, got %d", templateCalls.Load())
+	}
+	// The search stack's YAML template tags its Bucket for payments.
+	if !strings.Contains(output, "stack_name=payments logical_id=Bucket") || !strings.Contains(output, "stack_name=payments logical_id=Queue") ||
+		!strings.Contains(output, "stack_name=search logical_id=Bucket") || strings.Contains(output, "stack_name=search logical_id=Queue") {
+		t.Fatalf("unexpected substring tag matches:\n%s", output)
+	}
+
+	// The template tag on Bucket overrides the propagated stack tag.
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "find-stack-by-resource", "--tag-key", "team", "--tag-value", "payments", "--exact")
+	if err != nil {
+		t.Fatalf("execute find-stack-by-resource --exact: %v", err)
+	}
+	if strings.Contains(output, "stack_name=payments logical_id=Bucket") || !strings.Contains(output, "stack_name=payments logical_id=Queue") ||
+		!strings.Contains(output, "stack_name=search logical_id=Bucket") {
+		t.Fatalf("unexpected exact tag matches:\n%s", output)
+	}
+
+	// A !Ref tag value is only known at deploy time and never matches.
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "find-stack-by-resource", "--tag-key", "cost", "--tag-value", "Cost")
+	if err != nil {
+		t.Fatalf("execute find-stack-by-resource --tag-key cost: %v", err)
+	}
+	if strings.TrimSpace(output) != "" {
+		t.Fatalf("expected no matches for an intrinsic tag value:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "find-stack-by-resource", "--resource", "AWS::S3::Bucket", "--exact", "--tag-key", "TEAM")
+	if err != nil {
+		t.Fatalf("execute find-stack-by-resource --resource --tag-key: %v", err)
+	}
+	if strings.Count(output, "logical_id=Bucket") != 2 || strings.Contains(output, "logical_id=Queue") {
+		t.Fatalf("expected buckets from both stacks:\n%s", output)
+	}
+}
+
+func TestFindStackByResourceTagValueRequiresKey(t *testing.T) {
+	_, err := executeCommand(t, "cloudformation", "find-stack-by-resource", "--tag-value", "payments")
+	if err == nil || !strings.Contains(err.Error(), "--tag-value requires --tag-key") {
+		t.Fatalf("expected tag key error, got %v", err)
+	}
+}
+
+func TestDeleteStackSetRetainStacks(t *testing.T) {
+	var retainStacks []bool
+	client := &mockClient{
+		listStackInstancesFn: func(_ context.Context, _ *cloudformation.ListStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error) {
+			return &cloudformation.ListStackInstancesOutput{Summaries: []cloudformationtypes.StackInstanceSummary{{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("us-east-1")}}}, nil
+		},
+		deleteStackInstancesFn: func(_ context.Context, in *cloudformation.DeleteStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error) {
+			retainStacks = append(retainStacks, awssdk.ToBool(in.RetainStacks))
+			return &cloudformation.DeleteStackInstancesOutput{OperationId: cliutil.Ptr("op-1")}, nil
+		},
+		describeStackSetOperation: func(_ context.Context, _ *cloudformation.DescribeStackSetOperationInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error) {
+			return &cloudformation.DescribeStackSetOperationOutput{StackSetOperation: &cloudformationtypes.StackSetOperation{Status: cloudformationtypes.StackSetOperationStatusSucceeded}}, nil
+		},
+		deleteStackSetFn: func(_ context.Context, _ *cloudformation.DeleteStackSetInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackSetOutput, error) {
+			return &cloudformation.DeleteStackSetOutput{}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "cloudformation", "delete-stackset", "--stackset-name", "stackset-a", "--retain-stacks")
+	if err != nil {
+		t.Fatalf("execute delete-stackset --retain-stacks dry-run: %v", err)
+	}
+	if len(retainStacks) != 0 || !strings.Contains(output, "resource=stack-instance action=would-delete (retaining stacks)") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stackset", "--stackset-name", "stackset-a", "--retain-stacks")
+	if err != nil {
+		t.Fatalf("execute delete-stackset --retain-stacks: %v", err)
+	}
+	if len(retainStacks) != 1 || !retainStacks[0] {
+		t.Fatalf("expected RetainStacks=true, got %v", retainStacks)
+	}
+	if !strings.Contains(output, "action=deleted (stack retained)") || !strings.Contains(output, "resource=stackset action=deleted") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+}
+
+func TestListStacksFilters(t *testing.T) {
+	old := time.Now().UTC().AddDate(0, 0, -120)
+	recent := time.Now().UTC().AddDate(0, 0, -5)
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{
+				{StackName: cliutil.Ptr("sandbox-old"), StackStatus: cloudformationtypes.StackStatusRollbackComplete, CreationTime: &old},
+				{StackName: cliutil.Ptr("sandbox-new"), StackStatus: cloudformationtypes.StackStatusRollbackComplete, CreationTime: &recent},
+				{StackName: cliutil.Ptr("sandbox-nested"), StackStatus: cloudformationtypes.StackStatusRollbackComplete, CreationTime: &old, ParentId: cliutil.Ptr("parent-id")},
+				{StackName: cliutil.Ptr("prod"), StackStatus: cloudformationtypes.StackStatusCreateComplete, CreationTime: &old},
+				{StackName: cliutil.Ptr("sandbox-gone"), StackStatus: cloudformationtypes.StackStatusDeleteComplete, CreationTime: &old},
+			}}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "list-stacks", "--status", "rollback_complete", "--older-than-days", "90")
+	if err != nil {
+		t.Fatalf("execute list-stacks: %v", err)
+	}
+	expected := "stack_name=sandbox-old status=ROLLBACK_COMPLETE created=" + old.Format(time.RFC3339) + " parent_id="
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "cloudformation", "list-stacks", "--name-contains", "SANDBOX", "--include-nested")
+	if err != nil {
+		t.Fatalf("execute list-stacks --include-nested: %v", err)
+	}
+	for _, name := range []string{"sandbox-old", "sandbox-new", "sandbox-nested"} {
+		if !strings.Contains(output, "stack_name="+name+" ") {
+			t.Fatalf("expected %s in output:\n%s", name, output)
+		}
+	}
+	if strings.Contains(output, "prod") || strings.Contains(output, "sandbox-gone") || !strings.Contains(output, "parent_id=parent-id") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestListStacksRejectsUnknownStatus(t *testing.T) {
+	_, err := executeCommand(t, "cloudformation", "list-stacks", "--status", "BROKEN")
+	if err == nil || !strings.Contains(err.Error(), `--status "BROKEN" is not a CloudFormation stack status`) {
+		t.Fatalf("expected status error, got %v", err)
+	}
+
+	_, err = executeCommand(t, "cloudformation", "list-stacks", "--status", "delete_complete")
+	if err == nil || !strings.Contains(err.Error(), "--status DELETE_COMPLETE is not supported") {
+		t.Fatalf("expected DELETE_COMPLETE to be rejected, got %v", err)
+	}
+}
+
+func TestFindStackByResourceConcurrentKeepsOrderAndNamesFailedStack(t *testing.T) {
+	stacks := make([]cloudformationtypes.Stack, 0, 20)
+	for i := range 20 {
+		stacks = append(stacks, cloudformationtypes.Stack{StackName: cliutil.Ptr(fmt.Sprintf("stack-%02d", i))})
+	}
+	failStack := ""
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: stacks}, nil
+		},
+		listStackResourcesFn: func(_ context.Context, in *cloudformation.ListStackResourcesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+			name := cliutil.PointerToString(in.StackName)
+			if name == failStack {
+				return nil, errors.New("throttled")
+			}
+			return &cloudformation.ListStackResourcesOutput{StackResourceSummaries: []cloudformationtypes.StackResourceSummary{
+				{LogicalResourceId: cliutil.Ptr("Bucket"), PhysicalResourceId: cliutil.Ptr(name + "-bucket"), ResourceType: cliutil.Ptr("AWS::S3::Bucket")},
+			}}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "find-stack-by-resource", "--resource", "bucket", "--concurrency", "4")
+	if err != nil {
+		t.Fatalf("execute find-stack-by-resource --concurrency: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != len(stacks) {
+		t.Fatalf("expected %d rows, got:\n%s", len(stacks), output)
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, fmt.Sprintf("stack_name=stack-%02d ", i)) {
+			t.Fatalf("row %d out of order: %s", i, line)
+		}
+	}
+
+	failStack = "stack-07"
+	_, err = executeCommand(t, "cloudformation", "find-stack-by-resource", "--resource", "bucket", "--concurrency", "4")
+	if err == nil || !strings.Contains(err.Error(), "list resources for stack stack-07: throttled") {
+		t.Fatalf("expected error naming stack-07, got %v", err)
+	}
+
+	_, err = executeCommand(t, "cloudformation", "find-stack-by-resource", "--resource", "bucket", "--concurrency", "0")
+	if err == nil || !strings.Contains(err.Error(), "--concurrency must be at least 1") {
+		t.Fatalf("expected concurrency error, got %v", err)
+	}
+}
+
+func withMockEmptyBucket(t *testing.T, fn func(context.Context, awssdk.Config, string) error) {
+	t.Helper()
+	old := emptyBucket
+	emptyBucket = fn
+	t.Cleanup(func() { emptyBucket = old })
+}
+
+func deleteStackTestClient(deleted *[]string, describeCalls *int) *mockClient {
+	return &mockClient{
+		describeStacksFn: func(_ context.Context, in *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			*describeCalls++
+			status := cloudformationtypes.StackStatusCreateComplete
+			if len(*deleted) > 0 {
+				status = cloudformationtypes.StackStatusDeleteInProgress
+				if *describeCalls > 3 {
+					status = cloudformationtypes.StackStatusDeleteComplete
+				}
+			}
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{{
+				StackName:   cliutil.Ptr("app"),
+				StackId:     cliutil.Ptr("arn:aws:cloudformation:us-east-1:123456789012:stack/app/1"),
+				StackStatus: status,
+			}}}, nil
+		},
+		listStackResourcesFn: func(_ context.Context, _ *cloudformation.ListStackResourcesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+			return &cloudformation.ListStackResourcesOutput{StackResourceSummaries: []cloudformationtypes.StackResourceSummary{
+				{LogicalResourceId: cliutil.Ptr("Logs"), PhysicalResourceId: cliutil.Ptr("app-logs"), ResourceType: cliutil.Ptr("AWS::S3::Bucket")},
+				{LogicalResourceId: cliutil.Ptr("Queue"), PhysicalResourceId: cliutil.Ptr("queue-url"), ResourceType: cliutil.Ptr("AWS::SQS::Queue")},
+			}}, nil
+		},
+		deleteStackFn: func(_ context.Context, in *cloudformation.DeleteStackInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error) {
+			*deleted = append(*deleted, cliutil.PointerToString(in.StackName))
+			return &cloudformation.DeleteStackOutput{}, nil
+		},
+	}
+}
+
+func TestDeleteStackEmptiesBucketsThenWaits(t *testing.T) {
+	var deleted []string
+	describeCalls := 0
+	emptied := make([]string, 0)
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(deleteStackTestClient(&deleted, &describeCalls)))
+	withMockEmptyBucket(t, func(_ context.Context, _ awssdk.Config, bucket string) error {
+		emptied = append(emptied, bucket)
+		return nil
+	})
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "cloudformation", "delete-stack", "--stack-name", "app", "--empty-buckets")
+	if err != nil {
+		t.Fatalf("execute delete-stack dry-run: %v", err)
+	}
+	expected := strings.Join([]string{
+		"stack_name=app phase=empty-buckets resource=app-logs action=would-empty",
+		"stack_name=app phase=delete-stack resource=arn:aws:cloudformation:us-east-1:123456789012:stack/app/1 action=would-delete",
+	}, "\n")
+	if strings.TrimSpace(output) != expected || len(emptied) != 0 || len(deleted) != 0 {
+		t.Fatalf("unexpected dry-run output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stack", "--stack-name", "app", "--empty-buckets")
+	if err != nil {
+		t.Fatalf("execute delete-stack: %v", err)
+	}
+	if len(emptied) != 1 || emptied[0] != "app-logs" {
+		t.Fatalf("expected app-logs to be emptied, got %v", emptied)
+	}
+	if len(deleted) != 1 || !strings.HasSuffix(deleted[0], "stack/app/1") {
+		t.Fatalf("expected stack deletion by ID, got %v", deleted)
+	}
+	if !strings.Contains(output, "phase=empty-buckets resource=app-logs action=emptied") || !strings.Contains(output, "phase=delete-stack resource=arn:aws:cloudformation:us-east-1:123456789012:stack/app/1 action=deleted") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestDeleteStackSkipsDeletionWhenBucketFails(t *testing.T) {
+	var deleted []string
+	describeCalls := 0
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(deleteStackTestClient(&deleted, &describeCalls)))
+	withMockEmptyBucket(t, func(context.Context, awssdk.Config, string) error {
+		return errors.New("access denied")
+	})
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stack", "--stack-name", "app", "--empty-buckets")
+	if err != nil {
+		t.Fatalf("execute delete-stack: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected no stack deletion, got %v", deleted)
+	}
+	if !strings.Contains(output, "resource=app-logs action=failed:access denied") || !strings.Contains(output, "action=skipped:bucket emptying failed") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestDeleteStackPromptMentionsBucketsOnlyWhenEmptying(t *testing.T) {
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{{StackName: cliutil.Ptr("app"), StackId: cliutil.Ptr("stack-id")}}}, nil
+		},
+		deleteStackFn: func(_ context.Context, _ *cloudformation.DeleteStackInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error) {
+			t.Fatal("stack must not be deleted when the prompt is declined")
+			return nil, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	// Without --no-confirm, stdin is empty so the prompt is declined.
+	output, err := executeCommand(t, "--output", "text", "cloudformation", "delete-stack", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute delete-stack: %v", err)
+	}
+	if !strings.Contains(output, "Delete stack app") || strings.Contains(output, "after emptying") {
+		t.Fatalf("expected a prompt without the bucket clause:\n%s", output)
+	}
+}
+
+func TestDeleteStackReportsDeleteFailed(t *testing.T) {
+	client := &mockClient{
+		describeStacksFn: func(_ context.Context, _ *cloudformation.DescribeStacksInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error) {
+			return &cloudformation.DescribeStacksOutput{Stacks: []cloudformationtypes.Stack{{
+				StackName:         cliutil.Ptr("app"),
+				StackId:           cliutil.Ptr("stack-id"),
+				StackStatus:       cloudformationtypes.StackStatusDeleteFailed,
+				StackStatusReason: cliutil.Ptr("bucket not empty"),
+			}}}, nil
+		},
+		deleteStackFn: func(_ context.Context, _ *cloudformation.DeleteStackInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackOutput, error) {
+			return &cloudformation.DeleteStackOutput{}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stack", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute delete-stack: %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(output), "stack_name=app phase=delete-stack resource=stack-id action=failed:stack DELETE_FAILED: bucket not empty") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestDeleteStackSetTimeoutReportsTimedOut(t *testing.T) {
+	polls := 0
+	deletes := 0
+	client := &mockClient{
+		listStackInstancesFn: func(_ context.Context, _ *cloudformation.ListStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.ListStackInstancesOutput, error) {
+			return &cloudformation.ListStackInstancesOutput{Summaries: []cloudformationtypes.StackInstanceSummary{
+				{Account: cliutil.Ptr("111111111111"), Region: cliutil.Ptr("us-east-1")},
+				{Account: cliutil.Ptr("222222222222"), Region: cliutil.Ptr("us-east-1")},
+			}}, nil
+		},
+		deleteStackInstancesFn: func(_ context.Context, _ *cloudformation.DeleteStackInstancesInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackInstancesOutput, error) {
+			deletes++
+			return &cloudformation.DeleteStackInstancesOutput{OperationId: cliutil.Ptr("op-1")}, nil
+		},
+		describeStackSetOperation: func(ctx context.Context, _ *cloudformation.DescribeStackSetOperationInput, _ ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOperationOutput, error) {
+			polls++
+			if _, ok := ctx.Deadline(); !ok {
+				t.Fatal("expected the wait to run under a deadline")
+			}
+			return &cloudformation.DescribeStackSetOperationOutput{
+				StackSetOperation: &cloudformationtypes.StackSetOperation{Status: cloudformationtypes.StackSetOperationStatusRunning},
+			}, nil
+		},
+		deleteStackSetFn: func(_ context.Context, _ *cloudformation.DeleteStackSetInput, _ ...func(*cloudformation.Options)) (*cloudformation.DeleteStackSetOutput, error) {
+			t.Fatal("stack set must not be deleted after a timed out instance")
+			return nil, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+	var slept []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return cliutil.SleepContext(ctx, d)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "cloudformation", "delete-stackset", "--stackset-name", "stackset-a", "--poll-interval", "1h", "--timeout", "20ms")
+	if err != nil {
+		t.Fatalf("execute delete-stackset --timeout: %v", err)
+	}
+	if !strings.Contains(output, "account=111111111111 region=us-east-1 resource=stack-instance action=failed:timed out") ||
+		!strings.Contains(output, "account=222222222222 region=us-east-1 resource=stack-instance action=skipped:timed out") ||
+		!strings.Contains(output, "resource=stackset action=skipped:stack instance deletion failed") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if polls != 1 || len(slept) != 1 || slept[0] != time.Hour {
+		t.Fatalf("expected the poll sleep to end at the deadline, polls=%d slept=%v", polls, slept)
+	}
+	if deletes != 1 {
+		t.Fatalf("expected the timeout to cover the whole delete, got %d instance deletions", deletes)
+	}
+}
+
+func TestDeleteStackSetValidatesPollInterval(t *testing.T) {
+	_, err := executeCommand(t, "cloudformation", "delete-stackset", "--stackset-name", "stackset-a", "--poll-interval", "0s")
+	if err == nil || !strings.Contains(err.Error(), "--poll-interval must be greater than 0") {
+		t.Fatalf("expected poll interval error, got %v", err)
+	}
+}
+
+func TestExportTemplate(t *testing.T) {
+	var stages []cloudformationtypes.TemplateStage
+	body := "AWSTemplateFormatVersion: '2010-09-09'\nResources: {}"
+	client := &mockClient{
+		getTemplateFn: func(_ context.Context, in *cloudformation.GetTemplateInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+			stages = append(stages, in.TemplateStage)
+			return &cloudformation.GetTemplateOutput{TemplateBody: cliutil.Ptr(body)}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	output, err := executeCommand(t, "cloudformation", "export-template", "--stack-name", "app")
+	if err != nil {
+		t.Fatalf("execute export-template: %v", err)
+	}
+	if output != body+"\n" {
+		t.Fatalf("unexpected stdout template: %q", output)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "app-backup")
+	output, err = executeCommand(t, "cloudformation", "export-template", "--stack-name", "app", "--out", outPath, "--stage", "processed")
+	if err != nil {
+		t.Fatalf("execute export-template --out: %v", err)
+	}
+	written, err := os.ReadFile(outPath + ".yaml")
+	if err != nil {
+		t.Fatalf("expected .yaml file: %v", err)
+	}
+	if string(written) != body+"\n" || !strings.Contains(output, "wrote yaml template of app to "+outPath+".yaml") {
+		t.Fatalf("unexpected file %q or output %q", written, output)
+	}
+	if len(stages) != 2 || stages[0] != cloudformationtypes.TemplateStageOriginal || stages[1] != cloudformationtypes.TemplateStageProcessed {
+		t.Fatalf("unexpected template stages: %v", stages)
+	}
+}
+
+func TestExportTemplateRejectsEmptyBody(t *testing.T) {
+	client := &mockClient{
+		getTemplateFn: func(_ context.Context, _ *cloudformation.GetTemplateInput, _ ...func(*cloudformation.Options)) (*cloudformation.GetTemplateOutput, error) {
+			return &cloudformation.GetTemplateOutput{}, nil
+		},
+	}
+	withMockDeps(t, defaultMockLoader(), defaultMockClientFactory(client))
+
+	outPath := filepath.Join(t.TempDir(), "app.json")
+	_, err := executeCommand(t, "cloudformation", "export-template", "--stack-name", "app", "--out", outPath)
+	if err == nil || !strings.Contains(err.Error(), "stack app has no Original template body") {
+		t.Fatalf("expected empty template error, got %v", err)
+	}
+	if _, statErr := os.Stat(outPath); !os.IsNotExist(statErr) {
+		t.Fatalf("expected no file to be written, stat err=%v", statErr)
+	}
+
+	_, err = executeCommand(t, "cloudformation", "export-template", "--stack-name", "app", "--stage", "deployed")
+	if err == nil || !strings.Contains(err.Error(), "--stage must be Original or Processed") {
+		t.Fatalf("expected stage error, got %v", err)
+	}
+}
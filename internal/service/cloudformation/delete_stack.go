@@ -0,0 +1,140 @@
+package cloudformation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+	awstbxs3 "github.com/towardsthecloud/aws-toolbox/internal/service/s3"
+)
+
+const (
+	deleteStackPhaseEmptyBuckets = "empty-buckets"
+	deleteStackPhaseDeleteStack  = "delete-stack"
+)
+
+var emptyBucket = awstbxs3.EmptyBucket
+
+// runDeleteStack deletes a stack and waits for DELETE_COMPLETE. With
+// emptyBuckets, the stack's S3 buckets are emptied first because CloudFormation
+// cannot delete a bucket that still holds objects; if any bucket fails to
+// empty, the stack is left in place.
+func runDeleteStack(cmd *cobra.Command, stackName string, emptyBuckets bool) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	out, err := client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{StackName: cliutil.Ptr(stackName)})
+	if err != nil {
+		return fmt.Errorf("describe stack: %w", awstbxaws.WrapUserError(err))
+	}
+	if len(out.Stacks) == 0 {
+		return fmt.Errorf("stack %q not found", stackName)
+	}
+	// Deleted stacks are only described by ID, so the wait polls the ID.
+	stackID := cliutil.PointerToString(out.Stacks[0].StackId)
+
+	rows := make([][]string, 0)
+	if emptyBuckets {
+		resources, listErr := listStackResources(ctx, client, stackName)
+		if listErr != nil {
+			return fmt.Errorf("list resources for stack %s: %w", stackName, awstbxaws.WrapUserError(listErr))
+		}
+		action := cliutil.ActionPending
+		if runtime.Options.DryRun {
+			action = "would-empty"
+		}
+		for _, resource := range resources {
+			bucket := cliutil.PointerToString(resource.PhysicalResourceId)
+			if cliutil.PointerToString(resource.ResourceType) != "AWS::S3::Bucket" || bucket == "" || resource.ResourceStatus == cloudformationtypes.ResourceStatusDeleteComplete {
+				continue
+			}
+			rows = append(rows, []string{stackName, deleteStackPhaseEmptyBuckets, bucket, action})
+		}
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+	rows = append(rows, []string{stackName, deleteStackPhaseDeleteStack, stackID, action})
+
+	confirmPrompt := fmt.Sprintf("Delete stack %s", stackName)
+	if buckets := len(rows) - 1; buckets > 0 {
+		confirmPrompt = fmt.Sprintf("Delete stack %s after emptying %d bucket(s)", stackName, buckets)
+	}
+
+	bucketFailed := false
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"stack_name", "phase", "resource", "action"},
+		Rows:          rows,
+		ActionColumn:  3,
+		ConfirmPrompt: confirmPrompt,
+		Execute: func(rowIndex int) string {
+			row := rows[rowIndex]
+			if row[1] == deleteStackPhaseEmptyBuckets {
+				if emptyErr := emptyBucket(ctx, cfg, row[2]); emptyErr != nil {
+					bucketFailed = true
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(emptyErr))
+				}
+				return "emptied"
+			}
+
+			if bucketFailed {
+				return cliutil.SkippedActionMessage("bucket emptying failed")
+			}
+			if _, deleteErr := client.DeleteStack(ctx, &cloudformation.DeleteStackInput{StackName: cliutil.Ptr(stackID)}); deleteErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			}
+			if waitErr := waitForStackDeleted(ctx, client, stackID); waitErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
+
+func waitForStackDeleted(ctx context.Context, client API, stackID string) error {
+	const maxAttempts = 360
+	const pollInterval = 5 * time.Second
+	for range maxAttempts {
+		resp, err := client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{StackName: cliutil.Ptr(stackID)})
+		if err != nil {
+			return err
+		}
+		if len(resp.Stacks) == 0 {
+			return nil
+		}
+
+		stack := resp.Stacks[0]
+		switch stack.StackStatus {
+		case cloudformationtypes.StackStatusDeleteComplete:
+			return nil
+		case cloudformationtypes.StackStatusDeleteFailed:
+			reason := strings.TrimSpace(cliutil.PointerToString(stack.StackStatusReason))
+			if reason != "" {
+				return fmt.Errorf("stack %s: %s", stack.StackStatus, reason)
+			}
+			return fmt.Errorf("stack %s", stack.StackStatus)
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for stack %s to delete", stackID)
+}
@@ -0,0 +1,105 @@
+package cloudformation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runDetectDrift starts drift detection on a stack, waits for it to finish and
+// lists the drift status of every resource that was checked.
+func runDetectDrift(cmd *cobra.Command, stackName string, driftedOnly bool) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	started, err := client.DetectStackDrift(ctx, &cloudformation.DetectStackDriftInput{StackName: cliutil.Ptr(stackName)})
+	if err != nil {
+		return fmt.Errorf("detect stack drift: %w", awstbxaws.WrapUserError(err))
+	}
+	detectionID := cliutil.PointerToString(started.StackDriftDetectionId)
+	if err := waitForDriftDetection(ctx, client, detectionID); err != nil {
+		return fmt.Errorf("wait for drift detection on %s: %w", stackName, awstbxaws.WrapUserError(err))
+	}
+
+	drifts, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[cloudformationtypes.StackResourceDrift], error) {
+		page, listErr := client.DescribeStackResourceDrifts(callCtx, &cloudformation.DescribeStackResourceDriftsInput{
+			StackName: cliutil.Ptr(stackName),
+			NextToken: nextToken,
+		})
+		if listErr != nil {
+			return awstbxaws.PageResult[cloudformationtypes.StackResourceDrift]{}, listErr
+		}
+		return awstbxaws.PageResult[cloudformationtypes.StackResourceDrift]{
+			Items:     page.StackResourceDrifts,
+			NextToken: page.NextToken,
+		}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("describe stack resource drifts: %w", awstbxaws.WrapUserError(err))
+	}
+
+	rows := make([][]string, 0, len(drifts))
+	for _, drift := range drifts {
+		status := drift.StackResourceDriftStatus
+		if driftedOnly && status != cloudformationtypes.StackResourceDriftStatusModified && status != cloudformationtypes.StackResourceDriftStatusDeleted {
+			continue
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(drift.LogicalResourceId),
+			cliutil.PointerToString(drift.ResourceType),
+			cliutil.PointerToString(drift.PhysicalResourceId),
+			string(status),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"logical_id", "resource_type", "physical_id", "drift_status"}, rows)
+}
+
+func waitForDriftDetection(ctx context.Context, client API, detectionID string) error {
+	const maxAttempts = 120
+	const pollInterval = 5 * time.Second
+	for range maxAttempts {
+		resp, err := client.DescribeStackDriftDetectionStatus(ctx, &cloudformation.DescribeStackDriftDetectionStatusInput{
+			StackDriftDetectionId: cliutil.Ptr(detectionID),
+		})
+		if err != nil {
+			return err
+		}
+
+		switch resp.DetectionStatus {
+		case cloudformationtypes.StackDriftDetectionStatusDetectionComplete:
+			return nil
+		case cloudformationtypes.StackDriftDetectionStatusDetectionFailed:
+			reason := strings.TrimSpace(cliutil.PointerToString(resp.DetectionStatusReason))
+			if reason != "" {
+				return fmt.Errorf("drift detection %s: %s", resp.DetectionStatus, reason)
+			}
+			return fmt.Errorf("drift detection %s", resp.DetectionStatus)
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for drift detection %s", detectionID)
+}
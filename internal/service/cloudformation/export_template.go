@@ -0,0 +1,78 @@
+package cloudformation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runExportTemplate writes a stack's template body unchanged to stdout or to
+// outPath. When outPath has no extension, .json or .yaml is added to match
+// the template body.
+func runExportTemplate(cmd *cobra.Command, stackName, outPath, stage string) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+	var templateStage cloudformationtypes.TemplateStage
+	switch strings.ToLower(strings.TrimSpace(stage)) {
+	case "original":
+		templateStage = cloudformationtypes.TemplateStageOriginal
+	case "processed":
+		templateStage = cloudformationtypes.TemplateStageProcessed
+	default:
+		return fmt.Errorf("--stage must be Original or Processed")
+	}
+
+	_, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	out, err := client.GetTemplate(cmd.Context(), &cloudformation.GetTemplateInput{
+		StackName:     cliutil.Ptr(stackName),
+		TemplateStage: templateStage,
+	})
+	if err != nil {
+		return fmt.Errorf("get template: %w", awstbxaws.WrapUserError(err))
+	}
+	body := cliutil.PointerToString(out.TemplateBody)
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("stack %s has no %s template body", stackName, templateStage)
+	}
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	outPath = strings.TrimSpace(outPath)
+	if outPath == "" {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), body)
+		return err
+	}
+
+	format := templateBodyFormat(body)
+	if filepath.Ext(outPath) == "" {
+		outPath += "." + format
+	}
+	if err := os.WriteFile(outPath, []byte(body), 0o644); err != nil {
+		return fmt.Errorf("write template: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s template of %s to %s\n", format, stackName, outPath)
+	return nil
+}
+
+// templateBodyFormat reports "json" for a JSON template and "yaml" otherwise,
+// since those are the only two formats CloudFormation accepts.
+func templateBodyFormat(body string) string {
+	if strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return "json"
+	}
+	return "yaml"
+}
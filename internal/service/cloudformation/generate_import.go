@@ -0,0 +1,280 @@
+package cloudformation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+	"gopkg.in/yaml.v3"
+)
+
+// importIdentifierKeys maps common resource types to the property CloudFormation
+// uses as their import identifier. Other types need --identifier-key.
+var importIdentifierKeys = map[string]string{
+	"AWS::DynamoDB::Table":        "TableName",
+	"AWS::EC2::Instance":          "InstanceId",
+	"AWS::EC2::SecurityGroup":     "Id",
+	"AWS::EC2::VPC":               "VpcId",
+	"AWS::ECR::Repository":        "RepositoryName",
+	"AWS::IAM::Role":              "RoleName",
+	"AWS::KMS::Key":               "KeyId",
+	"AWS::Lambda::Function":       "FunctionName",
+	"AWS::Logs::LogGroup":         "LogGroupName",
+	"AWS::S3::Bucket":             "BucketName",
+	"AWS::SNS::Topic":             "TopicArn",
+	"AWS::SQS::Queue":             "QueueUrl",
+	"AWS::SSM::Parameter":         "Name",
+	"AWS::SecretsManager::Secret": "Id",
+}
+
+// importIdentifierAttributes lists the types above whose identifier is a
+// read-only attribute rather than a template property. Putting it in
+// Properties fails template validation, so it stays in ResourceIdentifier.
+var importIdentifierAttributes = map[string]bool{
+	"AWS::EC2::Instance":          true,
+	"AWS::EC2::SecurityGroup":     true,
+	"AWS::EC2::VPC":               true,
+	"AWS::KMS::Key":               true,
+	"AWS::SNS::Topic":             true,
+	"AWS::SQS::Queue":             true,
+	"AWS::SecretsManager::Secret": true,
+}
+
+// importIdentifierIsProperty reports whether key is a known writable name
+// property of resourceType, so the stub can carry the identifier in
+// Properties. Keys set with --identifier-key for other types are not copied.
+func importIdentifierIsProperty(resourceType, key string) bool {
+	return importIdentifierKeys[resourceType] == key && !importIdentifierAttributes[resourceType]
+}
+
+type resourceToImport struct {
+	ResourceType       string            `json:"ResourceType"`
+	LogicalResourceID  string            `json:"LogicalResourceId"`
+	ResourceIdentifier map[string]string `json:"ResourceIdentifier"`
+}
+
+func runGenerateImport(cmd *cobra.Command, stackName, resourceType, identifier, identifierKey, logicalID, outputFile string) error {
+	stackName = strings.TrimSpace(stackName)
+	resourceType = strings.TrimSpace(resourceType)
+	identifier = strings.TrimSpace(identifier)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+	if resourceType == "" {
+		return fmt.Errorf("--resource-type is required")
+	}
+	if identifier == "" {
+		return fmt.Errorf("--identifier is required")
+	}
+
+	key := strings.TrimSpace(identifierKey)
+	if key == "" {
+		key = importIdentifierKeys[resourceType]
+	}
+	if key == "" {
+		return fmt.Errorf("no known import identifier for %s; set --identifier-key", resourceType)
+	}
+
+	logicalID = strings.TrimSpace(logicalID)
+	if logicalID == "" {
+		logicalID = importLogicalID(identifier)
+	}
+
+	_, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	templateOut, err := client.GetTemplate(cmd.Context(), &cloudformation.GetTemplateInput{
+		StackName:     cliutil.Ptr(stackName),
+		TemplateStage: cloudformationtypes.TemplateStageOriginal,
+	})
+	if err != nil {
+		return fmt.Errorf("get template: %w", awstbxaws.WrapUserError(err))
+	}
+
+	template, err := mergeImportResource(cliutil.PointerToString(templateOut.TemplateBody), logicalID, resourceType, key, identifier)
+	if err != nil {
+		return err
+	}
+
+	resources := []resourceToImport{{
+		ResourceType:       resourceType,
+		LogicalResourceID:  logicalID,
+		ResourceIdentifier: map[string]string{key: identifier},
+	}}
+	changeSetName := "import-" + strings.ToLower(logicalID)
+	out := cmd.OutOrStdout()
+
+	// Without --output-file, stdout carries a single create-change-set input
+	// document holding both the mapping and the merged template.
+	if outputFile == "" {
+		input, err := json.MarshalIndent(importChangeSetInput{
+			StackName:         stackName,
+			ChangeSetName:     changeSetName,
+			ChangeSetType:     string(cloudformationtypes.ChangeSetTypeImport),
+			ResourcesToImport: resources,
+			TemplateBody:      string(template),
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "%s\n", input); err != nil {
+			return err
+		}
+		fmt.Fprint(
+			cmd.ErrOrStderr(),
+			"next: save the output above as import.json and run\n"+
+				"  aws cloudformation create-change-set --cli-input-json file://import.json\n"+
+				"then execute the change set; alternatively create a regular change set from TemplateBody with --import-existing-resources\n",
+		)
+		return nil
+	}
+
+	if err := os.WriteFile(outputFile, template, 0o644); err != nil {
+		return fmt.Errorf("write template: %w", err)
+	}
+	mapping, err := json.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "%s\n", mapping); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		cmd.ErrOrStderr(),
+		"next: save the mapping above as resources-to-import.json and run\n"+
+			"  aws cloudformation create-change-set --stack-name %s --change-set-name %s --change-set-type IMPORT --resources-to-import file://resources-to-import.json --template-body file://%s\n"+
+			"or pass --import-existing-resources to a regular change set, then execute the change set\n",
+		stackName, changeSetName, outputFile,
+	)
+
+	return nil
+}
+
+// importChangeSetInput is the create-change-set --cli-input-json document for
+// an import.
+type importChangeSetInput struct {
+	StackName         string             `json:"StackName"`
+	ChangeSetName     string             `json:"ChangeSetName"`
+	ChangeSetType     string             `json:"ChangeSetType"`
+	ResourcesToImport []resourceToImport `json:"ResourcesToImport"`
+	TemplateBody      string             `json:"TemplateBody"`
+}
+
+// importResource is a template resource for an import, which CloudFormation
+// only accepts with the Retain deletion policy.
+type importResource struct {
+	Type           string            `json:"Type" yaml:"Type"`
+	DeletionPolicy string            `json:"DeletionPolicy" yaml:"DeletionPolicy"`
+	Properties     map[string]string `json:"Properties,omitempty" yaml:"Properties,omitempty"`
+}
+
+// mergeImportResource adds the resource to the stack's template and returns
+// the full template in its original format. YAML templates are edited as a
+// node tree so short-form intrinsic functions such as !Ref survive.
+func mergeImportResource(templateBody, logicalID, resourceType, key, identifier string) ([]byte, error) {
+	resource := importResource{
+		Type:           resourceType,
+		DeletionPolicy: "Retain",
+	}
+	if importIdentifierIsProperty(resourceType, key) {
+		resource.Properties = map[string]string{key: identifier}
+	}
+
+	body := strings.TrimSpace(templateBody)
+	if !strings.HasPrefix(body, "{") {
+		return mergeImportResourceYAML(body, logicalID, resource)
+	}
+
+	var template map[string]any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&template); err != nil {
+		return nil, fmt.Errorf("parse stack template: %w", err)
+	}
+
+	resources, _ := template["Resources"].(map[string]any)
+	if resources == nil {
+		resources = make(map[string]any)
+	}
+	if _, exists := resources[logicalID]; exists {
+		return nil, fmt.Errorf("logical ID %q already exists in the stack template; set --logical-id", logicalID)
+	}
+	resources[logicalID] = resource
+	template["Resources"] = resources
+
+	return json.MarshalIndent(template, "", "  ")
+}
+
+func mergeImportResourceYAML(body, logicalID string, resource importResource) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &document); err != nil {
+		return nil, fmt.Errorf("parse stack template: %w", err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse stack template: expected a YAML mapping")
+	}
+	root := document.Content[0]
+
+	resources := yamlMappingValue(root, "Resources")
+	if resources == nil {
+		resources = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "Resources"}, resources)
+	}
+	if resources.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse stack template: Resources is not a mapping")
+	}
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		if resources.Content[i].Value == logicalID {
+			return nil, fmt.Errorf("logical ID %q already exists in the stack template; set --logical-id", logicalID)
+		}
+	}
+
+	var resourceNode yaml.Node
+	if err := resourceNode.Encode(resource); err != nil {
+		return nil, err
+	}
+	resources.Content = append(resources.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: logicalID}, &resourceNode)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// importLogicalID derives an alphanumeric logical ID from a physical identifier.
+func importLogicalID(identifier string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range identifier {
+		isAlnum := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlnum {
+			upperNext = true
+			continue
+		}
+		if upperNext && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upperNext = false
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "ImportedResource"
+	}
+	return "Imported" + b.String()
+}
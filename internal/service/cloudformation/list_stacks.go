@@ -0,0 +1,83 @@
+package cloudformation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runListStacks(cmd *cobra.Command, statuses []string, nameContains, createdBefore string, olderThanDays int, includeNested bool) error {
+	if olderThanDays < 0 {
+		return fmt.Errorf("--older-than-days must be >= 0")
+	}
+
+	wantStatus := make(map[cloudformationtypes.StackStatus]bool, len(statuses))
+	known := cloudformationtypes.StackStatus("").Values()
+	for _, raw := range statuses {
+		status := cloudformationtypes.StackStatus(strings.ToUpper(strings.TrimSpace(raw)))
+		if status == "" {
+			continue
+		}
+		if !slices.Contains(known, status) {
+			return fmt.Errorf("--status %q is not a CloudFormation stack status", raw)
+		}
+		// DescribeStacks never returns deleted stacks, so this filter could
+		// only ever produce an empty table.
+		if status == cloudformationtypes.StackStatusDeleteComplete {
+			return fmt.Errorf("--status %s is not supported: deleted stacks are not listed", status)
+		}
+		wantStatus[status] = true
+	}
+
+	var cutoff time.Time
+	spec := strings.TrimSpace(createdBefore)
+	if spec == "" && olderThanDays > 0 {
+		spec = fmt.Sprintf("%dd", olderThanDays)
+	}
+	if spec != "" {
+		parsed, err := cliutil.ParseTimeSpec(spec)
+		if err != nil {
+			return fmt.Errorf("--created-before: %w", err)
+		}
+		cutoff = parsed
+	}
+	needle := strings.ToLower(strings.TrimSpace(nameContains))
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	stacks, err := listStacksForSearch(cmd.Context(), client, includeNested)
+	if err != nil {
+		return fmt.Errorf("list stacks: %w", awstbxaws.WrapUserError(err))
+	}
+
+	rows := make([][]string, 0, len(stacks))
+	for _, stack := range stacks {
+		name := cliutil.PointerToString(stack.StackName)
+		if len(wantStatus) > 0 && !wantStatus[stack.StackStatus] {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(name), needle) {
+			continue
+		}
+		if !cutoff.IsZero() && (stack.CreationTime == nil || !stack.CreationTime.Before(cutoff)) {
+			continue
+		}
+
+		created := ""
+		if stack.CreationTime != nil {
+			created = stack.CreationTime.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{name, string(stack.StackStatus), created, cliutil.PointerToString(stack.ParentId)})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"stack_name", "status", "created", "parent_id"}, rows)
+}
@@ -0,0 +1,151 @@
+package cloudformation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+	"gopkg.in/yaml.v3"
+)
+
+// resourceTagFilter selects resources by tag in find-stack-by-resource. An
+// empty value matches any value of the key.
+type resourceTagFilter struct {
+	Key   string
+	Value string
+}
+
+func (f resourceTagFilter) enabled() bool {
+	return f.Key != ""
+}
+
+// stackResourceTags returns the tags CloudFormation applies to each resource
+// of a stack, keyed by logical ID. ListStackResources and DescribeStackResource
+// do not return tags, so they come from the stack's tags, which CloudFormation
+// propagates to the resources it creates, and from the Tags property declared
+// on each resource in the stack's JSON or YAML template.
+func stackResourceTags(ctx context.Context, client API, stack cloudformationtypes.Stack) (map[string]string, map[string]map[string]string, error) {
+	stackTags := make(map[string]string, len(stack.Tags))
+	for _, tag := range stack.Tags {
+		stackTags[cliutil.PointerToString(tag.Key)] = cliutil.PointerToString(tag.Value)
+	}
+
+	out, err := client.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+		StackName:     stack.StackName,
+		TemplateStage: cloudformationtypes.TemplateStageOriginal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stackTags, templateResourceTags(cliutil.PointerToString(out.TemplateBody)), nil
+}
+
+// templateResourceTags reads literal tag values from each resource's Tags
+// property. Both the list-of-Key/Value form and the map form used by some
+// resource types are accepted; values built with intrinsic functions, in
+// either the Fn:: or the YAML short form, are skipped because they are only
+// resolved at deploy time. YAML is a superset of JSON, so one parser reads
+// both template formats.
+func templateResourceTags(templateBody string) map[string]map[string]string {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(templateBody), &document); err != nil {
+		return nil
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil
+	}
+	resources := yamlMappingValue(document.Content[0], "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	tags := make(map[string]map[string]string)
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		logicalID := resources.Content[i].Value
+		raw := yamlMappingValue(yamlMappingValue(resources.Content[i+1], "Properties"), "Tags")
+		if raw == nil {
+			continue
+		}
+		resourceTags := make(map[string]string)
+
+		switch raw.Kind {
+		case yaml.SequenceNode:
+			for _, tag := range raw.Content {
+				key, value := yamlMappingValue(tag, "Key"), yamlMappingValue(tag, "Value")
+				if isLiteralYAMLString(key) && isLiteralYAMLString(value) {
+					resourceTags[key.Value] = value.Value
+				}
+			}
+		case yaml.MappingNode:
+			for j := 0; j+1 < len(raw.Content); j += 2 {
+				if value := raw.Content[j+1]; isLiteralYAMLString(value) {
+					resourceTags[raw.Content[j].Value] = value.Value
+				}
+			}
+		}
+		if len(resourceTags) > 0 {
+			tags[logicalID] = resourceTags
+		}
+	}
+	return tags
+}
+
+// yamlMappingValue returns the value stored under key in a mapping node, or
+// nil when node is not a mapping or has no such key.
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// isLiteralYAMLString reports whether node is a plain scalar rather than an
+// intrinsic function such as !Ref or !Sub. Unquoted numbers and booleans count,
+// since CloudFormation reads them as strings for tag values.
+func isLiteralYAMLString(node *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return false
+	}
+	tag := node.ShortTag()
+	return strings.HasPrefix(tag, "!!") && tag != "!!null"
+}
+
+// mergedResourceTags overlays a resource's template tags on the stack's tags;
+// a tag declared on the resource wins over a propagated stack tag.
+func mergedResourceTags(stackTags, resourceTags map[string]string) map[string]string {
+	merged := make(map[string]string, len(stackTags)+len(resourceTags))
+	for key, value := range stackTags {
+		merged[key] = value
+	}
+	for key, value := range resourceTags {
+		merged[key] = value
+	}
+	return merged
+}
+
+// resourceTagsMatch reports whether the filter key is present with a matching
+// value, using the same exact or substring semantics as --resource.
+func resourceTagsMatch(tags map[string]string, filter resourceTagFilter, exact bool) bool {
+	for key, value := range tags {
+		if !strings.EqualFold(key, filter.Key) {
+			continue
+		}
+		if filter.Value == "" {
+			return true
+		}
+		if exact && strings.EqualFold(strings.TrimSpace(value), filter.Value) {
+			return true
+		}
+		if !exact && strings.Contains(strings.ToLower(value), strings.ToLower(filter.Value)) {
+			return true
+		}
+	}
+	return false
+}
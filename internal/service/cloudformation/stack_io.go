@@ -0,0 +1,89 @@
+package cloudformation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const noEchoMask = "****"
+
+// runStackIO lists a stack's parameters and outputs. DescribeStacks does not
+// say which parameters are NoEcho, so the template summary is read to mask
+// them explicitly rather than trusting every value it returns.
+func runStackIO(cmd *cobra.Command, stackName string, parametersOnly, outputsOnly bool) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+	if parametersOnly && outputsOnly {
+		return fmt.Errorf("--parameters-only and --outputs-only cannot be combined")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	out, err := client.DescribeStacks(cmd.Context(), &cloudformation.DescribeStacksInput{StackName: cliutil.Ptr(stackName)})
+	if err != nil {
+		return fmt.Errorf("describe stack: %w", awstbxaws.WrapUserError(err))
+	}
+	if len(out.Stacks) == 0 {
+		return fmt.Errorf("stack %q not found", stackName)
+	}
+	stack := out.Stacks[0]
+
+	rows := make([][]string, 0, len(stack.Parameters)+len(stack.Outputs))
+	if !outputsOnly {
+		summary, summaryErr := client.GetTemplateSummary(cmd.Context(), &cloudformation.GetTemplateSummaryInput{StackName: cliutil.Ptr(stackName)})
+		if summaryErr != nil {
+			return fmt.Errorf("get template summary: %w", awstbxaws.WrapUserError(summaryErr))
+		}
+		noEcho := make(map[string]bool, len(summary.Parameters))
+		descriptions := make(map[string]string, len(summary.Parameters))
+		for _, declaration := range summary.Parameters {
+			key := cliutil.PointerToString(declaration.ParameterKey)
+			noEcho[key] = awssdk.ToBool(declaration.NoEcho)
+			descriptions[key] = cliutil.PointerToString(declaration.Description)
+		}
+
+		parameters := make([][]string, 0, len(stack.Parameters))
+		for _, parameter := range stack.Parameters {
+			key := cliutil.PointerToString(parameter.ParameterKey)
+			value := cliutil.PointerToString(parameter.ParameterValue)
+			if resolved := cliutil.PointerToString(parameter.ResolvedValue); resolved != "" {
+				value = resolved
+			}
+			if noEcho[key] {
+				value = noEchoMask
+			}
+			parameters = append(parameters, []string{"parameter", key, value, "", descriptions[key]})
+		}
+		sort.Slice(parameters, func(i, j int) bool { return parameters[i][1] < parameters[j][1] })
+		rows = append(rows, parameters...)
+	}
+
+	if !parametersOnly {
+		outputs := make([][]string, 0, len(stack.Outputs))
+		for _, output := range stack.Outputs {
+			outputs = append(outputs, []string{
+				"output",
+				cliutil.PointerToString(output.OutputKey),
+				cliutil.PointerToString(output.OutputValue),
+				cliutil.PointerToString(output.ExportName),
+				cliutil.PointerToString(output.Description),
+			})
+		}
+		sort.Slice(outputs, func(i, j int) bool { return outputs[i][1] < outputs[j][1] })
+		rows = append(rows, outputs...)
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"kind", "key", "value", "export_name", "description"}, rows)
+}
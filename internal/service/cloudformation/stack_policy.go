@@ -0,0 +1,159 @@
+package cloudformation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+type stackPolicy struct {
+	Statement []stackPolicyStatement `json:"Statement"`
+}
+
+type stackPolicyStatement struct {
+	Effect    string `json:"Effect"`
+	Action    any    `json:"Action"`
+	Principal string `json:"Principal"`
+	Resource  any    `json:"Resource"`
+}
+
+func runGetStackPolicy(cmd *cobra.Command, stackName string) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+
+	_, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	out, err := client.GetStackPolicy(cmd.Context(), &cloudformation.GetStackPolicyInput{StackName: cliutil.Ptr(stackName)})
+	if err != nil {
+		return fmt.Errorf("get stack policy: %w", awstbxaws.WrapUserError(err))
+	}
+
+	body := strings.TrimSpace(cliutil.PointerToString(out.StackPolicyBody))
+	if body == "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "stack %s has no stack policy; all resources can be updated\n", stackName)
+		return nil
+	}
+
+	policy, err := indentPolicy([]byte(body))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", policy)
+	return err
+}
+
+func runSetStackPolicy(cmd *cobra.Command, stackName, policyFile string, protectTypes []string) error {
+	stackName = strings.TrimSpace(stackName)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+	if strings.TrimSpace(policyFile) == "" && len(protectTypes) == 0 {
+		return fmt.Errorf("set --policy-file or --protect-types")
+	}
+	for _, pattern := range protectTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --protect-types pattern %q: %w", pattern, err)
+		}
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	var policy []byte
+	if strings.TrimSpace(policyFile) != "" {
+		data, readErr := os.ReadFile(policyFile)
+		if readErr != nil {
+			return fmt.Errorf("read policy file: %w", readErr)
+		}
+		policy, err = indentPolicy(data)
+		if err != nil {
+			return err
+		}
+	} else {
+		policy, err = protectTypesPolicy(cmd, client, stackName, protectTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s\n", policy); err != nil {
+		return err
+	}
+	if runtime.Options.DryRun {
+		fmt.Fprintf(cmd.ErrOrStderr(), "dry-run: stack policy not applied to %s\n", stackName)
+		return nil
+	}
+
+	ok, err := runtime.Prompter.Confirm(fmt.Sprintf("Replace the stack policy of %q", stackName), runtime.Options.NoConfirm)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintln(cmd.ErrOrStderr(), "stack policy not applied: cancelled")
+		return nil
+	}
+
+	_, err = client.SetStackPolicy(cmd.Context(), &cloudformation.SetStackPolicyInput{
+		StackName:       cliutil.Ptr(stackName),
+		StackPolicyBody: cliutil.Ptr(string(policy)),
+	})
+	if err != nil {
+		return fmt.Errorf("set stack policy: %w", awstbxaws.WrapUserError(err))
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "stack policy applied to %s\n", stackName)
+	return nil
+}
+
+// protectTypesPolicy allows all updates except replacement and deletion of the
+// stack's resources whose type matches one of the patterns.
+func protectTypesPolicy(cmd *cobra.Command, client API, stackName string, patterns []string) ([]byte, error) {
+	resources, err := listStackResources(cmd.Context(), client, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("list stack resources: %w", awstbxaws.WrapUserError(err))
+	}
+
+	protected := make([]string, 0)
+	for _, resource := range resources {
+		resourceType := cliutil.PointerToString(resource.ResourceType)
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, resourceType); matched {
+				protected = append(protected, "LogicalResourceId/"+cliutil.PointerToString(resource.LogicalResourceId))
+				break
+			}
+		}
+	}
+	if len(protected) == 0 {
+		return nil, fmt.Errorf("no resources in stack %s match --protect-types %s", stackName, strings.Join(patterns, ","))
+	}
+	sort.Strings(protected)
+
+	return json.MarshalIndent(stackPolicy{Statement: []stackPolicyStatement{
+		{Effect: "Allow", Action: "Update:*", Principal: "*", Resource: "*"},
+		{Effect: "Deny", Action: []string{"Update:Replace", "Update:Delete"}, Principal: "*", Resource: protected},
+	}}, "", "  ")
+}
+
+func indentPolicy(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(data), "", "  "); err != nil {
+		return nil, fmt.Errorf("parse stack policy: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,147 @@
+package cloudformation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+type stackTreeNode struct {
+	stack    cloudformationtypes.Stack
+	depth    int
+	children []*stackTreeNode
+}
+
+func runStackTree(cmd *cobra.Command, name, format string) error {
+	stackName := strings.TrimSpace(name)
+	if stackName == "" {
+		return fmt.Errorf("--stack-name is required")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "tree" && format != "mermaid" {
+		return fmt.Errorf("--format must be one of: tree, mermaid")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	stacks, err := listStacksForSearch(cmd.Context(), client, true)
+	if err != nil {
+		return fmt.Errorf("list stacks: %w", awstbxaws.WrapUserError(err))
+	}
+
+	root, err := buildStackTree(stacks, stackName)
+	if err != nil {
+		return err
+	}
+
+	if format == "mermaid" {
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.Join(renderStackTreeMermaid(root), "\n"))
+		return err
+	}
+
+	rows := make([][]string, 0)
+	walkStackTree(root, func(node *stackTreeNode) {
+		rows = append(rows, []string{
+			strings.Repeat("  ", node.depth) + cliutil.PointerToString(node.stack.StackName),
+			string(node.stack.StackStatus),
+			fmt.Sprintf("%d", node.depth),
+		})
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"stack_name", "status", "depth"}, rows)
+}
+
+// buildStackTree links stacks to their parent via ParentId and returns the
+// subtree rooted at the stack matching rootName (by name or stack id).
+func buildStackTree(stacks []cloudformationtypes.Stack, rootName string) (*stackTreeNode, error) {
+	nodes := make(map[string]*stackTreeNode, len(stacks))
+	var root *stackTreeNode
+	for _, stack := range stacks {
+		node := &stackTreeNode{stack: stack}
+		nodes[cliutil.PointerToString(stack.StackId)] = node
+		if cliutil.PointerToString(stack.StackName) == rootName || cliutil.PointerToString(stack.StackId) == rootName {
+			root = node
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("stack %q not found", rootName)
+	}
+
+	for _, stack := range stacks {
+		parentID := cliutil.PointerToString(stack.ParentId)
+		if parentID == "" {
+			continue
+		}
+		parent, ok := nodes[parentID]
+		if !ok {
+			continue
+		}
+		parent.children = append(parent.children, nodes[cliutil.PointerToString(stack.StackId)])
+	}
+
+	var assignDepth func(node *stackTreeNode, depth int)
+	assignDepth = func(node *stackTreeNode, depth int) {
+		node.depth = depth
+		sort.Slice(node.children, func(i, j int) bool {
+			return cliutil.PointerToString(node.children[i].stack.StackName) < cliutil.PointerToString(node.children[j].stack.StackName)
+		})
+		for _, child := range node.children {
+			assignDepth(child, depth+1)
+		}
+	}
+	assignDepth(root, 0)
+
+	return root, nil
+}
+
+func walkStackTree(node *stackTreeNode, visit func(*stackTreeNode)) {
+	visit(node)
+	for _, child := range node.children {
+		walkStackTree(child, visit)
+	}
+}
+
+// renderStackTreeMermaid suffixes each node ID with its walk index, because
+// sanitizing stack names can map different names to the same ID.
+func renderStackTreeMermaid(root *stackTreeNode) []string {
+	ids := make(map[*stackTreeNode]string)
+	walkStackTree(root, func(node *stackTreeNode) {
+		ids[node] = fmt.Sprintf("%s_%d", mermaidID(cliutil.PointerToString(node.stack.StackName)), len(ids))
+	})
+
+	lines := []string{"graph TB"}
+	walkStackTree(root, func(node *stackTreeNode) {
+		lines = append(lines, fmt.Sprintf("    %s[\"%s<br/>%s\"]", ids[node], mermaidEscape(cliutil.PointerToString(node.stack.StackName)), node.stack.StackStatus))
+		for _, child := range node.children {
+			lines = append(lines, fmt.Sprintf("    %s --> %s", ids[node], ids[child]))
+		}
+	})
+	return lines
+}
+
+func mermaidID(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(raw) {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "node"
+	}
+	return b.String()
+}
+
+func mermaidEscape(raw string) string {
+	return strings.ReplaceAll(raw, "\"", `\"`)
+}
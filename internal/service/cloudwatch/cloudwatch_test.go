@@ -3,6 +3,7 @@ package cloudwatch
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -238,6 +239,15 @@ func TestCloudWatchSetRetentionDryRun(t *testing.T) {
 	if !strings.Contains(output, "would-update") || !strings.Contains(output, "/aws/lambda/api") {
 		t.Fatalf("unexpected output: %s", output)
 	}
+	var records []map[string]string
+	if err := json.Unmarshal([]byte(output), &records); err != nil {
+		t.Fatalf("decode dry-run output: %v\n%s", err, output)
+	}
+	if len(records) != 2 ||
+		records[0]["current_retention_days"] != "7" || records[0]["target_retention_days"] != "30" || records[0]["retention_days_change"] != "7 -> 30" ||
+		records[1]["retention_days_change"] != "not_set -> 30" {
+		t.Fatalf("expected current, target and change values in dry-run output: %s", output)
+	}
 }
 
 func TestCloudWatchSetRetentionExecutesWhenNoConfirm(t *testing.T) {
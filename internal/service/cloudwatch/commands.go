@@ -22,7 +22,7 @@ func runCountLogGroups(cmd *cobra.Command, _ []string) error {
 
 	groups, err := listLogGroups(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list log groups: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list log groups: %w", awstbxaws.WrapUserError(err))
 	}
 
 	rows := [][]string{{"total_log_groups", fmt.Sprintf("%d", len(groups))}}
@@ -37,7 +37,7 @@ func runListLogGroups(cmd *cobra.Command, _ []string) error {
 
 	groups, err := listLogGroups(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list log groups: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list log groups: %w", awstbxaws.WrapUserError(err))
 	}
 
 	now := time.Now().UTC()
@@ -83,7 +83,7 @@ func runDeleteLogGroups(cmd *cobra.Command, retentionDays int, nameContains stri
 
 	groups, err := listLogGroups(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list log groups: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list log groups: %w", awstbxaws.WrapUserError(err))
 	}
 
 	now := time.Now().UTC()
@@ -154,7 +154,7 @@ func runSetRetention(cmd *cobra.Command, retentionDays int, printCounts bool) er
 
 	groups, err := listLogGroups(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list log groups: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list log groups: %w", awstbxaws.WrapUserError(err))
 	}
 
 	if printCounts {
@@ -174,22 +174,22 @@ func runSetRetention(cmd *cobra.Command, retentionDays int, printCounts bool) er
 	}
 	sortLogGroupsByName(targets)
 
+	headers := append(append([]string{"log_group"}, cliutil.ChangeHeaders("retention_days")...), "action")
+	actionColumn := len(headers) - 1
+
 	rows := make([][]string, 0, len(targets))
 	for _, target := range targets {
 		action := "would-update"
 		if !runtime.Options.DryRun {
 			action = "pending"
 		}
-		rows = append(rows, []string{
-			cliutil.PointerToString(target.LogGroupName),
-			retentionToString(target.RetentionInDays),
-			fmt.Sprintf("%d", targetRetention),
-			action,
-		})
+		row := []string{cliutil.PointerToString(target.LogGroupName)}
+		row = append(row, cliutil.ChangeColumns(retentionToString(target.RetentionInDays), fmt.Sprintf("%d", targetRetention))...)
+		rows = append(rows, append(row, action))
 	}
 
 	if len(targets) == 0 {
-		return cliutil.WriteDataset(cmd, runtime, []string{"log_group", "current_retention_days", "target_retention_days", "action"}, rows)
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
 	}
 
 	if !runtime.Options.DryRun {
@@ -202,9 +202,9 @@ func runSetRetention(cmd *cobra.Command, retentionDays int, printCounts bool) er
 		}
 		if !ok {
 			for i := range rows {
-				rows[i][3] = "cancelled"
+				rows[i][actionColumn] = "cancelled"
 			}
-			return cliutil.WriteDataset(cmd, runtime, []string{"log_group", "current_retention_days", "target_retention_days", "action"}, rows)
+			return cliutil.WriteDataset(cmd, runtime, headers, rows)
 		}
 
 		for i, target := range targets {
@@ -213,14 +213,14 @@ func runSetRetention(cmd *cobra.Command, retentionDays int, printCounts bool) er
 				RetentionInDays: cliutil.Ptr(targetRetention),
 			})
 			if updateErr != nil {
-				rows[i][3] = "failed: " + awstbxaws.FormatUserError(updateErr)
+				rows[i][actionColumn] = "failed: " + awstbxaws.FormatUserError(updateErr)
 				continue
 			}
-			rows[i][3] = "updated"
+			rows[i][actionColumn] = "updated"
 		}
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"log_group", "current_retention_days", "target_retention_days", "action"}, rows)
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
 }
 
 func writeRetentionCounts(cmd *cobra.Command, runtime cliutil.CommandRuntime, groups []cloudwatchlogstypes.LogGroup) error {
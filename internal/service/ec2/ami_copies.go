@@ -0,0 +1,137 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+var amiIDPattern = regexp.MustCompile(`^ami-[0-9a-f]+$`)
+
+// amiIDTokenPattern finds AMI IDs inside free text such as an image
+// description.
+var amiIDTokenPattern = regexp.MustCompile(`\bami-[0-9a-f]+\b`)
+
+// amiCopySourceTagKeys are tag keys commonly set on copied AMIs to record the
+// image they were copied from.
+var amiCopySourceTagKeys = []string{"SourceAMI", "SourceAmiId", "source-ami", "source_ami"}
+
+type amiCopy struct {
+	ImageID string
+	Name    string
+	Region  string
+	Match   string
+}
+
+func runFindAMICopies(cmd *cobra.Command, sourceAMI string, deregister bool) error {
+	sourceAMI = strings.TrimSpace(sourceAMI)
+	if !amiIDPattern.MatchString(sourceAMI) {
+		return fmt.Errorf("--source-ami must be an AMI id like ami-0123456789abcdef0")
+	}
+
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	regions, skipped, err := listRegions(cmd.Context(), baseClient)
+	if err != nil {
+		return fmt.Errorf("list regions: %w", awstbxaws.WrapUserError(err))
+	}
+	copies, regionStatuses := cliutil.ScanRegions(cmd.Context(), regions, skipped, func(ctx context.Context, region string) ([]amiCopy, error) {
+		return collectAMICopies(ctx, newRegionalClient(cfg, region), region, sourceAMI)
+	})
+	if err := cliutil.WriteRegionSummary(cmd, runtime, regionStatuses); err != nil {
+		return err
+	}
+
+	sort.Slice(copies, func(i, j int) bool {
+		if copies[i].Region == copies[j].Region {
+			return copies[i].ImageID < copies[j].ImageID
+		}
+		return copies[i].Region < copies[j].Region
+	})
+
+	headers := []string{"source_ami", "image_id", "name", "region", "match"}
+	rows := make([][]string, 0, len(copies))
+	for _, copied := range copies {
+		rows = append(rows, []string{sourceAMI, copied.ImageID, copied.Name, copied.Region, copied.Match})
+	}
+
+	if !deregister {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+	for i := range rows {
+		rows[i] = append(rows[i], action)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       append(headers, "action"),
+		Rows:          rows,
+		ActionColumn:  len(headers),
+		ConfirmPrompt: fmt.Sprintf("Deregister %d copy(ies) of %s", len(rows), sourceAMI),
+		Execute: func(rowIndex int) string {
+			copied := copies[rowIndex]
+			_, deregisterErr := newRegionalClient(cfg, copied.Region).DeregisterImage(cmd.Context(), &ec2.DeregisterImageInput{ImageId: cliutil.Ptr(copied.ImageID)})
+			if deregisterErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deregisterErr))
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
+
+func collectAMICopies(ctx context.Context, client API, region, sourceAMI string) ([]amiCopy, error) {
+	images, err := listOwnedImages(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list AMIs (%s): %w", region, awstbxaws.WrapUserError(err))
+	}
+
+	copies := make([]amiCopy, 0)
+	for _, image := range images {
+		imageID := cliutil.PointerToString(image.ImageId)
+		if imageID == "" || imageID == sourceAMI {
+			continue
+		}
+		match := amiCopyMatch(image, sourceAMI)
+		if match == "" {
+			continue
+		}
+		copies = append(copies, amiCopy{ImageID: imageID, Name: cliutil.PointerToString(image.Name), Region: region, Match: match})
+	}
+	return copies, nil
+}
+
+// amiCopyMatch reports how an image references the source AMI: the
+// SourceImageId that CopyImage records, the "[Copied ami-x from region]"
+// description it writes by default, or a source tag. It returns "" when the
+// image is not a copy of the source.
+func amiCopyMatch(image ec2types.Image, sourceAMI string) string {
+	if cliutil.PointerToString(image.SourceImageId) == sourceAMI {
+		return "source-image-id"
+	}
+	for _, token := range amiIDTokenPattern.FindAllString(cliutil.PointerToString(image.Description), -1) {
+		if token == sourceAMI {
+			return "description"
+		}
+	}
+	for _, key := range amiCopySourceTagKeys {
+		if tagValue(image.Tags, key) == sourceAMI {
+			return "tag:" + key
+		}
+	}
+	return ""
+}
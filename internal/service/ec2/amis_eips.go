@@ -14,7 +14,10 @@ import (
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
-func runDeleteAMIs(cmd *cobra.Command, retentionDays int, unusedOnly bool) error {
+// runDeleteAMIs deregisters owned AMIs matching the age, usage and name
+// filters. With deleteSnapshots, the EBS snapshots backing an AMI are deleted
+// once it is deregistered, each reported on its own row.
+func runDeleteAMIs(cmd *cobra.Command, retentionDays int, unusedOnly bool, nameContains string, deleteSnapshots, allRegions bool) error {
 	if retentionDays < 0 {
 		return fmt.Errorf("--retention-days must be >= 0")
 	}
@@ -22,103 +25,149 @@ func runDeleteAMIs(cmd *cobra.Command, retentionDays int, unusedOnly bool) error
 		return fmt.Errorf("set at least one filter: --unused or --retention-days")
 	}
 
-	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
+	var cutoff time.Time
+	if retentionDays > 0 {
+		cutoff = time.Now().UTC().AddDate(0, 0, -retentionDays)
+	}
+	needle := strings.ToLower(strings.TrimSpace(nameContains))
+
 	ctx := cmd.Context()
-	images, err := listOwnedImages(ctx, client)
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, func(ctx context.Context, client API, _ string) ([]ec2types.Image, error) {
+		return collectAMITargets(ctx, client, cutoff, unusedOnly, needle)
+	})
 	if err != nil {
-		return fmt.Errorf("list AMIs: %s", awstbxaws.FormatUserError(err))
+		return err
 	}
 
-	usedAMIIDs := map[string]struct{}{}
-	if unusedOnly {
-		usedAMIIDs, err = listUsedAMIIDs(ctx, client)
-		if err != nil {
-			return fmt.Errorf("list used AMIs: %s", awstbxaws.FormatUserError(err))
-		}
+	headers := []string{"image_id", "name", "region", "action"}
+	if deleteSnapshots {
+		headers = []string{"image_id", "name", "region", "snapshot_id", "action"}
 	}
-
-	var cutoff time.Time
-	if retentionDays > 0 {
-		cutoff = time.Now().UTC().AddDate(0, 0, -retentionDays)
+	actionColumn := len(headers) - 1
+	action := cliutil.ActionWouldDelete
+	if !runtime.Options.DryRun {
+		action = cliutil.ActionPending
 	}
 
-	targets := make([]ec2types.Image, 0)
-	for _, image := range images {
-		if image.ImageId == nil {
+	rows := make([][]string, 0, len(targets))
+	imageRows := make([]int, 0, len(targets))
+	snapshotRows := make([][]int, 0, len(targets))
+	for _, target := range targets {
+		image := target.Item
+		imageID := cliutil.PointerToString(image.ImageId)
+		name := cliutil.PointerToString(image.Name)
+		if !deleteSnapshots {
+			rows = append(rows, []string{imageID, name, target.Region, action})
+			imageRows = append(imageRows, len(rows)-1)
+			snapshotRows = append(snapshotRows, nil)
 			continue
 		}
 
-		if unusedOnly {
-			if _, inUse := usedAMIIDs[*image.ImageId]; inUse {
+		rows = append(rows, []string{imageID, name, target.Region, "", action})
+		imageRows = append(imageRows, len(rows)-1)
+		snapshots := make([]int, 0)
+		for _, mapping := range image.BlockDeviceMappings {
+			if mapping.Ebs == nil || cliutil.PointerToString(mapping.Ebs.SnapshotId) == "" {
 				continue
 			}
+			rows = append(rows, []string{imageID, name, target.Region, cliutil.PointerToString(mapping.Ebs.SnapshotId), action})
+			snapshots = append(snapshots, len(rows)-1)
 		}
+		snapshotRows = append(snapshotRows, snapshots)
+	}
 
-		if retentionDays > 0 {
-			createdAt, parseErr := parseAWSDate(strings.TrimSpace(cliutil.PointerToString(image.CreationDate)))
-			if parseErr != nil {
-				return fmt.Errorf("parse CreationDate for %s: %w", cliutil.PointerToString(image.ImageId), parseErr)
+	if len(targets) == 0 || runtime.Options.DryRun {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	prompt := fmt.Sprintf("Deregister %d AMI(s)", len(targets))
+	if deleteSnapshots {
+		prompt = fmt.Sprintf("Deregister %d AMI(s) and delete their snapshots", len(targets))
+	}
+	ok, confirmErr := runtime.Prompter.Confirm(prompt, runtime.Options.NoConfirm)
+	if confirmErr != nil {
+		return confirmErr
+	}
+	if !ok {
+		cliutil.SetActionForAllRows(rows, actionColumn, cliutil.ActionCancelled)
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	for i, target := range targets {
+		imageRow := rows[imageRows[i]]
+		if _, deregisterErr := target.Client.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: target.Item.ImageId}); deregisterErr != nil {
+			imageRow[actionColumn] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deregisterErr))
+			for _, rowIndex := range snapshotRows[i] {
+				rows[rowIndex][actionColumn] = cliutil.SkippedActionMessage("deregister-failed")
 			}
-			if createdAt.After(cutoff) {
+			continue
+		}
+		imageRow[actionColumn] = cliutil.ActionDeleted
+
+		for _, rowIndex := range snapshotRows[i] {
+			if _, deleteErr := target.Client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: cliutil.Ptr(rows[rowIndex][3])}); deleteErr != nil {
+				rows[rowIndex][actionColumn] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
 				continue
 			}
+			rows[rowIndex][actionColumn] = cliutil.ActionDeleted
 		}
-
-		targets = append(targets, image)
 	}
 
-	sort.Slice(targets, func(i, j int) bool {
-		return cliutil.PointerToString(targets[i].ImageId) < cliutil.PointerToString(targets[j].ImageId)
-	})
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
+}
 
-	rows := make([][]string, 0, len(targets))
-	for _, image := range targets {
-		action := cliutil.ActionWouldDelete
-		if !runtime.Options.DryRun {
-			action = cliutil.ActionPending
-		}
-		rows = append(rows, []string{
-			cliutil.PointerToString(image.ImageId),
-			cliutil.PointerToString(image.Name),
-			cfg.Region,
-			action,
-		})
+// collectAMITargets returns the owned AMIs created before cutoff (any age when
+// cutoff is zero) whose name contains needle, ordered by image ID. With
+// unusedOnly, AMIs a running instance was launched from are left out.
+func collectAMITargets(ctx context.Context, client API, cutoff time.Time, unusedOnly bool, needle string) ([]ec2types.Image, error) {
+	images, err := listOwnedImages(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list AMIs: %w", awstbxaws.WrapUserError(err))
 	}
 
-	if len(targets) == 0 {
-		return cliutil.WriteDataset(cmd, runtime, []string{"image_id", "name", "region", "action"}, rows)
+	usedAMIIDs := map[string]struct{}{}
+	if unusedOnly {
+		usedAMIIDs, err = listUsedAMIIDs(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("list used AMIs: %w", awstbxaws.WrapUserError(err))
+		}
 	}
 
-	if !runtime.Options.DryRun {
-		ok, confirmErr := runtime.Prompter.Confirm(
-			fmt.Sprintf("Deregister %d AMI(s)", len(targets)),
-			runtime.Options.NoConfirm,
-		)
-		if confirmErr != nil {
-			return confirmErr
+	targets := make([]ec2types.Image, 0)
+	for _, image := range images {
+		imageID := cliutil.PointerToString(image.ImageId)
+		if imageID == "" {
+			continue
 		}
-		if !ok {
-			for i := range rows {
-				rows[i][3] = cliutil.ActionCancelled
-			}
-			return cliutil.WriteDataset(cmd, runtime, []string{"image_id", "name", "region", "action"}, rows)
+		if _, inUse := usedAMIIDs[imageID]; inUse {
+			continue
 		}
-
-		for i, image := range targets {
-			_, deleteErr := client.DeregisterImage(ctx, &ec2.DeregisterImageInput{ImageId: image.ImageId})
-			if deleteErr != nil {
-				rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+		if needle != "" && !strings.Contains(strings.ToLower(cliutil.PointerToString(image.Name)), needle) {
+			continue
+		}
+		if !cutoff.IsZero() {
+			createdAt, parseErr := parseAWSDate(strings.TrimSpace(cliutil.PointerToString(image.CreationDate)))
+			if parseErr != nil {
+				return nil, fmt.Errorf("parse CreationDate for %s: %w", imageID, parseErr)
+			}
+			if createdAt.After(cutoff) {
 				continue
 			}
-			rows[i][3] = cliutil.ActionDeleted
 		}
+
+		targets = append(targets, image)
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"image_id", "name", "region", "action"}, rows)
+	sort.Slice(targets, func(i, j int) bool {
+		return cliutil.PointerToString(targets[i].ImageId) < cliutil.PointerToString(targets[j].ImageId)
+	})
+
+	return targets, nil
 }
 
 func runListEIPs(cmd *cobra.Command, _ []string) error {
@@ -129,7 +178,7 @@ func runListEIPs(cmd *cobra.Command, _ []string) error {
 
 	addresses, err := listAddresses(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list addresses: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list addresses: %w", awstbxaws.WrapUserError(err))
 	}
 
 	rows := make([][]string, 0)
@@ -152,36 +201,24 @@ func runListEIPs(cmd *cobra.Command, _ []string) error {
 	return cliutil.WriteDataset(cmd, runtime, []string{"allocation_id", "public_ip", "region", "status"}, rows)
 }
 
-func runDeleteEIPs(cmd *cobra.Command, _ []string) error {
-	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+func runDeleteEIPs(cmd *cobra.Command, allRegions bool) error {
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
-	addresses, err := listAddresses(cmd.Context(), client)
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, collectUnusedAddresses)
 	if err != nil {
-		return fmt.Errorf("list addresses: %s", awstbxaws.FormatUserError(err))
-	}
-
-	targets := make([]ec2types.Address, 0)
-	for _, address := range addresses {
-		if address.AssociationId != nil || address.AllocationId == nil {
-			continue
-		}
-		targets = append(targets, address)
+		return err
 	}
 
-	sort.Slice(targets, func(i, j int) bool {
-		return cliutil.PointerToString(targets[i].AllocationId) < cliutil.PointerToString(targets[j].AllocationId)
-	})
-
 	rows := make([][]string, 0, len(targets))
-	for _, address := range targets {
+	for _, target := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
-		rows = append(rows, []string{cliutil.PointerToString(address.AllocationId), cliutil.PointerToString(address.PublicIp), cfg.Region, action})
+		rows = append(rows, []string{cliutil.PointerToString(target.Item.AllocationId), cliutil.PointerToString(target.Item.PublicIp), target.Region, action})
 	}
 
 	if len(targets) == 0 {
@@ -203,8 +240,14 @@ func runDeleteEIPs(cmd *cobra.Command, _ []string) error {
 			return cliutil.WriteDataset(cmd, runtime, []string{"allocation_id", "public_ip", "region", "action"}, rows)
 		}
 
-		for i, address := range targets {
-			_, releaseErr := client.ReleaseAddress(cmd.Context(), &ec2.ReleaseAddressInput{AllocationId: address.AllocationId})
+		for i, target := range targets {
+			// VPC addresses are released by allocation ID, EC2-Classic ones by
+			// public IP.
+			input := &ec2.ReleaseAddressInput{AllocationId: target.Item.AllocationId}
+			if target.Item.AllocationId == nil {
+				input = &ec2.ReleaseAddressInput{PublicIp: target.Item.PublicIp}
+			}
+			_, releaseErr := target.Client.ReleaseAddress(cmd.Context(), input)
 			if releaseErr != nil {
 				rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(releaseErr))
 				continue
@@ -216,6 +259,35 @@ func runDeleteEIPs(cmd *cobra.Command, _ []string) error {
 	return cliutil.WriteDataset(cmd, runtime, []string{"allocation_id", "public_ip", "region", "action"}, rows)
 }
 
+// collectUnusedAddresses returns the Elastic IPs that are not associated with
+// an instance or network interface, ordered by allocation ID then public IP.
+func collectUnusedAddresses(ctx context.Context, client API, _ string) ([]ec2types.Address, error) {
+	addresses, err := listAddresses(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list addresses: %w", awstbxaws.WrapUserError(err))
+	}
+
+	targets := make([]ec2types.Address, 0)
+	for _, address := range addresses {
+		if address.AssociationId != nil || address.InstanceId != nil || address.NetworkInterfaceId != nil {
+			continue
+		}
+		if address.AllocationId == nil && address.PublicIp == nil {
+			continue
+		}
+		targets = append(targets, address)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if left, right := cliutil.PointerToString(targets[i].AllocationId), cliutil.PointerToString(targets[j].AllocationId); left != right {
+			return left < right
+		}
+		return cliutil.PointerToString(targets[i].PublicIp) < cliutil.PointerToString(targets[j].PublicIp)
+	})
+
+	return targets, nil
+}
+
 func listAddresses(ctx context.Context, client API) ([]ec2types.Address, error) {
 	page, err := client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
 	if err != nil {
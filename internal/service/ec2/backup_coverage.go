@@ -0,0 +1,117 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runAuditBackupCoverage(cmd *cobra.Command, backupTag string, apply bool) error {
+	backupKey, backupValue, err := cliutil.ParseTagFilter(backupTag)
+	if err != nil || backupKey == "" {
+		return fmt.Errorf("--backup-tag must use KEY=VALUE format")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instances, err := listInstances(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", awstbxaws.WrapUserError(err))
+	}
+	instanceTagged := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		instanceTagged[cliutil.PointerToString(instance.InstanceId)] = hasExactTag(instance.Tags, backupKey, backupValue)
+	}
+
+	volumes, err := listAttachedVolumes(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", awstbxaws.WrapUserError(err))
+	}
+
+	missingAction := "missing-tag"
+	if apply {
+		missingAction = "would-tag"
+		if !runtime.Options.DryRun {
+			missingAction = cliutil.ActionPending
+		}
+	}
+
+	rows := make([][]string, 0, len(volumes))
+	missing := 0
+	for _, volume := range volumes {
+		volumeTagged := hasExactTag(volume.Tags, backupKey, backupValue)
+		action := "ok"
+		if !volumeTagged {
+			action = missingAction
+			missing++
+		}
+		for _, attachment := range volume.Attachments {
+			instanceID := cliutil.PointerToString(attachment.InstanceId)
+			rows = append(rows, []string{
+				instanceID,
+				cliutil.PointerToString(volume.VolumeId),
+				fmt.Sprintf("%t", instanceTagged[instanceID]),
+				fmt.Sprintf("%t", volumeTagged),
+				cfg.Region,
+				action,
+			})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][0] == rows[j][0] {
+			return rows[i][1] < rows[j][1]
+		}
+		return rows[i][0] < rows[j][0]
+	})
+
+	headers := []string{"instance_id", "volume_id", "instance_tagged", "volume_tagged", "region", "action"}
+	if !apply || missing == 0 {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	tagged := make(map[string]string)
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          rows,
+		ActionColumn:  5,
+		ConfirmPrompt: fmt.Sprintf("Tag %d volume(s) with %s=%s", missing, backupKey, backupValue),
+		Execute: func(rowIndex int) string {
+			if rows[rowIndex][5] == "ok" {
+				return ""
+			}
+			// Multi-attach volumes appear once per instance; tag them only once.
+			volumeID := rows[rowIndex][1]
+			if result, ok := tagged[volumeID]; ok {
+				return result
+			}
+			_, tagErr := client.CreateTags(cmd.Context(), &ec2.CreateTagsInput{
+				Resources: []string{volumeID},
+				Tags:      []ec2types.Tag{{Key: cliutil.Ptr(backupKey), Value: cliutil.Ptr(backupValue)}},
+			})
+			result := "tagged"
+			if tagErr != nil {
+				result = cliutil.FailedActionMessage(awstbxaws.FormatUserError(tagErr))
+			}
+			tagged[volumeID] = result
+			return result
+		},
+	})
+}
+
+func hasExactTag(tags []ec2types.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if cliutil.PointerToString(tag.Key) == key && cliutil.PointerToString(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runFindUnusedCapacityReservations(cmd *cobra.Command, maxUtilization int, cancel bool) error {
+	if maxUtilization < 0 || maxUtilization > 100 {
+		return fmt.Errorf("--max-utilization must be between 0 and 100")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := listActiveCapacityReservations(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list capacity reservations: %w", awstbxaws.WrapUserError(err))
+	}
+
+	action := "unused"
+	if cancel {
+		action = "would-cancel"
+		if !runtime.Options.DryRun {
+			action = cliutil.ActionPending
+		}
+	}
+
+	rows := make([][]string, 0)
+	for _, reservation := range reservations {
+		total := int(cliutil.PointerToInt32(reservation.TotalInstanceCount))
+		available := int(cliutil.PointerToInt32(reservation.AvailableInstanceCount))
+		if total <= 0 {
+			continue
+		}
+		utilization := (total - available) * 100 / total
+		if utilization > maxUtilization {
+			continue
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(reservation.CapacityReservationId),
+			cliutil.PointerToString(reservation.InstanceType),
+			cliutil.PointerToString(reservation.AvailabilityZone),
+			strconv.Itoa(total),
+			strconv.Itoa(available),
+			strconv.Itoa(utilization),
+			cfg.Region,
+			action,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	headers := []string{"reservation_id", "instance_type", "availability_zone", "total_instances", "available_instances", "utilization_pct", "region", "action"}
+	if !cancel {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          rows,
+		ActionColumn:  7,
+		ConfirmPrompt: fmt.Sprintf("Cancel %d capacity reservation(s)", len(rows)),
+		Execute: func(rowIndex int) string {
+			_, cancelErr := client.CancelCapacityReservation(cmd.Context(), &ec2.CancelCapacityReservationInput{
+				CapacityReservationId: cliutil.Ptr(rows[rowIndex][0]),
+			})
+			if cancelErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(cancelErr))
+			}
+			return "reservation-cancelled"
+		},
+	})
+}
+
+func listActiveCapacityReservations(ctx context.Context, client API) ([]ec2types.CapacityReservation, error) {
+	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ec2types.CapacityReservation], error) {
+		page, err := client.DescribeCapacityReservations(callCtx, &ec2.DescribeCapacityReservationsInput{
+			Filters:   []ec2types.Filter{{Name: cliutil.Ptr("state"), Values: []string{string(ec2types.CapacityReservationStateActive)}}},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return awstbxaws.PageResult[ec2types.CapacityReservation]{}, err
+		}
+		return awstbxaws.PageResult[ec2types.CapacityReservation]{
+			Items:     page.CapacityReservations,
+			NextToken: page.NextToken,
+		}, nil
+	})
+}
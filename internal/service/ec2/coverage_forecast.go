@@ -0,0 +1,102 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// reservedCoverage tracks, for one instance type, how many instances run and
+// how much active reserved capacity covers them now and after the window.
+type reservedCoverage struct {
+	running        int
+	reserved       int
+	expiring       int
+	earliestExpiry time.Time
+}
+
+// runCoverageForecast forecasts which instance types lose reserved instance
+// coverage within the window. Reservations are matched to running instances
+// by instance type; size flexibility across a family is not modelled.
+func runCoverageForecast(cmd *cobra.Command, withinDays int) error {
+	if withinDays < 1 {
+		return fmt.Errorf("--within-days must be at least 1")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instances, err := listInstances(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", awstbxaws.WrapUserError(err))
+	}
+	reservations, err := client.DescribeReservedInstances(cmd.Context(), &ec2.DescribeReservedInstancesInput{
+		Filters: []ec2types.Filter{{Name: cliutil.Ptr("state"), Values: []string{string(ec2types.ReservedInstanceStateActive)}}},
+	})
+	if err != nil {
+		return fmt.Errorf("describe reserved instances: %w", awstbxaws.WrapUserError(err))
+	}
+
+	coverage := make(map[string]*reservedCoverage)
+	entry := func(instanceType string) *reservedCoverage {
+		if coverage[instanceType] == nil {
+			coverage[instanceType] = &reservedCoverage{}
+		}
+		return coverage[instanceType]
+	}
+	for _, instance := range instances {
+		if instanceStateName(instance) == ec2types.InstanceStateNameRunning {
+			entry(string(instance.InstanceType)).running++
+		}
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, withinDays)
+	for _, reservation := range reservations.ReservedInstances {
+		count := int(cliutil.PointerToInt32(reservation.InstanceCount))
+		item := entry(string(reservation.InstanceType))
+		item.reserved += count
+		if reservation.End == nil || !reservation.End.Before(cutoff) {
+			continue
+		}
+		item.expiring += count
+		if item.earliestExpiry.IsZero() || reservation.End.Before(item.earliestExpiry) {
+			item.earliestExpiry = reservation.End.UTC()
+		}
+	}
+
+	rows := make([][]string, 0)
+	for instanceType, item := range coverage {
+		covered := min(item.running, item.reserved)
+		coveredAfter := min(item.running, item.reserved-item.expiring)
+		exposure := covered - coveredAfter
+		if exposure == 0 {
+			continue
+		}
+		rows = append(rows, []string{
+			instanceType,
+			strconv.Itoa(item.running),
+			strconv.Itoa(covered),
+			item.earliestExpiry.Format("2006-01-02"),
+			strconv.Itoa(item.expiring),
+			strconv.Itoa(exposure),
+			cfg.Region,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][3] == rows[j][3] {
+			return rows[i][0] < rows[j][0]
+		}
+		return rows[i][3] < rows[j][3]
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"instance_type", "running", "covered", "covering_ri_expiry", "expiring_ri_count", "on_demand_exposure", "region"}, rows)
+}
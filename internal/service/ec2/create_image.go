@@ -0,0 +1,128 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runCreateImage backs an instance up as an AMI. The image and its snapshots
+// are tagged with the source instance and creation time so they can be traced
+// back later.
+func runCreateImage(cmd *cobra.Command, instanceID, name string, noReboot, wait bool) error {
+	instanceID = strings.TrimSpace(instanceID)
+	name = strings.TrimSpace(name)
+	if instanceID == "" {
+		return fmt.Errorf("--instance-id is required")
+	}
+	if name == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instance, err := describeInstance(cmd.Context(), client, instanceID)
+	if err != nil {
+		return fmt.Errorf("describe instance: %w", awstbxaws.WrapUserError(err))
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = "would-create"
+	}
+	rows := [][]string{{instanceID, name, string(instanceStateName(instance)), strconv.FormatBool(noReboot), "", "", action}}
+
+	prompt := fmt.Sprintf("Create image %q from instance %s", name, instanceID)
+	if !noReboot && instanceStateName(instance) == ec2types.InstanceStateNameRunning {
+		prompt = fmt.Sprintf("Reboot instance %s and create image %q from it", instanceID, name)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"instance_id", "name", "instance_state", "no_reboot", "image_id", "image_state", "action"},
+		Rows:          rows,
+		ActionColumn:  6,
+		ConfirmPrompt: prompt,
+		Execute: func(int) string {
+			imageID, createErr := createTaggedImage(cmd.Context(), client, instanceID, name, noReboot)
+			if createErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(createErr))
+			}
+			rows[0][4] = imageID
+			rows[0][5] = string(ec2types.ImageStatePending)
+			if !wait {
+				return "created"
+			}
+			if waitErr := waitForImageAvailable(cmd.Context(), client, imageID); waitErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+			}
+			rows[0][5] = string(ec2types.ImageStateAvailable)
+			return "created"
+		},
+	})
+}
+
+func createTaggedImage(ctx context.Context, client API, instanceID, name string, noReboot bool) (string, error) {
+	tags := []ec2types.Tag{
+		{Key: cliutil.Ptr("awstbx:source-instance-id"), Value: cliutil.Ptr(instanceID)},
+		{Key: cliutil.Ptr("awstbx:created-at"), Value: cliutil.Ptr(time.Now().UTC().Format(time.RFC3339))},
+	}
+	resp, err := client.CreateImage(ctx, &ec2.CreateImageInput{
+		InstanceId:  cliutil.Ptr(instanceID),
+		Name:        cliutil.Ptr(name),
+		Description: cliutil.Ptr(fmt.Sprintf("awstbx backup of %s", instanceID)),
+		NoReboot:    cliutil.Ptr(noReboot),
+		TagSpecifications: []ec2types.TagSpecification{
+			{ResourceType: ec2types.ResourceTypeImage, Tags: tags},
+			{ResourceType: ec2types.ResourceTypeSnapshot, Tags: tags},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	imageID := cliutil.PointerToString(resp.ImageId)
+	if imageID == "" {
+		return "", fmt.Errorf("create image for %s: empty image id", instanceID)
+	}
+	return imageID, nil
+}
+
+func waitForImageAvailable(ctx context.Context, client API, imageID string) error {
+	const maxAttempts = 240
+	const pollInterval = 15 * time.Second
+	for range maxAttempts {
+		resp, err := client.DescribeImages(ctx, &ec2.DescribeImagesInput{ImageIds: []string{imageID}})
+		if err != nil {
+			return err
+		}
+		if len(resp.Images) > 0 {
+			switch state := resp.Images[0].State; state {
+			case ec2types.ImageStateAvailable:
+				return nil
+			case ec2types.ImageStateFailed, ec2types.ImageStateError:
+				reason := ""
+				if resp.Images[0].StateReason != nil {
+					reason = ": " + cliutil.PointerToString(resp.Images[0].StateReason.Message)
+				}
+				return fmt.Errorf("image %s is %s%s", imageID, state, reason)
+			}
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for image %s to be available", imageID)
+}
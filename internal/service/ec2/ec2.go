@@ -19,22 +19,38 @@ import (
 
 // API defines the subset of EC2 operations used by this package.
 type API interface {
+	CancelCapacityReservation(context.Context, *ec2.CancelCapacityReservationInput, ...func(*ec2.Options)) (*ec2.CancelCapacityReservationOutput, error)
+	CancelSpotInstanceRequests(context.Context, *ec2.CancelSpotInstanceRequestsInput, ...func(*ec2.Options)) (*ec2.CancelSpotInstanceRequestsOutput, error)
+	CreateImage(context.Context, *ec2.CreateImageInput, ...func(*ec2.Options)) (*ec2.CreateImageOutput, error)
+	CreateSnapshot(context.Context, *ec2.CreateSnapshotInput, ...func(*ec2.Options)) (*ec2.CreateSnapshotOutput, error)
+	CreateTags(context.Context, *ec2.CreateTagsInput, ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
 	DescribeAddresses(context.Context, *ec2.DescribeAddressesInput, ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
+	DescribeCapacityReservations(context.Context, *ec2.DescribeCapacityReservationsInput, ...func(*ec2.Options)) (*ec2.DescribeCapacityReservationsOutput, error)
 	DescribeImages(context.Context, *ec2.DescribeImagesInput, ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
+	DescribeInstanceTypeOfferings(context.Context, *ec2.DescribeInstanceTypeOfferingsInput, ...func(*ec2.Options)) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+	DescribeInstanceTypes(context.Context, *ec2.DescribeInstanceTypesInput, ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error)
 	DescribeInstances(context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
 	DescribeKeyPairs(context.Context, *ec2.DescribeKeyPairsInput, ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
 	DescribeNetworkInterfaces(context.Context, *ec2.DescribeNetworkInterfacesInput, ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
 	DescribeRegions(context.Context, *ec2.DescribeRegionsInput, ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+	DescribeReservedInstances(context.Context, *ec2.DescribeReservedInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeReservedInstancesOutput, error)
 	DescribeSecurityGroups(context.Context, *ec2.DescribeSecurityGroupsInput, ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
 	DescribeSnapshots(context.Context, *ec2.DescribeSnapshotsInput, ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeSpotInstanceRequests(context.Context, *ec2.DescribeSpotInstanceRequestsInput, ...func(*ec2.Options)) (*ec2.DescribeSpotInstanceRequestsOutput, error)
+	DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput, ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
+	DescribeTags(context.Context, *ec2.DescribeTagsInput, ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error)
 	DescribeVolumes(context.Context, *ec2.DescribeVolumesInput, ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
 	DeleteKeyPair(context.Context, *ec2.DeleteKeyPairInput, ...func(*ec2.Options)) (*ec2.DeleteKeyPairOutput, error)
+	DeleteNetworkInterface(context.Context, *ec2.DeleteNetworkInterfaceInput, ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error)
 	DeleteSecurityGroup(context.Context, *ec2.DeleteSecurityGroupInput, ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error)
 	DeleteSnapshot(context.Context, *ec2.DeleteSnapshotInput, ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error)
 	DeleteVolume(context.Context, *ec2.DeleteVolumeInput, ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error)
 	DeregisterImage(context.Context, *ec2.DeregisterImageInput, ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error)
+	ModifyInstanceAttribute(context.Context, *ec2.ModifyInstanceAttributeInput, ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error)
 	ReleaseAddress(context.Context, *ec2.ReleaseAddressInput, ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error)
 	RevokeSecurityGroupIngress(context.Context, *ec2.RevokeSecurityGroupIngressInput, ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	StartInstances(context.Context, *ec2.StartInstancesInput, ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error)
+	StopInstances(context.Context, *ec2.StopInstancesInput, ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error)
 }
 
 var loadAWSConfig = awstbxaws.LoadAWSConfig
@@ -46,18 +62,122 @@ var newRegionalClient = func(cfg awssdk.Config, region string) API {
 	regionalCfg.Region = region
 	return ec2.NewFromConfig(regionalCfg)
 }
+var sleep = cliutil.SleepContext
 
 // NewCommand returns the top-level ec2 cobra command with all subcommands.
 func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("ec2", "Manage EC2 resources")
 
+	cmd.AddCommand(newAuditBackupCoverageCommand())
+	cmd.AddCommand(newAuditRequesterManagedENIsCommand())
+	cmd.AddCommand(newCancelSpotRequestsCommand())
+	cmd.AddCommand(newCoverageForecastCommand())
+	cmd.AddCommand(newCreateImageCommand())
 	cmd.AddCommand(newDeleteAMIsCommand())
 	cmd.AddCommand(newDeleteEIPsCommand())
 	cmd.AddCommand(newDeleteKeypairsCommand())
 	cmd.AddCommand(newDeleteSecurityGroupsCommand())
 	cmd.AddCommand(newDeleteSnapshotsCommand())
 	cmd.AddCommand(newDeleteVolumesCommand())
+	cmd.AddCommand(newFindAMICopiesCommand())
+	cmd.AddCommand(newFindEphemeralPublicIPsCommand())
+	cmd.AddCommand(newFindOrphanedENIsCommand())
+	cmd.AddCommand(newFindUnusedCapacityReservationsCommand())
 	cmd.AddCommand(newListEIPsCommand())
+	cmd.AddCommand(newListInstanceTypeOfferingsCommand())
+	cmd.AddCommand(newListInstancesCommand())
+	cmd.AddCommand(newResizeInstanceCommand())
+	cmd.AddCommand(newRevokeOpenIngressCommand())
+	cmd.AddCommand(newTagFromCSVCommand())
+	cmd.AddCommand(newVolumeReportCommand())
+
+	return cmd
+}
+
+func newAuditBackupCoverageCommand() *cobra.Command {
+	var backupTag string
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "audit-backup-coverage",
+		Short: "Report attached EBS volumes missing the DLM backup tag",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditBackupCoverage(cmd, backupTag, apply)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&backupTag, "backup-tag", "Backup=true", "Tag expected by DLM policies in KEY=VALUE form")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Tag volumes that are missing the backup tag")
+
+	return cmd
+}
+
+func newAuditRequesterManagedENIsCommand() *cobra.Command {
+	var deleteENIs bool
+
+	cmd := &cobra.Command{
+		Use:   "audit-requester-managed-enis",
+		Short: "Find leaked service-managed network interfaces that block subnet and security group deletion",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditRequesterManagedENIs(cmd, deleteENIs)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&deleteENIs, "delete", false, "Delete the reported network interfaces; attached interfaces are never detached")
+
+	return cmd
+}
+
+func newCancelSpotRequestsCommand() *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:   "cancel-spot-requests",
+		Short: "Cancel open or active spot instance requests that have no instance",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCancelSpotRequests(cmd, olderThan)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only cancel requests created before this time (RFC3339, 2006-01-02, or relative like 7d, 12h)")
+
+	return cmd
+}
+
+func newCoverageForecastCommand() *cobra.Command {
+	var withinDays int
+
+	cmd := &cobra.Command{
+		Use:   "coverage-forecast",
+		Short: "Forecast running instances that lose reserved instance coverage as reservations expire",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCoverageForecast(cmd, withinDays)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().IntVar(&withinDays, "within-days", 30, "Forecast window in days")
+
+	return cmd
+}
+
+func newCreateImageCommand() *cobra.Command {
+	var instanceID string
+	var name string
+	var noReboot bool
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "create-image",
+		Short: "Back up an instance as a tagged AMI",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCreateImage(cmd, instanceID, name, noReboot, wait)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&instanceID, "instance-id", "", "Instance to create the image from")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the new image")
+	cmd.Flags().BoolVar(&noReboot, "no-reboot", false, "Do not reboot the instance before imaging; file system integrity is not guaranteed")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the image is available")
 
 	return cmd
 }
@@ -65,42 +185,58 @@ func NewCommand() *cobra.Command {
 func newDeleteAMIsCommand() *cobra.Command {
 	var retentionDays int
 	var unusedOnly bool
+	var nameContains string
+	var deleteSnapshots bool
+	var allRegions bool
 
 	cmd := &cobra.Command{
-		Use:   "delete-amis",
-		Short: "Deregister stale AMIs",
+		Use:     "delete-amis",
+		Aliases: []string{"deregister-old-amis"},
+		Short:   "Deregister stale AMIs",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteAMIs(cmd, retentionDays, unusedOnly)
+			return runDeleteAMIs(cmd, retentionDays, unusedOnly, nameContains, deleteSnapshots, allRegions)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().IntVar(&retentionDays, "retention-days", 0, "Only target AMIs older than this many days")
-	cmd.Flags().BoolVar(&unusedOnly, "unused", false, "Only target AMIs not used by any EC2 instance")
+	cmd.Flags().BoolVar(&unusedOnly, "unused", false, "Only target AMIs not used by any running EC2 instance")
+	cmd.Flags().StringVar(&nameContains, "name-contains", "", "Only target AMIs whose name contains this text (case-insensitive)")
+	cmd.Flags().BoolVar(&deleteSnapshots, "delete-snapshots", false, "Delete the EBS snapshots backing each AMI after it is deregistered")
+	addAllRegionsFlag(cmd, &allRegions)
 
 	return cmd
 }
 
 func newDeleteEIPsCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:          "delete-eips",
-		Short:        "Release unused Elastic IPs",
-		RunE:         runDeleteEIPs,
+	var allRegions bool
+
+	cmd := &cobra.Command{
+		Use:     "delete-eips",
+		Aliases: []string{"release-unused-eips"},
+		Short:   "Release unused Elastic IPs",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteEIPs(cmd, allRegions)
+		},
 		SilenceUsage: true,
 	}
+	addAllRegionsFlag(cmd, &allRegions)
+
+	return cmd
 }
 
 func newDeleteKeypairsCommand() *cobra.Command {
 	var allRegions bool
 
 	cmd := &cobra.Command{
-		Use:   "delete-keypairs",
-		Short: "Delete unused EC2 key pairs",
+		Use:     "delete-keypairs",
+		Short:   "Delete unused EC2 key pairs",
+		Aliases: []string{"delete-unused-key-pairs"},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runDeleteKeypairs(cmd, allRegions)
 		},
 		SilenceUsage: true,
 	}
-	cmd.Flags().BoolVar(&allRegions, "all-regions", false, "Scan all enabled regions")
+	addAllRegionsFlag(cmd, &allRegions)
 
 	return cmd
 }
@@ -110,48 +246,135 @@ func newDeleteSecurityGroupsCommand() *cobra.Command {
 	var tagFilter string
 	var unusedOnly bool
 	var securityGroupType string
+	var allRegions bool
 
 	cmd := &cobra.Command{
-		Use:   "delete-security-groups",
-		Short: "Delete or harden security groups",
+		Use:     "delete-security-groups",
+		Aliases: []string{"delete-unused-security-groups"},
+		Short:   "Delete or harden security groups",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteSecurityGroups(cmd, sshRules, tagFilter, unusedOnly, securityGroupType)
+			// The delete-unused-security-groups alias always means --unused.
+			if cmd.CalledAs() == "delete-unused-security-groups" {
+				unusedOnly = true
+			}
+			return runDeleteSecurityGroups(cmd, sshRules, tagFilter, unusedOnly, securityGroupType, allRegions)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().BoolVar(&sshRules, "ssh-rules", false, "Revoke inbound TCP/22 rules instead of deleting groups")
 	cmd.Flags().StringVar(&tagFilter, "filter-tag", "", "Tag filter in KEY=VALUE form")
-	cmd.Flags().BoolVar(&unusedOnly, "unused", false, "Only target security groups not attached to ENIs")
+	cmd.Flags().BoolVar(&unusedOnly, "unused", false, "Only target security groups not attached to ENIs or referenced by another group's rules")
 	cmd.Flags().StringVar(&securityGroupType, "type", "all", "Filter by naming convention: all|ec2|rds|elb")
+	addAllRegionsFlag(cmd, &allRegions)
 
 	return cmd
 }
 
 func newDeleteSnapshotsCommand() *cobra.Command {
 	var retentionDays int
+	var dangling bool
+	var allRegions bool
 
 	cmd := &cobra.Command{
 		Use:   "delete-snapshots",
 		Short: "Delete orphaned EBS snapshots",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteSnapshots(cmd, retentionDays)
+			return runDeleteSnapshots(cmd, retentionDays, dangling, allRegions)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().IntVar(&retentionDays, "retention-days", 0, "Only target snapshots older than this many days")
+	cmd.Flags().BoolVar(&dangling, "dangling", true, "Only target snapshots whose source volume no longer exists; set to false to select by --retention-days alone")
+	addAllRegionsFlag(cmd, &allRegions)
 
 	return cmd
 }
 
 func newDeleteVolumesCommand() *cobra.Command {
+	var snapshotFirst bool
+	var olderThanDays int
+	var excludeTags []string
+	var allRegions bool
+
+	cmd := &cobra.Command{
+		Use:     "delete-volumes",
+		Aliases: []string{"delete-unattached-volumes"},
+		Short:   "Delete unattached EBS volumes",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteVolumes(cmd, snapshotFirst, olderThanDays, excludeTags, allRegions)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&snapshotFirst, "snapshot-first", false, "Create and wait for a tagged snapshot of each volume before deleting it")
+	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 0, "Only target volumes created more than this many days ago (0 disables the filter)")
+	cmd.Flags().StringArrayVar(&excludeTags, "exclude-tag", nil, "Keep volumes carrying this tag in KEY=VALUE form (repeatable)")
+	addAllRegionsFlag(cmd, &allRegions)
+	cliutil.AddInteractiveFlag(cmd)
+
+	return cmd
+}
+
+func newFindAMICopiesCommand() *cobra.Command {
+	var sourceAMI string
+	var deregisterCopies bool
+
+	cmd := &cobra.Command{
+		Use:   "find-ami-copies",
+		Short: "Find copies of an AMI across all enabled regions",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFindAMICopies(cmd, sourceAMI, deregisterCopies)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&sourceAMI, "source-ami", "", "AMI id the copies were made from")
+	cmd.Flags().BoolVar(&deregisterCopies, "deregister-copies", false, "Deregister the copies that were found")
+
+	return cmd
+}
+
+func newFindEphemeralPublicIPsCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:          "delete-volumes",
-		Short:        "Delete unattached EBS volumes",
-		RunE:         runDeleteVolumes,
+		Use:          "find-ephemeral-public-ips",
+		Short:        "Find running instances with auto-assigned public IPs not backed by an Elastic IP",
+		RunE:         runFindEphemeralPublicIPs,
 		SilenceUsage: true,
 	}
 }
 
+func newFindOrphanedENIsCommand() *cobra.Command {
+	var deleteENIs bool
+
+	cmd := &cobra.Command{
+		Use:   "find-orphaned-enis",
+		Short: "Find detached network interfaces that keep security groups in use",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFindOrphanedENIs(cmd, deleteENIs)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&deleteENIs, "delete", false, "Delete the reported network interfaces")
+
+	return cmd
+}
+
+func newFindUnusedCapacityReservationsCommand() *cobra.Command {
+	var maxUtilization int
+	var cancel bool
+
+	cmd := &cobra.Command{
+		Use:   "find-unused-capacity-reservations",
+		Short: "Report active On-Demand Capacity Reservations with unused capacity",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFindUnusedCapacityReservations(cmd, maxUtilization, cancel)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().IntVar(&maxUtilization, "max-utilization", 0, "Report reservations whose utilization percentage is at or below this value")
+	cmd.Flags().BoolVar(&cancel, "cancel", false, "Cancel the reported capacity reservations")
+
+	return cmd
+}
+
 func newListEIPsCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:          "list-eips",
@@ -161,6 +384,96 @@ func newListEIPsCommand() *cobra.Command {
 	}
 }
 
+func newListInstanceTypeOfferingsCommand() *cobra.Command {
+	var locationType string
+	var location string
+	var typeFilter string
+
+	cmd := &cobra.Command{
+		Use:   "list-instance-type-offerings",
+		Short: "List the instance types offered in a region or availability zone",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListInstanceTypeOfferings(cmd, locationType, location, typeFilter)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&locationType, "location-type", string(ec2types.LocationTypeRegion), "Location type: region, availability-zone, availability-zone-id, or outpost")
+	cmd.Flags().StringVar(&location, "location", "", "Only list offerings in this location, such as us-east-1a (default: all locations of the type)")
+	cmd.Flags().StringVar(&typeFilter, "type-filter", "", "Glob pattern on the instance type, such as m6i.*")
+
+	return cmd
+}
+
+func newListInstancesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:          "list-instances",
+		Short:        "List EC2 instances",
+		RunE:         runListInstances,
+		SilenceUsage: true,
+	}
+}
+
+func newResizeInstanceCommand() *cobra.Command {
+	var instanceID string
+	var instanceType string
+
+	cmd := &cobra.Command{
+		Use:   "resize-instance",
+		Short: "Stop an instance, change its instance type and start it again",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runResizeInstance(cmd, instanceID, instanceType)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&instanceID, "instance-id", "", "Instance ID to resize")
+	cmd.Flags().StringVar(&instanceType, "type", "", "Target instance type (for example m6i.large)")
+
+	return cmd
+}
+
+func newRevokeOpenIngressCommand() *cobra.Command {
+	var ports []int
+	var includeBroader bool
+
+	cmd := &cobra.Command{
+		Use:   "revoke-open-ingress",
+		Short: "Revoke ingress rules that open sensitive ports to the internet",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRevokeOpenIngress(cmd, ports, includeBroader)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().IntSliceVar(&ports, "ports", []int{22, 3389}, "Ports whose rules open to 0.0.0.0/0 or ::/0 are revoked")
+	cmd.Flags().BoolVar(&includeBroader, "include-broader", false, "Also revoke all-traffic and port-range rules that open other ports besides --ports")
+
+	return cmd
+}
+
+func newTagFromCSVCommand() *cobra.Command {
+	var filePath string
+
+	cmd := &cobra.Command{
+		Use:   "tag-from-csv",
+		Short: "Bulk-apply tags from a resource_id,key,value CSV file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTagFromCSV(cmd, filePath)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&filePath, "file", "", "Path to a CSV file with resource_id,key,value rows")
+
+	return cmd
+}
+
+func newVolumeReportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:          "volume-report",
+		Short:        "Report EBS volume attachments and orphaned volumes",
+		RunE:         runVolumeReport,
+		SilenceUsage: true,
+	}
+}
+
 func listOwnedImages(ctx context.Context, client API) ([]ec2types.Image, error) {
 	images := make([]ec2types.Image, 0)
 	var nextToken *string
@@ -181,6 +494,8 @@ func listOwnedImages(ctx context.Context, client API) ([]ec2types.Image, error)
 	return images, nil
 }
 
+// listUsedAMIIDs returns the AMIs that pending or running instances were
+// launched from. Stopped and terminated instances do not hold their AMI.
 func listUsedAMIIDs(ctx context.Context, client API) (map[string]struct{}, error) {
 	used := make(map[string]struct{})
 	var nextToken *string
@@ -193,6 +508,9 @@ func listUsedAMIIDs(ctx context.Context, client API) (map[string]struct{}, error
 
 		for _, reservation := range page.Reservations {
 			for _, instance := range reservation.Instances {
+				if instance.State != nil && instance.State.Name != ec2types.InstanceStateNamePending && instance.State.Name != ec2types.InstanceStateNameRunning {
+					continue
+				}
 				if instance.ImageId != nil {
 					used[*instance.ImageId] = struct{}{}
 				}
@@ -244,19 +562,91 @@ func matchesSecurityGroupType(groupName, securityGroupType string) bool {
 	}
 }
 
-func listRegions(ctx context.Context, client API) ([]string, error) {
-	resp, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+// listRegions returns the regions enabled for the account and, separately,
+// the opt-in regions the account has not enabled.
+func listRegions(ctx context.Context, client API) ([]string, []string, error) {
+	resp, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{AllRegions: cliutil.Ptr(true)})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	regions := make([]string, 0, len(resp.Regions))
+	enabled := make([]string, 0, len(resp.Regions))
+	skipped := make([]string, 0)
 	for _, region := range resp.Regions {
-		if region.RegionName != nil {
-			regions = append(regions, *region.RegionName)
+		if region.RegionName == nil {
+			continue
+		}
+		if cliutil.PointerToString(region.OptInStatus) == "not-opted-in" {
+			skipped = append(skipped, *region.RegionName)
+			continue
 		}
+		enabled = append(enabled, *region.RegionName)
+	}
+	sort.Strings(enabled)
+	sort.Strings(skipped)
+
+	return enabled, skipped, nil
+}
+
+// regionalTarget is an item a cleanup command found in one region, kept with
+// the client that acts on it there.
+type regionalTarget[T any] struct {
+	Item   T
+	Region string
+	Client API
+}
+
+func addAllRegionsFlag(cmd *cobra.Command, allRegions *bool) {
+	cmd.Flags().BoolVar(allRegions, "all-regions", false, "Scan all enabled regions")
+}
+
+// collectRegionalTargets runs collect against the configured region or, with
+// allRegions, against every enabled region through a client scoped to it. In
+// the latter case the per-region outcome is written to stderr and a region
+// that fails does not stop the others. Targets are ordered by region, keeping
+// the order collect returned them in within a region.
+func collectRegionalTargets[T any](
+	cmd *cobra.Command,
+	runtime cliutil.CommandRuntime,
+	cfg awssdk.Config,
+	baseClient API,
+	allRegions bool,
+	collect func(ctx context.Context, client API, region string) ([]T, error),
+) ([]regionalTarget[T], error) {
+	if !allRegions {
+		items, err := collect(cmd.Context(), baseClient, cfg.Region)
+		if err != nil {
+			return nil, err
+		}
+		targets := make([]regionalTarget[T], 0, len(items))
+		for _, item := range items {
+			targets = append(targets, regionalTarget[T]{Item: item, Region: cfg.Region, Client: baseClient})
+		}
+		return targets, nil
+	}
+
+	regions, skipped, err := listRegions(cmd.Context(), baseClient)
+	if err != nil {
+		return nil, fmt.Errorf("list regions: %w", awstbxaws.WrapUserError(err))
+	}
+	targets, statuses := cliutil.ScanRegions(cmd.Context(), regions, skipped, func(ctx context.Context, region string) ([]regionalTarget[T], error) {
+		client := newRegionalClient(cfg, region)
+		items, collectErr := collect(ctx, client, region)
+		if collectErr != nil {
+			return nil, collectErr
+		}
+		regionTargets := make([]regionalTarget[T], 0, len(items))
+		for _, item := range items {
+			regionTargets = append(regionTargets, regionalTarget[T]{Item: item, Region: region, Client: client})
+		}
+		return regionTargets, nil
+	})
+	if err := cliutil.WriteRegionSummary(cmd, runtime, statuses); err != nil {
+		return nil, err
 	}
-	sort.Strings(regions)
 
-	return regions, nil
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].Region < targets[j].Region
+	})
+	return targets, nil
 }
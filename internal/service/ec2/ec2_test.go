@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,22 +21,73 @@ import (
 )
 
 type mockClient struct {
-	describeAddressesFn         func(context.Context, *ec2.DescribeAddressesInput, ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
-	describeImagesFn            func(context.Context, *ec2.DescribeImagesInput, ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
-	describeInstancesFn         func(context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
-	describeKeyPairsFn          func(context.Context, *ec2.DescribeKeyPairsInput, ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
-	describeNetworkInterfacesFn func(context.Context, *ec2.DescribeNetworkInterfacesInput, ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
-	describeRegionsFn           func(context.Context, *ec2.DescribeRegionsInput, ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
-	describeSecurityGroupsFn    func(context.Context, *ec2.DescribeSecurityGroupsInput, ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
-	describeSnapshotsFn         func(context.Context, *ec2.DescribeSnapshotsInput, ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
-	describeVolumesFn           func(context.Context, *ec2.DescribeVolumesInput, ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
-	deleteKeyPairFn             func(context.Context, *ec2.DeleteKeyPairInput, ...func(*ec2.Options)) (*ec2.DeleteKeyPairOutput, error)
-	deleteSecurityGroupFn       func(context.Context, *ec2.DeleteSecurityGroupInput, ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error)
-	deleteSnapshotFn            func(context.Context, *ec2.DeleteSnapshotInput, ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error)
-	deleteVolumeFn              func(context.Context, *ec2.DeleteVolumeInput, ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error)
-	deregisterImageFn           func(context.Context, *ec2.DeregisterImageInput, ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error)
-	releaseAddressFn            func(context.Context, *ec2.ReleaseAddressInput, ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error)
-	revokeSecurityIngressFn     func(context.Context, *ec2.RevokeSecurityGroupIngressInput, ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	cancelCapacityReservationFn     func(context.Context, *ec2.CancelCapacityReservationInput, ...func(*ec2.Options)) (*ec2.CancelCapacityReservationOutput, error)
+	cancelSpotRequestsFn            func(context.Context, *ec2.CancelSpotInstanceRequestsInput, ...func(*ec2.Options)) (*ec2.CancelSpotInstanceRequestsOutput, error)
+	createImageFn                   func(context.Context, *ec2.CreateImageInput, ...func(*ec2.Options)) (*ec2.CreateImageOutput, error)
+	createSnapshotFn                func(context.Context, *ec2.CreateSnapshotInput, ...func(*ec2.Options)) (*ec2.CreateSnapshotOutput, error)
+	createTagsFn                    func(context.Context, *ec2.CreateTagsInput, ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error)
+	describeAddressesFn             func(context.Context, *ec2.DescribeAddressesInput, ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
+	describeCapacityReservationsFn  func(context.Context, *ec2.DescribeCapacityReservationsInput, ...func(*ec2.Options)) (*ec2.DescribeCapacityReservationsOutput, error)
+	describeImagesFn                func(context.Context, *ec2.DescribeImagesInput, ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
+	describeInstanceTypeOfferingsFn func(context.Context, *ec2.DescribeInstanceTypeOfferingsInput, ...func(*ec2.Options)) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+	describeInstanceTypesFn         func(context.Context, *ec2.DescribeInstanceTypesInput, ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error)
+	describeInstancesFn             func(context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	describeKeyPairsFn              func(context.Context, *ec2.DescribeKeyPairsInput, ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
+	describeNetworkInterfacesFn     func(context.Context, *ec2.DescribeNetworkInterfacesInput, ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
+	describeRegionsFn               func(context.Context, *ec2.DescribeRegionsInput, ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+	describeReservedInstancesFn     func(context.Context, *ec2.DescribeReservedInstancesInput, ...func(*ec2.Options)) (*ec2.DescribeReservedInstancesOutput, error)
+	describeSecurityGroupsFn        func(context.Context, *ec2.DescribeSecurityGroupsInput, ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	describeSnapshotsFn             func(context.Context, *ec2.DescribeSnapshotsInput, ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	describeSpotRequestsFn          func(context.Context, *ec2.DescribeSpotInstanceRequestsInput, ...func(*ec2.Options)) (*ec2.DescribeSpotInstanceRequestsOutput, error)
+	describeSubnetsFn               func(context.Context, *ec2.DescribeSubnetsInput, ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
+	describeTagsFn                  func(context.Context, *ec2.DescribeTagsInput, ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error)
+	describeVolumesFn               func(context.Context, *ec2.DescribeVolumesInput, ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	deleteKeyPairFn                 func(context.Context, *ec2.DeleteKeyPairInput, ...func(*ec2.Options)) (*ec2.DeleteKeyPairOutput, error)
+	deleteNetworkInterfaceFn        func(context.Context, *ec2.DeleteNetworkInterfaceInput, ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error)
+	deleteSecurityGroupFn           func(context.Context, *ec2.DeleteSecurityGroupInput, ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error)
+	deleteSnapshotFn                func(context.Context, *ec2.DeleteSnapshotInput, ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error)
+	deleteVolumeFn                  func(context.Context, *ec2.DeleteVolumeInput, ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error)
+	deregisterImageFn               func(context.Context, *ec2.DeregisterImageInput, ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error)
+	modifyInstanceAttributeFn       func(context.Context, *ec2.ModifyInstanceAttributeInput, ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error)
+	releaseAddressFn                func(context.Context, *ec2.ReleaseAddressInput, ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error)
+	revokeSecurityIngressFn         func(context.Context, *ec2.RevokeSecurityGroupIngressInput, ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	startInstancesFn                func(context.Context, *ec2.StartInstancesInput, ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error)
+	stopInstancesFn                 func(context.Context, *ec2.StopInstancesInput, ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error)
+}
+
+func (m *mockClient) CancelCapacityReservation(ctx context.Context, in *ec2.CancelCapacityReservationInput, optFns ...func(*ec2.Options)) (*ec2.CancelCapacityReservationOutput, error) {
+	if m.cancelCapacityReservationFn == nil {
+		return nil, errors.New("CancelCapacityReservation not mocked")
+	}
+	return m.cancelCapacityReservationFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) CancelSpotInstanceRequests(ctx context.Context, in *ec2.CancelSpotInstanceRequestsInput, optFns ...func(*ec2.Options)) (*ec2.CancelSpotInstanceRequestsOutput, error) {
+	if m.cancelSpotRequestsFn == nil {
+		return nil, errors.New("CancelSpotInstanceRequests not mocked")
+	}
+	return m.cancelSpotRequestsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) CreateImage(ctx context.Context, in *ec2.CreateImageInput, optFns ...func(*ec2.Options)) (*ec2.CreateImageOutput, error) {
+	if m.createImageFn == nil {
+		return nil, errors.New("CreateImage not mocked")
+	}
+	return m.createImageFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) CreateSnapshot(ctx context.Context, in *ec2.CreateSnapshotInput, optFns ...func(*ec2.Options)) (*ec2.CreateSnapshotOutput, error) {
+	if m.createSnapshotFn == nil {
+		return nil, errors.New("CreateSnapshot not mocked")
+	}
+	return m.createSnapshotFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) CreateTags(ctx context.Context, in *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	if m.createTagsFn == nil {
+		return nil, errors.New("CreateTags not mocked")
+	}
+	return m.createTagsFn(ctx, in, optFns...)
 }
 
 func (m *mockClient) DescribeAddresses(ctx context.Context, in *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
@@ -41,6 +97,13 @@ func (m *mockClient) DescribeAddresses(ctx context.Context, in *ec2.DescribeAddr
 	return m.describeAddressesFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeCapacityReservations(ctx context.Context, in *ec2.DescribeCapacityReservationsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeCapacityReservationsOutput, error) {
+	if m.describeCapacityReservationsFn == nil {
+		return nil, errors.New("DescribeCapacityReservations not mocked")
+	}
+	return m.describeCapacityReservationsFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeImages(ctx context.Context, in *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
 	if m.describeImagesFn == nil {
 		return nil, errors.New("DescribeImages not mocked")
@@ -48,6 +111,20 @@ func (m *mockClient) DescribeImages(ctx context.Context, in *ec2.DescribeImagesI
 	return m.describeImagesFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeInstanceTypeOfferings(ctx context.Context, in *ec2.DescribeInstanceTypeOfferingsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	if m.describeInstanceTypeOfferingsFn == nil {
+		return nil, errors.New("DescribeInstanceTypeOfferings not mocked")
+	}
+	return m.describeInstanceTypeOfferingsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) DescribeInstanceTypes(ctx context.Context, in *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	if m.describeInstanceTypesFn == nil {
+		return nil, errors.New("DescribeInstanceTypes not mocked")
+	}
+	return m.describeInstanceTypesFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeInstances(ctx context.Context, in *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
 	if m.describeInstancesFn == nil {
 		return nil, errors.New("DescribeInstances not mocked")
@@ -76,6 +153,13 @@ func (m *mockClient) DescribeRegions(ctx context.Context, in *ec2.DescribeRegion
 	return m.describeRegionsFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeReservedInstances(ctx context.Context, in *ec2.DescribeReservedInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeReservedInstancesOutput, error) {
+	if m.describeReservedInstancesFn == nil {
+		return nil, errors.New("DescribeReservedInstances not mocked")
+	}
+	return m.describeReservedInstancesFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeSecurityGroups(ctx context.Context, in *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
 	if m.describeSecurityGroupsFn == nil {
 		return nil, errors.New("DescribeSecurityGroups not mocked")
@@ -90,6 +174,27 @@ func (m *mockClient) DescribeSnapshots(ctx context.Context, in *ec2.DescribeSnap
 	return m.describeSnapshotsFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeSpotInstanceRequests(ctx context.Context, in *ec2.DescribeSpotInstanceRequestsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSpotInstanceRequestsOutput, error) {
+	if m.describeSpotRequestsFn == nil {
+		return nil, errors.New("DescribeSpotInstanceRequests not mocked")
+	}
+	return m.describeSpotRequestsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) DescribeSubnets(ctx context.Context, in *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	if m.describeSubnetsFn == nil {
+		return nil, errors.New("DescribeSubnets not mocked")
+	}
+	return m.describeSubnetsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) DescribeTags(ctx context.Context, in *ec2.DescribeTagsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error) {
+	if m.describeTagsFn == nil {
+		return nil, errors.New("DescribeTags not mocked")
+	}
+	return m.describeTagsFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeVolumes(ctx context.Context, in *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
 	if m.describeVolumesFn == nil {
 		return nil, errors.New("DescribeVolumes not mocked")
@@ -104,6 +209,13 @@ func (m *mockClient) DeleteKeyPair(ctx context.Context, in *ec2.DeleteKeyPairInp
 	return m.deleteKeyPairFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DeleteNetworkInterface(ctx context.Context, in *ec2.DeleteNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	if m.deleteNetworkInterfaceFn == nil {
+		return nil, errors.New("DeleteNetworkInterface not mocked")
+	}
+	return m.deleteNetworkInterfaceFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DeleteSecurityGroup(ctx context.Context, in *ec2.DeleteSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
 	if m.deleteSecurityGroupFn == nil {
 		return nil, errors.New("DeleteSecurityGroup not mocked")
@@ -132,6 +244,13 @@ func (m *mockClient) DeregisterImage(ctx context.Context, in *ec2.DeregisterImag
 	return m.deregisterImageFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) ModifyInstanceAttribute(ctx context.Context, in *ec2.ModifyInstanceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error) {
+	if m.modifyInstanceAttributeFn == nil {
+		return nil, errors.New("ModifyInstanceAttribute not mocked")
+	}
+	return m.modifyInstanceAttributeFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) ReleaseAddress(ctx context.Context, in *ec2.ReleaseAddressInput, optFns ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error) {
 	if m.releaseAddressFn == nil {
 		return nil, errors.New("ReleaseAddress not mocked")
@@ -146,21 +265,38 @@ func (m *mockClient) RevokeSecurityGroupIngress(ctx context.Context, in *ec2.Rev
 	return m.revokeSecurityIngressFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) StartInstances(ctx context.Context, in *ec2.StartInstancesInput, optFns ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error) {
+	if m.startInstancesFn == nil {
+		return nil, errors.New("StartInstances not mocked")
+	}
+	return m.startInstancesFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) StopInstances(ctx context.Context, in *ec2.StopInstancesInput, optFns ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error) {
+	if m.stopInstancesFn == nil {
+		return nil, errors.New("StopInstances not mocked")
+	}
+	return m.stopInstancesFn(ctx, in, optFns...)
+}
+
 func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, error), nc func(awssdk.Config) API, newRegional func(awssdk.Config, string) API) {
 	t.Helper()
 
 	oldLoader := loadAWSConfig
 	oldNewClient := newClient
 	oldNewRegional := newRegionalClient
+	oldSleep := sleep
 
 	loadAWSConfig = loader
 	newClient = nc
 	newRegionalClient = newRegional
+	sleep = func(context.Context, time.Duration) error { return nil }
 
 	t.Cleanup(func() {
 		loadAWSConfig = oldLoader
 		newClient = oldNewClient
 		newRegionalClient = oldNewRegional
+		sleep = oldSleep
 	})
 }
 
@@ -250,6 +386,77 @@ func TestEC2DeleteAMIsExecutesWhenNoConfirm(t *testing.T) {
 	}
 }
 
+func TestEC2DeleteAMIsDeletesSnapshotsAndIgnoresStoppedInstances(t *testing.T) {
+	ebs := func(snapshotIDs ...string) []ec2types.BlockDeviceMapping {
+		mappings := []ec2types.BlockDeviceMapping{{DeviceName: cliutil.Ptr("/dev/sdz"), VirtualName: cliutil.Ptr("ephemeral0")}}
+		for _, snapshotID := range snapshotIDs {
+			mappings = append(mappings, ec2types.BlockDeviceMapping{Ebs: &ec2types.EbsBlockDevice{SnapshotId: cliutil.Ptr(snapshotID)}})
+		}
+		return mappings
+	}
+	recent := time.Now().UTC().AddDate(0, 0, -1).Format(time.RFC3339)
+	calls := make([]string, 0)
+	client := &mockClient{
+		describeImagesFn: func(_ context.Context, _ *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+			return &ec2.DescribeImagesOutput{Images: []ec2types.Image{
+				{ImageId: cliutil.Ptr("ami-old"), Name: cliutil.Ptr("web-2020"), CreationDate: cliutil.Ptr("2020-01-01T00:00:00.000Z"), BlockDeviceMappings: ebs("snap-a", "snap-b")},
+				{ImageId: cliutil.Ptr("ami-used"), Name: cliutil.Ptr("web-used"), CreationDate: cliutil.Ptr("2020-01-01T00:00:00.000Z"), BlockDeviceMappings: ebs("snap-c")},
+				{ImageId: cliutil.Ptr("ami-fail"), Name: cliutil.Ptr("WEB-fail"), CreationDate: cliutil.Ptr("2020-01-01T00:00:00.000Z"), BlockDeviceMappings: ebs("snap-d")},
+				{ImageId: cliutil.Ptr("ami-recent"), Name: cliutil.Ptr("web-new"), CreationDate: cliutil.Ptr(recent)},
+				{ImageId: cliutil.Ptr("ami-db"), Name: cliutil.Ptr("db-2020"), CreationDate: cliutil.Ptr("2020-01-01T00:00:00.000Z")},
+				{ImageId: cliutil.Ptr("ami-stopped"), Name: cliutil.Ptr("web-stopped"), CreationDate: cliutil.Ptr("2020-01-01T00:00:00.000Z")},
+			}}, nil
+		},
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{ImageId: cliutil.Ptr("ami-used"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}},
+				{ImageId: cliutil.Ptr("ami-stopped"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}},
+				{ImageId: cliutil.Ptr("ami-fail"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameTerminated}},
+			}}}}, nil
+		},
+		deregisterImageFn: func(_ context.Context, in *ec2.DeregisterImageInput, _ ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error) {
+			calls = append(calls, "deregister:"+cliutil.PointerToString(in.ImageId))
+			if cliutil.PointerToString(in.ImageId) == "ami-fail" {
+				return nil, errors.New("deregister blocked")
+			}
+			return &ec2.DeregisterImageOutput{}, nil
+		},
+		deleteSnapshotFn: func(_ context.Context, in *ec2.DeleteSnapshotInput, _ ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error) {
+			calls = append(calls, "delete:"+cliutil.PointerToString(in.SnapshotId))
+			if cliutil.PointerToString(in.SnapshotId) == "snap-b" {
+				return nil, errors.New("snapshot busy")
+			}
+			return &ec2.DeleteSnapshotOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-amis", "--retention-days", "30", "--unused", "--name-contains", "web", "--delete-snapshots")
+	if err != nil {
+		t.Fatalf("execute delete-amis: %v", err)
+	}
+	expected := strings.Join([]string{
+		"image_id=ami-fail name=WEB-fail region=us-east-1 snapshot_id= action=failed:deregister blocked (UnknownError)",
+		"image_id=ami-fail name=WEB-fail region=us-east-1 snapshot_id=snap-d action=skipped:deregister-failed",
+		"image_id=ami-old name=web-2020 region=us-east-1 snapshot_id= action=deleted",
+		"image_id=ami-old name=web-2020 region=us-east-1 snapshot_id=snap-a action=deleted",
+		"image_id=ami-old name=web-2020 region=us-east-1 snapshot_id=snap-b action=failed:snapshot busy (UnknownError)",
+		"image_id=ami-stopped name=web-stopped region=us-east-1 snapshot_id= action=deleted",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(calls, ",") != "deregister:ami-fail,deregister:ami-old,delete:snap-a,delete:snap-b,deregister:ami-stopped" {
+		t.Fatalf("unexpected call order: %v", calls)
+	}
+}
+
 func TestEC2DeleteEIPsDryRun(t *testing.T) {
 	client := &mockClient{
 		describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
@@ -341,6 +548,50 @@ func TestEC2DeleteEIPsStatusMatchesSortedAllocationIDRows(t *testing.T) {
 	}
 }
 
+func TestEC2ReleaseUnusedEIPsHandlesClassicAddresses(t *testing.T) {
+	released := make([]string, 0)
+	client := &mockClient{
+		describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
+			return &ec2.DescribeAddressesOutput{Addresses: []ec2types.Address{
+				{AllocationId: cliutil.Ptr("eipalloc-vpc"), PublicIp: cliutil.Ptr("1.1.1.1")},
+				{PublicIp: cliutil.Ptr("3.3.3.3")},
+				{AllocationId: cliutil.Ptr("eipalloc-eni"), PublicIp: cliutil.Ptr("4.4.4.4"), NetworkInterfaceId: cliutil.Ptr("eni-1")},
+				{PublicIp: cliutil.Ptr("5.5.5.5"), InstanceId: cliutil.Ptr("i-classic")},
+				{AllocationId: cliutil.Ptr("eipalloc-assoc"), PublicIp: cliutil.Ptr("6.6.6.6"), AssociationId: cliutil.Ptr("eipassoc-1")},
+			}}, nil
+		},
+		releaseAddressFn: func(_ context.Context, in *ec2.ReleaseAddressInput, _ ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error) {
+			if in.AllocationId != nil && in.PublicIp != nil {
+				t.Fatalf("expected either AllocationId or PublicIp, got both: %#v", in)
+			}
+			released = append(released, cliutil.PointerToString(in.AllocationId)+cliutil.PointerToString(in.PublicIp))
+			return &ec2.ReleaseAddressOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "release-unused-eips")
+	if err != nil {
+		t.Fatalf("execute release-unused-eips: %v", err)
+	}
+	expected := strings.Join([]string{
+		"allocation_id= public_ip=3.3.3.3 region=us-east-1 action=deleted",
+		"allocation_id=eipalloc-vpc public_ip=1.1.1.1 region=us-east-1 action=deleted",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(released, ",") != "3.3.3.3,eipalloc-vpc" {
+		t.Fatalf("unexpected released addresses: %v", released)
+	}
+}
+
 func TestEC2DeleteKeypairsAllRegionsDryRun(t *testing.T) {
 	clientByRegion := map[string]*mockClient{
 		"us-east-1": {
@@ -415,6 +666,48 @@ func TestEC2DeleteKeypairsExecutesWhenNoConfirm(t *testing.T) {
 	}
 }
 
+func TestEC2DeleteUnusedKeyPairsIgnoresTerminatedInstances(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeKeyPairsFn: func(_ context.Context, _ *ec2.DescribeKeyPairsInput, _ ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error) {
+			return &ec2.DescribeKeyPairsOutput{KeyPairs: []ec2types.KeyPairInfo{
+				{KeyName: cliutil.Ptr("running-key")},
+				{KeyName: cliutil.Ptr("stopped-key")},
+				{KeyName: cliutil.Ptr("terminated-key")},
+			}}, nil
+		},
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{KeyName: cliutil.Ptr("running-key"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}},
+				{KeyName: cliutil.Ptr("stopped-key"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}},
+				{KeyName: cliutil.Ptr("terminated-key"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameTerminated}},
+			}}}}, nil
+		},
+		deleteKeyPairFn: func(_ context.Context, in *ec2.DeleteKeyPairInput, _ ...func(*ec2.Options)) (*ec2.DeleteKeyPairOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.KeyName))
+			return &ec2.DeleteKeyPairOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-unused-key-pairs")
+	if err != nil {
+		t.Fatalf("execute delete-unused-key-pairs: %v", err)
+	}
+	if strings.TrimSpace(output) != "key_name=terminated-key region=us-east-1 action=deleted" {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "terminated-key" {
+		t.Fatalf("unexpected deleted key pairs: %v", deleted)
+	}
+}
+
 func TestEC2DeleteKeypairsCancelledPrompt(t *testing.T) {
 	client := &mockClient{
 		describeKeyPairsFn: func(_ context.Context, _ *ec2.DescribeKeyPairsInput, _ ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error) {
@@ -528,6 +821,70 @@ func TestEC2DeleteSecurityGroupsDeleteWithTagAndType(t *testing.T) {
 	}
 }
 
+func TestEC2DeleteUnusedSecurityGroups(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeSecurityGroupsFn: func(_ context.Context, _ *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+			return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []ec2types.SecurityGroup{
+				{GroupId: cliutil.Ptr("sg-default"), GroupName: cliutil.Ptr("default")},
+				{GroupId: cliutil.Ptr("sg-cache"), GroupName: cliutil.Ptr("redis-cluster")},
+				{
+					GroupId:   cliutil.Ptr("sg-app"),
+					GroupName: cliutil.Ptr("app"),
+					IpPermissions: []ec2types.IpPermission{{UserIdGroupPairs: []ec2types.UserIdGroupPair{
+						{GroupId: cliutil.Ptr("sg-lb")},
+						{GroupId: cliutil.Ptr("sg-app")},
+					}}},
+				},
+				{GroupId: cliutil.Ptr("sg-lb"), GroupName: cliutil.Ptr("lb")},
+				{
+					GroupId:             cliutil.Ptr("sg-orphan"),
+					GroupName:           cliutil.Ptr("orphan"),
+					IpPermissionsEgress: []ec2types.IpPermission{{UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: cliutil.Ptr("sg-egress-target")}}}},
+				},
+				{GroupId: cliutil.Ptr("sg-egress-target"), GroupName: cliutil.Ptr("egress-target")},
+				{GroupId: cliutil.Ptr("sg-stale"), GroupName: cliutil.Ptr("stale")},
+			}}, nil
+		},
+		describeNetworkInterfacesFn: func(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+			return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []ec2types.NetworkInterface{
+				{Groups: []ec2types.GroupIdentifier{{GroupId: cliutil.Ptr("sg-cache")}}},
+			}}, nil
+		},
+		deleteSecurityGroupFn: func(_ context.Context, in *ec2.DeleteSecurityGroupInput, _ ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+			groupID := cliutil.PointerToString(in.GroupId)
+			if groupID == "sg-stale" {
+				return nil, &smithy.GenericAPIError{Code: "DependencyViolation", Message: "resource sg-stale has a dependent object"}
+			}
+			deleted = append(deleted, groupID)
+			return &ec2.DeleteSecurityGroupOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-unused-security-groups")
+	if err != nil {
+		t.Fatalf("execute delete-unused-security-groups: %v", err)
+	}
+	expected := strings.Join([]string{
+		"group_id=sg-app group_name=app region=us-east-1 action=deleted",
+		"group_id=sg-orphan group_name=orphan region=us-east-1 action=deleted",
+		"group_id=sg-stale group_name=stale region=us-east-1 action=failed:resource sg-stale has a dependent object (DependencyViolation)",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "sg-app,sg-orphan" {
+		t.Fatalf("unexpected deleted groups: %v", deleted)
+	}
+}
+
 func TestEC2DeleteSnapshotsDryRun(t *testing.T) {
 	oldStart := time.Now().UTC().AddDate(0, 0, -60)
 
@@ -568,6 +925,75 @@ func TestEC2DeleteSnapshotsDryRun(t *testing.T) {
 	}
 }
 
+func TestEC2DeleteSnapshotsDanglingToggle(t *testing.T) {
+	old := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	recent := time.Now().UTC().AddDate(0, 0, -5)
+	client := &mockClient{
+		describeSnapshotsFn: func(_ context.Context, in *ec2.DescribeSnapshotsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+			if len(in.OwnerIds) != 1 || in.OwnerIds[0] != "self" {
+				t.Fatalf("expected owner=self, got %v", in.OwnerIds)
+			}
+			return &ec2.DescribeSnapshotsOutput{Snapshots: []ec2types.Snapshot{
+				{SnapshotId: cliutil.Ptr("snap-ami"), VolumeId: cliutil.Ptr("vol-gone"), StartTime: &old},
+				{SnapshotId: cliutil.Ptr("snap-dangling"), VolumeId: cliutil.Ptr("vol-gone"), StartTime: &old},
+				{SnapshotId: cliutil.Ptr("snap-live"), VolumeId: cliutil.Ptr("vol-live"), StartTime: &old},
+				{SnapshotId: cliutil.Ptr("snap-recent"), VolumeId: cliutil.Ptr("vol-gone"), StartTime: &recent},
+			}}, nil
+		},
+		describeImagesFn: func(_ context.Context, _ *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+			return &ec2.DescribeImagesOutput{Images: []ec2types.Image{{
+				BlockDeviceMappings: []ec2types.BlockDeviceMapping{{Ebs: &ec2types.EbsBlockDevice{SnapshotId: cliutil.Ptr("snap-ami")}}},
+			}}}, nil
+		},
+		describeVolumesFn: func(_ context.Context, in *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			if in.VolumeIds[0] == "vol-gone" {
+				return nil, &smithy.GenericAPIError{Code: "InvalidVolume.NotFound", Message: "missing"}
+			}
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{{VolumeId: cliutil.Ptr(in.VolumeIds[0])}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "ec2", "delete-snapshots", "--retention-days", "30", "--dangling=false")
+	if err != nil {
+		t.Fatalf("execute delete-snapshots --dangling=false: %v", err)
+	}
+	expected := strings.Join([]string{
+		"snapshot_id=snap-ami volume_id=vol-gone region=us-east-1 action=skipped:in-use-by-ami",
+		"snapshot_id=snap-dangling volume_id=vol-gone region=us-east-1 action=would-delete",
+		"snapshot_id=snap-live volume_id=vol-live region=us-east-1 action=would-delete",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	deleted := make([]string, 0)
+	client.deleteSnapshotFn = func(_ context.Context, in *ec2.DeleteSnapshotInput, _ ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error) {
+		deleted = append(deleted, cliutil.PointerToString(in.SnapshotId))
+		return &ec2.DeleteSnapshotOutput{}, nil
+	}
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-snapshots", "--retention-days", "30")
+	if err != nil {
+		t.Fatalf("execute delete-snapshots: %v", err)
+	}
+	if strings.TrimSpace(output) != "snapshot_id=snap-dangling volume_id=vol-gone region=us-east-1 action=deleted" {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "snap-dangling" {
+		t.Fatalf("unexpected deleted snapshots: %v", deleted)
+	}
+
+	if _, err := executeCommand(t, "ec2", "delete-snapshots", "--dangling=false"); err == nil || !strings.Contains(err.Error(), "set --retention-days when --dangling=false") {
+		t.Fatalf("expected --retention-days error, got %v", err)
+	}
+}
+
 func TestEC2DeleteSnapshotsExecutesWhenNoConfirm(t *testing.T) {
 	deleted := 0
 	start := time.Now().UTC().AddDate(0, 0, -60)
@@ -655,6 +1081,93 @@ func TestEC2DeleteVolumesExecutesWhenNoConfirm(t *testing.T) {
 	}
 }
 
+func TestEC2DeleteVolumesAgeAndExcludeTagFilters(t *testing.T) {
+	old := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Now().UTC().AddDate(0, 0, -2)
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeVolumesFn: func(_ context.Context, in *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			if len(in.Filters) != 1 || cliutil.PointerToString(in.Filters[0].Name) != "status" || in.Filters[0].Values[0] != "available" {
+				t.Fatalf("expected status=available filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: cliutil.Ptr("vol-old"), Size: cliutil.Ptr(int32(100)), CreateTime: &old},
+				{VolumeId: cliutil.Ptr("vol-kept"), Size: cliutil.Ptr(int32(50)), CreateTime: &old, Tags: []ec2types.Tag{{Key: cliutil.Ptr("keep"), Value: cliutil.Ptr("true")}}},
+				{VolumeId: cliutil.Ptr("vol-other-tag"), Size: cliutil.Ptr(int32(8)), CreateTime: &old, Tags: []ec2types.Tag{{Key: cliutil.Ptr("keep"), Value: cliutil.Ptr("false")}}},
+				{VolumeId: cliutil.Ptr("vol-recent"), Size: cliutil.Ptr(int32(20)), CreateTime: &recent},
+			}}, nil
+		},
+		deleteVolumeFn: func(_ context.Context, in *ec2.DeleteVolumeInput, _ ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.VolumeId))
+			return &ec2.DeleteVolumeOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-unattached-volumes", "--older-than-days", "30", "--exclude-tag", "keep=true")
+	if err != nil {
+		t.Fatalf("execute delete-unattached-volumes: %v", err)
+	}
+	expected := strings.Join([]string{
+		"volume_id=vol-old size_gib=100 region=us-east-1 action=deleted",
+		"volume_id=vol-other-tag size_gib=8 region=us-east-1 action=deleted",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "vol-old,vol-other-tag" {
+		t.Fatalf("unexpected deleted volumes: %v", deleted)
+	}
+
+	if _, err := executeCommand(t, "ec2", "delete-volumes", "--exclude-tag", "keep"); err == nil || !strings.Contains(err.Error(), "--exclude-tag must use KEY=VALUE format") {
+		t.Fatalf("expected --exclude-tag error, got %v", err)
+	}
+}
+
+func TestEC2DeleteVolumesInteractiveDeletesSelectedOnly(t *testing.T) {
+	original := cliutil.StdinIsTerminal
+	cliutil.StdinIsTerminal = func(io.Reader) bool { return true }
+	t.Cleanup(func() { cliutil.StdinIsTerminal = original })
+
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeVolumesFn: func(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: cliutil.Ptr("vol-1"), Size: cliutil.Ptr(int32(8))},
+				{VolumeId: cliutil.Ptr("vol-2"), Size: cliutil.Ptr(int32(20))},
+			}}, nil
+		},
+		deleteVolumeFn: func(_ context.Context, in *ec2.DeleteVolumeInput, _ ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.VolumeId))
+			return &ec2.DeleteVolumeOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommandWithInput(t, "2\ny\n", "--output", "text", "ec2", "delete-volumes", "--interactive")
+	if err != nil {
+		t.Fatalf("execute delete-volumes --interactive: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "vol-2" {
+		t.Fatalf("expected only vol-2 deleted, got %v: %s", deleted, output)
+	}
+	if !strings.Contains(output, "volume_id=vol-2 size_gib=20 region=us-east-1 action=deleted") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+}
+
 func TestEC2ListEIPsAllOutputFormats(t *testing.T) {
 	client := &mockClient{
 		describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
@@ -809,3 +1322,1331 @@ func TestEC2DeleteVolumesCancelledPrompt(t *testing.T) {
 		t.Fatalf("expected cancelled action: %s", output)
 	}
 }
+
+func TestEC2DeleteVolumesSnapshotFirst(t *testing.T) {
+	calls := make([]string, 0)
+	describeCalls := 0
+	client := &mockClient{
+		describeVolumesFn: func(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: cliutil.Ptr("vol-1"), Size: cliutil.Ptr(int32(20))},
+				{VolumeId: cliutil.Ptr("vol-2"), Size: cliutil.Ptr(int32(8))},
+			}}, nil
+		},
+		createSnapshotFn: func(_ context.Context, in *ec2.CreateSnapshotInput, _ ...func(*ec2.Options)) (*ec2.CreateSnapshotOutput, error) {
+			volumeID := cliutil.PointerToString(in.VolumeId)
+			calls = append(calls, "snapshot:"+volumeID)
+			if volumeID == "vol-2" {
+				return nil, &smithy.GenericAPIError{Code: "SnapshotLimitExceeded", Message: "limit"}
+			}
+			if len(in.TagSpecifications) != 1 || !hasTagMatch(in.TagSpecifications[0].Tags, "awstbx:source-volume-id", volumeID) {
+				t.Fatalf("expected source volume tag on snapshot, got %#v", in.TagSpecifications)
+			}
+			return &ec2.CreateSnapshotOutput{SnapshotId: cliutil.Ptr("snap-" + volumeID)}, nil
+		},
+		describeSnapshotsFn: func(_ context.Context, in *ec2.DescribeSnapshotsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+			describeCalls++
+			state := ec2types.SnapshotStatePending
+			if describeCalls > 1 {
+				state = ec2types.SnapshotStateCompleted
+			}
+			return &ec2.DescribeSnapshotsOutput{Snapshots: []ec2types.Snapshot{{SnapshotId: cliutil.Ptr(in.SnapshotIds[0]), State: state}}}, nil
+		},
+		deleteVolumeFn: func(_ context.Context, in *ec2.DeleteVolumeInput, _ ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error) {
+			calls = append(calls, "delete:"+cliutil.PointerToString(in.VolumeId))
+			return &ec2.DeleteVolumeOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--no-confirm", "ec2", "delete-volumes", "--snapshot-first")
+	if err != nil {
+		t.Fatalf("execute delete-volumes --snapshot-first: %v", err)
+	}
+
+	if got := strings.Join(calls, ","); got != "snapshot:vol-1,delete:vol-1,snapshot:vol-2" {
+		t.Fatalf("unexpected call sequence: %s", got)
+	}
+	if describeCalls != 2 {
+		t.Fatalf("expected snapshot completion polling, got %d DescribeSnapshots calls", describeCalls)
+	}
+	for _, expected := range []string{`"snapshot_id": "snap-vol-1"`, `"action": "deleted"`, `"action": "skipped:snapshot-failed"`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %s in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2ListInstancesIncludeTags(t *testing.T) {
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId:   cliutil.Ptr("i-1"),
+				InstanceType: ec2types.InstanceTypeT3Micro,
+				State:        &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+				Tags:         []ec2types.Tag{{Key: cliutil.Ptr("Name"), Value: cliutil.Ptr("web")}},
+			}}}}}, nil
+		},
+		describeTagsFn: func(_ context.Context, in *ec2.DescribeTagsInput, _ ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error) {
+			if len(in.Filters) != 1 || in.Filters[0].Values[0] != "i-1" {
+				t.Fatalf("unexpected DescribeTags filters: %#v", in.Filters)
+			}
+			return &ec2.DescribeTagsOutput{Tags: []ec2types.TagDescription{{Key: cliutil.Ptr("CostCenter"), Value: cliutil.Ptr("cc-42")}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--include-tags", "CostCenter,Owner", "ec2", "list-instances")
+	if err != nil {
+		t.Fatalf("execute list-instances: %v", err)
+	}
+	for _, expected := range []string{`"name": "web"`, `"state": "running"`, `"tag:CostCenter": "cc-42"`, `"tag:Owner": ""`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %s in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2ListInstancesLimit(t *testing.T) {
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: cliutil.Ptr("i-3")},
+				{InstanceId: cliutil.Ptr("i-1")},
+				{InstanceId: cliutil.Ptr("i-2")},
+			}}}}, nil
+		},
+		describeTagsFn: func(_ context.Context, in *ec2.DescribeTagsInput, _ ...func(*ec2.Options)) (*ec2.DescribeTagsOutput, error) {
+			if in.Filters[0].Values[0] == "i-3" {
+				t.Fatal("did not expect tag lookup for an instance beyond --limit")
+			}
+			return &ec2.DescribeTagsOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--limit", "2", "--include-tags", "Owner", "ec2", "list-instances")
+	if err != nil {
+		t.Fatalf("execute list-instances: %v", err)
+	}
+	if !strings.Contains(output, "instance_id=i-1") || !strings.Contains(output, "instance_id=i-2") || strings.Contains(output, "instance_id=i-3") {
+		t.Fatalf("expected the first two instances only: %s", output)
+	}
+	if !strings.Contains(output, "showing 2 of 3 rows") {
+		t.Fatalf("expected limit summary in output: %s", output)
+	}
+}
+
+func TestEC2TagFromCSVBatchesIdenticalTagSets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.csv")
+	content := strings.Join([]string{
+		"resource_id,key,value",
+		"i-111,Owner,team-a",
+		"vol-222,Owner,team-a",
+		"i-111,Env,prod",
+		"vol-222,Env,prod",
+		"snap-333,Owner,team-b",
+		"bogus-444,Owner,team-a",
+		"sg-555,Owner,team-b",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	batches := make(map[string][]string)
+	client := &mockClient{
+		createTagsFn: func(_ context.Context, in *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+			pairs := make([]string, 0, len(in.Tags))
+			for _, tag := range in.Tags {
+				pairs = append(pairs, *tag.Key+"="+*tag.Value)
+			}
+			signature := strings.Join(pairs, ";")
+			if _, exists := batches[signature]; exists {
+				t.Fatalf("tag set %q sent in more than one CreateTags call", signature)
+			}
+			batches[signature] = in.Resources
+			if signature == "Owner=team-b" {
+				return nil, &smithy.GenericAPIError{Code: "InvalidID", Message: "bad id"}
+			}
+			return &ec2.CreateTagsOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--no-confirm", "ec2", "tag-from-csv", "--file", path)
+	if err != nil {
+		t.Fatalf("execute tag-from-csv: %v", err)
+	}
+
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 CreateTags calls, got %d: %v", len(batches), batches)
+	}
+	if got := strings.Join(batches["Env=prod;Owner=team-a"], ","); got != "i-111,vol-222" {
+		t.Fatalf("unexpected resources for team-a batch: %s", got)
+	}
+	if got := strings.Join(batches["Owner=team-b"], ","); got != "snap-333,sg-555" {
+		t.Fatalf("unexpected resources for team-b batch: %s", got)
+	}
+	for _, expected := range []string{
+		"warning: skipping bogus-444",
+		`"action": "tagged"`,
+		`"action": "skipped:unknown-resource-type"`,
+		`"action": "failed:bad id (InvalidID)"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %s in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2TagFromCSVKeepsLookalikeTagSetsApart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.csv")
+	content := strings.Join([]string{
+		`i-111,a,"b;c=d"`,
+		"i-222,a,b",
+		"i-222,c,d",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	calls := make([]string, 0)
+	client := &mockClient{
+		createTagsFn: func(_ context.Context, in *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+			calls = append(calls, strings.Join(in.Resources, ","))
+			return &ec2.CreateTagsOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	if _, err := executeCommand(t, "--no-confirm", "ec2", "tag-from-csv", "--file", path); err != nil {
+		t.Fatalf("execute tag-from-csv: %v", err)
+	}
+	if strings.Join(calls, "|") != "i-111|i-222" {
+		t.Fatalf("expected distinct tag sets in separate CreateTags calls, got %v", calls)
+	}
+}
+
+func TestEC2TagFromCSVSkipsPromptWithoutTaggableResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.csv")
+	if err := os.WriteFile(path, []byte("bogus-1,Owner,team-a\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	client := &mockClient{}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "tag-from-csv", "--file", path)
+	if err != nil {
+		t.Fatalf("execute tag-from-csv: %v", err)
+	}
+	if strings.Contains(output, "Apply tags") || !strings.Contains(output, "resource_id=bogus-1 tags=Owner=team-a action=skipped:unknown-resource-type") {
+		t.Fatalf("expected skipped row without a prompt: %s", output)
+	}
+}
+
+func TestEC2TagFromCSVDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.csv")
+	if err := os.WriteFile(path, []byte("i-111,Owner,team-a\n"), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	client := &mockClient{}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--dry-run", "ec2", "tag-from-csv", "--file", path)
+	if err != nil {
+		t.Fatalf("execute tag-from-csv: %v", err)
+	}
+	if !strings.Contains(output, `"action": "would-tag"`) || !strings.Contains(output, `"tags": "Owner=team-a"`) {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+}
+
+func TestEC2AuditBackupCoverageApplyTagsMissingVolumes(t *testing.T) {
+	var taggedResources []string
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: awssdk.String("i-1"), Tags: []ec2types.Tag{{Key: awssdk.String("Backup"), Value: awssdk.String("true")}}},
+				{InstanceId: awssdk.String("i-2")},
+			}}}}, nil
+		},
+		describeVolumesFn: func(_ context.Context, in *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			if len(in.Filters) != 1 || awssdk.ToString(in.Filters[0].Name) != "attachment.status" {
+				t.Fatalf("expected attachment.status filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: awssdk.String("vol-covered"), Tags: []ec2types.Tag{{Key: awssdk.String("Backup"), Value: awssdk.String("true")}}, Attachments: []ec2types.VolumeAttachment{{InstanceId: awssdk.String("i-1")}}},
+				{VolumeId: awssdk.String("vol-missing"), Attachments: []ec2types.VolumeAttachment{{InstanceId: awssdk.String("i-2")}}},
+			}}, nil
+		},
+		createTagsFn: func(_ context.Context, in *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+			taggedResources = append(taggedResources, in.Resources...)
+			return &ec2.CreateTagsOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "audit-backup-coverage", "--apply")
+	if err != nil {
+		t.Fatalf("execute audit-backup-coverage: %v", err)
+	}
+	if strings.Join(taggedResources, ",") != "vol-missing" {
+		t.Fatalf("expected only vol-missing to be tagged, got %v", taggedResources)
+	}
+	for _, expected := range []string{
+		"instance_id=i-1 volume_id=vol-covered instance_tagged=true volume_tagged=true region=us-east-1 action=ok",
+		"instance_id=i-2 volume_id=vol-missing instance_tagged=false volume_tagged=false region=us-east-1 action=tagged",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2AuditBackupCoverageReadOnlyByDefault(t *testing.T) {
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{}, nil
+		},
+		describeVolumesFn: func(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: awssdk.String("vol-missing"), Attachments: []ec2types.VolumeAttachment{{InstanceId: awssdk.String("i-2")}}},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "ec2", "audit-backup-coverage", "--backup-tag", "Backup=true")
+	if err != nil {
+		t.Fatalf("execute audit-backup-coverage: %v", err)
+	}
+	if !strings.Contains(output, `"action": "missing-tag"`) {
+		t.Fatalf("expected missing-tag action: %s", output)
+	}
+}
+
+func TestEC2VolumeReportFlagsOrphanedAndAvailableVolumes(t *testing.T) {
+	client := &mockClient{
+		describeVolumesFn: func(_ context.Context, in *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+			if len(in.Filters) != 0 {
+				t.Fatalf("expected unfiltered DescribeVolumes, got %#v", in.Filters)
+			}
+			return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{
+				{VolumeId: awssdk.String("vol-c"), State: ec2types.VolumeStateInUse, Size: awssdk.Int32(8), VolumeType: ec2types.VolumeTypeGp3, Attachments: []ec2types.VolumeAttachment{{InstanceId: awssdk.String("i-gone"), Device: awssdk.String("/dev/xvdf")}}},
+				{VolumeId: awssdk.String("vol-a"), State: ec2types.VolumeStateInUse, Size: awssdk.Int32(20), VolumeType: ec2types.VolumeTypeGp3, Attachments: []ec2types.VolumeAttachment{{InstanceId: awssdk.String("i-1"), Device: awssdk.String("/dev/xvda")}}},
+				{VolumeId: awssdk.String("vol-b"), State: ec2types.VolumeStateAvailable, Size: awssdk.Int32(100), VolumeType: ec2types.VolumeTypeSt1},
+			}}, nil
+		},
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: awssdk.String("i-1"), Tags: []ec2types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String("web")}}},
+			}}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "volume-report")
+	if err != nil {
+		t.Fatalf("execute volume-report: %v", err)
+	}
+	for _, expected := range []string{
+		"volume_id=vol-a state=in-use size_gib=20 volume_type=gp3 instance_id=i-1 instance_name=web device=/dev/xvda instance_exists=true region=us-east-1 finding=ok",
+		"volume_id=vol-b state=available size_gib=100 volume_type=st1",
+		"finding=available",
+		"volume_id=vol-c state=in-use size_gib=8 volume_type=gp3 instance_id=i-gone instance_name= device=/dev/xvdf instance_exists=false region=us-east-1 finding=orphaned-attachment",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2DeleteKeypairsAllRegionsReportsRegionSummary(t *testing.T) {
+	keyPairClient := func(name string) *mockClient {
+		return &mockClient{
+			describeKeyPairsFn: func(_ context.Context, _ *ec2.DescribeKeyPairsInput, _ ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error) {
+				return &ec2.DescribeKeyPairsOutput{KeyPairs: []ec2types.KeyPairInfo{{KeyName: cliutil.Ptr(name)}}}, nil
+			},
+			describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+				return &ec2.DescribeInstancesOutput{}, nil
+			},
+		}
+	}
+	clientByRegion := map[string]API{
+		"us-east-1": keyPairClient("unused-east"),
+		"eu-west-1": keyPairClient("unused-eu"),
+		"ap-southeast-2": &mockClient{
+			describeKeyPairsFn: func(_ context.Context, _ *ec2.DescribeKeyPairsInput, _ ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}
+			},
+		},
+	}
+
+	baseClient := &mockClient{
+		describeRegionsFn: func(_ context.Context, in *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+			if !awssdk.ToBool(in.AllRegions) {
+				t.Fatal("expected AllRegions=true to detect opted-out regions")
+			}
+			return &ec2.DescribeRegionsOutput{Regions: []ec2types.Region{
+				{RegionName: cliutil.Ptr("us-east-1"), OptInStatus: cliutil.Ptr("opt-in-not-required")},
+				{RegionName: cliutil.Ptr("eu-west-1"), OptInStatus: cliutil.Ptr("opt-in-not-required")},
+				{RegionName: cliutil.Ptr("ap-southeast-2"), OptInStatus: cliutil.Ptr("opt-in-not-required")},
+				{RegionName: cliutil.Ptr("af-south-1"), OptInStatus: cliutil.Ptr("not-opted-in")},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return baseClient },
+		func(_ awssdk.Config, region string) API {
+			client, ok := clientByRegion[region]
+			if !ok {
+				t.Errorf("unexpected scan of region %s", region)
+				return &mockClient{}
+			}
+			return client
+		},
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "ec2", "delete-keypairs", "--all-regions")
+	if err != nil {
+		t.Fatalf("execute delete-keypairs: %v", err)
+	}
+	for _, expected := range []string{
+		"key_name=unused-east region=us-east-1 action=would-delete",
+		"key_name=unused-eu region=eu-west-1 action=would-delete",
+		"region=us-east-1 status=succeeded",
+		"region=eu-west-1 status=succeeded",
+		"region=af-south-1 status=skipped",
+		"region=ap-southeast-2 status=errored detail=list key pairs (ap-southeast-2): not authorized (AccessDenied)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestEC2DeleteEIPsAllRegionsContinuesPastFailedRegion(t *testing.T) {
+	released := make([]string, 0)
+	addressClient := func(allocationID string) *mockClient {
+		return &mockClient{
+			describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
+				return &ec2.DescribeAddressesOutput{Addresses: []ec2types.Address{{AllocationId: cliutil.Ptr(allocationID), PublicIp: cliutil.Ptr("203.0.113.10")}}}, nil
+			},
+			releaseAddressFn: func(_ context.Context, in *ec2.ReleaseAddressInput, _ ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error) {
+				released = append(released, cliutil.PointerToString(in.AllocationId))
+				return &ec2.ReleaseAddressOutput{}, nil
+			},
+		}
+	}
+	clientByRegion := map[string]API{
+		"us-east-1": addressClient("eipalloc-east"),
+		"eu-west-1": addressClient("eipalloc-eu"),
+		"ap-southeast-2": &mockClient{
+			describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
+				return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}
+			},
+		},
+	}
+
+	baseClient := &mockClient{
+		describeRegionsFn: func(_ context.Context, _ *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+			return &ec2.DescribeRegionsOutput{Regions: []ec2types.Region{
+				{RegionName: cliutil.Ptr("us-east-1")},
+				{RegionName: cliutil.Ptr("eu-west-1")},
+				{RegionName: cliutil.Ptr("ap-southeast-2")},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return baseClient },
+		func(_ awssdk.Config, region string) API { return clientByRegion[region] },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-eips", "--all-regions")
+	if err != nil {
+		t.Fatalf("execute delete-eips: %v", err)
+	}
+	for _, expected := range []string{
+		"region=ap-southeast-2 status=errored detail=list addresses: not authorized (AccessDenied)",
+		"allocation_id=eipalloc-eu public_ip=203.0.113.10 region=eu-west-1 action=deleted\nallocation_id=eipalloc-east public_ip=203.0.113.10 region=us-east-1 action=deleted",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Join(released, ",") != "eipalloc-eu,eipalloc-east" {
+		t.Fatalf("unexpected released addresses: %v", released)
+	}
+}
+
+func TestEC2DeleteVolumesAllRegionsUsesRegionalClients(t *testing.T) {
+	deleted := make([]string, 0)
+	volumeClient := func(region, volumeID string) *mockClient {
+		return &mockClient{
+			describeVolumesFn: func(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+				return &ec2.DescribeVolumesOutput{Volumes: []ec2types.Volume{{VolumeId: cliutil.Ptr(volumeID), Size: cliutil.Ptr(int32(10))}}}, nil
+			},
+			deleteVolumeFn: func(_ context.Context, in *ec2.DeleteVolumeInput, _ ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error) {
+				deleted = append(deleted, region+"/"+cliutil.PointerToString(in.VolumeId))
+				return &ec2.DeleteVolumeOutput{}, nil
+			},
+		}
+	}
+	clientByRegion := map[string]API{
+		"us-east-1": volumeClient("us-east-1", "vol-east"),
+		"eu-west-1": volumeClient("eu-west-1", "vol-eu"),
+	}
+	baseClient := &mockClient{
+		describeRegionsFn: func(_ context.Context, _ *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+			return &ec2.DescribeRegionsOutput{Regions: []ec2types.Region{
+				{RegionName: cliutil.Ptr("us-east-1")},
+				{RegionName: cliutil.Ptr("eu-west-1")},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return baseClient },
+		func(_ awssdk.Config, region string) API { return clientByRegion[region] },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "delete-volumes", "--all-regions")
+	if err != nil {
+		t.Fatalf("execute delete-volumes: %v", err)
+	}
+	if !strings.Contains(output, "volume_id=vol-eu size_gib=10 region=eu-west-1 action=deleted\nvolume_id=vol-east size_gib=10 region=us-east-1 action=deleted") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	if strings.Join(deleted, ",") != "eu-west-1/vol-eu,us-east-1/vol-east" {
+		t.Fatalf("unexpected deleted volumes: %v", deleted)
+	}
+}
+
+func TestEC2FindUnusedCapacityReservationsReportsIdleCapacity(t *testing.T) {
+	client := &mockClient{
+		describeCapacityReservationsFn: func(_ context.Context, in *ec2.DescribeCapacityReservationsInput, _ ...func(*ec2.Options)) (*ec2.DescribeCapacityReservationsOutput, error) {
+			if len(in.Filters) != 1 || awssdk.ToString(in.Filters[0].Name) != "state" || in.Filters[0].Values[0] != "active" {
+				t.Fatalf("expected state=active filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeCapacityReservationsOutput{CapacityReservations: []ec2types.CapacityReservation{
+				{CapacityReservationId: awssdk.String("cr-idle"), InstanceType: awssdk.String("m5.large"), AvailabilityZone: awssdk.String("us-east-1a"), TotalInstanceCount: awssdk.Int32(4), AvailableInstanceCount: awssdk.Int32(4)},
+				{CapacityReservationId: awssdk.String("cr-busy"), InstanceType: awssdk.String("c5.xlarge"), AvailabilityZone: awssdk.String("us-east-1b"), TotalInstanceCount: awssdk.Int32(2), AvailableInstanceCount: awssdk.Int32(0)},
+				{CapacityReservationId: awssdk.String("cr-low"), InstanceType: awssdk.String("r5.large"), AvailabilityZone: awssdk.String("us-east-1c"), TotalInstanceCount: awssdk.Int32(10), AvailableInstanceCount: awssdk.Int32(8)},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "find-unused-capacity-reservations", "--max-utilization", "25")
+	if err != nil {
+		t.Fatalf("execute find-unused-capacity-reservations: %v", err)
+	}
+	for _, expected := range []string{
+		"reservation_id=cr-idle instance_type=m5.large availability_zone=us-east-1a total_instances=4 available_instances=4 utilization_pct=0 region=us-east-1 action=unused",
+		"reservation_id=cr-low instance_type=r5.large availability_zone=us-east-1c total_instances=10 available_instances=8 utilization_pct=20 region=us-east-1 action=unused",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "cr-busy") {
+		t.Fatalf("did not expect fully used reservation in output: %s", output)
+	}
+}
+
+func TestEC2FindUnusedCapacityReservationsCancel(t *testing.T) {
+	var cancelled []string
+	client := &mockClient{
+		describeCapacityReservationsFn: func(_ context.Context, _ *ec2.DescribeCapacityReservationsInput, _ ...func(*ec2.Options)) (*ec2.DescribeCapacityReservationsOutput, error) {
+			return &ec2.DescribeCapacityReservationsOutput{CapacityReservations: []ec2types.CapacityReservation{
+				{CapacityReservationId: awssdk.String("cr-idle"), InstanceType: awssdk.String("m5.large"), AvailabilityZone: awssdk.String("us-east-1a"), TotalInstanceCount: awssdk.Int32(1), AvailableInstanceCount: awssdk.Int32(1)},
+			}}, nil
+		},
+		cancelCapacityReservationFn: func(_ context.Context, in *ec2.CancelCapacityReservationInput, _ ...func(*ec2.Options)) (*ec2.CancelCapacityReservationOutput, error) {
+			cancelled = append(cancelled, awssdk.ToString(in.CapacityReservationId))
+			return &ec2.CancelCapacityReservationOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--dry-run", "ec2", "find-unused-capacity-reservations", "--cancel")
+	if err != nil {
+		t.Fatalf("execute dry-run: %v", err)
+	}
+	if len(cancelled) != 0 || !strings.Contains(output, `"action": "would-cancel"`) {
+		t.Fatalf("expected dry-run to only report, cancelled=%v output=%s", cancelled, output)
+	}
+
+	output, err = executeCommand(t, "--output", "json", "--no-confirm", "ec2", "find-unused-capacity-reservations", "--cancel")
+	if err != nil {
+		t.Fatalf("execute cancel: %v", err)
+	}
+	if strings.Join(cancelled, ",") != "cr-idle" || !strings.Contains(output, `"action": "reservation-cancelled"`) {
+		t.Fatalf("expected cr-idle cancelled, cancelled=%v output=%s", cancelled, output)
+	}
+}
+
+func TestEC2FindEphemeralPublicIPsSkipsElasticIPs(t *testing.T) {
+	running := &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: awssdk.String("i-eph"), State: running, PublicIpAddress: awssdk.String("3.3.3.3"), SubnetId: awssdk.String("subnet-pub"), Tags: []ec2types.Tag{{Key: awssdk.String("Name"), Value: awssdk.String("web")}}},
+				{InstanceId: awssdk.String("i-eip"), State: running, PublicIpAddress: awssdk.String("4.4.4.4"), SubnetId: awssdk.String("subnet-pub")},
+				{InstanceId: awssdk.String("i-private"), State: running, SubnetId: awssdk.String("subnet-priv")},
+				{InstanceId: awssdk.String("i-stopped"), State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}, PublicIpAddress: awssdk.String("5.5.5.5")},
+				{InstanceId: awssdk.String("i-manual"), State: running, PublicIpAddress: awssdk.String("6.6.6.6"), SubnetId: awssdk.String("subnet-nomap")},
+			}}}}, nil
+		},
+		describeAddressesFn: func(_ context.Context, _ *ec2.DescribeAddressesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error) {
+			return &ec2.DescribeAddressesOutput{Addresses: []ec2types.Address{{PublicIp: awssdk.String("4.4.4.4")}}}, nil
+		},
+		describeSubnetsFn: func(_ context.Context, in *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+			if strings.Join(in.SubnetIds, ",") != "subnet-pub,subnet-nomap" {
+				t.Fatalf("unexpected subnet ids: %v", in.SubnetIds)
+			}
+			return &ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: awssdk.String("subnet-pub"), MapPublicIpOnLaunch: awssdk.Bool(true)},
+				{SubnetId: awssdk.String("subnet-nomap"), MapPublicIpOnLaunch: awssdk.Bool(false)},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "find-ephemeral-public-ips")
+	if err != nil {
+		t.Fatalf("execute find-ephemeral-public-ips: %v", err)
+	}
+	for _, expected := range []string{
+		"instance_id=i-eph name=web public_ip=3.3.3.3 subnet_id=subnet-pub map_public_ip_on_launch=true region=us-east-1 suggestion=allocate-eip-or-move-to-private-subnet",
+		"instance_id=i-manual name= public_ip=6.6.6.6 subnet_id=subnet-nomap map_public_ip_on_launch=false region=us-east-1 suggestion=allocate-eip",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	for _, unexpected := range []string{"i-eip", "i-private", "i-stopped"} {
+		if strings.Contains(output, unexpected) {
+			t.Fatalf("did not expect %s in output: %s", unexpected, output)
+		}
+	}
+}
+
+func TestResizeInstanceStopsModifiesAndStarts(t *testing.T) {
+	state := ec2types.InstanceStateNameRunning
+	instanceType := ec2types.InstanceTypeT3Large
+	calls := make([]string, 0)
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId:         cliutil.Ptr("i-1"),
+				InstanceType:       instanceType,
+				Architecture:       ec2types.ArchitectureValuesX8664,
+				VirtualizationType: ec2types.VirtualizationTypeHvm,
+				EnaSupport:         cliutil.Ptr(true),
+				State:              &ec2types.InstanceState{Name: state},
+			}}}}}, nil
+		},
+		describeInstanceTypesFn: func(_ context.Context, in *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+			return &ec2.DescribeInstanceTypesOutput{InstanceTypes: []ec2types.InstanceTypeInfo{{
+				InstanceType:                 in.InstanceTypes[0],
+				ProcessorInfo:                &ec2types.ProcessorInfo{SupportedArchitectures: []ec2types.ArchitectureType{ec2types.ArchitectureTypeX8664}},
+				SupportedVirtualizationTypes: []ec2types.VirtualizationType{ec2types.VirtualizationTypeHvm},
+				NetworkInfo:                  &ec2types.NetworkInfo{EnaSupport: ec2types.EnaSupportRequired},
+			}}}, nil
+		},
+		stopInstancesFn: func(_ context.Context, _ *ec2.StopInstancesInput, _ ...func(*ec2.Options)) (*ec2.StopInstancesOutput, error) {
+			calls = append(calls, "stop")
+			state = ec2types.InstanceStateNameStopped
+			return &ec2.StopInstancesOutput{}, nil
+		},
+		modifyInstanceAttributeFn: func(_ context.Context, in *ec2.ModifyInstanceAttributeInput, _ ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error) {
+			calls = append(calls, "modify:"+cliutil.PointerToString(in.InstanceType.Value))
+			instanceType = ec2types.InstanceType(cliutil.PointerToString(in.InstanceType.Value))
+			return &ec2.ModifyInstanceAttributeOutput{}, nil
+		},
+		startInstancesFn: func(_ context.Context, _ *ec2.StartInstancesInput, _ ...func(*ec2.Options)) (*ec2.StartInstancesOutput, error) {
+			calls = append(calls, "start")
+			state = ec2types.InstanceStateNameRunning
+			return &ec2.StartInstancesOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "ec2", "resize-instance", "--instance-id", "i-1", "--type", "m6i.large")
+	if err != nil {
+		t.Fatalf("dry-run resize-instance: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("dry-run made mutating calls: %v", calls)
+	}
+	for _, expected := range []string{"step=stop change=running -> stopped action=would-stop", "change=t3.large -> m6i.large action=would-modify", "action=would-start"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "resize-instance", "--instance-id", "i-1", "--type", "m6i.large")
+	if err != nil {
+		t.Fatalf("resize-instance: %v", err)
+	}
+	if strings.Join(calls, ",") != "stop,modify:m6i.large,start" {
+		t.Fatalf("unexpected call order: %v", calls)
+	}
+	for _, expected := range []string{"action=stopped", "action=modified", "action=started"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestResizeInstanceRejectsIncompatibleArchitecture(t *testing.T) {
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId:   cliutil.Ptr("i-1"),
+				InstanceType: ec2types.InstanceTypeT3Large,
+				Architecture: ec2types.ArchitectureValuesX8664,
+				State:        &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped},
+			}}}}}, nil
+		},
+		describeInstanceTypesFn: func(_ context.Context, _ *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+			return &ec2.DescribeInstanceTypesOutput{InstanceTypes: []ec2types.InstanceTypeInfo{{
+				ProcessorInfo: &ec2types.ProcessorInfo{SupportedArchitectures: []ec2types.ArchitectureType{ec2types.ArchitectureTypeArm64}},
+			}}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	_, err := executeCommand(t, "--no-confirm", "ec2", "resize-instance", "--instance-id", "i-1", "--type", "m7g.large")
+	if err == nil || !strings.Contains(err.Error(), "does not support architecture x86_64") {
+		t.Fatalf("expected architecture error, got %v", err)
+	}
+}
+
+func TestEC2FindAMICopiesAcrossRegions(t *testing.T) {
+	var mu sync.Mutex
+	deregistered := make([]string, 0)
+	imagesClient := func(images ...ec2types.Image) *mockClient {
+		return &mockClient{
+			describeImagesFn: func(_ context.Context, _ *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+				return &ec2.DescribeImagesOutput{Images: images}, nil
+			},
+			deregisterImageFn: func(_ context.Context, in *ec2.DeregisterImageInput, _ ...func(*ec2.Options)) (*ec2.DeregisterImageOutput, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				deregistered = append(deregistered, cliutil.PointerToString(in.ImageId))
+				return &ec2.DeregisterImageOutput{}, nil
+			},
+		}
+	}
+	clientByRegion := map[string]API{
+		"us-east-1": imagesClient(
+			ec2types.Image{ImageId: cliutil.Ptr("ami-0abc"), Name: cliutil.Ptr("web")},
+			ec2types.Image{ImageId: cliutil.Ptr("ami-other"), Name: cliutil.Ptr("db"), Description: cliutil.Ptr("[Copied ami-0abcdef from us-east-1] db")},
+		),
+		"eu-west-1": imagesClient(ec2types.Image{ImageId: cliutil.Ptr("ami-eu"), Name: cliutil.Ptr("web"), SourceImageId: cliutil.Ptr("ami-0abc")}),
+		"us-west-2": imagesClient(
+			ec2types.Image{ImageId: cliutil.Ptr("ami-west"), Name: cliutil.Ptr("web"), Description: cliutil.Ptr("[Copied ami-0abc from us-east-1] web")},
+			ec2types.Image{ImageId: cliutil.Ptr("ami-tagged"), Name: cliutil.Ptr("web-tagged"), Tags: []ec2types.Tag{{Key: cliutil.Ptr("SourceAMI"), Value: cliutil.Ptr("ami-0abc")}}},
+		),
+	}
+	baseClient := &mockClient{
+		describeRegionsFn: func(_ context.Context, _ *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+			return &ec2.DescribeRegionsOutput{Regions: []ec2types.Region{
+				{RegionName: cliutil.Ptr("us-east-1")},
+				{RegionName: cliutil.Ptr("eu-west-1")},
+				{RegionName: cliutil.Ptr("us-west-2")},
+			}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return baseClient },
+		func(_ awssdk.Config, region string) API { return clientByRegion[region] },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "find-ami-copies", "--source-ami", "ami-0abc")
+	if err != nil {
+		t.Fatalf("execute find-ami-copies: %v", err)
+	}
+	for _, expected := range []string{
+		"source_ami=ami-0abc image_id=ami-eu name=web region=eu-west-1 match=source-image-id",
+		"source_ami=ami-0abc image_id=ami-tagged name=web-tagged region=us-west-2 match=tag:SourceAMI",
+		"source_ami=ami-0abc image_id=ami-west name=web region=us-west-2 match=description",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "image_id=ami-0abc") || strings.Contains(output, "ami-other") || strings.Contains(output, "action=") {
+		t.Fatalf("unexpected row in read-only output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "find-ami-copies", "--source-ami", "ami-0abc", "--deregister-copies")
+	if err != nil {
+		t.Fatalf("execute find-ami-copies --deregister-copies: %v", err)
+	}
+	if strings.Join(deregistered, ",") != "ami-eu,ami-tagged,ami-west" {
+		t.Fatalf("unexpected deregistered images: %v", deregistered)
+	}
+	if strings.Count(output, "action=deleted") != 3 {
+		t.Fatalf("expected three deleted rows: %s", output)
+	}
+}
+
+func TestEC2CancelSpotRequestsCancelsOrphanedRequests(t *testing.T) {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	cancelled := make([]string, 0)
+	client := &mockClient{
+		describeSpotRequestsFn: func(_ context.Context, in *ec2.DescribeSpotInstanceRequestsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSpotInstanceRequestsOutput, error) {
+			if len(in.Filters) != 1 || strings.Join(in.Filters[0].Values, ",") != "open,active" {
+				t.Fatalf("expected open/active state filter, got %#v", in.Filters)
+			}
+			if in.NextToken == nil {
+				return &ec2.DescribeSpotInstanceRequestsOutput{
+					SpotInstanceRequests: []ec2types.SpotInstanceRequest{
+						{SpotInstanceRequestId: cliutil.Ptr("sir-old"), State: ec2types.SpotInstanceStateOpen, Status: &ec2types.SpotInstanceStatus{Code: cliutil.Ptr("capacity-not-available")}, CreateTime: &old},
+						{SpotInstanceRequestId: cliutil.Ptr("sir-running"), State: ec2types.SpotInstanceStateActive, InstanceId: cliutil.Ptr("i-1"), CreateTime: &old},
+					},
+					NextToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &ec2.DescribeSpotInstanceRequestsOutput{SpotInstanceRequests: []ec2types.SpotInstanceRequest{
+				{SpotInstanceRequestId: cliutil.Ptr("sir-recent"), State: ec2types.SpotInstanceStateOpen, Status: &ec2types.SpotInstanceStatus{Code: cliutil.Ptr("pending-evaluation")}, CreateTime: &recent},
+			}}, nil
+		},
+		cancelSpotRequestsFn: func(_ context.Context, in *ec2.CancelSpotInstanceRequestsInput, _ ...func(*ec2.Options)) (*ec2.CancelSpotInstanceRequestsOutput, error) {
+			cancelled = append(cancelled, in.SpotInstanceRequestIds...)
+			return &ec2.CancelSpotInstanceRequestsOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "ec2", "cancel-spot-requests")
+	if err != nil {
+		t.Fatalf("execute cancel-spot-requests --dry-run: %v", err)
+	}
+	for _, expected := range []string{
+		"request_id=sir-old state=open status_code=capacity-not-available",
+		"request_id=sir-recent state=open status_code=pending-evaluation",
+		"action=would-cancel",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "sir-running") || len(cancelled) != 0 {
+		t.Fatalf("unexpected dry-run result: %s (cancelled %v)", output, cancelled)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "cancel-spot-requests", "--older-than", "7d")
+	if err != nil {
+		t.Fatalf("execute cancel-spot-requests: %v", err)
+	}
+	if strings.Join(cancelled, ",") != "sir-old" || !strings.Contains(output, "request_id=sir-old") || !strings.Contains(output, "action=cancelled") {
+		t.Fatalf("expected only sir-old cancelled, got %v: %s", cancelled, output)
+	}
+	if strings.Contains(output, "sir-recent") {
+		t.Fatalf("expected --older-than to skip sir-recent: %s", output)
+	}
+}
+
+func TestEC2AuditRequesterManagedENIsReportsAndDeletesLeaked(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeNetworkInterfacesFn: func(_ context.Context, in *ec2.DescribeNetworkInterfacesInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+			if len(in.Filters) != 1 || cliutil.PointerToString(in.Filters[0].Name) != "requester-managed" {
+				t.Fatalf("expected requester-managed filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []ec2types.NetworkInterface{
+				{
+					NetworkInterfaceId: cliutil.Ptr("eni-lambda"), RequesterManaged: cliutil.Ptr(true),
+					InterfaceType: ec2types.NetworkInterfaceTypeLambda, Description: cliutil.Ptr("AWS Lambda VPC ENI-old-fn"),
+					SubnetId: cliutil.Ptr("subnet-1"), Status: ec2types.NetworkInterfaceStatusAvailable,
+				},
+				{
+					NetworkInterfaceId: cliutil.Ptr("eni-elb"), RequesterManaged: cliutil.Ptr(true),
+					InterfaceType: ec2types.NetworkInterfaceTypeInterface, Description: cliutil.Ptr("ELB app/old-alb/123"),
+					SubnetId: cliutil.Ptr("subnet-2"), Status: ec2types.NetworkInterfaceStatusAvailable,
+				},
+				{
+					NetworkInterfaceId: cliutil.Ptr("eni-in-use"), RequesterManaged: cliutil.Ptr(true),
+					InterfaceType: ec2types.NetworkInterfaceTypeNatGateway, SubnetId: cliutil.Ptr("subnet-3"),
+					Status: ec2types.NetworkInterfaceStatusInUse, Attachment: &ec2types.NetworkInterfaceAttachment{AttachmentId: cliutil.Ptr("eni-attach-2")},
+				},
+			}}, nil
+		},
+		deleteNetworkInterfaceFn: func(_ context.Context, in *ec2.DeleteNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.NetworkInterfaceId))
+			return &ec2.DeleteNetworkInterfaceOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "audit-requester-managed-enis")
+	if err != nil {
+		t.Fatalf("execute audit-requester-managed-enis: %v", err)
+	}
+	for _, expected := range []string{
+		"eni_id=eni-elb interface_type=interface description=ELB app/old-alb/123 subnet_id=subnet-2 status=available likely_service=elb",
+		"eni_id=eni-lambda interface_type=lambda",
+		"likely_service=lambda",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "eni-in-use") || strings.Contains(output, "action=") {
+		t.Fatalf("unexpected report output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "audit-requester-managed-enis", "--delete")
+	if err != nil {
+		t.Fatalf("execute audit-requester-managed-enis --delete: %v", err)
+	}
+	if strings.Join(deleted, ",") != "eni-elb,eni-lambda" {
+		t.Fatalf("unexpected cleanup: deleted %v", deleted)
+	}
+	if strings.Count(output, "action=deleted") != 2 {
+		t.Fatalf("expected two deleted rows: %s", output)
+	}
+}
+
+func TestEC2CoverageForecastReportsExpiringReservation(t *testing.T) {
+	running := &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}
+	soon := time.Now().UTC().AddDate(0, 0, 10)
+	later := time.Now().UTC().AddDate(1, 0, 0)
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+				{InstanceId: cliutil.Ptr("i-1"), InstanceType: ec2types.InstanceTypeM5Large, State: running},
+				{InstanceId: cliutil.Ptr("i-2"), InstanceType: ec2types.InstanceTypeM5Large, State: running},
+				{InstanceId: cliutil.Ptr("i-3"), InstanceType: ec2types.InstanceTypeM5Large, State: running},
+				{InstanceId: cliutil.Ptr("i-4"), InstanceType: ec2types.InstanceTypeC5Large, State: running},
+				{InstanceId: cliutil.Ptr("i-5"), InstanceType: ec2types.InstanceTypeT3Micro, State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}},
+			}}}}, nil
+		},
+		describeReservedInstancesFn: func(_ context.Context, in *ec2.DescribeReservedInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeReservedInstancesOutput, error) {
+			if len(in.Filters) != 1 || in.Filters[0].Values[0] != "active" {
+				t.Fatalf("expected active state filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeReservedInstancesOutput{ReservedInstances: []ec2types.ReservedInstances{
+				{InstanceType: ec2types.InstanceTypeM5Large, InstanceCount: cliutil.Ptr(int32(2)), End: &soon},
+				{InstanceType: ec2types.InstanceTypeM5Large, InstanceCount: cliutil.Ptr(int32(1)), End: &later},
+				{InstanceType: ec2types.InstanceTypeC5Large, InstanceCount: cliutil.Ptr(int32(1)), End: &later},
+				{InstanceType: ec2types.InstanceTypeT3Micro, InstanceCount: cliutil.Ptr(int32(1)), End: &soon},
+			}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "coverage-forecast", "--within-days", "30")
+	if err != nil {
+		t.Fatalf("execute coverage-forecast: %v", err)
+	}
+	expected := fmt.Sprintf("instance_type=m5.large running=3 covered=3 covering_ri_expiry=%s expiring_ri_count=2 on_demand_exposure=2 region=us-east-1", soon.Format("2006-01-02"))
+	if !strings.Contains(output, expected) {
+		t.Fatalf("expected %q in output: %s", expected, output)
+	}
+	if strings.Contains(output, "c5.large") || strings.Contains(output, "t3.micro") {
+		t.Fatalf("expected only m5.large to lose coverage: %s", output)
+	}
+
+	if _, err := executeCommand(t, "ec2", "coverage-forecast", "--within-days", "0"); err == nil {
+		t.Fatal("expected --within-days validation error")
+	}
+}
+
+func TestListInstanceTypeOfferingsFiltersByGlobAcrossPages(t *testing.T) {
+	client := &mockClient{
+		describeInstanceTypeOfferingsFn: func(_ context.Context, in *ec2.DescribeInstanceTypeOfferingsInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+			if in.LocationType != ec2types.LocationTypeAvailabilityZone || len(in.Filters) != 1 || in.Filters[0].Values[0] != "us-east-1a" {
+				t.Fatalf("unexpected input: %#v", in)
+			}
+			if in.NextToken == nil {
+				return &ec2.DescribeInstanceTypeOfferingsOutput{
+					InstanceTypeOfferings: []ec2types.InstanceTypeOffering{
+						{InstanceType: ec2types.InstanceTypeM6iXlarge, LocationType: ec2types.LocationTypeAvailabilityZone, Location: cliutil.Ptr("us-east-1a")},
+						{InstanceType: ec2types.InstanceTypeC6iLarge, LocationType: ec2types.LocationTypeAvailabilityZone, Location: cliutil.Ptr("us-east-1a")},
+					},
+					NextToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &ec2.DescribeInstanceTypeOfferingsOutput{InstanceTypeOfferings: []ec2types.InstanceTypeOffering{
+				{InstanceType: ec2types.InstanceTypeM6iLarge, LocationType: ec2types.LocationTypeAvailabilityZone, Location: cliutil.Ptr("us-east-1a")},
+			}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "list-instance-type-offerings", "--location-type", "availability-zone", "--location", "us-east-1a", "--type-filter", "m6i.*")
+	if err != nil {
+		t.Fatalf("execute list-instance-type-offerings: %v", err)
+	}
+	expected := "instance_type=m6i.large location_type=availability-zone location=us-east-1a\ninstance_type=m6i.xlarge location_type=availability-zone location=us-east-1a"
+	if !strings.Contains(output, expected) || strings.Contains(output, "c6i.large") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+
+	if _, err := executeCommand(t, "ec2", "list-instance-type-offerings", "--location-type", "zone"); err == nil {
+		t.Fatal("expected error for invalid --location-type")
+	}
+}
+
+func TestCreateImageTagsAndWaitsForAvailable(t *testing.T) {
+	var created *ec2.CreateImageInput
+	describes := 0
+	client := &mockClient{
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId: cliutil.Ptr("i-1"),
+				State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning},
+			}}}}}, nil
+		},
+		createImageFn: func(_ context.Context, in *ec2.CreateImageInput, _ ...func(*ec2.Options)) (*ec2.CreateImageOutput, error) {
+			created = in
+			return &ec2.CreateImageOutput{ImageId: cliutil.Ptr("ami-new")}, nil
+		},
+		describeImagesFn: func(_ context.Context, in *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+			describes++
+			state := ec2types.ImageStatePending
+			if describes > 1 {
+				state = ec2types.ImageStateAvailable
+			}
+			return &ec2.DescribeImagesOutput{Images: []ec2types.Image{{ImageId: cliutil.Ptr(in.ImageIds[0]), State: state}}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "ec2", "create-image", "--instance-id", "i-1", "--name", "backup-1")
+	if err != nil {
+		t.Fatalf("execute dry-run: %v", err)
+	}
+	if created != nil || !strings.Contains(output, "instance_id=i-1 name=backup-1 instance_state=running no_reboot=false image_id= image_state= action=would-create") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "create-image", "--instance-id", "i-1", "--name", "backup-1", "--no-reboot", "--wait")
+	if err != nil {
+		t.Fatalf("execute create-image: %v", err)
+	}
+	if !strings.Contains(output, "no_reboot=true image_id=ami-new image_state=available action=created") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	if created == nil || !awssdk.ToBool(created.NoReboot) || len(created.TagSpecifications) != 2 {
+		t.Fatalf("unexpected create input: %#v", created)
+	}
+	tags := map[string]string{}
+	for _, tag := range created.TagSpecifications[0].Tags {
+		tags[cliutil.PointerToString(tag.Key)] = cliutil.PointerToString(tag.Value)
+	}
+	if tags["awstbx:source-instance-id"] != "i-1" || tags["awstbx:created-at"] == "" {
+		t.Fatalf("unexpected image tags: %v", tags)
+	}
+	if describes != 2 {
+		t.Fatalf("expected to poll until available, got %d describes", describes)
+	}
+}
+
+func TestEC2FindOrphanedENIsReportsAndDeletes(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		describeNetworkInterfacesFn: func(_ context.Context, in *ec2.DescribeNetworkInterfacesInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+			if len(in.Filters) != 1 || cliutil.PointerToString(in.Filters[0].Name) != "status" || in.Filters[0].Values[0] != "available" {
+				t.Fatalf("expected status=available filter, got %#v", in.Filters)
+			}
+			return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []ec2types.NetworkInterface{
+				{
+					NetworkInterfaceId: cliutil.Ptr("eni-lambda"), SubnetId: cliutil.Ptr("subnet-1"), Description: cliutil.Ptr("AWS Lambda VPC ENI-old-fn"),
+					Groups: []ec2types.GroupIdentifier{{GroupId: cliutil.Ptr("sg-b")}, {GroupId: cliutil.Ptr("sg-a")}},
+				},
+				{NetworkInterfaceId: cliutil.Ptr("eni-ecs"), SubnetId: cliutil.Ptr("subnet-2"), Description: cliutil.Ptr("arn:aws:ecs:us-east-1:123456789012:attachment/abc")},
+			}}, nil
+		},
+		deleteNetworkInterfaceFn: func(_ context.Context, in *ec2.DeleteNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.NetworkInterfaceId))
+			if cliutil.PointerToString(in.NetworkInterfaceId) == "eni-ecs" {
+				return nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound", Message: "gone"}
+			}
+			return &ec2.DeleteNetworkInterfaceOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ec2", "find-orphaned-enis")
+	if err != nil {
+		t.Fatalf("execute find-orphaned-enis: %v", err)
+	}
+	expected := strings.Join([]string{
+		"eni_id=eni-ecs subnet_id=subnet-2 security_groups= description=arn:aws:ecs:us-east-1:123456789012:attachment/abc region=us-east-1",
+		"eni_id=eni-lambda subnet_id=subnet-1 security_groups=sg-a,sg-b description=AWS Lambda VPC ENI-old-fn region=us-east-1",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected report output:\n%s", output)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("report must not delete, got %v", deleted)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "find-orphaned-enis", "--delete")
+	if err != nil {
+		t.Fatalf("execute find-orphaned-enis --delete: %v", err)
+	}
+	if strings.Join(deleted, ",") != "eni-ecs,eni-lambda" {
+		t.Fatalf("unexpected deletions: %v", deleted)
+	}
+	if !strings.Contains(output, "eni_id=eni-ecs") || !strings.Contains(output, "action=failed:gone (InvalidNetworkInterfaceID.NotFound)") || !strings.Contains(output, "region=us-east-1 action=deleted") {
+		t.Fatalf("unexpected delete output:\n%s", output)
+	}
+}
+
+func TestEC2RevokeOpenIngressRevokesOnlyPublicSensitiveRules(t *testing.T) {
+	revoked := make([]string, 0)
+	client := &mockClient{
+		describeSecurityGroupsFn: func(_ context.Context, _ *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+			return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []ec2types.SecurityGroup{
+				{
+					GroupId: cliutil.Ptr("sg-web"), GroupName: cliutil.Ptr("web"),
+					IpPermissions: []ec2types.IpPermission{
+						{
+							IpProtocol: cliutil.Ptr("tcp"), FromPort: cliutil.Ptr(int32(22)), ToPort: cliutil.Ptr(int32(22)),
+							IpRanges:   []ec2types.IpRange{{CidrIp: cliutil.Ptr("10.0.0.0/8")}, {CidrIp: cliutil.Ptr("0.0.0.0/0")}},
+							Ipv6Ranges: []ec2types.Ipv6Range{{CidrIpv6: cliutil.Ptr("::/0")}},
+						},
+						{IpProtocol: cliutil.Ptr("tcp"), FromPort: cliutil.Ptr(int32(443)), ToPort: cliutil.Ptr(int32(443)), IpRanges: []ec2types.IpRange{{CidrIp: cliutil.Ptr("0.0.0.0/0")}}},
+					},
+				},
+				{
+					GroupId: cliutil.Ptr("sg-admin"), GroupName: cliutil.Ptr("admin"),
+					IpPermissions: []ec2types.IpPermission{
+						{IpProtocol: cliutil.Ptr("tcp"), FromPort: cliutil.Ptr(int32(3000)), ToPort: cliutil.Ptr(int32(4000)), IpRanges: []ec2types.IpRange{{CidrIp: cliutil.Ptr("0.0.0.0/0")}}},
+						{IpProtocol: cliutil.Ptr("-1"), IpRanges: []ec2types.IpRange{{CidrIp: cliutil.Ptr("0.0.0.0/0")}}},
+					},
+				},
+			}}, nil
+		},
+		revokeSecurityIngressFn: func(_ context.Context, in *ec2.RevokeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+			if len(in.IpPermissions) != 1 || len(in.IpPermissions[0].IpRanges)+len(in.IpPermissions[0].Ipv6Ranges) != 1 {
+				t.Fatalf("expected a single CIDR per revoke, got %#v", in.IpPermissions)
+			}
+			permission := in.IpPermissions[0]
+			cidr := ""
+			if len(permission.IpRanges) == 1 {
+				cidr = cliutil.PointerToString(permission.IpRanges[0].CidrIp)
+			} else {
+				cidr = cliutil.PointerToString(permission.Ipv6Ranges[0].CidrIpv6)
+			}
+			revoked = append(revoked, cliutil.PointerToString(in.GroupId)+"/"+cliutil.PointerToString(permission.IpProtocol)+"/"+cidr)
+			return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+		func(awssdk.Config, string) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ec2", "revoke-open-ingress")
+	if err != nil {
+		t.Fatalf("execute revoke-open-ingress: %v", err)
+	}
+	expected := strings.Join([]string{
+		"group_id=sg-admin group_name=admin protocol=tcp port_range=3000-4000 cidr=0.0.0.0/0 region=us-east-1 action=skipped:broader-than-requested-ports",
+		"group_id=sg-admin group_name=admin protocol=all port_range=all cidr=0.0.0.0/0 region=us-east-1 action=skipped:broader-than-requested-ports",
+		"group_id=sg-web group_name=web protocol=tcp port_range=22 cidr=0.0.0.0/0 region=us-east-1 action=revoked",
+		"group_id=sg-web group_name=web protocol=tcp port_range=22 cidr=::/0 region=us-east-1 action=revoked",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(revoked, ",") != "sg-web/tcp/0.0.0.0/0,sg-web/tcp/::/0" {
+		t.Fatalf("unexpected revocations: %v", revoked)
+	}
+
+	revoked = revoked[:0]
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ec2", "revoke-open-ingress", "--ports", "22,3389", "--include-broader")
+	if err != nil {
+		t.Fatalf("execute revoke-open-ingress --include-broader: %v", err)
+	}
+	if strings.Count(output, "action=revoked") != 4 {
+		t.Fatalf("expected broader rules to be revoked, got:\n%s", output)
+	}
+	if strings.Join(revoked, ",") != "sg-admin/tcp/0.0.0.0/0,sg-admin/-1/0.0.0.0/0,sg-web/tcp/0.0.0.0/0,sg-web/tcp/::/0" {
+		t.Fatalf("unexpected revocations: %v", revoked)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--dry-run", "ec2", "revoke-open-ingress", "--ports", "22")
+	if err != nil {
+		t.Fatalf("execute revoke-open-ingress dry-run: %v", err)
+	}
+	if !strings.Contains(output, "port_range=22 cidr=0.0.0.0/0 region=us-east-1 action=would-revoke") {
+		t.Fatalf("expected would-revoke action, got:\n%s", output)
+	}
+
+	if _, err := executeCommand(t, "ec2", "revoke-open-ingress", "--ports", "70000"); err == nil || !strings.Contains(err.Error(), "between 0 and 65535") {
+		t.Fatalf("expected port validation error, got %v", err)
+	}
+}
+
+func TestPollWaitsStopWhenContextIsCancelled(t *testing.T) {
+	client := &mockClient{
+		describeSnapshotsFn: func(_ context.Context, _ *ec2.DescribeSnapshotsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+			return &ec2.DescribeSnapshotsOutput{Snapshots: []ec2types.Snapshot{{State: ec2types.SnapshotStatePending}}}, nil
+		},
+		describeInstancesFn: func(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+			return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{{
+				InstanceId: cliutil.Ptr("i-1"),
+				State:      &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopping},
+			}}}}}, nil
+		},
+		describeImagesFn: func(_ context.Context, _ *ec2.DescribeImagesInput, _ ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error) {
+			return &ec2.DescribeImagesOutput{Images: []ec2types.Image{{State: ec2types.ImageStatePending}}}, nil
+		},
+	}
+	oldSleep := sleep
+	sleep = cliutil.SleepContext
+	t.Cleanup(func() { sleep = oldSleep })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	waits := map[string]func() error{
+		"snapshot": func() error { return waitForSnapshotCompleted(ctx, client, "snap-1") },
+		"instance": func() error { return waitForInstanceState(ctx, client, "i-1", ec2types.InstanceStateNameStopped) },
+		"image":    func() error { return waitForImageAvailable(ctx, client, "ami-1") },
+	}
+	for name, wait := range waits {
+		if err := wait(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("%s wait: expected context.Canceled, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// eniDescriptionServices maps description prefixes that AWS services write on
+// the ENIs they create to the owning service, for interface types that do not
+// identify it on their own.
+var eniDescriptionServices = []struct {
+	Prefix  string
+	Service string
+}{
+	{"AWS Lambda VPC ENI", "lambda"},
+	{"ELB ", "elb"},
+	{"EFS mount target", "efs"},
+	{"RDSNetworkInterface", "rds"},
+	{"Amazon EKS", "eks"},
+	{"ElastiCache", "elasticache"},
+	{"AWS created network interface for directory", "directory-service"},
+	{"Interface for NAT Gateway", "nat-gateway"},
+	{"VPC Endpoint Interface", "vpc-endpoint"},
+}
+
+// runAuditRequesterManagedENIs reports requester-managed ENIs in the available
+// state. Nothing is attached to them, which for a service-managed interface
+// means the service that created it is gone. Attached interfaces are never
+// reported, so deleteENIs cannot pull an interface out from under a live
+// service.
+func runAuditRequesterManagedENIs(cmd *cobra.Command, deleteENIs bool) error {
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	interfaces, err := listRequesterManagedENIs(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list network interfaces: %w", awstbxaws.WrapUserError(err))
+	}
+
+	leaked := make([]ec2types.NetworkInterface, 0)
+	for _, networkInterface := range interfaces {
+		if networkInterface.Status == ec2types.NetworkInterfaceStatusAvailable {
+			leaked = append(leaked, networkInterface)
+		}
+	}
+	sort.Slice(leaked, func(i, j int) bool {
+		return cliutil.PointerToString(leaked[i].NetworkInterfaceId) < cliutil.PointerToString(leaked[j].NetworkInterfaceId)
+	})
+
+	headers := []string{"eni_id", "interface_type", "description", "subnet_id", "status", "likely_service", "region"}
+	rows := make([][]string, 0, len(leaked))
+	for _, networkInterface := range leaked {
+		rows = append(rows, []string{
+			cliutil.PointerToString(networkInterface.NetworkInterfaceId),
+			string(networkInterface.InterfaceType),
+			cliutil.PointerToString(networkInterface.Description),
+			cliutil.PointerToString(networkInterface.SubnetId),
+			string(networkInterface.Status),
+			likelyENIService(networkInterface),
+			cfg.Region,
+		})
+	}
+
+	if !deleteENIs {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+	for i := range rows {
+		rows[i] = append(rows[i], action)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       append(headers, "action"),
+		Rows:          rows,
+		ActionColumn:  len(headers),
+		ConfirmPrompt: fmt.Sprintf("Delete %d leaked requester-managed network interface(s)", len(rows)),
+		Execute: func(rowIndex int) string {
+			if _, deleteErr := client.DeleteNetworkInterface(cmd.Context(), &ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: leaked[rowIndex].NetworkInterfaceId}); deleteErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
+
+// runFindOrphanedENIs reports network interfaces in the available state, which
+// nothing is attached to. They are usually left behind by deleted Lambda
+// functions or ECS tasks and keep their security groups from being deleted.
+func runFindOrphanedENIs(cmd *cobra.Command, deleteENIs bool) error {
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	interfaces, err := listAvailableENIs(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list network interfaces: %w", awstbxaws.WrapUserError(err))
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return cliutil.PointerToString(interfaces[i].NetworkInterfaceId) < cliutil.PointerToString(interfaces[j].NetworkInterfaceId)
+	})
+
+	headers := []string{"eni_id", "subnet_id", "security_groups", "description", "region"}
+	rows := make([][]string, 0, len(interfaces))
+	for _, networkInterface := range interfaces {
+		groupIDs := make([]string, 0, len(networkInterface.Groups))
+		for _, group := range networkInterface.Groups {
+			groupIDs = append(groupIDs, cliutil.PointerToString(group.GroupId))
+		}
+		sort.Strings(groupIDs)
+		rows = append(rows, []string{
+			cliutil.PointerToString(networkInterface.NetworkInterfaceId),
+			cliutil.PointerToString(networkInterface.SubnetId),
+			strings.Join(groupIDs, ","),
+			cliutil.PointerToString(networkInterface.Description),
+			cfg.Region,
+		})
+	}
+
+	if !deleteENIs {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+	for i := range rows {
+		rows[i] = append(rows[i], action)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       append(headers, "action"),
+		Rows:          rows,
+		ActionColumn:  len(headers),
+		ConfirmPrompt: fmt.Sprintf("Delete %d detached network interface(s)", len(rows)),
+		Execute: func(rowIndex int) string {
+			if _, deleteErr := client.DeleteNetworkInterface(cmd.Context(), &ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: interfaces[rowIndex].NetworkInterfaceId}); deleteErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
+
+func listAvailableENIs(ctx context.Context, client API) ([]ec2types.NetworkInterface, error) {
+	interfaces := make([]ec2types.NetworkInterface, 0)
+	var nextToken *string
+	for {
+		page, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+			Filters:   []ec2types.Filter{{Name: cliutil.Ptr("status"), Values: []string{string(ec2types.NetworkInterfaceStatusAvailable)}}},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		interfaces = append(interfaces, page.NetworkInterfaces...)
+		if page.NextToken == nil || *page.NextToken == "" {
+			break
+		}
+		nextToken = page.NextToken
+	}
+	return interfaces, nil
+}
+
+func listRequesterManagedENIs(ctx context.Context, client API) ([]ec2types.NetworkInterface, error) {
+	interfaces := make([]ec2types.NetworkInterface, 0)
+	var nextToken *string
+	for {
+		page, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+			Filters:   []ec2types.Filter{{Name: cliutil.Ptr("requester-managed"), Values: []string{"true"}}},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, networkInterface := range page.NetworkInterfaces {
+			if awssdk.ToBool(networkInterface.RequesterManaged) {
+				interfaces = append(interfaces, networkInterface)
+			}
+		}
+		if page.NextToken == nil || *page.NextToken == "" {
+			break
+		}
+		nextToken = page.NextToken
+	}
+	return interfaces, nil
+}
+
+// likelyENIService guesses which AWS service created an ENI from its
+// interface type, then its description, then the requester id.
+func likelyENIService(networkInterface ec2types.NetworkInterface) string {
+	switch networkInterface.InterfaceType {
+	case ec2types.NetworkInterfaceTypeLambda:
+		return "lambda"
+	case ec2types.NetworkInterfaceTypeNatGateway:
+		return "nat-gateway"
+	case ec2types.NetworkInterfaceTypeLoadBalancer, ec2types.NetworkInterfaceTypeNetworkLoadBalancer, ec2types.NetworkInterfaceTypeGatewayLoadBalancer:
+		return "elb"
+	case ec2types.NetworkInterfaceTypeVpcEndpoint, ec2types.NetworkInterfaceTypeGatewayLoadBalancerEndpoint:
+		return "vpc-endpoint"
+	case ec2types.NetworkInterfaceTypeTransitGateway:
+		return "transit-gateway"
+	case ec2types.NetworkInterfaceTypeApiGatewayManaged:
+		return "api-gateway"
+	case ec2types.NetworkInterfaceTypeQuicksight:
+		return "quicksight"
+	}
+
+	description := cliutil.PointerToString(networkInterface.Description)
+	for _, known := range eniDescriptionServices {
+		if strings.HasPrefix(description, known.Prefix) {
+			return known.Service
+		}
+	}
+	if requester := cliutil.PointerToString(networkInterface.RequesterId); requester != "" {
+		return "requester:" + requester
+	}
+	return "unknown"
+}
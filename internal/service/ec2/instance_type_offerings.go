@@ -0,0 +1,82 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runListInstanceTypeOfferings lists the instance types offered in a region,
+// availability zone or zone id. Without --location every location of that
+// type in the configured region is reported.
+func runListInstanceTypeOfferings(cmd *cobra.Command, locationType, location, typeFilter string) error {
+	parsedType := ec2types.LocationType(strings.TrimSpace(locationType))
+	if !slices.Contains(parsedType.Values(), parsedType) {
+		return fmt.Errorf("--location-type must be one of: region, availability-zone, availability-zone-id, outpost")
+	}
+	typeFilter = strings.TrimSpace(typeFilter)
+	if typeFilter != "" {
+		if _, err := path.Match(typeFilter, ""); err != nil {
+			return fmt.Errorf("--type-filter %q: %w", typeFilter, err)
+		}
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	offerings, err := listInstanceTypeOfferings(cmd.Context(), client, parsedType, strings.TrimSpace(location))
+	if err != nil {
+		return fmt.Errorf("describe instance type offerings: %w", awstbxaws.WrapUserError(err))
+	}
+
+	rows := make([][]string, 0, len(offerings))
+	for _, offering := range offerings {
+		instanceType := string(offering.InstanceType)
+		if typeFilter != "" {
+			if matched, _ := path.Match(typeFilter, instanceType); !matched {
+				continue
+			}
+		}
+		rows = append(rows, []string{instanceType, string(offering.LocationType), cliutil.PointerToString(offering.Location)})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][2] == rows[j][2] {
+			return rows[i][0] < rows[j][0]
+		}
+		return rows[i][2] < rows[j][2]
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"instance_type", "location_type", "location"}, rows)
+}
+
+func listInstanceTypeOfferings(ctx context.Context, client API, locationType ec2types.LocationType, location string) ([]ec2types.InstanceTypeOffering, error) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{LocationType: locationType}
+	if location != "" {
+		input.Filters = []ec2types.Filter{{Name: cliutil.Ptr("location"), Values: []string{location}}}
+	}
+
+	offerings := make([]ec2types.InstanceTypeOffering, 0)
+	for {
+		page, err := client.DescribeInstanceTypeOfferings(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		offerings = append(offerings, page.InstanceTypeOfferings...)
+		if page.NextToken == nil || *page.NextToken == "" {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+	return offerings, nil
+}
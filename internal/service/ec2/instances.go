@@ -0,0 +1,218 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runListInstances(cmd *cobra.Command, _ []string) error {
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instances, err := listInstances(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", awstbxaws.WrapUserError(err))
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return cliutil.PointerToString(instances[i].InstanceId) < cliutil.PointerToString(instances[j].InstanceId)
+	})
+
+	rows := make([][]string, 0, len(instances))
+	for _, instance := range instances {
+		state := ""
+		if instance.State != nil {
+			state = string(instance.State.Name)
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(instance.InstanceId),
+			tagValue(instance.Tags, "Name"),
+			string(instance.InstanceType),
+			state,
+			cfg.Region,
+		})
+	}
+
+	// Cap before tag lookups so skipped instances cost no API calls.
+	total := len(rows)
+	rows = cliutil.LimitRows(runtime, rows)
+
+	headers, rows, err := cliutil.AppendTagColumns(cmd.Context(), tagResolver(client), runtime.Options.IncludeTags,
+		[]string{"instance_id", "name", "instance_type", "state", "region"}, rows, 0)
+	if err != nil {
+		return fmt.Errorf("include tags: %w", awstbxaws.WrapUserError(err))
+	}
+
+	return cliutil.WriteLimitedDataset(cmd, runtime, headers, rows, total)
+}
+
+func runFindEphemeralPublicIPs(cmd *cobra.Command, _ []string) error {
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instances, err := listInstances(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", awstbxaws.WrapUserError(err))
+	}
+
+	addresses, err := listAddresses(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list addresses: %w", awstbxaws.WrapUserError(err))
+	}
+	elasticIPs := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		elasticIPs[cliutil.PointerToString(address.PublicIp)] = struct{}{}
+	}
+
+	targets := make([]ec2types.Instance, 0)
+	subnetIDs := make([]string, 0)
+	seenSubnets := make(map[string]struct{})
+	for _, instance := range instances {
+		if instance.State == nil || instance.State.Name != ec2types.InstanceStateNameRunning {
+			continue
+		}
+		publicIP := cliutil.PointerToString(instance.PublicIpAddress)
+		if publicIP == "" {
+			continue
+		}
+		if _, ok := elasticIPs[publicIP]; ok {
+			continue
+		}
+		targets = append(targets, instance)
+
+		subnetID := cliutil.PointerToString(instance.SubnetId)
+		if _, ok := seenSubnets[subnetID]; !ok && subnetID != "" {
+			seenSubnets[subnetID] = struct{}{}
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+
+	mapOnLaunch, err := subnetMapPublicIPOnLaunch(cmd.Context(), client, subnetIDs)
+	if err != nil {
+		return fmt.Errorf("describe subnets: %w", awstbxaws.WrapUserError(err))
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return cliutil.PointerToString(targets[i].InstanceId) < cliutil.PointerToString(targets[j].InstanceId)
+	})
+
+	rows := make([][]string, 0, len(targets))
+	for _, instance := range targets {
+		subnetID := cliutil.PointerToString(instance.SubnetId)
+		// A subnet that auto-assigns public IPs is a public subnet, so the
+		// instance may only be exposed because of where it was launched and
+		// could move to a private one. Either way an EIP keeps the IP stable.
+		suggestion := "allocate-eip"
+		if mapOnLaunch[subnetID] {
+			suggestion = "allocate-eip-or-move-to-private-subnet"
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(instance.InstanceId),
+			tagValue(instance.Tags, "Name"),
+			cliutil.PointerToString(instance.PublicIpAddress),
+			subnetID,
+			fmt.Sprintf("%t", mapOnLaunch[subnetID]),
+			cfg.Region,
+			suggestion,
+		})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"instance_id", "name", "public_ip", "subnet_id", "map_public_ip_on_launch", "region", "suggestion"}, rows)
+}
+
+func subnetMapPublicIPOnLaunch(ctx context.Context, client API, subnetIDs []string) (map[string]bool, error) {
+	mapOnLaunch := make(map[string]bool, len(subnetIDs))
+	if len(subnetIDs) == 0 {
+		return mapOnLaunch, nil
+	}
+
+	subnets, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ec2types.Subnet], error) {
+		page, err := client.DescribeSubnets(callCtx, &ec2.DescribeSubnetsInput{SubnetIds: subnetIDs, NextToken: nextToken})
+		if err != nil {
+			return awstbxaws.PageResult[ec2types.Subnet]{}, err
+		}
+		return awstbxaws.PageResult[ec2types.Subnet]{
+			Items:     page.Subnets,
+			NextToken: page.NextToken,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subnet := range subnets {
+		mapOnLaunch[cliutil.PointerToString(subnet.SubnetId)] = subnet.MapPublicIpOnLaunch != nil && *subnet.MapPublicIpOnLaunch
+	}
+	return mapOnLaunch, nil
+}
+
+func listInstances(ctx context.Context, client API) ([]ec2types.Instance, error) {
+	reservations, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ec2types.Reservation], error) {
+		page, err := client.DescribeInstances(callCtx, &ec2.DescribeInstancesInput{NextToken: nextToken})
+		if err != nil {
+			return awstbxaws.PageResult[ec2types.Reservation]{}, err
+		}
+		return awstbxaws.PageResult[ec2types.Reservation]{
+			Items:     page.Reservations,
+			NextToken: page.NextToken,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]ec2types.Instance, 0)
+	for _, reservation := range reservations {
+		instances = append(instances, reservation.Instances...)
+	}
+
+	return instances, nil
+}
+
+// tagResolver resolves tags for any taggable EC2 resource id via DescribeTags.
+func tagResolver(client API) cliutil.TagResolver {
+	return cliutil.TagResolverFunc(func(ctx context.Context, resourceID string) (map[string]string, error) {
+		tags, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ec2types.TagDescription], error) {
+			page, err := client.DescribeTags(callCtx, &ec2.DescribeTagsInput{
+				Filters:   []ec2types.Filter{{Name: cliutil.Ptr("resource-id"), Values: []string{resourceID}}},
+				NextToken: nextToken,
+			})
+			if err != nil {
+				return awstbxaws.PageResult[ec2types.TagDescription]{}, err
+			}
+			return awstbxaws.PageResult[ec2types.TagDescription]{
+				Items:     page.Tags,
+				NextToken: page.NextToken,
+			}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string, len(tags))
+		for _, tag := range tags {
+			values[cliutil.PointerToString(tag.Key)] = cliutil.PointerToString(tag.Value)
+		}
+		return values, nil
+	})
+}
+
+func tagValue(tags []ec2types.Tag, key string) string {
+	for _, tag := range tags {
+		if cliutil.PointerToString(tag.Key) == key {
+			return cliutil.PointerToString(tag.Value)
+		}
+	}
+	return ""
+}
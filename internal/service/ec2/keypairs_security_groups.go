@@ -23,41 +23,18 @@ func runDeleteKeypairs(cmd *cobra.Command, allRegions bool) error {
 		return fmt.Errorf("resolve AWS region: set --region, AWS_REGION, or profile default region")
 	}
 
-	regions := []string{cfg.Region}
-	if allRegions {
-		regions, err = listRegions(cmd.Context(), baseClient)
-		if err != nil {
-			return fmt.Errorf("list regions: %s", awstbxaws.FormatUserError(err))
-		}
-	}
-
-	targets := make([]keyPairTarget, 0)
-	for _, region := range regions {
-		if region == "" {
-			continue
-		}
-		regionalClient := newRegionalClient(cfg, region)
-		regionTargets, collectErr := collectUnusedKeyPairs(cmd.Context(), regionalClient, region)
-		if collectErr != nil {
-			return collectErr
-		}
-		targets = append(targets, regionTargets...)
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, collectUnusedKeyPairs)
+	if err != nil {
+		return err
 	}
 
-	sort.Slice(targets, func(i, j int) bool {
-		if targets[i].Region == targets[j].Region {
-			return targets[i].Name < targets[j].Name
-		}
-		return targets[i].Region < targets[j].Region
-	})
-
 	rows := make([][]string, 0, len(targets))
 	for _, target := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
-		rows = append(rows, []string{target.Name, target.Region, action})
+		rows = append(rows, []string{target.Item, target.Region, action})
 	}
 
 	if len(targets) == 0 {
@@ -80,8 +57,7 @@ func runDeleteKeypairs(cmd *cobra.Command, allRegions bool) error {
 		}
 
 		for i, target := range targets {
-			client := newRegionalClient(cfg, target.Region)
-			_, deleteErr := client.DeleteKeyPair(cmd.Context(), &ec2.DeleteKeyPairInput{KeyName: &target.Name})
+			_, deleteErr := target.Client.DeleteKeyPair(cmd.Context(), &ec2.DeleteKeyPairInput{KeyName: &target.Item})
 			if deleteErr != nil {
 				rows[i][2] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
 				continue
@@ -99,6 +75,7 @@ func runDeleteSecurityGroups(
 	tagFilter string,
 	unusedOnly bool,
 	securityGroupType string,
+	allRegions bool,
 ) error {
 	if securityGroupType != "all" && securityGroupType != "ec2" && securityGroupType != "rds" && securityGroupType != "elb" {
 		return fmt.Errorf("--type must be one of: all, ec2, rds, elb")
@@ -111,67 +88,33 @@ func runDeleteSecurityGroups(
 	if err != nil {
 		return err
 	}
+	filter := securityGroupFilter{
+		SSHRules:   sshRules,
+		UnusedOnly: unusedOnly,
+		TagKey:     tagKey,
+		TagValue:   tagValue,
+		Type:       securityGroupType,
+	}
 
-	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
-	groups, err := listSecurityGroups(cmd.Context(), client)
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, func(ctx context.Context, client API, _ string) ([]securityGroupTarget, error) {
+		return collectSecurityGroupTargets(ctx, client, filter)
+	})
 	if err != nil {
-		return fmt.Errorf("list security groups: %s", awstbxaws.FormatUserError(err))
-	}
-
-	usedGroups := map[string]struct{}{}
-	if unusedOnly {
-		usedGroups, err = listUsedSecurityGroups(cmd.Context(), client)
-		if err != nil {
-			return fmt.Errorf("list used security groups: %s", awstbxaws.FormatUserError(err))
-		}
-	}
-
-	targets := make([]securityGroupTarget, 0)
-	for _, group := range groups {
-		groupID := cliutil.PointerToString(group.GroupId)
-		groupName := cliutil.PointerToString(group.GroupName)
-		if groupID == "" || strings.EqualFold(groupName, "default") {
-			continue
-		}
-		if !matchesSecurityGroupType(groupName, securityGroupType) {
-			continue
-		}
-		if unusedOnly {
-			if _, inUse := usedGroups[groupID]; inUse {
-				continue
-			}
-		}
-		if tagKey != "" && !hasTagMatch(group.Tags, tagKey, tagValue) {
-			continue
-		}
-
-		sshOnlyPermissions := ingressSSHRules(group.IpPermissions)
-		if sshRules && len(sshOnlyPermissions) == 0 {
-			continue
-		}
-
-		targets = append(targets, securityGroupTarget{
-			GroupID:        groupID,
-			GroupName:      groupName,
-			SSHPermissions: sshOnlyPermissions,
-		})
+		return err
 	}
 
-	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].GroupID < targets[j].GroupID
-	})
-
 	rows := make([][]string, 0, len(targets))
 	for _, target := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
-		rows = append(rows, []string{target.GroupID, target.GroupName, cfg.Region, action})
+		rows = append(rows, []string{target.Item.GroupID, target.Item.GroupName, target.Region, action})
 	}
 
 	if len(targets) == 0 {
@@ -200,15 +143,15 @@ func runDeleteSecurityGroups(
 		for i, target := range targets {
 			var opErr error
 			if sshRules {
-				_, opErr = client.RevokeSecurityGroupIngress(cmd.Context(), &ec2.RevokeSecurityGroupIngressInput{
-					GroupId:       &target.GroupID,
-					IpPermissions: target.SSHPermissions,
+				_, opErr = target.Client.RevokeSecurityGroupIngress(cmd.Context(), &ec2.RevokeSecurityGroupIngressInput{
+					GroupId:       &target.Item.GroupID,
+					IpPermissions: target.Item.SSHPermissions,
 				})
 				if opErr == nil {
 					rows[i][3] = cliutil.ActionDeleted
 				}
 			} else {
-				_, opErr = client.DeleteSecurityGroup(cmd.Context(), &ec2.DeleteSecurityGroupInput{GroupId: &target.GroupID})
+				_, opErr = target.Client.DeleteSecurityGroup(cmd.Context(), &ec2.DeleteSecurityGroupInput{GroupId: &target.Item.GroupID})
 				if opErr == nil {
 					rows[i][3] = cliutil.ActionDeleted
 				}
@@ -223,9 +166,226 @@ func runDeleteSecurityGroups(
 	return cliutil.WriteDataset(cmd, runtime, []string{"group_id", "group_name", "region", "action"}, rows)
 }
 
-type keyPairTarget struct {
-	Name   string
-	Region string
+// securityGroupFilter holds the delete-security-groups selection flags.
+type securityGroupFilter struct {
+	SSHRules   bool
+	UnusedOnly bool
+	TagKey     string
+	TagValue   string
+	Type       string
+}
+
+// collectSecurityGroupTargets returns the non-default security groups that
+// match filter, ordered by group ID.
+func collectSecurityGroupTargets(ctx context.Context, client API, filter securityGroupFilter) ([]securityGroupTarget, error) {
+	groups, err := listSecurityGroups(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list security groups: %w", awstbxaws.WrapUserError(err))
+	}
+
+	// A group counts as used when a network interface carries it, which covers
+	// attachments made by services such as RDS, ElastiCache or Firehose, or when
+	// another group's rules reference it.
+	usedGroups := map[string]struct{}{}
+	if filter.UnusedOnly {
+		usedGroups, err = listUsedSecurityGroups(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("list used security groups: %w", awstbxaws.WrapUserError(err))
+		}
+		for groupID := range listReferencedSecurityGroups(groups) {
+			usedGroups[groupID] = struct{}{}
+		}
+	}
+
+	targets := make([]securityGroupTarget, 0)
+	for _, group := range groups {
+		groupID := cliutil.PointerToString(group.GroupId)
+		groupName := cliutil.PointerToString(group.GroupName)
+		if groupID == "" || strings.EqualFold(groupName, "default") {
+			continue
+		}
+		if !matchesSecurityGroupType(groupName, filter.Type) {
+			continue
+		}
+		if _, inUse := usedGroups[groupID]; inUse {
+			continue
+		}
+		if filter.TagKey != "" && !hasTagMatch(group.Tags, filter.TagKey, filter.TagValue) {
+			continue
+		}
+
+		sshOnlyPermissions := ingressSSHRules(group.IpPermissions)
+		if filter.SSHRules && len(sshOnlyPermissions) == 0 {
+			continue
+		}
+
+		targets = append(targets, securityGroupTarget{
+			GroupID:        groupID,
+			GroupName:      groupName,
+			SSHPermissions: sshOnlyPermissions,
+		})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].GroupID < targets[j].GroupID
+	})
+
+	return targets, nil
+}
+
+// openIngressRule is a single CIDR of an ingress rule that opens a port to
+// the whole internet.
+type openIngressRule struct {
+	GroupID    string
+	GroupName  string
+	Permission ec2types.IpPermission
+	Broader    bool
+}
+
+// runRevokeOpenIngress revokes the ingress rules that allow 0.0.0.0/0 or ::/0
+// to reach any of ports, one row per CIDR. A rule that also admits ports not
+// in ports, such as an all-traffic rule or a wide port range, is only revoked
+// with includeBroader, since revoking it cuts off those ports too.
+func runRevokeOpenIngress(cmd *cobra.Command, ports []int, includeBroader bool) error {
+	if len(ports) == 0 {
+		return fmt.Errorf("--ports must list at least one port")
+	}
+	for _, port := range ports {
+		if port < 0 || port > 65535 {
+			return fmt.Errorf("--ports must be between 0 and 65535, got %d", port)
+		}
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	groups, err := listSecurityGroups(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list security groups: %w", awstbxaws.WrapUserError(err))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return cliutil.PointerToString(groups[i].GroupId) < cliutil.PointerToString(groups[j].GroupId)
+	})
+
+	targets := make([]openIngressRule, 0)
+	for _, group := range groups {
+		for _, permission := range group.IpPermissions {
+			covers, broader := permissionCoversPorts(permission, ports)
+			if !covers {
+				continue
+			}
+			for _, ipRange := range permission.IpRanges {
+				if cliutil.PointerToString(ipRange.CidrIp) != "0.0.0.0/0" {
+					continue
+				}
+				rule := permission
+				rule.IpRanges = []ec2types.IpRange{ipRange}
+				rule.Ipv6Ranges, rule.PrefixListIds, rule.UserIdGroupPairs = nil, nil, nil
+				targets = append(targets, openIngressRule{GroupID: cliutil.PointerToString(group.GroupId), GroupName: cliutil.PointerToString(group.GroupName), Permission: rule, Broader: broader})
+			}
+			for _, ipv6Range := range permission.Ipv6Ranges {
+				if cliutil.PointerToString(ipv6Range.CidrIpv6) != "::/0" {
+					continue
+				}
+				rule := permission
+				rule.Ipv6Ranges = []ec2types.Ipv6Range{ipv6Range}
+				rule.IpRanges, rule.PrefixListIds, rule.UserIdGroupPairs = nil, nil, nil
+				targets = append(targets, openIngressRule{GroupID: cliutil.PointerToString(group.GroupId), GroupName: cliutil.PointerToString(group.GroupName), Permission: rule, Broader: broader})
+			}
+		}
+	}
+
+	headers := []string{"group_id", "group_name", "protocol", "port_range", "cidr", "region", "action"}
+	rows := make([][]string, 0, len(targets))
+	revocable := 0
+	for _, target := range targets {
+		action := "would-revoke"
+		if !runtime.Options.DryRun {
+			action = cliutil.ActionPending
+		}
+		if target.Broader && !includeBroader {
+			action = cliutil.SkippedActionMessage("broader-than-requested-ports")
+		} else {
+			revocable++
+		}
+		var cidr string
+		if len(target.Permission.IpRanges) > 0 {
+			cidr = cliutil.PointerToString(target.Permission.IpRanges[0].CidrIp)
+		} else {
+			cidr = cliutil.PointerToString(target.Permission.Ipv6Ranges[0].CidrIpv6)
+		}
+		protocol, portRange := describePermissionPorts(target.Permission)
+		rows = append(rows, []string{target.GroupID, target.GroupName, protocol, portRange, cidr, cfg.Region, action})
+	}
+
+	if revocable == 0 || runtime.Options.DryRun {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	ok, confirmErr := runtime.Prompter.Confirm(fmt.Sprintf("Revoke %d open ingress rule(s)", revocable), runtime.Options.NoConfirm)
+	if confirmErr != nil {
+		return confirmErr
+	}
+	for i, target := range targets {
+		if rows[i][6] != cliutil.ActionPending {
+			continue
+		}
+		if !ok {
+			rows[i][6] = cliutil.ActionCancelled
+			continue
+		}
+		if _, revokeErr := client.RevokeSecurityGroupIngress(cmd.Context(), &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       cliutil.Ptr(target.GroupID),
+			IpPermissions: []ec2types.IpPermission{target.Permission},
+		}); revokeErr != nil {
+			rows[i][6] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(revokeErr))
+			continue
+		}
+		rows[i][6] = "revoked"
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
+}
+
+// permissionCoversPorts reports whether a TCP, UDP or all-protocol rule
+// admits traffic on any of ports and, if so, whether it also admits ports
+// outside them.
+func permissionCoversPorts(permission ec2types.IpPermission, ports []int) (bool, bool) {
+	switch cliutil.PointerToString(permission.IpProtocol) {
+	case "-1":
+		return true, true
+	case "tcp", "udp", "6", "17":
+	default:
+		return false, false
+	}
+	if permission.FromPort == nil || permission.ToPort == nil {
+		return false, false
+	}
+	from, to := int(*permission.FromPort), int(*permission.ToPort)
+	covered := make(map[int]struct{})
+	for _, port := range ports {
+		if from <= port && port <= to {
+			covered[port] = struct{}{}
+		}
+	}
+	if len(covered) == 0 {
+		return false, false
+	}
+	return true, len(covered) < to-from+1
+}
+
+func describePermissionPorts(permission ec2types.IpPermission) (string, string) {
+	protocol := cliutil.PointerToString(permission.IpProtocol)
+	if protocol == "-1" {
+		return "all", "all"
+	}
+	from, to := cliutil.PointerToInt32(permission.FromPort), cliutil.PointerToInt32(permission.ToPort)
+	if from == to {
+		return protocol, fmt.Sprintf("%d", from)
+	}
+	return protocol, fmt.Sprintf("%d-%d", from, to)
 }
 
 type securityGroupTarget struct {
@@ -234,18 +394,20 @@ type securityGroupTarget struct {
 	SSHPermissions []ec2types.IpPermission
 }
 
-func collectUnusedKeyPairs(ctx context.Context, client API, region string) ([]keyPairTarget, error) {
+// collectUnusedKeyPairs returns the names of the key pairs in region that no
+// instance uses, sorted.
+func collectUnusedKeyPairs(ctx context.Context, client API, region string) ([]string, error) {
 	keyPairs, err := listKeyPairs(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("list key pairs (%s): %s", region, awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("list key pairs (%s): %w", region, awstbxaws.WrapUserError(err))
 	}
 
 	usedKeys, err := listUsedKeyPairs(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("list used key pairs (%s): %s", region, awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("list used key pairs (%s): %w", region, awstbxaws.WrapUserError(err))
 	}
 
-	targets := make([]keyPairTarget, 0)
+	names := make([]string, 0)
 	for _, keyPair := range keyPairs {
 		name := cliutil.PointerToString(keyPair.KeyName)
 		if name == "" {
@@ -254,10 +416,11 @@ func collectUnusedKeyPairs(ctx context.Context, client API, region string) ([]ke
 		if _, used := usedKeys[name]; used {
 			continue
 		}
-		targets = append(targets, keyPairTarget{Name: name, Region: region})
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	return targets, nil
+	return names, nil
 }
 
 func listKeyPairs(ctx context.Context, client API) ([]ec2types.KeyPairInfo, error) {
@@ -279,6 +442,11 @@ func listUsedKeyPairs(ctx context.Context, client API) (map[string]struct{}, err
 		}
 		for _, reservation := range page.Reservations {
 			for _, instance := range reservation.Instances {
+				// A terminated instance no longer holds its key pair, so the
+				// key is free to delete once no other instance uses it.
+				if instance.State != nil && instance.State.Name == ec2types.InstanceStateNameTerminated {
+					continue
+				}
 				if instance.KeyName != nil {
 					used[*instance.KeyName] = struct{}{}
 				}
@@ -332,6 +500,23 @@ func listUsedSecurityGroups(ctx context.Context, client API) (map[string]struct{
 	return used, nil
 }
 
+// listReferencedSecurityGroups returns the groups that another group's ingress
+// or egress rules point at. A group referencing itself does not count.
+func listReferencedSecurityGroups(groups []ec2types.SecurityGroup) map[string]struct{} {
+	referenced := make(map[string]struct{})
+	for _, group := range groups {
+		groupID := cliutil.PointerToString(group.GroupId)
+		for _, permission := range append(append([]ec2types.IpPermission{}, group.IpPermissions...), group.IpPermissionsEgress...) {
+			for _, pair := range permission.UserIdGroupPairs {
+				if pairID := cliutil.PointerToString(pair.GroupId); pairID != "" && pairID != groupID {
+					referenced[pairID] = struct{}{}
+				}
+			}
+		}
+	}
+	return referenced
+}
+
 func ingressSSHRules(permissions []ec2types.IpPermission) []ec2types.IpPermission {
 	matches := make([]ec2types.IpPermission, 0)
 	for _, permission := range permissions {
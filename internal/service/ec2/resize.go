@@ -0,0 +1,210 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const (
+	resizeStepStop   = "stop"
+	resizeStepModify = "modify-instance-type"
+	resizeStepStart  = "start"
+)
+
+func runResizeInstance(cmd *cobra.Command, instanceID, targetType string) error {
+	instanceID = strings.TrimSpace(instanceID)
+	targetType = strings.TrimSpace(targetType)
+	if instanceID == "" {
+		return fmt.Errorf("--instance-id is required")
+	}
+	if targetType == "" {
+		return fmt.Errorf("--type is required")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instance, err := describeInstance(cmd.Context(), client, instanceID)
+	if err != nil {
+		return fmt.Errorf("describe instance: %w", awstbxaws.WrapUserError(err))
+	}
+
+	currentType := string(instance.InstanceType)
+	if currentType == targetType {
+		return fmt.Errorf("instance %s is already %s", instanceID, targetType)
+	}
+
+	state := instanceStateName(instance)
+	if state != ec2types.InstanceStateNameRunning && state != ec2types.InstanceStateNameStopped {
+		return fmt.Errorf("instance %s is %s; wait until it is running or stopped", instanceID, state)
+	}
+
+	if err := checkInstanceTypeCompatible(cmd.Context(), client, instance, targetType); err != nil {
+		return err
+	}
+
+	pending := map[string]string{
+		resizeStepStop:   cliutil.ActionPending,
+		resizeStepModify: cliutil.ActionPending,
+		resizeStepStart:  cliutil.ActionPending,
+	}
+	if runtime.Options.DryRun {
+		pending = map[string]string{
+			resizeStepStop:   "would-stop",
+			resizeStepModify: "would-modify",
+			resizeStepStart:  "would-start",
+		}
+	}
+
+	wasRunning := state == ec2types.InstanceStateNameRunning
+	rows := make([][]string, 0, 3)
+	if wasRunning {
+		rows = append(rows, []string{instanceID, resizeStepStop, "running -> stopped", pending[resizeStepStop]})
+	}
+	rows = append(rows, []string{instanceID, resizeStepModify, currentType + " -> " + targetType, pending[resizeStepModify]})
+	if wasRunning {
+		rows = append(rows, []string{instanceID, resizeStepStart, "stopped -> running", pending[resizeStepStart]})
+	}
+
+	prompt := fmt.Sprintf("Change instance %s from %s to %s", instanceID, currentType, targetType)
+	if wasRunning {
+		prompt = fmt.Sprintf("Stop instance %s, change it from %s to %s and start it again", instanceID, currentType, targetType)
+	}
+
+	// A failed stop leaves the instance untouched, so the remaining steps are
+	// skipped. A failed modify still restarts the instance on its old type.
+	stopped := !wasRunning
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"instance_id", "step", "change", "action"},
+		Rows:          rows,
+		ActionColumn:  3,
+		ConfirmPrompt: prompt,
+		Execute: func(rowIndex int) string {
+			switch rows[rowIndex][1] {
+			case resizeStepStop:
+				if _, stopErr := client.StopInstances(cmd.Context(), &ec2.StopInstancesInput{InstanceIds: []string{instanceID}}); stopErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(stopErr))
+				}
+				if waitErr := waitForInstanceState(cmd.Context(), client, instanceID, ec2types.InstanceStateNameStopped); waitErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+				}
+				stopped = true
+				return "stopped"
+			case resizeStepModify:
+				if !stopped {
+					return cliutil.SkippedActionMessage("instance-not-stopped")
+				}
+				_, modifyErr := client.ModifyInstanceAttribute(cmd.Context(), &ec2.ModifyInstanceAttributeInput{
+					InstanceId:   cliutil.Ptr(instanceID),
+					InstanceType: &ec2types.AttributeValue{Value: cliutil.Ptr(targetType)},
+				})
+				if modifyErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(modifyErr))
+				}
+				return "modified"
+			case resizeStepStart:
+				if !stopped {
+					return cliutil.SkippedActionMessage("instance-not-stopped")
+				}
+				if _, startErr := client.StartInstances(cmd.Context(), &ec2.StartInstancesInput{InstanceIds: []string{instanceID}}); startErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(startErr))
+				}
+				if waitErr := waitForInstanceState(cmd.Context(), client, instanceID, ec2types.InstanceStateNameRunning); waitErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+				}
+				return "started"
+			}
+			return ""
+		},
+	})
+}
+
+// checkInstanceTypeCompatible rejects target types that cannot boot the
+// instance's current image: a different architecture or virtualization type,
+// or a type that requires ENA on an instance without it.
+func checkInstanceTypeCompatible(ctx context.Context, client API, instance ec2types.Instance, targetType string) error {
+	out, err := client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []ec2types.InstanceType{ec2types.InstanceType(targetType)},
+	})
+	if err != nil {
+		return fmt.Errorf("describe instance type %s: %w", targetType, awstbxaws.WrapUserError(err))
+	}
+	if len(out.InstanceTypes) == 0 {
+		return fmt.Errorf("instance type %s is not offered in this region", targetType)
+	}
+	info := out.InstanceTypes[0]
+
+	if info.ProcessorInfo != nil && instance.Architecture != "" {
+		architecture := ec2types.ArchitectureType(instance.Architecture)
+		if !slices.Contains(info.ProcessorInfo.SupportedArchitectures, architecture) {
+			return fmt.Errorf("instance type %s does not support architecture %s", targetType, instance.Architecture)
+		}
+	}
+	if instance.VirtualizationType != "" && len(info.SupportedVirtualizationTypes) > 0 {
+		virtualization := ec2types.VirtualizationType(instance.VirtualizationType)
+		if !slices.Contains(info.SupportedVirtualizationTypes, virtualization) {
+			return fmt.Errorf("instance type %s does not support %s virtualization", targetType, instance.VirtualizationType)
+		}
+	}
+	if info.NetworkInfo != nil && info.NetworkInfo.EnaSupport == ec2types.EnaSupportRequired && !awssdk.ToBool(instance.EnaSupport) {
+		return fmt.Errorf("instance type %s requires ENA, which is not enabled on %s", targetType, cliutil.PointerToString(instance.InstanceId))
+	}
+
+	return nil
+}
+
+func describeInstance(ctx context.Context, client API, instanceID string) (ec2types.Instance, error) {
+	out, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
+	if err != nil {
+		return ec2types.Instance{}, err
+	}
+	for _, reservation := range out.Reservations {
+		if len(reservation.Instances) > 0 {
+			return reservation.Instances[0], nil
+		}
+	}
+	return ec2types.Instance{}, fmt.Errorf("instance %s not found", instanceID)
+}
+
+func waitForInstanceState(ctx context.Context, client API, instanceID string, desired ec2types.InstanceStateName) error {
+	const maxAttempts = 120
+	const pollInterval = 5 * time.Second
+	for range maxAttempts {
+		instance, err := describeInstance(ctx, client, instanceID)
+		if err != nil {
+			return err
+		}
+		state := instanceStateName(instance)
+		if state == desired {
+			return nil
+		}
+		if state == ec2types.InstanceStateNameTerminated || state == ec2types.InstanceStateNameShuttingDown {
+			return fmt.Errorf("instance %s is %s", instanceID, state)
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for instance %s to be %s", instanceID, desired)
+}
+
+func instanceStateName(instance ec2types.Instance) ec2types.InstanceStateName {
+	if instance.State == nil {
+		return ""
+	}
+	return instance.State.Name
+}
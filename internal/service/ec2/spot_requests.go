@@ -0,0 +1,116 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runCancelSpotRequests(cmd *cobra.Command, olderThan string) error {
+	var cutoff time.Time
+	if strings.TrimSpace(olderThan) != "" {
+		parsed, err := cliutil.ParseTimeSpec(olderThan)
+		if err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+		cutoff = parsed
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	requests, err := listOpenSpotInstanceRequests(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list spot instance requests: %w", awstbxaws.WrapUserError(err))
+	}
+
+	action := "would-cancel"
+	if !runtime.Options.DryRun {
+		action = cliutil.ActionPending
+	}
+
+	rows := make([][]string, 0)
+	for _, request := range requests {
+		// A request with an instance is doing its job; only requests still
+		// trying to launch one are orphaned.
+		if cliutil.PointerToString(request.InstanceId) != "" {
+			continue
+		}
+		if !cutoff.IsZero() && (request.CreateTime == nil || !request.CreateTime.Before(cutoff)) {
+			continue
+		}
+
+		statusCode := ""
+		if request.Status != nil {
+			statusCode = cliutil.PointerToString(request.Status.Code)
+		}
+		createTime := ""
+		if request.CreateTime != nil {
+			createTime = request.CreateTime.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(request.SpotInstanceRequestId),
+			string(request.State),
+			statusCode,
+			createTime,
+			cfg.Region,
+			action,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"request_id", "state", "status_code", "create_time", "region", "action"},
+		Rows:          rows,
+		ActionColumn:  5,
+		ConfirmPrompt: fmt.Sprintf("Cancel %d spot instance request(s)", len(rows)),
+		Execute: func(rowIndex int) string {
+			_, cancelErr := client.CancelSpotInstanceRequests(cmd.Context(), &ec2.CancelSpotInstanceRequestsInput{
+				SpotInstanceRequestIds: []string{rows[rowIndex][0]},
+			})
+			if cancelErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(cancelErr))
+			}
+			return "cancelled"
+		},
+	})
+}
+
+func listOpenSpotInstanceRequests(ctx context.Context, client API) ([]ec2types.SpotInstanceRequest, error) {
+	requests := make([]ec2types.SpotInstanceRequest, 0)
+	var nextToken *string
+
+	for {
+		page, err := client.DescribeSpotInstanceRequests(ctx, &ec2.DescribeSpotInstanceRequestsInput{
+			Filters: []ec2types.Filter{{
+				Name:   cliutil.Ptr("state"),
+				Values: []string{string(ec2types.SpotInstanceStateOpen), string(ec2types.SpotInstanceStateActive)},
+			}},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, page.SpotInstanceRequests...)
+		if page.NextToken == nil || *page.NextToken == "" {
+			break
+		}
+		nextToken = page.NextToken
+	}
+
+	return requests, nil
+}
@@ -14,55 +14,132 @@ import (
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
-func runDeleteSnapshots(cmd *cobra.Command, retentionDays int) error {
+// runDeleteSnapshots deletes owned snapshots no AMI uses. With dangling, the
+// default, only snapshots whose source volume no longer exists are targeted;
+// without it the age cutoff alone selects them, and the snapshots an AMI still
+// uses are reported as skipped since they cannot be deleted.
+func runDeleteSnapshots(cmd *cobra.Command, retentionDays int, dangling, allRegions bool) error {
 	if retentionDays < 0 {
 		return fmt.Errorf("--retention-days must be >= 0")
 	}
+	if !dangling && retentionDays == 0 {
+		return fmt.Errorf("set --retention-days when --dangling=false")
+	}
 
-	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
-	snapshots, err := listSnapshots(cmd.Context(), client)
+	var cutoff time.Time
+	if retentionDays > 0 {
+		cutoff = time.Now().UTC().AddDate(0, 0, -retentionDays)
+	}
+
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, func(ctx context.Context, client API, _ string) ([]snapshotTarget, error) {
+		return collectSnapshotTargets(ctx, client, cutoff, dangling)
+	})
 	if err != nil {
-		return fmt.Errorf("list snapshots: %s", awstbxaws.FormatUserError(err))
+		return err
+	}
+
+	headers := []string{"snapshot_id", "volume_id", "region", "action"}
+	rows := make([][]string, 0, len(targets))
+	deletable := 0
+	for _, target := range targets {
+		action := cliutil.ActionWouldDelete
+		if !runtime.Options.DryRun {
+			action = cliutil.ActionPending
+		}
+		if target.Item.InUse {
+			action = cliutil.SkippedActionMessage("in-use-by-ami")
+		} else {
+			deletable++
+		}
+		rows = append(rows, []string{
+			cliutil.PointerToString(target.Item.Snapshot.SnapshotId),
+			cliutil.PointerToString(target.Item.Snapshot.VolumeId),
+			target.Region,
+			action,
+		})
+	}
+
+	if deletable == 0 || runtime.Options.DryRun {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
 	}
 
-	usedSnapshots, err := listSnapshotIDsUsedByAMIs(cmd.Context(), client)
+	prompt := fmt.Sprintf("Delete %d orphaned snapshot(s)", deletable)
+	if !dangling {
+		prompt = fmt.Sprintf("Delete %d snapshot(s) older than %d day(s)", deletable, retentionDays)
+	}
+	ok, confirmErr := runtime.Prompter.Confirm(prompt, runtime.Options.NoConfirm)
+	if confirmErr != nil {
+		return confirmErr
+	}
+	for i, target := range targets {
+		if rows[i][3] != cliutil.ActionPending {
+			continue
+		}
+		if !ok {
+			rows[i][3] = cliutil.ActionCancelled
+			continue
+		}
+		if _, deleteErr := target.Client.DeleteSnapshot(cmd.Context(), &ec2.DeleteSnapshotInput{SnapshotId: target.Item.Snapshot.SnapshotId}); deleteErr != nil {
+			rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			continue
+		}
+		rows[i][3] = cliutil.ActionDeleted
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
+}
+
+// snapshotTarget is an owned snapshot selected by delete-snapshots.
+type snapshotTarget struct {
+	Snapshot ec2types.Snapshot
+	InUse    bool
+}
+
+// collectSnapshotTargets returns the owned snapshots started before cutoff (any
+// age when cutoff is zero), ordered by snapshot ID. With dangling, snapshots
+// whose source volume still exists or that an AMI uses are left out; otherwise
+// the latter are kept and flagged InUse.
+func collectSnapshotTargets(ctx context.Context, client API, cutoff time.Time, dangling bool) ([]snapshotTarget, error) {
+	snapshots, err := listSnapshots(ctx, client)
 	if err != nil {
-		return fmt.Errorf("list AMI snapshot references: %s", awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("list snapshots: %w", awstbxaws.WrapUserError(err))
 	}
 
-	var cutoff time.Time
-	if retentionDays > 0 {
-		cutoff = time.Now().UTC().AddDate(0, 0, -retentionDays)
+	usedSnapshots, err := listSnapshotIDsUsedByAMIs(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list AMI snapshot references: %w", awstbxaws.WrapUserError(err))
 	}
 
 	// Cache volume existence checks so that multiple snapshots referencing the
 	// same volume only trigger a single DescribeVolumes API call.
 	checkedVolumes := make(map[string]bool)
 
-	targets := make([]ec2types.Snapshot, 0)
+	targets := make([]snapshotTarget, 0)
 	for _, snapshot := range snapshots {
 		snapshotID := cliutil.PointerToString(snapshot.SnapshotId)
 		if snapshotID == "" {
 			continue
 		}
-		if _, used := usedSnapshots[snapshotID]; used {
+		_, inUse := usedSnapshots[snapshotID]
+		if inUse && dangling {
 			continue
 		}
-		if retentionDays > 0 && snapshot.StartTime != nil && snapshot.StartTime.After(cutoff) {
+		if !cutoff.IsZero() && snapshot.StartTime != nil && snapshot.StartTime.After(cutoff) {
 			continue
 		}
 
 		volumeID := cliutil.PointerToString(snapshot.VolumeId)
-		if volumeID != "" {
+		if dangling && volumeID != "" {
 			exists, ok := checkedVolumes[volumeID]
 			if !ok {
-				exists, err = volumeExists(cmd.Context(), client, volumeID)
+				exists, err = volumeExists(ctx, client, volumeID)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				checkedVolumes[volumeID] = exists
 			}
@@ -71,118 +148,187 @@ func runDeleteSnapshots(cmd *cobra.Command, retentionDays int) error {
 			}
 		}
 
-		targets = append(targets, snapshot)
+		targets = append(targets, snapshotTarget{Snapshot: snapshot, InUse: inUse})
 	}
 
 	sort.Slice(targets, func(i, j int) bool {
-		return cliutil.PointerToString(targets[i].SnapshotId) < cliutil.PointerToString(targets[j].SnapshotId)
+		return cliutil.PointerToString(targets[i].Snapshot.SnapshotId) < cliutil.PointerToString(targets[j].Snapshot.SnapshotId)
 	})
 
+	return targets, nil
+}
+
+// runDeleteVolumes deletes unattached volumes created more than olderThanDays
+// ago (any age when zero), leaving alone any volume that carries one of the
+// KEY=VALUE excludeTags.
+func runDeleteVolumes(cmd *cobra.Command, snapshotFirst bool, olderThanDays int, excludeTags []string, allRegions bool) error {
+	if olderThanDays < 0 {
+		return fmt.Errorf("--older-than-days must be >= 0")
+	}
+	excluded := make([]ec2types.Tag, 0, len(excludeTags))
+	for _, raw := range excludeTags {
+		key, value, err := cliutil.ParseTagFilter(raw)
+		if err != nil || key == "" {
+			return fmt.Errorf("--exclude-tag must use KEY=VALUE format")
+		}
+		excluded = append(excluded, ec2types.Tag{Key: cliutil.Ptr(key), Value: cliutil.Ptr(value)})
+	}
+	var cutoff time.Time
+	if olderThanDays > 0 {
+		cutoff = time.Now().UTC().AddDate(0, 0, -olderThanDays)
+	}
+
+	runtime, cfg, baseClient, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	targets, err := collectRegionalTargets(cmd, runtime, cfg, baseClient, allRegions, func(ctx context.Context, client API, _ string) ([]ec2types.Volume, error) {
+		return collectUnattachedVolumes(ctx, client, cutoff, excluded)
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"volume_id", "size_gib", "region", "action"}
+	if snapshotFirst {
+		headers = []string{"volume_id", "size_gib", "region", "snapshot_id", "action"}
+	}
+	actionColumn := len(headers) - 1
+
 	rows := make([][]string, 0, len(targets))
-	for _, snapshot := range targets {
+	for _, target := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
-		rows = append(rows, []string{
-			cliutil.PointerToString(snapshot.SnapshotId),
-			cliutil.PointerToString(snapshot.VolumeId),
-			cfg.Region,
-			action,
-		})
-	}
-
-	if len(targets) == 0 {
-		return cliutil.WriteDataset(cmd, runtime, []string{"snapshot_id", "volume_id", "region", "action"}, rows)
+		row := []string{
+			cliutil.PointerToString(target.Item.VolumeId),
+			fmt.Sprintf("%d", cliutil.PointerToInt32(target.Item.Size)),
+			target.Region,
+		}
+		if snapshotFirst {
+			row = append(row, "")
+		}
+		rows = append(rows, append(row, action))
 	}
 
-	if !runtime.Options.DryRun {
-		ok, confirmErr := runtime.Prompter.Confirm(
-			fmt.Sprintf("Delete %d orphaned snapshot(s)", len(targets)),
-			runtime.Options.NoConfirm,
-		)
-		if confirmErr != nil {
-			return confirmErr
-		}
-		if !ok {
-			for i := range rows {
-				rows[i][3] = cliutil.ActionCancelled
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          rows,
+		ActionColumn:  actionColumn,
+		ConfirmPrompt: fmt.Sprintf("Delete %d unattached volume(s)", len(rows)),
+		Execute: func(rowIndex int) string {
+			target := targets[rowIndex]
+			if snapshotFirst {
+				snapshotID, snapshotErr := snapshotVolume(cmd.Context(), target.Client, cliutil.PointerToString(target.Item.VolumeId))
+				rows[rowIndex][3] = snapshotID
+				if snapshotErr != nil {
+					return cliutil.SkippedActionMessage("snapshot-failed")
+				}
 			}
-			return cliutil.WriteDataset(cmd, runtime, []string{"snapshot_id", "volume_id", "region", "action"}, rows)
-		}
 
-		for i, snapshot := range targets {
-			_, deleteErr := client.DeleteSnapshot(cmd.Context(), &ec2.DeleteSnapshotInput{SnapshotId: snapshot.SnapshotId})
+			_, deleteErr := target.Client.DeleteVolume(cmd.Context(), &ec2.DeleteVolumeInput{VolumeId: target.Item.VolumeId})
 			if deleteErr != nil {
-				rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
-				continue
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
 			}
-			rows[i][3] = cliutil.ActionDeleted
-		}
-	}
-
-	return cliutil.WriteDataset(cmd, runtime, []string{"snapshot_id", "volume_id", "region", "action"}, rows)
+			return cliutil.ActionDeleted
+		},
+	})
 }
 
-func runDeleteVolumes(cmd *cobra.Command, _ []string) error {
-	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+// collectUnattachedVolumes returns the available volumes created before cutoff
+// (any age when cutoff is zero) that carry none of the excluded tags, ordered
+// by volume ID.
+func collectUnattachedVolumes(ctx context.Context, client API, cutoff time.Time, excluded []ec2types.Tag) ([]ec2types.Volume, error) {
+	volumes, err := listUnattachedVolumes(ctx, client)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("list volumes: %w", awstbxaws.WrapUserError(err))
 	}
 
-	volumes, err := listUnattachedVolumes(cmd.Context(), client)
-	if err != nil {
-		return fmt.Errorf("list volumes: %s", awstbxaws.FormatUserError(err))
+	targets := make([]ec2types.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		if !cutoff.IsZero() && (volume.CreateTime == nil || !volume.CreateTime.Before(cutoff)) {
+			continue
+		}
+		if hasAnyTag(volume.Tags, excluded) {
+			continue
+		}
+		targets = append(targets, volume)
 	}
 
-	sort.Slice(volumes, func(i, j int) bool {
-		return cliutil.PointerToString(volumes[i].VolumeId) < cliutil.PointerToString(volumes[j].VolumeId)
+	sort.Slice(targets, func(i, j int) bool {
+		return cliutil.PointerToString(targets[i].VolumeId) < cliutil.PointerToString(targets[j].VolumeId)
 	})
 
-	rows := make([][]string, 0, len(volumes))
-	for _, volume := range volumes {
-		action := cliutil.ActionWouldDelete
-		if !runtime.Options.DryRun {
-			action = cliutil.ActionPending
+	return targets, nil
+}
+
+// hasAnyTag reports whether tags contain one of wanted with the exact value.
+func hasAnyTag(tags []ec2types.Tag, wanted []ec2types.Tag) bool {
+	for _, tag := range tags {
+		for _, want := range wanted {
+			if cliutil.PointerToString(tag.Key) == cliutil.PointerToString(want.Key) && cliutil.PointerToString(tag.Value) == cliutil.PointerToString(want.Value) {
+				return true
+			}
 		}
-		rows = append(rows, []string{
-			cliutil.PointerToString(volume.VolumeId),
-			fmt.Sprintf("%d", cliutil.PointerToInt32(volume.Size)),
-			cfg.Region,
-			action,
-		})
+	}
+	return false
+}
+
+// snapshotVolume creates a tagged recovery snapshot of a volume and blocks
+// until it completes, so the volume can be deleted safely afterwards.
+func snapshotVolume(ctx context.Context, client API, volumeID string) (string, error) {
+	resp, err := client.CreateSnapshot(ctx, &ec2.CreateSnapshotInput{
+		VolumeId:    cliutil.Ptr(volumeID),
+		Description: cliutil.Ptr(fmt.Sprintf("awstbx snapshot of %s before deletion", volumeID)),
+		TagSpecifications: []ec2types.TagSpecification{{
+			ResourceType: ec2types.ResourceTypeSnapshot,
+			Tags: []ec2types.Tag{
+				{Key: cliutil.Ptr("awstbx:source-volume-id"), Value: cliutil.Ptr(volumeID)},
+				{Key: cliutil.Ptr("awstbx:note"), Value: cliutil.Ptr("created by awstbx before volume deletion")},
+			},
+		}},
+	})
+	if err != nil {
+		return "", err
 	}
 
-	if len(volumes) == 0 {
-		return cliutil.WriteDataset(cmd, runtime, []string{"volume_id", "size_gib", "region", "action"}, rows)
+	snapshotID := cliutil.PointerToString(resp.SnapshotId)
+	if snapshotID == "" {
+		return "", fmt.Errorf("create snapshot for %s: empty snapshot id", volumeID)
 	}
 
-	if !runtime.Options.DryRun {
-		ok, confirmErr := runtime.Prompter.Confirm(
-			fmt.Sprintf("Delete %d unattached volume(s)", len(volumes)),
-			runtime.Options.NoConfirm,
-		)
-		if confirmErr != nil {
-			return confirmErr
+	return snapshotID, waitForSnapshotCompleted(ctx, client, snapshotID)
+}
+
+func waitForSnapshotCompleted(ctx context.Context, client API, snapshotID string) error {
+	const maxAttempts = 360
+	const pollInterval = 5 * time.Second
+	for range maxAttempts {
+		resp, err := client.DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{SnapshotIds: []string{snapshotID}})
+		if err != nil {
+			return err
 		}
-		if !ok {
-			for i := range rows {
-				rows[i][3] = cliutil.ActionCancelled
+		if len(resp.Snapshots) > 0 {
+			switch resp.Snapshots[0].State {
+			case ec2types.SnapshotStateCompleted:
+				return nil
+			case ec2types.SnapshotStateError, ec2types.SnapshotStateRecoverable:
+				reason := strings.TrimSpace(cliutil.PointerToString(resp.Snapshots[0].StateMessage))
+				if reason != "" {
+					return fmt.Errorf("snapshot %s: %s", snapshotID, reason)
+				}
+				return fmt.Errorf("snapshot %s entered state %s", snapshotID, resp.Snapshots[0].State)
 			}
-			return cliutil.WriteDataset(cmd, runtime, []string{"volume_id", "size_gib", "region", "action"}, rows)
 		}
 
-		for i, volume := range volumes {
-			_, deleteErr := client.DeleteVolume(cmd.Context(), &ec2.DeleteVolumeInput{VolumeId: volume.VolumeId})
-			if deleteErr != nil {
-				rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
-				continue
-			}
-			rows[i][3] = cliutil.ActionDeleted
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
 		}
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"volume_id", "size_gib", "region", "action"}, rows)
+	return fmt.Errorf("timed out waiting for snapshot %s", snapshotID)
 }
 
 func listSnapshots(ctx context.Context, client API) ([]ec2types.Snapshot, error) {
@@ -223,16 +369,24 @@ func volumeExists(ctx context.Context, client API, volumeID string) (bool, error
 		if strings.EqualFold(code, "InvalidVolume.NotFound") {
 			return false, nil
 		}
-		return false, fmt.Errorf("check volume %s: %s", volumeID, awstbxaws.FormatUserError(err))
+		return false, fmt.Errorf("check volume %s: %w", volumeID, awstbxaws.WrapUserError(err))
 	}
 
 	return len(output.Volumes) > 0, nil
 }
 
 func listUnattachedVolumes(ctx context.Context, client API) ([]ec2types.Volume, error) {
+	return listVolumes(ctx, client, ec2types.Filter{Name: cliutil.Ptr("status"), Values: []string{"available"}})
+}
+
+func listAttachedVolumes(ctx context.Context, client API) ([]ec2types.Volume, error) {
+	return listVolumes(ctx, client, ec2types.Filter{Name: cliutil.Ptr("attachment.status"), Values: []string{"attached"}})
+}
+
+func listVolumes(ctx context.Context, client API, filters ...ec2types.Filter) ([]ec2types.Volume, error) {
 	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ec2types.Volume], error) {
 		page, err := client.DescribeVolumes(callCtx, &ec2.DescribeVolumesInput{
-			Filters:   []ec2types.Filter{{Name: cliutil.Ptr("status"), Values: []string{"available"}}},
+			Filters:   filters,
 			NextToken: nextToken,
 		})
 		if err != nil {
@@ -244,3 +398,68 @@ func listUnattachedVolumes(ctx context.Context, client API) ([]ec2types.Volume,
 		}, nil
 	})
 }
+
+func runVolumeReport(cmd *cobra.Command, _ []string) error {
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	volumes, err := listVolumes(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", awstbxaws.WrapUserError(err))
+	}
+	instances, err := listInstances(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", awstbxaws.WrapUserError(err))
+	}
+
+	instanceNames := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		if instance.State != nil && instance.State.Name == ec2types.InstanceStateNameTerminated {
+			continue
+		}
+		instanceNames[cliutil.PointerToString(instance.InstanceId)] = tagValue(instance.Tags, "Name")
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return cliutil.PointerToString(volumes[i].VolumeId) < cliutil.PointerToString(volumes[j].VolumeId)
+	})
+
+	rows := make([][]string, 0, len(volumes))
+	for _, volume := range volumes {
+		base := []string{
+			cliutil.PointerToString(volume.VolumeId),
+			string(volume.State),
+			fmt.Sprintf("%d", cliutil.PointerToInt32(volume.Size)),
+			string(volume.VolumeType),
+		}
+		if len(volume.Attachments) == 0 {
+			finding := "ok"
+			if volume.State == ec2types.VolumeStateAvailable {
+				finding = "available"
+			}
+			rows = append(rows, append(base, "", "", "", "", cfg.Region, finding))
+			continue
+		}
+		for _, attachment := range volume.Attachments {
+			instanceID := cliutil.PointerToString(attachment.InstanceId)
+			name, exists := instanceNames[instanceID]
+			finding := "ok"
+			if !exists {
+				finding = "orphaned-attachment"
+			}
+			row := append(append([]string{}, base...),
+				instanceID,
+				name,
+				cliutil.PointerToString(attachment.Device),
+				fmt.Sprintf("%t", exists),
+				cfg.Region,
+				finding,
+			)
+			rows = append(rows, row)
+		}
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"volume_id", "state", "size_gib", "volume_type", "instance_id", "instance_name", "device", "instance_exists", "region", "finding"}, rows)
+}
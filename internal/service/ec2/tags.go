@@ -0,0 +1,213 @@
+package ec2
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// maxCreateTagsResources is the CreateTags limit on resource ids per call.
+const maxCreateTagsResources = 1000
+
+// taggableResourcePrefixes lists the EC2 resource id prefixes accepted by tag-from-csv.
+var taggableResourcePrefixes = []string{
+	"acl-", "ami-", "cgw-", "dopt-", "eigw-", "eipalloc-", "eni-", "fl-", "i-", "igw-", "key-", "lt-",
+	"nat-", "pcx-", "rtb-", "sg-", "snap-", "subnet-", "tgw-", "vgw-", "vol-", "vpc-", "vpce-", "vpn-",
+}
+
+type tagBatch struct {
+	tags        map[string]string
+	resourceIDs []string
+	rowIndexes  []int
+	action      string
+	executed    bool
+}
+
+func runTagFromCSV(cmd *cobra.Command, filePath string) error {
+	if strings.TrimSpace(filePath) == "" {
+		return fmt.Errorf("--file is required")
+	}
+	tagsByResource, order, err := readTagsCSV(filePath)
+	if err != nil {
+		return err
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = "would-tag"
+	}
+
+	rows := make([][]string, 0, len(order))
+	batches := make([]*tagBatch, 0)
+	batchByRow := make(map[int]*tagBatch)
+	batchBySignature := make(map[string][]*tagBatch)
+	taggable := 0
+	for _, resourceID := range order {
+		tags := tagsByResource[resourceID]
+		rowIndex := len(rows)
+		if !isTaggableResourceID(resourceID) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: skipping %s: unknown resource id prefix\n", resourceID)
+			rows = append(rows, []string{resourceID, formatTagSet(tags), cliutil.SkippedActionMessage("unknown-resource-type")})
+			continue
+		}
+		rows = append(rows, []string{resourceID, formatTagSet(tags), action})
+		taggable++
+
+		signature := tagSetSignature(tags)
+		candidates := batchBySignature[signature]
+		var batch *tagBatch
+		if len(candidates) > 0 && len(candidates[len(candidates)-1].resourceIDs) < maxCreateTagsResources {
+			batch = candidates[len(candidates)-1]
+		} else {
+			batch = &tagBatch{tags: tags}
+			batchBySignature[signature] = append(candidates, batch)
+			batches = append(batches, batch)
+		}
+		batch.resourceIDs = append(batch.resourceIDs, resourceID)
+		batch.rowIndexes = append(batch.rowIndexes, rowIndex)
+		batchByRow[rowIndex] = batch
+	}
+
+	headers := []string{"resource_id", "tags", "action"}
+	if taggable == 0 {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          rows,
+		ActionColumn:  2,
+		ConfirmPrompt: fmt.Sprintf("Apply tags to %d resource(s) in %d CreateTags call(s)", taggable, len(batches)),
+		Execute: func(rowIndex int) string {
+			batch, ok := batchByRow[rowIndex]
+			if !ok {
+				return ""
+			}
+			if !batch.executed {
+				batch.executed = true
+				_, createErr := client.CreateTags(cmd.Context(), &ec2.CreateTagsInput{
+					Resources: batch.resourceIDs,
+					Tags:      toEC2Tags(batch.tags),
+				})
+				if createErr != nil {
+					batch.action = cliutil.FailedActionMessage(awstbxaws.FormatUserError(createErr))
+				} else {
+					batch.action = "tagged"
+				}
+			}
+			return batch.action
+		},
+	})
+}
+
+// readTagsCSV parses resource_id,key,value rows into a tag set per resource,
+// preserving the order in which resources first appear.
+func readTagsCSV(path string) (map[string]map[string]string, []string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open tags file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	recs, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read CSV file: %w", err)
+	}
+
+	tagsByResource := make(map[string]map[string]string)
+	order := make([]string, 0)
+	for i, rec := range recs {
+		if len(rec) < 3 {
+			return nil, nil, fmt.Errorf("invalid CSV row %d: expected 3 columns", i+1)
+		}
+		resourceID := strings.TrimSpace(rec[0])
+		key := strings.TrimSpace(rec[1])
+		value := strings.TrimSpace(rec[2])
+		if i == 0 && strings.EqualFold(resourceID, "resource_id") && strings.EqualFold(key, "key") {
+			continue
+		}
+		if resourceID == "" || key == "" {
+			return nil, nil, fmt.Errorf("invalid CSV row %d: resource_id and key are required", i+1)
+		}
+
+		tags, ok := tagsByResource[resourceID]
+		if !ok {
+			tags = make(map[string]string)
+			tagsByResource[resourceID] = tags
+			order = append(order, resourceID)
+		}
+		tags[key] = value
+	}
+
+	return tagsByResource, order, nil
+}
+
+func isTaggableResourceID(resourceID string) bool {
+	for _, prefix := range taggableResourcePrefixes {
+		if strings.HasPrefix(resourceID, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTagSet renders tags as sorted key=value pairs for display.
+func formatTagSet(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, pair := range sortedTagPairs(tags) {
+		pairs = append(pairs, pair[0]+"="+pair[1])
+	}
+	return strings.Join(pairs, ";")
+}
+
+// tagSetSignature identifies a tag set for batching identical ones. It is the
+// JSON encoding of the sorted pairs, so keys or values containing "=" or ";"
+// cannot make two different tag sets collide.
+func tagSetSignature(tags map[string]string) string {
+	signature, _ := json.Marshal(sortedTagPairs(tags))
+	return string(signature)
+}
+
+func sortedTagPairs(tags map[string]string) [][2]string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([][2]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, [2]string{key, tags[key]})
+	}
+	return pairs
+}
+
+func toEC2Tags(tags map[string]string) []ec2types.Tag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]ec2types.Tag, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, ec2types.Tag{Key: cliutil.Ptr(key), Value: cliutil.Ptr(tags[key])})
+	}
+	return out
+}
@@ -103,7 +103,7 @@ func runDeleteTaskDefinitions(cmd *cobra.Command, _ []string) error {
 
 	taskDefinitionARNs, err := listInactiveTaskDefinitionARNs(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list inactive task definitions: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list inactive task definitions: %w", awstbxaws.WrapUserError(err))
 	}
 
 	sort.Strings(taskDefinitionARNs)
@@ -70,7 +70,7 @@ func runDeleteFilesystems(cmd *cobra.Command, tagFilter string) error {
 
 	fileSystems, err := listFileSystems(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list EFS file systems: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list EFS file systems: %w", awstbxaws.WrapUserError(err))
 	}
 
 	targets := make([]deleteTarget, 0, len(fileSystems))
@@ -83,7 +83,7 @@ func runDeleteFilesystems(cmd *cobra.Command, tagFilter string) error {
 		if tagKey != "" {
 			match, tagErr := fileSystemMatchesTag(cmd.Context(), client, fileSystemID, tagKey, tagValue)
 			if tagErr != nil {
-				return fmt.Errorf("list tags for file system %s: %s", fileSystemID, awstbxaws.FormatUserError(tagErr))
+				return fmt.Errorf("list tags for file system %s: %w", fileSystemID, awstbxaws.WrapUserError(tagErr))
 			}
 			if !match {
 				continue
@@ -92,7 +92,7 @@ func runDeleteFilesystems(cmd *cobra.Command, tagFilter string) error {
 
 		mountTargetIDs, mountErr := listMountTargetIDs(cmd.Context(), client, fileSystemID)
 		if mountErr != nil {
-			return fmt.Errorf("list mount targets for %s: %s", fileSystemID, awstbxaws.FormatUserError(mountErr))
+			return fmt.Errorf("list mount targets for %s: %w", fileSystemID, awstbxaws.WrapUserError(mountErr))
 		}
 
 		targets = append(targets, deleteTarget{fileSystemID: fileSystemID, mountTargetIDs: mountTargetIDs})
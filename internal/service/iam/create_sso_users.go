@@ -33,7 +33,7 @@ func runCreateSSOUsers(cmd *cobra.Command, emailFlags []string, inputFile, group
 
 	identityStoreID, err := resolveIdentityStoreID(ctx, ssoClient)
 	if err != nil {
-		return fmt.Errorf("resolve IAM Identity Center instance: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("resolve IAM Identity Center instance: %w", awstbxaws.WrapUserError(err))
 	}
 
 	requestedGroup := strings.TrimSpace(groupName)
@@ -41,7 +41,7 @@ func runCreateSSOUsers(cmd *cobra.Command, emailFlags []string, inputFile, group
 	if requestedGroup != "" {
 		groupID, err = resolveIdentityStoreGroupID(ctx, identityStoreClient, identityStoreID, requestedGroup)
 		if err != nil {
-			return fmt.Errorf("lookup group %q: %s", requestedGroup, awstbxaws.FormatUserError(err))
+			return fmt.Errorf("lookup group %q: %w", requestedGroup, awstbxaws.WrapUserError(err))
 		}
 	}
 
@@ -32,7 +32,7 @@ func runRotateKeys(cmd *cobra.Command, username, keyID string, disable, deleteKe
 
 	keys, err := listAccessKeys(cmd.Context(), client, user)
 	if err != nil {
-		return fmt.Errorf("list access keys for %s: %s", user, awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list access keys for %s: %w", user, awstbxaws.WrapUserError(err))
 	}
 
 	activeCount := 0
@@ -79,7 +79,7 @@ func runDeleteKeys(cmd *cobra.Command, tagFilter string, unusedOnly bool, pendin
 
 	keys, err := listCustomerManagedKeys(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list KMS keys: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list KMS keys: %w", awstbxaws.WrapUserError(err))
 	}
 
 	targets := make([]kmstypes.KeyMetadata, 0, len(keys))
@@ -87,7 +87,7 @@ func runDeleteKeys(cmd *cobra.Command, tagFilter string, unusedOnly bool, pendin
 		if modeTag != "" {
 			match, matchErr := keyMatchesTag(cmd.Context(), client, key, tagKey, tagValue)
 			if matchErr != nil {
-				return fmt.Errorf("list tags for key %s: %s", cliutil.PointerToString(key.KeyId), awstbxaws.FormatUserError(matchErr))
+				return fmt.Errorf("list tags for key %s: %w", cliutil.PointerToString(key.KeyId), awstbxaws.WrapUserError(matchErr))
 			}
 			if !match {
 				continue
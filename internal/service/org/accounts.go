@@ -2,6 +2,7 @@ package org
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -17,7 +18,16 @@ import (
 
 var orgAccountIDPattern = regexp.MustCompile(`^\d{12}$`)
 
-func runListAccounts(cmd *cobra.Command, ouNames []string) error {
+// ouNameCompletionCache names the completion cache filled with the OU names
+// findOUByName walks past.
+const ouNameCompletionCache = "org-ou-names"
+
+func runListAccounts(cmd *cobra.Command, ouNames []string, orphans bool, statuses []string) error {
+	statusFilter, err := parseAccountStatusFilter(statuses)
+	if err != nil {
+		return err
+	}
+
 	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
 	if err != nil {
 		return err
@@ -30,23 +40,28 @@ func runListAccounts(cmd *cobra.Command, ouNames []string) error {
 		parentPathByID := make(map[string]string)
 		accounts, listErr := listAccounts(ctx, orgClient)
 		if listErr != nil {
-			return fmt.Errorf("list accounts: %s", awstbxaws.FormatUserError(listErr))
+			return fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(listErr))
 		}
 		for _, account := range accounts {
 			id := cliutil.PointerToString(account.Id)
-			if id == "" {
+			if id == "" || !matchesAccountStatus(account, statusFilter) {
 				continue
 			}
 			parentPath, parentErr := resolveAccountParentPath(ctx, orgClient, id, parentPathByID)
 			if parentErr != nil {
-				return fmt.Errorf("resolve parent for account %s: %s", id, awstbxaws.FormatUserError(parentErr))
+				return fmt.Errorf("resolve parent for account %s: %w", id, awstbxaws.WrapUserError(parentErr))
+			}
+			// Accounts directly under the root sit outside every OU and
+			// therefore outside any OU-attached SCPs.
+			if orphans && parentPath != "/" {
+				continue
 			}
 			accountRows[id] = []string{id, cliutil.PointerToString(account.Name), cliutil.PointerToString(account.Email), string(account.Status), parentPath}
 		}
 	} else {
 		rootID, _, rootErr := getRoot(ctx, orgClient)
 		if rootErr != nil {
-			return fmt.Errorf("resolve organization root: %s", awstbxaws.FormatUserError(rootErr))
+			return fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(rootErr))
 		}
 		for _, ouName := range ouNames {
 			ou, ouErr := findOUByName(ctx, orgClient, rootID, ouName)
@@ -55,11 +70,11 @@ func runListAccounts(cmd *cobra.Command, ouNames []string) error {
 			}
 			accounts, listErr := listAccountsForParent(ctx, orgClient, cliutil.PointerToString(ou.Id))
 			if listErr != nil {
-				return fmt.Errorf("list accounts for OU %q: %s", ouName, awstbxaws.FormatUserError(listErr))
+				return fmt.Errorf("list accounts for OU %q: %w", ouName, awstbxaws.WrapUserError(listErr))
 			}
 			for _, account := range accounts {
 				id := cliutil.PointerToString(account.Id)
-				if id == "" {
+				if id == "" || !matchesAccountStatus(account, statusFilter) {
 					continue
 				}
 				accountRows[id] = []string{id, cliutil.PointerToString(account.Name), cliutil.PointerToString(account.Email), string(account.Status), "/" + cliutil.PointerToString(ou.Name)}
@@ -77,7 +92,77 @@ func runListAccounts(cmd *cobra.Command, ouNames []string) error {
 	for _, id := range ids {
 		rows = append(rows, accountRows[id])
 	}
-	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "account_name", "email", "status", "parent"}, rows)
+
+	// Cap before tag lookups so skipped accounts cost no API calls.
+	total := len(rows)
+	rows = cliutil.LimitRows(runtime, rows)
+
+	headers, rows, err := cliutil.AppendTagColumns(ctx, tagResolver(orgClient), runtime.Options.IncludeTags,
+		[]string{"account_id", "account_name", "email", "status", "parent"}, rows, 0)
+	if err != nil {
+		return fmt.Errorf("include tags: %w", awstbxaws.WrapUserError(err))
+	}
+
+	return cliutil.WriteLimitedDataset(cmd, runtime, headers, rows, total)
+}
+
+// parseAccountStatusFilter validates --status values case-insensitively and
+// returns them as a set, or nil when no filter is set.
+func parseAccountStatusFilter(statuses []string) (map[organizationtypes.AccountStatus]bool, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	known := organizationtypes.AccountStatus("").Values()
+	filter := make(map[organizationtypes.AccountStatus]bool, len(statuses))
+	for _, raw := range statuses {
+		status := organizationtypes.AccountStatus(strings.ToUpper(strings.TrimSpace(raw)))
+		valid := false
+		for _, candidate := range known {
+			if status == candidate {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("--status must be one of: ACTIVE, SUSPENDED, PENDING_CLOSURE")
+		}
+		filter[status] = true
+	}
+	return filter, nil
+}
+
+// matchesAccountStatus reports whether account passes the status filter. An
+// account without a status never matches a filter.
+func matchesAccountStatus(account organizationtypes.Account, filter map[organizationtypes.AccountStatus]bool) bool {
+	if filter == nil {
+		return true
+	}
+	return account.Status != "" && filter[account.Status]
+}
+
+// tagResolver resolves tags for accounts, OUs, roots, and policies via ListTagsForResource.
+func tagResolver(orgClient OrganizationsAPI) cliutil.TagResolver {
+	return cliutil.TagResolverFunc(func(ctx context.Context, resourceID string) (map[string]string, error) {
+		tags, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[organizationtypes.Tag], error) {
+			out, err := orgClient.ListTagsForResource(callCtx, &organizations.ListTagsForResourceInput{ResourceId: cliutil.Ptr(resourceID), NextToken: nextToken})
+			if err != nil {
+				return awstbxaws.PageResult[organizationtypes.Tag]{}, err
+			}
+			return awstbxaws.PageResult[organizationtypes.Tag]{
+				Items:     out.Tags,
+				NextToken: out.NextToken,
+			}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string, len(tags))
+		for _, tag := range tags {
+			values[cliutil.PointerToString(tag.Key)] = cliutil.PointerToString(tag.Value)
+		}
+		return values, nil
+	})
 }
 
 func runGetAccount(cmd *cobra.Command, accountID string) error {
@@ -93,12 +178,12 @@ func runGetAccount(cmd *cobra.Command, accountID string) error {
 	ctx := cmd.Context()
 	out, err := orgClient.DescribeAccount(ctx, &organizations.DescribeAccountInput{AccountId: cliutil.Ptr(accountID)})
 	if err != nil {
-		return fmt.Errorf("describe account %s: %s", accountID, awstbxaws.FormatUserError(err))
+		return fmt.Errorf("describe account %s: %w", accountID, awstbxaws.WrapUserError(err))
 	}
 
 	tags, err := orgClient.ListTagsForResource(ctx, &organizations.ListTagsForResourceInput{ResourceId: cliutil.Ptr(accountID)})
 	if err != nil {
-		return fmt.Errorf("list account tags: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list account tags: %w", awstbxaws.WrapUserError(err))
 	}
 
 	rows := [][]string{
@@ -120,35 +205,142 @@ func runGetAccount(cmd *cobra.Command, accountID string) error {
 	return cliutil.WriteDataset(cmd, runtime, []string{"field", "value"}, rows)
 }
 
-func runGenerateDiagram(cmd *cobra.Command, maxAccountsPerOU int) error {
-	if maxAccountsPerOU < 1 {
-		return fmt.Errorf("--max-accounts-per-ou must be >= 1")
+// runCloseAccount closes a member account. Closed accounts stay in
+// PENDING_CLOSURE for 90 days before AWS suspends them, so accounts already
+// past ACTIVE are reported as skipped rather than closed again.
+func runCloseAccount(cmd *cobra.Command, accountID string) error {
+	if err := validateAccountID(accountID); err != nil {
+		return err
 	}
+	accountID = strings.TrimSpace(accountID)
 
 	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
 	if err != nil {
 		return err
 	}
-	_ = runtime
 
 	ctx := cmd.Context()
-	rootID, rootName, err := getRoot(ctx, orgClient)
+	out, err := orgClient.DescribeAccount(ctx, &organizations.DescribeAccountInput{AccountId: cliutil.Ptr(accountID)})
 	if err != nil {
-		return fmt.Errorf("resolve organization root: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("describe account %s: %w", accountID, awstbxaws.WrapUserError(err))
+	}
+	account := out.Account
+	if account == nil {
+		account = &organizationtypes.Account{}
 	}
 
-	lines := []string{"graph TB"}
-	rootNode := mermaidID(rootID)
-	lines = append(lines, fmt.Sprintf("    %s[\"%s (Root)\"]", rootNode, mermaidEscape(rootName)))
+	headers := []string{"account_id", "account_name", "email", "status", "action"}
+	row := []string{accountID, cliutil.PointerToString(account.Name), cliutil.PointerToString(account.Email), string(account.Status), cliutil.ActionPending}
+	if account.Status != "" && account.Status != organizationtypes.AccountStatusActive {
+		row[4] = cliutil.SkippedActionMessage("already-" + strings.ToLower(strings.ReplaceAll(string(account.Status), "_", "-")))
+		return cliutil.WriteDataset(cmd, runtime, headers, [][]string{row})
+	}
+	if runtime.Options.DryRun {
+		row[4] = "would-close"
+	}
 
-	appendLines, err := buildMermaid(ctx, orgClient, rootID, rootNode, maxAccountsPerOU)
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          [][]string{row},
+		ActionColumn:  4,
+		ConfirmPrompt: fmt.Sprintf("Close account %s (%s); this cannot be undone after 90 days", accountID, cliutil.PointerToString(account.Name)),
+		Execute: func(int) string {
+			if _, closeErr := orgClient.CloseAccount(ctx, &organizations.CloseAccountInput{AccountId: cliutil.Ptr(accountID)}); closeErr != nil {
+				return cliutil.FailedActionMessage(closeAccountFailure(closeErr))
+			}
+			if described, describeErr := orgClient.DescribeAccount(ctx, &organizations.DescribeAccountInput{AccountId: cliutil.Ptr(accountID)}); describeErr == nil && described.Account != nil {
+				row[3] = string(described.Account.Status)
+			}
+			return "closed"
+		},
+	})
+}
+
+// runMoveAccount moves an account from its current parent to the OU named
+// destOUName. OU names are not unique across the tree, so a name that
+// matches more than one OU is rejected rather than guessed.
+func runMoveAccount(cmd *cobra.Command, accountID, destOUName string) error {
+	if err := validateAccountID(accountID); err != nil {
+		return err
+	}
+	accountID = strings.TrimSpace(accountID)
+	destOUName = strings.TrimSpace(destOUName)
+	if destOUName == "" {
+		return fmt.Errorf("--dest-ou-name is required")
+	}
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	rootID, _, err := getRoot(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
+	}
+	parents, err := listParentsForChild(ctx, orgClient, accountID)
 	if err != nil {
-		return fmt.Errorf("build diagram: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list parents for account %s: %w", accountID, awstbxaws.WrapUserError(err))
 	}
-	lines = append(lines, appendLines...)
+	if len(parents) == 0 {
+		return fmt.Errorf("account %s has no parent in the organization", accountID)
+	}
+	sourceID := cliutil.PointerToString(parents[0].Id)
 
-	_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.Join(lines, "\n"))
-	return err
+	dest, pathByID, err := findUniqueOU(ctx, orgClient, rootID, destOUName)
+	if err != nil {
+		return err
+	}
+	destID := cliutil.PointerToString(dest.Id)
+
+	sourcePath, ok := pathByID[sourceID]
+	if !ok {
+		sourcePath = "/" + sourceID
+	}
+
+	headers := []string{"account_id", "source_id", "source_path", "dest_id", "dest_path", "action"}
+	row := []string{accountID, sourceID, sourcePath, destID, pathByID[destID], cliutil.ActionPending}
+	if sourceID == destID {
+		row[5] = cliutil.SkippedActionMessage("already-in-destination")
+		return cliutil.WriteDataset(cmd, runtime, headers, [][]string{row})
+	}
+	if runtime.Options.DryRun {
+		row[5] = "would-move"
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          [][]string{row},
+		ActionColumn:  5,
+		ConfirmPrompt: fmt.Sprintf("Move account %s from %s to %s", accountID, sourcePath, pathByID[destID]),
+		Execute: func(int) string {
+			_, moveErr := orgClient.MoveAccount(ctx, &organizations.MoveAccountInput{
+				AccountId:           cliutil.Ptr(accountID),
+				SourceParentId:      cliutil.Ptr(sourceID),
+				DestinationParentId: cliutil.Ptr(destID),
+			})
+			if moveErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(moveErr))
+			}
+			return "moved"
+		},
+	})
+}
+
+// closeAccountFailure explains the closure limits AWS enforces, which surface
+// as a generic constraint violation.
+func closeAccountFailure(err error) string {
+	var constraintErr *organizationtypes.ConstraintViolationException
+	if errors.As(err, &constraintErr) {
+		switch constraintErr.Reason {
+		case organizationtypes.ConstraintViolationExceptionReasonCloseAccountQuotaExceeded:
+			return "closure quota exceeded: AWS allows closing 10% of member accounts per 30 days"
+		case organizationtypes.ConstraintViolationExceptionReasonCloseAccountRequestsLimitExceeded:
+			return "too many concurrent close requests: retry once pending closures finish"
+		}
+	}
+	return awstbxaws.FormatUserError(err)
 }
 
 func validateAccountID(accountID string) error {
@@ -272,32 +464,89 @@ func findOUByName(ctx context.Context, orgClient OrganizationsAPI, rootID, ouNam
 		return organizationtypes.OrganizationalUnit{}, fmt.Errorf("organizational unit not found: %s", ouName)
 	}
 
-	queue := []string{rootID}
+	var found *organizationtypes.OrganizationalUnit
+	err := walkOUs(ctx, orgClient, rootID, func(ou organizationtypes.OrganizationalUnit, _ string) bool {
+		if strings.EqualFold(cliutil.PointerToString(ou.Name), targetName) {
+			found = &ou
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return organizationtypes.OrganizationalUnit{}, err
+	}
+	if found == nil {
+		return organizationtypes.OrganizationalUnit{}, fmt.Errorf("organizational unit not found: %s", ouName)
+	}
+	return *found, nil
+}
+
+// findUniqueOU resolves an OU name anywhere below rootID and fails when no OU
+// or more than one OU carries it, listing the candidates by path. It also
+// returns the path of every OU visited, keyed by ID, with the root as "/".
+func findUniqueOU(ctx context.Context, orgClient OrganizationsAPI, rootID, ouName string) (organizationtypes.OrganizationalUnit, map[string]string, error) {
+	pathByID := map[string]string{rootID: "/"}
+	matches := make([]organizationtypes.OrganizationalUnit, 0, 1)
+	err := walkOUs(ctx, orgClient, rootID, func(ou organizationtypes.OrganizationalUnit, path string) bool {
+		pathByID[cliutil.PointerToString(ou.Id)] = path
+		if strings.EqualFold(cliutil.PointerToString(ou.Name), ouName) {
+			matches = append(matches, ou)
+		}
+		return true
+	})
+	if err != nil {
+		return organizationtypes.OrganizationalUnit{}, nil, fmt.Errorf("list organizational units: %w", awstbxaws.WrapUserError(err))
+	}
+	switch {
+	case len(matches) == 0:
+		return organizationtypes.OrganizationalUnit{}, nil, fmt.Errorf("organizational unit not found: %s", ouName)
+	case len(matches) > 1:
+		candidates := make([]string, 0, len(matches))
+		for _, ou := range matches {
+			id := cliutil.PointerToString(ou.Id)
+			candidates = append(candidates, fmt.Sprintf("%s (%s)", pathByID[id], id))
+		}
+		return organizationtypes.OrganizationalUnit{}, nil, fmt.Errorf("organizational unit name %q is ambiguous: %s", ouName, strings.Join(candidates, ", "))
+	}
+	return matches[0], pathByID, nil
+}
+
+// walkOUs visits every OU below rootID breadth-first together with its path
+// from the root (e.g. "/Workloads/Prod") until visit returns false.
+func walkOUs(ctx context.Context, orgClient OrganizationsAPI, rootID string, visit func(ou organizationtypes.OrganizationalUnit, path string) bool) error {
+	// Every OU name seen on the way feeds --ou-name shell completion.
+	seen := make([]string, 0)
+	defer func() { cliutil.RememberCompletionValues(ouNameCompletionCache, seen) }()
+
+	type pendingParent struct{ id, path string }
+	queue := []pendingParent{{id: rootID}}
 	for len(queue) > 0 {
-		parentID := queue[0]
+		parent := queue[0]
 		queue = queue[1:]
 
-		ous, err := listOUsForParent(ctx, orgClient, parentID)
+		ous, err := listOUsForParent(ctx, orgClient, parent.id)
 		if err != nil {
-			return organizationtypes.OrganizationalUnit{}, err
+			return err
 		}
 		for _, ou := range ous {
-			if strings.EqualFold(cliutil.PointerToString(ou.Name), targetName) {
-				return ou, nil
+			name := cliutil.PointerToString(ou.Name)
+			seen = append(seen, name)
+			path := parent.path + "/" + name
+			if !visit(ou, path) {
+				return nil
 			}
 			if id := cliutil.PointerToString(ou.Id); id != "" {
-				queue = append(queue, id)
+				queue = append(queue, pendingParent{id: id, path: path})
 			}
 		}
 	}
-
-	return organizationtypes.OrganizationalUnit{}, fmt.Errorf("organizational unit not found: %s", ouName)
+	return nil
 }
 
 func listAccountIDsByOU(ctx context.Context, orgClient OrganizationsAPI, ouName string) ([]string, error) {
 	rootID, _, err := getRoot(ctx, orgClient)
 	if err != nil {
-		return nil, fmt.Errorf("resolve organization root: %s", awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
 	}
 	ou, err := findOUByName(ctx, orgClient, rootID, ouName)
 	if err != nil {
@@ -305,7 +554,7 @@ func listAccountIDsByOU(ctx context.Context, orgClient OrganizationsAPI, ouName
 	}
 	accounts, err := listAccountsForParent(ctx, orgClient, cliutil.PointerToString(ou.Id))
 	if err != nil {
-		return nil, fmt.Errorf("list accounts for OU %q: %s", ouName, awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("list accounts for OU %q: %w", ouName, awstbxaws.WrapUserError(err))
 	}
 	ids := make([]string, 0, len(accounts))
 	for _, account := range accounts {
@@ -316,78 +565,6 @@ func listAccountIDsByOU(ctx context.Context, orgClient OrganizationsAPI, ouName
 	return ids, nil
 }
 
-func buildMermaid(ctx context.Context, orgClient OrganizationsAPI, parentID, parentNode string, maxAccountsPerOU int) ([]string, error) {
-	lines := make([]string, 0)
-	accounts, err := listAccountsForParent(ctx, orgClient, parentID)
-	if err != nil {
-		return nil, err
-	}
-	sort.Slice(accounts, func(i, j int) bool {
-		return cliutil.PointerToString(accounts[i].Name) < cliutil.PointerToString(accounts[j].Name)
-	})
-	active := make([]organizationtypes.Account, 0)
-	for _, acct := range accounts {
-		if acct.Status == organizationtypes.AccountStatusActive {
-			active = append(active, acct)
-		}
-	}
-	if len(active) > maxAccountsPerOU {
-		active = active[:maxAccountsPerOU]
-	}
-	for _, acct := range active {
-		node := mermaidID(cliutil.PointerToString(acct.Id))
-		lines = append(lines, fmt.Sprintf("    %s[\"%s\"]", node, mermaidEscape(cliutil.PointerToString(acct.Name))))
-		lines = append(lines, fmt.Sprintf("    %s --> %s", parentNode, node))
-	}
-
-	ous, err := listOUsForParent(ctx, orgClient, parentID)
-	if err != nil {
-		return nil, err
-	}
-	sort.Slice(ous, func(i, j int) bool {
-		return cliutil.PointerToString(ous[i].Name) < cliutil.PointerToString(ous[j].Name)
-	})
-	for _, ou := range ous {
-		ouID := cliutil.PointerToString(ou.Id)
-		node := mermaidID(ouID)
-		lines = append(lines, fmt.Sprintf("    %s[\"%s\"]", node, mermaidEscape(cliutil.PointerToString(ou.Name))))
-		lines = append(lines, fmt.Sprintf("    %s --> %s", parentNode, node))
-		children, childErr := buildMermaid(ctx, orgClient, ouID, node, maxAccountsPerOU)
-		if childErr != nil {
-			return nil, childErr
-		}
-		lines = append(lines, children...)
-	}
-	return lines, nil
-}
-
-func mermaidID(raw string) string {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return "node"
-	}
-	var b strings.Builder
-	for _, r := range raw {
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
-			b.WriteRune(r)
-		} else {
-			b.WriteByte('_')
-		}
-	}
-	out := b.String()
-	if out == "" {
-		out = "node"
-	}
-	if out[0] >= '0' && out[0] <= '9' {
-		out = "n_" + out
-	}
-	return out
-}
-
-func mermaidEscape(raw string) string {
-	return strings.ReplaceAll(raw, "\"", `\\"`)
-}
-
 func formatTime(value *time.Time) string {
 	if value == nil {
 		return ""
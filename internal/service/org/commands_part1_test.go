@@ -3,6 +3,7 @@ package org
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssoadmin"
 	ssoadmintypes "github.com/aws/aws-sdk-go-v2/service/ssoadmin/types"
+	"github.com/aws/smithy-go"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
@@ -127,6 +129,75 @@ func TestOrgListAccountsByOUFilter(t *testing.T) {
 	if !strings.Contains(output, "\"parent\": \"/Sandbox\"") {
 		t.Fatalf("expected OU path in output: %s", output)
 	}
+
+	// The OU walk caches names for shell completion of --ou-name.
+	output, err = executeCommand(t, "__complete", "org", "list-accounts", "--ou-name", "sa")
+	if err != nil {
+		t.Fatalf("complete --ou-name: %v", err)
+	}
+	if !strings.Contains(output, "Sandbox\n") {
+		t.Fatalf("expected cached OU name in completion output: %s", output)
+	}
+}
+
+func TestOrgListAccountsStatusFilter(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("active"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("suspended"), Status: organizationtypes.AccountStatusSuspended},
+				{Id: cliutil.Ptr("333333333333"), Name: cliutil.Ptr("closing"), Status: organizationtypes.AccountStatusPendingClosure},
+				{Id: cliutil.Ptr("444444444444"), Name: cliutil.Ptr("unknown")},
+			}}, nil
+		},
+		listParentsFn: func(_ context.Context, _ *organizations.ListParentsInput, _ ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+			return &organizations.ListParentsOutput{Parents: []organizationtypes.Parent{{Id: cliutil.Ptr("r-root"), Type: organizationtypes.ParentTypeRoot}}}, nil
+		},
+		listRootsFn: func(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+			return &organizations.ListRootsOutput{Roots: []organizationtypes.Root{{Id: cliutil.Ptr("r-root"), Name: cliutil.Ptr("Main")}}}, nil
+		},
+		listOUsFn: func(_ context.Context, _ *organizations.ListOrganizationalUnitsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+			return &organizations.ListOrganizationalUnitsForParentOutput{
+				OrganizationalUnits: []organizationtypes.OrganizationalUnit{{Id: cliutil.Ptr("ou-1"), Name: cliutil.Ptr("Retired")}},
+			}, nil
+		},
+		listForParentFn: func(_ context.Context, _ *organizations.ListAccountsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error) {
+			return &organizations.ListAccountsForParentOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("555555555555"), Name: cliutil.Ptr("ou-active"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("666666666666"), Name: cliutil.Ptr("ou-suspended"), Status: organizationtypes.AccountStatusSuspended},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "list-accounts", "--status", "suspended", "--status", "PENDING_CLOSURE")
+	if err != nil {
+		t.Fatalf("execute list-accounts --status: %v", err)
+	}
+	if !strings.Contains(output, "account_id=222222222222") || !strings.Contains(output, "account_id=333333333333") ||
+		strings.Contains(output, "111111111111") || strings.Contains(output, "444444444444") {
+		t.Fatalf("unexpected status-filtered output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "org", "list-accounts", "--ou-name", "Retired", "--status", "SUSPENDED")
+	if err != nil {
+		t.Fatalf("execute list-accounts --ou-name --status: %v", err)
+	}
+	if strings.TrimSpace(output) != "account_id=666666666666 account_name=ou-suspended email= status=SUSPENDED parent=/Retired" {
+		t.Fatalf("unexpected OU status-filtered output: %s", output)
+	}
+
+	if _, err := executeCommand(t, "org", "list-accounts", "--status", "CLOSED"); err == nil || !strings.Contains(err.Error(), "--status must be one of") {
+		t.Fatalf("expected invalid status error, got %v", err)
+	}
 }
 
 func TestOrgListAccountsDefaultsRootParentPath(t *testing.T) {
@@ -163,6 +234,96 @@ func TestOrgListAccountsDefaultsRootParentPath(t *testing.T) {
 	}
 }
 
+func TestOrgListAccountsOrphans(t *testing.T) {
+	describeOUCalls := 0
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("prod"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("forgotten"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("333333333333"), Name: cliutil.Ptr("staging"), Status: organizationtypes.AccountStatusActive},
+			}}, nil
+		},
+		listParentsFn: func(_ context.Context, in *organizations.ListParentsInput, _ ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+			if cliutil.PointerToString(in.ChildId) == "222222222222" {
+				return &organizations.ListParentsOutput{Parents: []organizationtypes.Parent{{Id: cliutil.Ptr("r-root"), Type: organizationtypes.ParentTypeRoot}}}, nil
+			}
+			return &organizations.ListParentsOutput{Parents: []organizationtypes.Parent{{Id: cliutil.Ptr("ou-workloads"), Type: organizationtypes.ParentTypeOrganizationalUnit}}}, nil
+		},
+		describeOUFn: func(_ context.Context, _ *organizations.DescribeOrganizationalUnitInput, _ ...func(*organizations.Options)) (*organizations.DescribeOrganizationalUnitOutput, error) {
+			describeOUCalls++
+			return &organizations.DescribeOrganizationalUnitOutput{OrganizationalUnit: &organizationtypes.OrganizationalUnit{Id: cliutil.Ptr("ou-workloads"), Name: cliutil.Ptr("Workloads")}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "list-accounts", "--orphans")
+	if err != nil {
+		t.Fatalf("execute list-accounts --orphans: %v", err)
+	}
+	if !strings.Contains(output, "account_id=222222222222 account_name=forgotten") || !strings.Contains(output, "parent=/") {
+		t.Fatalf("expected root-level account in output: %s", output)
+	}
+	if strings.Contains(output, "111111111111") || strings.Contains(output, "333333333333") {
+		t.Fatalf("did not expect OU-placed accounts in output: %s", output)
+	}
+	if describeOUCalls != 1 {
+		t.Fatalf("expected OU lookups to be cached, got %d DescribeOrganizationalUnit calls", describeOUCalls)
+	}
+
+	if _, err := executeCommand(t, "org", "list-accounts", "--orphans", "--ou-name", "Sandbox"); err == nil {
+		t.Fatal("expected --orphans and --ou-name to be mutually exclusive")
+	}
+}
+
+func TestOrgListAccountsIncludeTags(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{
+				Accounts: []organizationtypes.Account{
+					{Id: cliutil.Ptr("123456789012"), Name: cliutil.Ptr("sandbox"), Email: cliutil.Ptr("sandbox@example.com"), Status: organizationtypes.AccountStatusActive},
+				},
+			}, nil
+		},
+		listParentsFn: func(_ context.Context, _ *organizations.ListParentsInput, _ ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+			return &organizations.ListParentsOutput{
+				Parents: []organizationtypes.Parent{{Id: cliutil.Ptr("r-root"), Type: organizationtypes.ParentTypeRoot}},
+			}, nil
+		},
+		listTagsFn: func(_ context.Context, in *organizations.ListTagsForResourceInput, _ ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error) {
+			if cliutil.PointerToString(in.ResourceId) != "123456789012" {
+				t.Fatalf("unexpected tag lookup for %q", cliutil.PointerToString(in.ResourceId))
+			}
+			return &organizations.ListTagsForResourceOutput{Tags: []organizationtypes.Tag{{Key: cliutil.Ptr("Owner"), Value: cliutil.Ptr("team-a")}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	output, err := executeCommand(t, "--output", "json", "--include-tags", "Owner", "org", "list-accounts")
+	if err != nil {
+		t.Fatalf("execute list-accounts: %v", err)
+	}
+	if !strings.Contains(output, "\"tag:Owner\": \"team-a\"") {
+		t.Fatalf("expected Owner tag column in output: %s", output)
+	}
+}
+
 func TestOrgListAccountsByNestedOUFilter(t *testing.T) {
 	orgClient := &mockOrganizationsClient{
 		listRootsFn: func(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
@@ -251,6 +412,71 @@ func TestOrgGenerateDiagramEmitsMermaid(t *testing.T) {
 	}
 }
 
+func TestOrgTreeMermaidKeepsSanitizedIDsUnique(t *testing.T) {
+	root := &orgTreeNode{ID: "r-1", Name: "Root", Type: orgNodeTypeRoot, Children: []*orgTreeNode{
+		{ID: "ou-a.b", Name: "Dotted"},
+		{ID: "ou-a-b", Name: "Dashed"},
+	}}
+
+	output := strings.Join(renderOrgTreeMermaid(root), "\n")
+	for _, expected := range []string{"r_1_0 --> ou_a_b_1", "r_1_0 --> ou_a_b_2"} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestOrgGenerateDiagramDOTAndJSONFormats(t *testing.T) {
+	orgClient := ouTreeClient()
+	orgClient.listRootsFn = func(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+		return &organizations.ListRootsOutput{Roots: []organizationtypes.Root{{Id: cliutil.Ptr("r-1"), Name: cliutil.Ptr("Main")}}}, nil
+	}
+	orgClient.listForParentFn = func(_ context.Context, in *organizations.ListAccountsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error) {
+		if cliutil.PointerToString(in.ParentId) == "ou-prod" {
+			return &organizations.ListAccountsForParentOutput{Accounts: []organizationtypes.Account{{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("app \"prod\""), Status: organizationtypes.AccountStatusActive}}}, nil
+		}
+		return &organizations.ListAccountsForParentOutput{}, nil
+	}
+	withOUTreeClient(t, orgClient)
+
+	output, err := executeCommand(t, "org", "generate-diagram", "--format", "dot")
+	if err != nil {
+		t.Fatalf("execute generate-diagram --format dot: %v", err)
+	}
+	for _, want := range []string{
+		"digraph organization {",
+		`"r-1" [label="Main (Root)"];`,
+		`"111111111111" [label="app \"prod\"", shape=ellipse];`,
+		`"r-1" -> "ou-work";`,
+		`"ou-prod" -> "111111111111";`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in DOT output: %s", want, output)
+		}
+	}
+	if !strings.HasSuffix(strings.TrimSpace(output), "}") {
+		t.Fatalf("expected closed digraph: %s", output)
+	}
+
+	output, err = executeCommand(t, "org", "generate-diagram", "--format", "json")
+	if err != nil {
+		t.Fatalf("execute generate-diagram --format json: %v", err)
+	}
+	var tree orgTreeNode
+	if err := json.Unmarshal([]byte(output), &tree); err != nil {
+		t.Fatalf("decode JSON tree: %v: %s", err, output)
+	}
+	if tree.Type != "root" || len(tree.Children) != 1 || tree.Children[0].Name != "Workloads" ||
+		len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].Children[0].ID != "111111111111" ||
+		tree.Children[0].Children[0].Children[0].Type != "account" {
+		t.Fatalf("unexpected JSON tree: %s", output)
+	}
+
+	if _, err := executeCommand(t, "org", "generate-diagram", "--format", "svg"); err == nil || !strings.Contains(err.Error(), "--format must be one of") {
+		t.Fatalf("expected format validation error, got %v", err)
+	}
+}
+
 func TestOrgSetAlternateContactRequiresContactsFile(t *testing.T) {
 	if _, err := executeCommand(t, "org", "set-alternate-contact"); err == nil {
 		t.Fatal("expected input-file validation error")
@@ -303,6 +529,121 @@ func TestOrgSetAlternateContactDryRun(t *testing.T) {
 	}
 }
 
+func TestOrgSetAlternateContactPerAccountOverride(t *testing.T) {
+	puts := make(map[string]*account.PutAlternateContactInput)
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("prod")},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("sandbox")},
+			}}, nil
+		},
+	}
+	accountClient := &mockAccountClient{
+		putAlternateContactFn: func(_ context.Context, in *account.PutAlternateContactInput, _ ...func(*account.Options)) (*account.PutAlternateContactOutput, error) {
+			puts[cliutil.PointerToString(in.AccountId)+"/"+string(in.AlternateContactType)] = in
+			return &account.PutAlternateContactOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return accountClient },
+	)
+
+	contactsFile := filepath.Join(t.TempDir(), "contacts.json")
+	content := `{
+	  "security": {"name":"Sec","title":"Security Lead","emailAddress":"sec@example.com","phoneNumber":"+10000000000"},
+	  "billing": {"name":"Bill","title":"Finance Lead","emailAddress":"bill@example.com","phoneNumber":"+10000000001"},
+	  "operations": {"name":"Ops","title":"Ops Lead","emailAddress":"ops@example.com","phoneNumber":"+10000000002"},
+	  "accounts": {
+	    "111111111111": {"operations": {"name":"Prod Ops","emailAddress":"prod-ops@example.com"}}
+	  }
+	}`
+	if err := os.WriteFile(contactsFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write contacts file: %v", err)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "org", "set-alternate-contact", "--input-file", contactsFile)
+	if err != nil {
+		t.Fatalf("execute set-alternate-contact --dry-run: %v", err)
+	}
+	for _, expected := range []string{
+		"account_id=111111111111 contact_type=OPERATIONS email=prod-ops@example.com name=Prod Ops title=Ops Lead phone=+10000000002 source=override action=would-set",
+		"account_id=111111111111 contact_type=SECURITY email=sec@example.com name=Sec title=Security Lead phone=+10000000000 source=default action=would-set",
+		"account_id=222222222222 contact_type=OPERATIONS email=ops@example.com name=Ops title=Ops Lead phone=+10000000002 source=default action=would-set",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in dry-run output: %s", expected, output)
+		}
+	}
+
+	if _, err := executeCommand(t, "--no-confirm", "org", "set-alternate-contact", "--input-file", contactsFile); err != nil {
+		t.Fatalf("execute set-alternate-contact: %v", err)
+	}
+	if len(puts) != 6 {
+		t.Fatalf("expected 6 PutAlternateContact calls, got %d", len(puts))
+	}
+	if got := cliutil.PointerToString(puts["111111111111/OPERATIONS"].EmailAddress); got != "prod-ops@example.com" {
+		t.Fatalf("expected override email for prod operations contact, got %s", got)
+	}
+	if got := cliutil.PointerToString(puts["222222222222/OPERATIONS"].EmailAddress); got != "ops@example.com" {
+		t.Fatalf("expected default email for sandbox operations contact, got %s", got)
+	}
+}
+
+func TestOrgSetAlternateContactAccountOverridesSecurityOnly(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("prod")},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("sandbox")},
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	contactsFile := filepath.Join(t.TempDir(), "contacts.json")
+	content := `{
+	  "securityContact": {"name":"Sec","title":"Security Lead","emailAddress":"sec@example.com","phoneNumber":"+10000000000"},
+	  "billingContact": {"name":"Bill","title":"Finance Lead","emailAddress":"bill@example.com","phoneNumber":"+10000000001"},
+	  "operationsContact": {"name":"Ops","title":"Ops Lead","emailAddress":"ops@example.com","phoneNumber":"+10000000002"},
+	  "accountOverrides": {
+	    "222222222222": {"securityContact": {"emailAddress":"sandbox-sec@example.com"}}
+	  }
+	}`
+	if err := os.WriteFile(contactsFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write contacts file: %v", err)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "org", "set-alternate-contact", "--input-file", contactsFile)
+	if err != nil {
+		t.Fatalf("execute set-alternate-contact --dry-run: %v", err)
+	}
+	for _, expected := range []string{
+		"account_id=222222222222 contact_type=SECURITY email=sandbox-sec@example.com name=Sec title=Security Lead phone=+10000000000 source=override action=would-set",
+		"account_id=222222222222 contact_type=BILLING email=bill@example.com name=Bill title=Finance Lead phone=+10000000001 source=default action=would-set",
+		"account_id=222222222222 contact_type=OPERATIONS email=ops@example.com name=Ops title=Ops Lead phone=+10000000002 source=default action=would-set",
+		"account_id=111111111111 contact_type=SECURITY email=sec@example.com name=Sec title=Security Lead phone=+10000000000 source=default action=would-set",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in dry-run output: %s", expected, output)
+		}
+	}
+}
+
 func TestOrgAssignSSOAccessDryRun(t *testing.T) {
 	createCalls := 0
 	orgClient := &mockOrganizationsClient{
@@ -525,6 +866,92 @@ func TestOrgAssignSSOAccessNoConfirmReportsProvisioningFailure(t *testing.T) {
 	}
 }
 
+func TestOrgAssignSSOAccessAllAccountsAndAccountIDs(t *testing.T) {
+	targets := make([]string, 0)
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("222222222222"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("111111111111"), Status: organizationtypes.AccountStatusActive},
+				{Id: cliutil.Ptr("333333333333"), Status: organizationtypes.AccountStatusSuspended},
+			}}, nil
+		},
+	}
+	ssoClient := &mockSSOAdminClient{
+		listInstancesFn: func(_ context.Context, _ *ssoadmin.ListInstancesInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.ListInstancesOutput, error) {
+			return &ssoadmin.ListInstancesOutput{
+				Instances: []ssoadmintypes.InstanceMetadata{{InstanceArn: cliutil.Ptr("arn:aws:sso:::instance/ssoins-123"), IdentityStoreId: cliutil.Ptr("d-123")}},
+			}, nil
+		},
+		listPSFn: func(_ context.Context, _ *ssoadmin.ListPermissionSetsInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.ListPermissionSetsOutput, error) {
+			return &ssoadmin.ListPermissionSetsOutput{PermissionSets: []string{"arn:aws:sso:::permissionSet/ps-1"}}, nil
+		},
+		describePSFn: func(_ context.Context, _ *ssoadmin.DescribePermissionSetInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.DescribePermissionSetOutput, error) {
+			return &ssoadmin.DescribePermissionSetOutput{PermissionSet: &ssoadmintypes.PermissionSet{Name: cliutil.Ptr("ReadOnlyAccess")}}, nil
+		},
+		createAssignmentFn: func(_ context.Context, in *ssoadmin.CreateAccountAssignmentInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.CreateAccountAssignmentOutput, error) {
+			targets = append(targets, cliutil.PointerToString(in.TargetId))
+			if cliutil.PointerToString(in.TargetId) == "111111111111" {
+				return nil, &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}
+			}
+			return &ssoadmin.CreateAccountAssignmentOutput{
+				AccountAssignmentCreationStatus: &ssoadmintypes.AccountAssignmentOperationStatus{RequestId: cliutil.Ptr("req-1")},
+			}, nil
+		},
+		describeCreationStatusFn: func(_ context.Context, _ *ssoadmin.DescribeAccountAssignmentCreationStatusInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.DescribeAccountAssignmentCreationStatusOutput, error) {
+			return &ssoadmin.DescribeAccountAssignmentCreationStatusOutput{
+				AccountAssignmentCreationStatus: &ssoadmintypes.AccountAssignmentOperationStatus{Status: ssoadmintypes.StatusValuesSucceeded},
+			}, nil
+		},
+	}
+	identityClient := &mockIdentityStoreClient{
+		listGroupsFn: func(_ context.Context, _ *identitystore.ListGroupsInput, _ ...func(*identitystore.Options)) (*identitystore.ListGroupsOutput, error) {
+			return &identitystore.ListGroupsOutput{Groups: []identitystoretypes.Group{{GroupId: cliutil.Ptr("group-1")}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return ssoClient },
+		func(awssdk.Config) IdentityStoreAPI { return identityClient },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	baseArgs := []string{"--output", "text", "--no-confirm", "org", "assign-sso-access", "--principal-name", "Auditors", "--permission-set-name", "ReadOnlyAccess"}
+	output, err := executeCommand(t, append(baseArgs, "--all-accounts")...)
+	if err != nil {
+		t.Fatalf("execute assign-sso-access --all-accounts: %v", err)
+	}
+	expected := strings.Join([]string{
+		"account_id=111111111111 principal_type=GROUP principal_name=Auditors permission_set=ReadOnlyAccess action=failed:denied (AccessDeniedException)",
+		"account_id=222222222222 principal_type=GROUP principal_name=Auditors permission_set=ReadOnlyAccess action=assigned",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected --all-accounts output:\n%s", output)
+	}
+
+	targets = targets[:0]
+	output, err = executeCommand(t, append(baseArgs, "--account-ids", "444444444444,222222222222,444444444444")...)
+	if err != nil {
+		t.Fatalf("execute assign-sso-access --account-ids: %v", err)
+	}
+	if strings.Join(targets, ",") != "222222222222,444444444444" || strings.Count(output, "action=assigned") != 2 {
+		t.Fatalf("unexpected --account-ids run: targets %v output %s", targets, output)
+	}
+
+	if _, err := executeCommand(t, append(baseArgs, "--account-ids", "1234")...); err == nil || !strings.Contains(err.Error(), "12-digit") {
+		t.Fatalf("expected account id validation error, got %v", err)
+	}
+	if _, err := executeCommand(t, baseArgs...); err == nil || !strings.Contains(err.Error(), "one of --ou-name, --account-ids or --all-accounts is required") {
+		t.Fatalf("expected missing selector error, got %v", err)
+	}
+	if _, err := executeCommand(t, append(baseArgs, "--ou-name", "Sandbox", "--all-accounts")...); err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Fatalf("expected mutually exclusive selector error, got %v", err)
+	}
+}
+
 func TestOrgRemoveSSOAccessDryRun(t *testing.T) {
 	deleteCalls := 0
 	orgClient := &mockOrganizationsClient{
@@ -721,6 +1148,88 @@ func TestOrgGetAccountRejectsInvalidAccountID(t *testing.T) {
 	}
 }
 
+func TestOrgCloseAccount(t *testing.T) {
+	status := organizationtypes.AccountStatusActive
+	closed := 0
+	var closeErr error
+	orgClient := &mockOrganizationsClient{
+		describeAccountFn: func(_ context.Context, in *organizations.DescribeAccountInput, _ ...func(*organizations.Options)) (*organizations.DescribeAccountOutput, error) {
+			return &organizations.DescribeAccountOutput{Account: &organizationtypes.Account{
+				Id: in.AccountId, Name: cliutil.Ptr("sandbox"), Email: cliutil.Ptr("sandbox@example.com"), Status: status,
+			}}, nil
+		},
+		closeAccountFn: func(_ context.Context, in *organizations.CloseAccountInput, _ ...func(*organizations.Options)) (*organizations.CloseAccountOutput, error) {
+			if cliutil.PointerToString(in.AccountId) != "123456789012" {
+				t.Fatalf("unexpected account id: %s", cliutil.PointerToString(in.AccountId))
+			}
+			closed++
+			if closeErr != nil {
+				return nil, closeErr
+			}
+			status = organizationtypes.AccountStatusPendingClosure
+			return &organizations.CloseAccountOutput{}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	if _, err := executeCommand(t, "org", "close-account", "--account-id", "1234"); err == nil || !strings.Contains(err.Error(), "12-digit") {
+		t.Fatalf("expected account-id validation error, got %v", err)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "org", "close-account", "--account-id", "123456789012")
+	if err != nil {
+		t.Fatalf("execute close-account --dry-run: %v", err)
+	}
+	if strings.TrimSpace(output) != "account_id=123456789012 account_name=sandbox email=sandbox@example.com status=ACTIVE action=would-close" || closed != 0 {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommandWithInput(t, "n\n", "--output", "text", "org", "close-account", "--account-id", "123456789012")
+	if err != nil {
+		t.Fatalf("execute close-account with prompt: %v", err)
+	}
+	if !strings.Contains(output, "action=cancelled") || closed != 0 {
+		t.Fatalf("expected cancelled close: %s", output)
+	}
+
+	closeErr = &organizationtypes.ConstraintViolationException{
+		Message: cliutil.Ptr("quota exceeded"),
+		Reason:  organizationtypes.ConstraintViolationExceptionReasonCloseAccountQuotaExceeded,
+	}
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "close-account", "--account-id", "123456789012")
+	if err != nil {
+		t.Fatalf("execute close-account over quota: %v", err)
+	}
+	if !strings.Contains(output, "status=ACTIVE action=failed:closure quota exceeded: AWS allows closing 10% of member accounts per 30 days") {
+		t.Fatalf("expected quota failure: %s", output)
+	}
+
+	closeErr = nil
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "close-account", "--account-id", "123456789012")
+	if err != nil {
+		t.Fatalf("execute close-account: %v", err)
+	}
+	if !strings.Contains(output, "status=PENDING_CLOSURE action=closed") {
+		t.Fatalf("expected closed account with refreshed status: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "close-account", "--account-id", "123456789012")
+	if err != nil {
+		t.Fatalf("execute close-account again: %v", err)
+	}
+	if !strings.Contains(output, "action=skipped:already-pending-closure") || closed != 2 {
+		t.Fatalf("expected already-closing skip: %s (close calls %d)", output, closed)
+	}
+}
+
 func TestOrgListSSOAssignments(t *testing.T) {
 	orgClient := &mockOrganizationsClient{
 		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
@@ -767,3 +1276,87 @@ func TestOrgListSSOAssignments(t *testing.T) {
 		t.Fatalf("unexpected output: %s", output)
 	}
 }
+
+func TestOrgListSSOAssignmentsResolvesNamesOnce(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("prod")},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("sandbox")},
+			}}, nil
+		},
+	}
+	describePSCalls := 0
+	ssoClient := &mockSSOAdminClient{
+		listInstancesFn: func(_ context.Context, _ *ssoadmin.ListInstancesInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.ListInstancesOutput, error) {
+			return &ssoadmin.ListInstancesOutput{
+				Instances: []ssoadmintypes.InstanceMetadata{{InstanceArn: cliutil.Ptr("arn:aws:sso:::instance/ssoins-123"), IdentityStoreId: cliutil.Ptr("d-123")}},
+			}, nil
+		},
+		listPSFn: func(_ context.Context, _ *ssoadmin.ListPermissionSetsInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.ListPermissionSetsOutput, error) {
+			return &ssoadmin.ListPermissionSetsOutput{PermissionSets: []string{"arn:aws:sso:::permissionSet/ps-1"}}, nil
+		},
+		describePSFn: func(_ context.Context, _ *ssoadmin.DescribePermissionSetInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.DescribePermissionSetOutput, error) {
+			describePSCalls++
+			return &ssoadmin.DescribePermissionSetOutput{PermissionSet: &ssoadmintypes.PermissionSet{Name: cliutil.Ptr("AdministratorAccess")}}, nil
+		},
+		listAssignmentsFn: func(_ context.Context, in *ssoadmin.ListAccountAssignmentsInput, _ ...func(*ssoadmin.Options)) (*ssoadmin.ListAccountAssignmentsOutput, error) {
+			assignments := []ssoadmintypes.AccountAssignment{{PrincipalType: ssoadmintypes.PrincipalTypeGroup, PrincipalId: cliutil.Ptr("group-1")}}
+			if cliutil.PointerToString(in.AccountId) == "222222222222" {
+				assignments = append(assignments, ssoadmintypes.AccountAssignment{PrincipalType: ssoadmintypes.PrincipalTypeUser, PrincipalId: cliutil.Ptr("user-1")})
+			}
+			return &ssoadmin.ListAccountAssignmentsOutput{AccountAssignments: assignments}, nil
+		},
+	}
+	describeGroupCalls := 0
+	identityClient := &mockIdentityStoreClient{
+		describeGroupFn: func(_ context.Context, in *identitystore.DescribeGroupInput, _ ...func(*identitystore.Options)) (*identitystore.DescribeGroupOutput, error) {
+			describeGroupCalls++
+			if cliutil.PointerToString(in.IdentityStoreId) != "d-123" {
+				t.Fatalf("unexpected identity store id: %s", cliutil.PointerToString(in.IdentityStoreId))
+			}
+			return &identitystore.DescribeGroupOutput{GroupId: in.GroupId, DisplayName: cliutil.Ptr("Administrators")}, nil
+		},
+		describeUserFn: func(_ context.Context, _ *identitystore.DescribeUserInput, _ ...func(*identitystore.Options)) (*identitystore.DescribeUserOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException", Message: "user not found"}
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return ssoClient },
+		func(awssdk.Config) IdentityStoreAPI { return identityClient },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "list-sso-assignments")
+	if err != nil {
+		t.Fatalf("execute list-sso-assignments: %v", err)
+	}
+	for _, expected := range []string{
+		"account_id=111111111111 account_name=prod principal_type=GROUP principal_id=group-1 permission_set_arn=arn:aws:sso:::permissionSet/ps-1 principal_name=Administrators permission_set_name=AdministratorAccess",
+		"account_id=222222222222 account_name=sandbox principal_type=GROUP principal_id=group-1 permission_set_arn=arn:aws:sso:::permissionSet/ps-1 principal_name=Administrators",
+		"account_id=222222222222 account_name=sandbox principal_type=USER principal_id=user-1 permission_set_arn=arn:aws:sso:::permissionSet/ps-1 principal_name= permission_set_name=AdministratorAccess",
+		"warning: resolve user user-1: user not found (ResourceNotFoundException)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Count(output, "warning: resolve user user-1") != 1 {
+		t.Fatalf("expected one warning for the failed principal lookup: %s", output)
+	}
+	if describeGroupCalls != 1 || describePSCalls != 1 {
+		t.Fatalf("expected cached lookups, got %d DescribeGroup and %d DescribePermissionSet calls", describeGroupCalls, describePSCalls)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "org", "list-sso-assignments", "--resolve-names=false")
+	if err != nil {
+		t.Fatalf("execute list-sso-assignments --resolve-names=false: %v", err)
+	}
+	if strings.Contains(output, "principal_name") || describeGroupCalls != 1 {
+		t.Fatalf("expected no name lookups with --resolve-names=false: %s", output)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssoadmin"
 	ssoadmintypes "github.com/aws/aws-sdk-go-v2/service/ssoadmin/types"
+	"github.com/aws/smithy-go"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
@@ -275,8 +276,8 @@ func TestLoadContactsLegacyKeys(t *testing.T) {
 	if err != nil {
 		t.Fatalf("load contacts: %v", err)
 	}
-	if contacts[accounttypes.AlternateContactTypeSecurity].EmailAddress != "sec@example.com" {
-		t.Fatalf("unexpected security contact: %+v", contacts[accounttypes.AlternateContactTypeSecurity])
+	if contacts.defaults[accounttypes.AlternateContactTypeSecurity].EmailAddress != "sec@example.com" {
+		t.Fatalf("unexpected security contact: %+v", contacts.defaults[accounttypes.AlternateContactTypeSecurity])
 	}
 }
 
@@ -318,3 +319,503 @@ func TestFormatTime(t *testing.T) {
 		t.Fatalf("unexpected format: %q", got)
 	}
 }
+
+func TestOrgAuditRootUsageFlagsMissingSecurityContact(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		describeOrgFn: func(_ context.Context, _ *organizations.DescribeOrganizationInput, _ ...func(*organizations.Options)) (*organizations.DescribeOrganizationOutput, error) {
+			return &organizations.DescribeOrganizationOutput{Organization: &organizationtypes.Organization{MasterAccountId: cliutil.Ptr("111111111111")}}, nil
+		},
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("workload")},
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("management")},
+				{Id: cliutil.Ptr("333333333333"), Name: cliutil.Ptr("restricted")},
+			}}, nil
+		},
+	}
+	accountClient := &mockAccountClient{
+		getAlternateContactFn: func(_ context.Context, in *account.GetAlternateContactInput, _ ...func(*account.Options)) (*account.GetAlternateContactOutput, error) {
+			switch cliutil.PointerToString(in.AccountId) {
+			case "":
+				return &account.GetAlternateContactOutput{AlternateContact: &accounttypes.AlternateContact{EmailAddress: cliutil.Ptr("sec@example.com")}}, nil
+			case "222222222222":
+				return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException", Message: "no contact"}
+			case "333333333333":
+				return nil, &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "denied"}
+			}
+			t.Fatalf("unexpected account id %q", cliutil.PointerToString(in.AccountId))
+			return nil, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return accountClient },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "audit-root-usage")
+	if err != nil {
+		t.Fatalf("execute audit-root-usage: %v", err)
+	}
+	for _, expected := range []string{
+		"account_id=111111111111 account_name=management management_account=true security_contact=present finding=ok",
+		"account_id=222222222222 account_name=workload management_account=false security_contact=missing finding=missing-security-contact",
+		"account_id=333333333333 account_name=restricted management_account=false security_contact=unknown finding=unknown",
+		"warning: get security contact for account 333333333333: denied (AccessDeniedException)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestOrgSecurityBaselineReportChecks(t *testing.T) {
+	orgClient := &mockOrganizationsClient{
+		describeOrgFn: func(_ context.Context, _ *organizations.DescribeOrganizationInput, _ ...func(*organizations.Options)) (*organizations.DescribeOrganizationOutput, error) {
+			return &organizations.DescribeOrganizationOutput{Organization: &organizationtypes.Organization{
+				Id:              cliutil.Ptr("o-abc123"),
+				FeatureSet:      organizationtypes.OrganizationFeatureSetAll,
+				MasterAccountId: cliutil.Ptr("111111111111"),
+			}}, nil
+		},
+		listDelegatedFn: func(_ context.Context, _ *organizations.ListDelegatedAdministratorsInput, _ ...func(*organizations.Options)) (*organizations.ListDelegatedAdministratorsOutput, error) {
+			return &organizations.ListDelegatedAdministratorsOutput{DelegatedAdministrators: []organizationtypes.DelegatedAdministrator{{Id: cliutil.Ptr("222222222222")}}}, nil
+		},
+		listServicesFn: func(_ context.Context, in *organizations.ListDelegatedServicesForAccountInput, _ ...func(*organizations.Options)) (*organizations.ListDelegatedServicesForAccountOutput, error) {
+			if cliutil.PointerToString(in.AccountId) != "222222222222" {
+				t.Fatalf("unexpected account id %q", cliutil.PointerToString(in.AccountId))
+			}
+			return &organizations.ListDelegatedServicesForAccountOutput{DelegatedServices: []organizationtypes.DelegatedService{
+				{ServicePrincipal: cliutil.Ptr("guardduty.amazonaws.com")},
+				{ServicePrincipal: cliutil.Ptr("securityhub.amazonaws.com")},
+			}}, nil
+		},
+		listRootsFn: func(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+			return &organizations.ListRootsOutput{Roots: []organizationtypes.Root{{
+				Id: cliutil.Ptr("r-root"),
+				PolicyTypes: []organizationtypes.PolicyTypeSummary{
+					{Type: organizationtypes.PolicyTypeServiceControlPolicy, Status: organizationtypes.PolicyTypeStatusEnabled},
+				},
+			}}}, nil
+		},
+		listPoliciesFn: func(_ context.Context, in *organizations.ListPoliciesInput, _ ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error) {
+			if in.Filter != organizationtypes.PolicyTypeServiceControlPolicy {
+				t.Fatalf("unexpected policy filter %q", in.Filter)
+			}
+			return &organizations.ListPoliciesOutput{Policies: []organizationtypes.PolicySummary{
+				{Name: cliutil.Ptr("FullAWSAccess"), AwsManaged: true},
+				{Name: cliutil.Ptr("DenyLeaveOrg")},
+			}}, nil
+		},
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("222222222222")},
+				{Id: cliutil.Ptr("111111111111")},
+			}}, nil
+		},
+	}
+	accountClient := &mockAccountClient{
+		getAlternateContactFn: func(_ context.Context, in *account.GetAlternateContactInput, _ ...func(*account.Options)) (*account.GetAlternateContactOutput, error) {
+			if cliutil.PointerToString(in.AccountId) == "" {
+				return &account.GetAlternateContactOutput{AlternateContact: &accounttypes.AlternateContact{EmailAddress: cliutil.Ptr("sec@example.com")}}, nil
+			}
+			return nil, &smithy.GenericAPIError{Code: "ResourceNotFoundException", Message: "no contact"}
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return accountClient },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "security-baseline-report")
+	if err != nil {
+		t.Fatalf("execute security-baseline-report: %v", err)
+	}
+	for _, expected := range []string{
+		"scope=organization subject=o-abc123 check=feature-set value=ALL result=pass",
+		"scope=service subject=guardduty.amazonaws.com check=delegated-admin value=222222222222 result=pass",
+		"scope=service subject=inspector2.amazonaws.com check=delegated-admin value=none result=fail",
+		"scope=policy subject=service-control-policy check=enforcement value=enabled (1 customer-managed) result=pass",
+		"scope=policy subject=tag-policy check=enforcement value=disabled result=fail",
+		"scope=account subject=111111111111 check=security-contact value=present result=pass",
+		"scope=account subject=222222222222 check=security-contact value=missing result=fail",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestOrgTagComplianceReportsCompliantAndViolatingAccounts(t *testing.T) {
+	effective := `{"tags":{"costcenter":{"tag_key":"CostCenter","tag_value":["100","200*"]},"owner":{"tag_key":"Owner"}}}`
+	tagsByAccount := map[string][]organizationtypes.Tag{
+		"111111111111": {
+			{Key: cliutil.Ptr("CostCenter"), Value: cliutil.Ptr("200-eu")},
+			{Key: cliutil.Ptr("Owner"), Value: cliutil.Ptr("platform")},
+		},
+		"222222222222": {
+			{Key: cliutil.Ptr("CostCenter"), Value: cliutil.Ptr("999")},
+			{Key: cliutil.Ptr("owner"), Value: cliutil.Ptr("data")},
+		},
+	}
+	orgClient := &mockOrganizationsClient{
+		listAccountsFn: func(_ context.Context, _ *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+			return &organizations.ListAccountsOutput{Accounts: []organizationtypes.Account{
+				{Id: cliutil.Ptr("333333333333"), Name: cliutil.Ptr("sandbox")},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("data")},
+				{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("platform")},
+			}}, nil
+		},
+		describeEffectiveFn: func(_ context.Context, in *organizations.DescribeEffectivePolicyInput, _ ...func(*organizations.Options)) (*organizations.DescribeEffectivePolicyOutput, error) {
+			if in.PolicyType != organizationtypes.EffectivePolicyTypeTagPolicy {
+				t.Fatalf("unexpected policy type %s", in.PolicyType)
+			}
+			if cliutil.PointerToString(in.TargetId) == "333333333333" {
+				return nil, &organizationtypes.EffectivePolicyNotFoundException{Message: cliutil.Ptr("no effective policy")}
+			}
+			return &organizations.DescribeEffectivePolicyOutput{EffectivePolicy: &organizationtypes.EffectivePolicy{PolicyContent: cliutil.Ptr(effective)}}, nil
+		},
+		listTagsFn: func(_ context.Context, in *organizations.ListTagsForResourceInput, _ ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error) {
+			return &organizations.ListTagsForResourceOutput{Tags: tagsByAccount[cliutil.PointerToString(in.ResourceId)]}, nil
+		},
+		describePolicyFn: func(_ context.Context, _ *organizations.DescribePolicyInput, _ ...func(*organizations.Options)) (*organizations.DescribePolicyOutput, error) {
+			return &organizations.DescribePolicyOutput{Policy: &organizationtypes.Policy{
+				PolicySummary: &organizationtypes.PolicySummary{Type: organizationtypes.PolicyTypeTagPolicy},
+				Content:       cliutil.Ptr(`{"tags":{"env":{"tag_key":{"@@assign":"Environment"},"tag_value":{"@@assign":["prod"]}}}}`),
+			}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "org", "tag-compliance")
+	if err != nil {
+		t.Fatalf("execute tag-compliance: %v", err)
+	}
+	for _, expected := range []string{
+		"account_id=111111111111 account_name=platform status=compliant violations=\n",
+		"account_id=222222222222 account_name=data status=non-compliant violations=CostCenter=999: value not allowed; owner: key must be capitalized as Owner",
+		"account_id=333333333333 account_name=sandbox status=no-policy",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+
+	output, err = executeCommand(t, "--output", "text", "org", "tag-compliance", "--policy-id", "p-tag")
+	if err != nil {
+		t.Fatalf("execute tag-compliance --policy-id: %v", err)
+	}
+	if strings.Count(output, "violations=Environment: missing") != 3 {
+		t.Fatalf("expected every account to miss Environment: %s", output)
+	}
+}
+
+// ouTreeClient mocks an organization with root r-1 holding OU Workloads
+// (ou-work), which holds OU Prod (ou-prod) and account 111111111111.
+func ouTreeClient() *mockOrganizationsClient {
+	return &mockOrganizationsClient{
+		listRootsFn: func(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+			return &organizations.ListRootsOutput{Roots: []organizationtypes.Root{{Id: cliutil.Ptr("r-1")}}}, nil
+		},
+		listOUsFn: func(_ context.Context, in *organizations.ListOrganizationalUnitsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+			switch cliutil.PointerToString(in.ParentId) {
+			case "r-1":
+				return &organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: []organizationtypes.OrganizationalUnit{{Id: cliutil.Ptr("ou-work"), Name: cliutil.Ptr("Workloads")}}}, nil
+			case "ou-work":
+				return &organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: []organizationtypes.OrganizationalUnit{{Id: cliutil.Ptr("ou-prod"), Name: cliutil.Ptr("Prod")}}}, nil
+			}
+			return &organizations.ListOrganizationalUnitsForParentOutput{}, nil
+		},
+		listForParentFn: func(_ context.Context, in *organizations.ListAccountsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error) {
+			if cliutil.PointerToString(in.ParentId) == "ou-work" {
+				return &organizations.ListAccountsForParentOutput{Accounts: []organizationtypes.Account{{Id: cliutil.Ptr("111111111111"), Name: cliutil.Ptr("app")}}}, nil
+			}
+			return &organizations.ListAccountsForParentOutput{}, nil
+		},
+	}
+}
+
+func withOUTreeClient(t *testing.T, orgClient *mockOrganizationsClient) {
+	t.Helper()
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) OrganizationsAPI { return orgClient },
+		func(awssdk.Config) SSOAdminAPI { return &mockSSOAdminClient{} },
+		func(awssdk.Config) IdentityStoreAPI { return &mockIdentityStoreClient{} },
+		func(awssdk.Config) AccountAPI { return &mockAccountClient{} },
+	)
+}
+
+func TestCreateOUUnderNamedParent(t *testing.T) {
+	orgClient := ouTreeClient()
+	var created *organizations.CreateOrganizationalUnitInput
+	orgClient.createOUFn = func(_ context.Context, in *organizations.CreateOrganizationalUnitInput, _ ...func(*organizations.Options)) (*organizations.CreateOrganizationalUnitOutput, error) {
+		created = in
+		return &organizations.CreateOrganizationalUnitOutput{OrganizationalUnit: &organizationtypes.OrganizationalUnit{Id: cliutil.Ptr("ou-new")}}, nil
+	}
+	withOUTreeClient(t, orgClient)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "org", "create-ou", "--name", "Staging", "--parent", "workloads")
+	if err != nil {
+		t.Fatalf("execute create-ou --dry-run: %v", err)
+	}
+	if created != nil || !strings.Contains(output, "ou_name=Staging parent=workloads parent_id=ou-work ou_id= action=would-create") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "create-ou", "--name", "Staging", "--parent", "Workloads")
+	if err != nil {
+		t.Fatalf("execute create-ou: %v", err)
+	}
+	if created == nil || cliutil.PointerToString(created.ParentId) != "ou-work" || cliutil.PointerToString(created.Name) != "Staging" {
+		t.Fatalf("unexpected create input: %#v", created)
+	}
+	if !strings.Contains(output, "ou_id=ou-new action=created") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+
+	if _, err := executeCommand(t, "org", "create-ou", "--name", "prod", "--parent", "Workloads"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate OU error, got %v", err)
+	}
+}
+
+func TestDeleteOURefusesNonEmptyOU(t *testing.T) {
+	orgClient := ouTreeClient()
+	deleted := make([]string, 0)
+	orgClient.deleteOUFn = func(_ context.Context, in *organizations.DeleteOrganizationalUnitInput, _ ...func(*organizations.Options)) (*organizations.DeleteOrganizationalUnitOutput, error) {
+		deleted = append(deleted, cliutil.PointerToString(in.OrganizationalUnitId))
+		return &organizations.DeleteOrganizationalUnitOutput{}, nil
+	}
+	withOUTreeClient(t, orgClient)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "org", "delete-ou", "--name", "Workloads")
+	if err != nil {
+		t.Fatalf("execute delete-ou: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected non-empty OU to be kept, deleted %v", deleted)
+	}
+	if !strings.Contains(output, "blocked_by=account:111111111111 (app); ou:ou-prod (Prod) action=skipped:not-empty") {
+		t.Fatalf("expected blockers in output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "delete-ou", "--name", "Prod")
+	if err != nil {
+		t.Fatalf("execute delete-ou empty: %v", err)
+	}
+	if strings.Join(deleted, ",") != "ou-prod" || !strings.Contains(output, "ou_name=Prod ou_id=ou-prod blocked_by= action=deleted") {
+		t.Fatalf("expected empty OU deleted, got %v: %s", deleted, output)
+	}
+}
+
+func TestOrgAttachAndDetachSCP(t *testing.T) {
+	orgClient := ouTreeClient()
+	orgClient.listPoliciesFn = func(_ context.Context, in *organizations.ListPoliciesInput, _ ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error) {
+		if in.Filter != organizationtypes.PolicyTypeServiceControlPolicy {
+			t.Fatalf("unexpected policy filter: %s", in.Filter)
+		}
+		return &organizations.ListPoliciesOutput{Policies: []organizationtypes.PolicySummary{
+			{Id: cliutil.Ptr("p-region"), Name: cliutil.Ptr("RegionLock"), Description: cliutil.Ptr("Allowed regions only")},
+			{Id: cliutil.Ptr("p-full"), Name: cliutil.Ptr("FullAWSAccess"), AwsManaged: true},
+		}}, nil
+	}
+	attached := make([]string, 0)
+	orgClient.attachPolicyFn = func(_ context.Context, in *organizations.AttachPolicyInput, _ ...func(*organizations.Options)) (*organizations.AttachPolicyOutput, error) {
+		if cliutil.PointerToString(in.TargetId) == "r-1" {
+			return nil, &organizationtypes.DuplicatePolicyAttachmentException{Message: cliutil.Ptr("already attached")}
+		}
+		attached = append(attached, cliutil.PointerToString(in.PolicyId)+"@"+cliutil.PointerToString(in.TargetId))
+		return &organizations.AttachPolicyOutput{}, nil
+	}
+	detached := make([]string, 0)
+	orgClient.detachPolicyFn = func(_ context.Context, in *organizations.DetachPolicyInput, _ ...func(*organizations.Options)) (*organizations.DetachPolicyOutput, error) {
+		detached = append(detached, cliutil.PointerToString(in.PolicyId)+"@"+cliutil.PointerToString(in.TargetId))
+		return &organizations.DetachPolicyOutput{}, nil
+	}
+	withOUTreeClient(t, orgClient)
+
+	output, err := executeCommand(t, "--output", "text", "org", "list-scps")
+	if err != nil {
+		t.Fatalf("execute list-scps: %v", err)
+	}
+	if !strings.Contains(output, "policy_id=p-full policy_name=FullAWSAccess aws_managed=true") || strings.Index(output, "FullAWSAccess") > strings.Index(output, "RegionLock") {
+		t.Fatalf("unexpected list-scps output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--dry-run", "org", "attach-scp", "--policy-name", "RegionLock", "--target", "prod")
+	if err != nil {
+		t.Fatalf("execute attach-scp --dry-run: %v", err)
+	}
+	if len(attached) != 0 || !strings.Contains(output, "target_type=ou target=prod target_id=ou-prod action=would-attach") {
+		t.Fatalf("unexpected dry-run output (attached %v): %s", attached, output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "attach-scp", "--policy-name", "RegionLock", "--target", "111111111111")
+	if err != nil {
+		t.Fatalf("execute attach-scp: %v", err)
+	}
+	if strings.Join(attached, ",") != "p-region@111111111111" || !strings.Contains(output, "target_type=account target=111111111111 target_id=111111111111 action=attached") {
+		t.Fatalf("unexpected attach (attached %v): %s", attached, output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "attach-scp", "--policy-name", "RegionLock", "--target", "root")
+	if err != nil {
+		t.Fatalf("execute attach-scp root: %v", err)
+	}
+	if !strings.Contains(output, "target_id=r-1 action=skipped:already-attached") {
+		t.Fatalf("expected duplicate attachment to be skipped: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "detach-scp", "--policy-name", "RegionLock", "--target", "Workloads")
+	if err != nil {
+		t.Fatalf("execute detach-scp: %v", err)
+	}
+	if strings.Join(detached, ",") != "p-region@ou-work" || !strings.Contains(output, "action=detached") {
+		t.Fatalf("unexpected detach (detached %v): %s", detached, output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "detach-scp", "--policy-name", "RegionLock", "--target", "ou-prod")
+	if err != nil {
+		t.Fatalf("execute detach-scp by OU ID: %v", err)
+	}
+	if strings.Join(detached, ",") != "p-region@ou-work,p-region@ou-prod" || !strings.Contains(output, "target_type=ou target=ou-prod target_id=ou-prod") {
+		t.Fatalf("unexpected detach by OU ID (detached %v): %s", detached, output)
+	}
+
+	if _, err := executeCommand(t, "org", "attach-scp", "--policy-name", "Missing", "--target", "root"); err == nil || !strings.Contains(err.Error(), "service control policy not found: Missing") {
+		t.Fatalf("expected missing policy error, got %v", err)
+	}
+
+	listOUs := orgClient.listOUsFn
+	orgClient.listOUsFn = func(ctx context.Context, in *organizations.ListOrganizationalUnitsForParentInput, optFns ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+		if cliutil.PointerToString(in.ParentId) == "r-1" {
+			return &organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: []organizationtypes.OrganizationalUnit{
+				{Id: cliutil.Ptr("ou-work"), Name: cliutil.Ptr("Workloads")},
+				{Id: cliutil.Ptr("ou-prod-top"), Name: cliutil.Ptr("Prod")},
+			}}, nil
+		}
+		return listOUs(ctx, in, optFns...)
+	}
+	if _, err := executeCommand(t, "--no-confirm", "org", "attach-scp", "--policy-name", "RegionLock", "--target", "Prod"); err == nil ||
+		!strings.Contains(err.Error(), `"Prod" is ambiguous: /Prod (ou-prod-top), /Workloads/Prod (ou-prod)`) {
+		t.Fatalf("expected ambiguous OU error, got %v", err)
+	}
+}
+
+func TestOrgMoveAccount(t *testing.T) {
+	orgClient := ouTreeClient()
+	orgClient.listParentsFn = func(_ context.Context, _ *organizations.ListParentsInput, _ ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+		return &organizations.ListParentsOutput{Parents: []organizationtypes.Parent{{Id: cliutil.Ptr("ou-work"), Type: organizationtypes.ParentTypeOrganizationalUnit}}}, nil
+	}
+	var moved *organizations.MoveAccountInput
+	orgClient.moveAccountFn = func(_ context.Context, in *organizations.MoveAccountInput, _ ...func(*organizations.Options)) (*organizations.MoveAccountOutput, error) {
+		moved = in
+		return &organizations.MoveAccountOutput{}, nil
+	}
+	withOUTreeClient(t, orgClient)
+
+	if _, err := executeCommand(t, "org", "move-account", "--account-id", "12345", "--dest-ou-name", "Prod"); err == nil || !strings.Contains(err.Error(), "12-digit") {
+		t.Fatalf("expected account ID validation error, got %v", err)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "org", "move-account", "--account-id", "111111111111", "--dest-ou-name", "prod")
+	if err != nil {
+		t.Fatalf("execute move-account --dry-run: %v", err)
+	}
+	if moved != nil || !strings.Contains(output, "source_id=ou-work source_path=/Workloads dest_id=ou-prod dest_path=/Workloads/Prod action=would-move") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "move-account", "--account-id", "111111111111", "--dest-ou-name", "Prod")
+	if err != nil {
+		t.Fatalf("execute move-account: %v", err)
+	}
+	if moved == nil || cliutil.PointerToString(moved.SourceParentId) != "ou-work" || cliutil.PointerToString(moved.DestinationParentId) != "ou-prod" || !strings.Contains(output, "action=moved") {
+		t.Fatalf("unexpected move (input %#v): %s", moved, output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "org", "move-account", "--account-id", "111111111111", "--dest-ou-name", "Workloads")
+	if err != nil {
+		t.Fatalf("execute move-account to current OU: %v", err)
+	}
+	if !strings.Contains(output, "action=skipped:already-in-destination") {
+		t.Fatalf("expected move to current OU to be skipped: %s", output)
+	}
+
+	listOUs := orgClient.listOUsFn
+	orgClient.listOUsFn = func(ctx context.Context, in *organizations.ListOrganizationalUnitsForParentInput, optFns ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+		if cliutil.PointerToString(in.ParentId) == "r-1" {
+			return &organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: []organizationtypes.OrganizationalUnit{
+				{Id: cliutil.Ptr("ou-work"), Name: cliutil.Ptr("Workloads")},
+				{Id: cliutil.Ptr("ou-prod-top"), Name: cliutil.Ptr("Prod")},
+			}}, nil
+		}
+		return listOUs(ctx, in, optFns...)
+	}
+	if _, err := executeCommand(t, "org", "move-account", "--account-id", "111111111111", "--dest-ou-name", "Prod"); err == nil ||
+		!strings.Contains(err.Error(), `"Prod" is ambiguous: /Prod (ou-prod-top), /Workloads/Prod (ou-prod)`) {
+		t.Fatalf("expected ambiguous OU error, got %v", err)
+	}
+}
+
+func TestOrgListDelegatedAdmins(t *testing.T) {
+	var requestedPrincipal string
+	orgClient := &mockOrganizationsClient{
+		listDelegatedFn: func(_ context.Context, in *organizations.ListDelegatedAdministratorsInput, _ ...func(*organizations.Options)) (*organizations.ListDelegatedAdministratorsOutput, error) {
+			requestedPrincipal = cliutil.PointerToString(in.ServicePrincipal)
+			return &organizations.ListDelegatedAdministratorsOutput{DelegatedAdministrators: []organizationtypes.DelegatedAdministrator{
+				{Id: cliutil.Ptr("333333333333"), Name: cliutil.Ptr("audit"), Email: cliutil.Ptr("audit@example.com")},
+				{Id: cliutil.Ptr("222222222222"), Name: cliutil.Ptr("security"), Email: cliutil.Ptr("security@example.com")},
+			}}, nil
+		},
+		listServicesFn: func(_ context.Context, in *organizations.ListDelegatedServicesForAccountInput, _ ...func(*organizations.Options)) (*organizations.ListDelegatedServicesForAccountOutput, error) {
+			if cliutil.PointerToString(in.AccountId) == "333333333333" {
+				return &organizations.ListDelegatedServicesForAccountOutput{DelegatedServices: []organizationtypes.DelegatedService{
+					{ServicePrincipal: cliutil.Ptr("config.amazonaws.com")},
+				}}, nil
+			}
+			return &organizations.ListDelegatedServicesForAccountOutput{DelegatedServices: []organizationtypes.DelegatedService{
+				{ServicePrincipal: cliutil.Ptr("securityhub.amazonaws.com")},
+				{ServicePrincipal: cliutil.Ptr("guardduty.amazonaws.com")},
+			}}, nil
+		},
+	}
+	withOUTreeClient(t, orgClient)
+
+	output, err := executeCommand(t, "--output", "text", "org", "list-delegated-admins")
+	if err != nil {
+		t.Fatalf("execute list-delegated-admins: %v", err)
+	}
+	guardDuty := strings.Index(output, "account_id=222222222222 account_name=security email=security@example.com service_principal=guardduty.amazonaws.com")
+	securityHub := strings.Index(output, "account_id=222222222222 account_name=security email=security@example.com service_principal=securityhub.amazonaws.com")
+	config := strings.Index(output, "account_id=333333333333 account_name=audit email=audit@example.com service_principal=config.amazonaws.com")
+	if guardDuty < 0 || securityHub < guardDuty || config < securityHub || requestedPrincipal != "" {
+		t.Fatalf("unexpected list-delegated-admins output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "org", "list-delegated-admins", "--service-principal", "guardduty.amazonaws.com")
+	if err != nil {
+		t.Fatalf("execute list-delegated-admins --service-principal: %v", err)
+	}
+	if requestedPrincipal != "guardduty.amazonaws.com" || strings.Contains(output, "securityhub") || strings.Contains(output, "config.amazonaws.com") || !strings.Contains(output, "service_principal=guardduty.amazonaws.com") {
+		t.Fatalf("unexpected filtered output: %s", output)
+	}
+}
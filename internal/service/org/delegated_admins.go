@@ -0,0 +1,94 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runListDelegatedAdmins lists one row per delegated administrator account
+// and service principal. servicePrincipal narrows ListDelegatedAdministrators
+// to a single service, such as guardduty.amazonaws.com.
+func runListDelegatedAdmins(cmd *cobra.Command, servicePrincipal string) error {
+	servicePrincipal = strings.TrimSpace(servicePrincipal)
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	admins, err := listDelegatedAdministrators(ctx, orgClient, servicePrincipal)
+	if err != nil {
+		return fmt.Errorf("list delegated administrators: %w", awstbxaws.WrapUserError(err))
+	}
+
+	rows := make([][]string, 0, len(admins))
+	for _, admin := range admins {
+		accountID := cliutil.PointerToString(admin.Id)
+		services, listErr := listDelegatedServicesForAccount(ctx, orgClient, accountID)
+		if listErr != nil {
+			return fmt.Errorf("list delegated services for account %s: %w", accountID, awstbxaws.WrapUserError(listErr))
+		}
+		for _, service := range services {
+			principal := cliutil.PointerToString(service.ServicePrincipal)
+			if servicePrincipal != "" && principal != servicePrincipal {
+				continue
+			}
+			rows = append(rows, []string{
+				accountID,
+				cliutil.PointerToString(admin.Name),
+				cliutil.PointerToString(admin.Email),
+				principal,
+				formatTime(service.DelegationEnabledDate),
+			})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][0] != rows[j][0] {
+			return rows[i][0] < rows[j][0]
+		}
+		return rows[i][3] < rows[j][3]
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "account_name", "email", "service_principal", "delegated_since"}, rows)
+}
+
+// listDelegatedAdministrators lists every delegated administrator, or only
+// those for servicePrincipal when it is set.
+func listDelegatedAdministrators(ctx context.Context, orgClient OrganizationsAPI, servicePrincipal string) ([]organizationtypes.DelegatedAdministrator, error) {
+	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[organizationtypes.DelegatedAdministrator], error) {
+		in := &organizations.ListDelegatedAdministratorsInput{NextToken: nextToken}
+		if servicePrincipal != "" {
+			in.ServicePrincipal = cliutil.Ptr(servicePrincipal)
+		}
+		out, err := orgClient.ListDelegatedAdministrators(callCtx, in)
+		if err != nil {
+			return awstbxaws.PageResult[organizationtypes.DelegatedAdministrator]{}, err
+		}
+		return awstbxaws.PageResult[organizationtypes.DelegatedAdministrator]{
+			Items:     out.DelegatedAdministrators,
+			NextToken: out.NextToken,
+		}, nil
+	})
+}
+
+func listDelegatedServicesForAccount(ctx context.Context, orgClient OrganizationsAPI, accountID string) ([]organizationtypes.DelegatedService, error) {
+	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[organizationtypes.DelegatedService], error) {
+		out, err := orgClient.ListDelegatedServicesForAccount(callCtx, &organizations.ListDelegatedServicesForAccountInput{AccountId: cliutil.Ptr(accountID), NextToken: nextToken})
+		if err != nil {
+			return awstbxaws.PageResult[organizationtypes.DelegatedService]{}, err
+		}
+		return awstbxaws.PageResult[organizationtypes.DelegatedService]{
+			Items:     out.DelegatedServices,
+			NextToken: out.NextToken,
+		}, nil
+	})
+}
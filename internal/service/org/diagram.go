@@ -0,0 +1,212 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const (
+	orgNodeTypeRoot    = "root"
+	orgNodeTypeOU      = "ou"
+	orgNodeTypeAccount = "account"
+)
+
+// orgTreeNode is one root, OU or account in the organization tree. Every
+// diagram format renders from the same tree, and the JSON format emits it
+// as is.
+type orgTreeNode struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	Children []*orgTreeNode `json:"children,omitempty"`
+}
+
+func runGenerateDiagram(cmd *cobra.Command, maxAccountsPerOU int, format string) error {
+	if maxAccountsPerOU < 1 {
+		return fmt.Errorf("--max-accounts-per-ou must be >= 1")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "mermaid" && format != "dot" && format != "json" {
+		return fmt.Errorf("--format must be one of: mermaid, dot, json")
+	}
+
+	_, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	rootID, rootName, err := getRoot(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
+	}
+
+	root := &orgTreeNode{ID: rootID, Name: rootName, Type: orgNodeTypeRoot}
+	if err := buildOrgTree(ctx, orgClient, root, maxAccountsPerOU); err != nil {
+		return fmt.Errorf("build diagram: %w", awstbxaws.WrapUserError(err))
+	}
+
+	var lines []string
+	switch format {
+	case "dot":
+		lines = renderOrgTreeDOT(root)
+	case "json":
+		data, marshalErr := json.MarshalIndent(root, "", "  ")
+		if marshalErr != nil {
+			return fmt.Errorf("encode diagram: %w", marshalErr)
+		}
+		lines = []string{string(data)}
+	default:
+		lines = renderOrgTreeMermaid(root)
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.Join(lines, "\n"))
+	return err
+}
+
+// buildOrgTree adds the active accounts and the OUs below parent, accounts
+// first and each group sorted by name, keeping at most maxAccountsPerOU
+// accounts per parent.
+func buildOrgTree(ctx context.Context, orgClient OrganizationsAPI, parent *orgTreeNode, maxAccountsPerOU int) error {
+	accounts, err := listAccountsForParent(ctx, orgClient, parent.ID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return cliutil.PointerToString(accounts[i].Name) < cliutil.PointerToString(accounts[j].Name)
+	})
+	active := make([]organizationtypes.Account, 0)
+	for _, acct := range accounts {
+		if acct.Status == organizationtypes.AccountStatusActive {
+			active = append(active, acct)
+		}
+	}
+	if len(active) > maxAccountsPerOU {
+		active = active[:maxAccountsPerOU]
+	}
+	for _, acct := range active {
+		parent.Children = append(parent.Children, &orgTreeNode{
+			ID:   cliutil.PointerToString(acct.Id),
+			Name: cliutil.PointerToString(acct.Name),
+			Type: orgNodeTypeAccount,
+		})
+	}
+
+	ous, err := listOUsForParent(ctx, orgClient, parent.ID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(ous, func(i, j int) bool {
+		return cliutil.PointerToString(ous[i].Name) < cliutil.PointerToString(ous[j].Name)
+	})
+	for _, ou := range ous {
+		child := &orgTreeNode{
+			ID:   cliutil.PointerToString(ou.Id),
+			Name: cliutil.PointerToString(ou.Name),
+			Type: orgNodeTypeOU,
+		}
+		if err := buildOrgTree(ctx, orgClient, child, maxAccountsPerOU); err != nil {
+			return err
+		}
+		parent.Children = append(parent.Children, child)
+	}
+	return nil
+}
+
+func walkOrgTree(node *orgTreeNode, visit func(*orgTreeNode)) {
+	visit(node)
+	for _, child := range node.Children {
+		walkOrgTree(child, visit)
+	}
+}
+
+func orgTreeLabel(node *orgTreeNode) string {
+	if node.Type == orgNodeTypeRoot {
+		return node.Name + " (Root)"
+	}
+	return node.Name
+}
+
+// renderOrgTreeMermaid suffixes each node ID with its walk index, because
+// sanitizing Organizations IDs can map different IDs to the same one.
+func renderOrgTreeMermaid(root *orgTreeNode) []string {
+	ids := make(map[*orgTreeNode]string)
+	walkOrgTree(root, func(node *orgTreeNode) {
+		ids[node] = fmt.Sprintf("%s_%d", mermaidID(node.ID), len(ids))
+	})
+
+	lines := []string{"graph TB"}
+	lines = append(lines, fmt.Sprintf("    %s[\"%s\"]", ids[root], mermaidEscape(orgTreeLabel(root))))
+	var render func(node *orgTreeNode)
+	render = func(node *orgTreeNode) {
+		for _, child := range node.Children {
+			lines = append(lines, fmt.Sprintf("    %s[\"%s\"]", ids[child], mermaidEscape(orgTreeLabel(child))))
+			lines = append(lines, fmt.Sprintf("    %s --> %s", ids[node], ids[child]))
+			render(child)
+		}
+	}
+	render(root)
+	return lines
+}
+
+// renderOrgTreeDOT renders a Graphviz digraph. Node IDs are quoted, so
+// Organizations IDs are used unchanged; accounts get a distinct shape.
+func renderOrgTreeDOT(root *orgTreeNode) []string {
+	lines := []string{
+		"digraph organization {",
+		"    rankdir=TB;",
+		"    node [shape=box];",
+	}
+	walkOrgTree(root, func(node *orgTreeNode) {
+		shape := ""
+		if node.Type == orgNodeTypeAccount {
+			shape = ", shape=ellipse"
+		}
+		lines = append(lines, fmt.Sprintf("    %s [label=%s%s];", dotQuote(node.ID), dotQuote(orgTreeLabel(node)), shape))
+	})
+	walkOrgTree(root, func(node *orgTreeNode) {
+		for _, child := range node.Children {
+			lines = append(lines, fmt.Sprintf("    %s -> %s;", dotQuote(node.ID), dotQuote(child.ID)))
+		}
+	})
+	return append(lines, "}")
+}
+
+func dotQuote(raw string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(raw) + `"`
+}
+
+func mermaidID(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "node"
+	}
+	var b strings.Builder
+	for _, r := range raw {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	out := b.String()
+	if out == "" {
+		out = "node"
+	}
+	if out[0] >= '0' && out[0] <= '9' {
+		out = "n_" + out
+	}
+	return out
+}
+
+func mermaidEscape(raw string) string {
+	return strings.ReplaceAll(raw, "\"", `\\"`)
+}
@@ -38,6 +38,41 @@ type contactsPayload struct {
 	Security          contact `json:"security"`
 	Billing           contact `json:"billing"`
 	Operations        contact `json:"operations"`
+
+	// Accounts holds per-account overrides keyed by account ID. Non-empty
+	// fields replace the org-wide default for that account only.
+	// AccountOverrides is accepted as a longer name for the same map.
+	Accounts         map[string]contactsPayload `json:"accounts"`
+	AccountOverrides map[string]contactsPayload `json:"accountOverrides"`
+}
+
+// alternateContacts are the org-wide default contacts plus per-account overrides.
+type alternateContacts struct {
+	defaults  map[accounttypes.AlternateContactType]contact
+	overrides map[string]map[accounttypes.AlternateContactType]contact
+}
+
+// forAccount returns the effective contact for the account and whether an
+// override contributed to it.
+func (c alternateContacts) forAccount(accountID string, contactType accounttypes.AlternateContactType) (contact, bool) {
+	effective := c.defaults[contactType]
+	override, ok := c.overrides[accountID][contactType]
+	if !ok || override == (contact{}) {
+		return effective, false
+	}
+	if override.Name != "" {
+		effective.Name = override.Name
+	}
+	if override.Title != "" {
+		effective.Title = override.Title
+	}
+	if override.EmailAddress != "" {
+		effective.EmailAddress = override.EmailAddress
+	}
+	if override.PhoneNumber != "" {
+		effective.PhoneNumber = override.PhoneNumber
+	}
+	return effective, true
 }
 
 func runImportSSOUsers(cmd *cobra.Command, inputFile string) error {
@@ -97,7 +132,7 @@ func runSetAlternateContact(cmd *cobra.Command, inputFile string) error {
 	if strings.TrimSpace(inputFile) == "" {
 		return fmt.Errorf("--input-file is required")
 	}
-	contactsByType, err := loadContacts(inputFile)
+	contacts, err := loadContacts(inputFile)
 	if err != nil {
 		return err
 	}
@@ -109,26 +144,43 @@ func runSetAlternateContact(cmd *cobra.Command, inputFile string) error {
 
 	accounts, err := listAccounts(cmd.Context(), orgClient)
 	if err != nil {
-		return fmt.Errorf("list accounts: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(err))
 	}
 	sortAccountsByID(accounts)
 
+	known := make(map[string]struct{}, len(accounts))
+	for _, acct := range accounts {
+		known[cliutil.PointerToString(acct.Id)] = struct{}{}
+	}
+	for accountID := range contacts.overrides {
+		if _, ok := known[accountID]; !ok {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: contacts file overrides account %s, which is not in the organization\n", accountID)
+		}
+	}
+
 	typesInOrder := []accounttypes.AlternateContactType{
 		accounttypes.AlternateContactTypeSecurity,
 		accounttypes.AlternateContactTypeBilling,
 		accounttypes.AlternateContactTypeOperations,
 	}
 
+	headers := []string{"account_id", "contact_type", "email", "name", "title", "phone", "source", "action"}
 	rows := make([][]string, 0, len(accounts)*len(typesInOrder))
+	effective := make([]contact, 0, cap(rows))
 	for _, acct := range accounts {
 		id := cliutil.PointerToString(acct.Id)
 		for _, contactType := range typesInOrder {
-			c := contactsByType[contactType]
+			c, overridden := contacts.forAccount(id, contactType)
+			source := "default"
+			if overridden {
+				source = "override"
+			}
 			action := "would-set"
 			if !runtime.Options.DryRun {
 				action = "pending"
 			}
-			rows = append(rows, []string{id, string(contactType), c.EmailAddress, c.Name, c.Title, c.PhoneNumber, action})
+			rows = append(rows, []string{id, string(contactType), c.EmailAddress, c.Name, c.Title, c.PhoneNumber, source, action})
+			effective = append(effective, c)
 		}
 	}
 
@@ -139,31 +191,30 @@ func runSetAlternateContact(cmd *cobra.Command, inputFile string) error {
 		}
 		if !ok {
 			for i := range rows {
-				rows[i][6] = "cancelled"
+				rows[i][7] = "cancelled"
 			}
-			return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "contact_type", "email", "name", "title", "phone", "action"}, rows)
+			return cliutil.WriteDataset(cmd, runtime, headers, rows)
 		}
 
 		for i := range rows {
-			contactType := accounttypes.AlternateContactType(rows[i][1])
-			c := contactsByType[contactType]
+			c := effective[i]
 			_, putErr := accountClient.PutAlternateContact(cmd.Context(), &account.PutAlternateContactInput{
 				AccountId:            cliutil.Ptr(rows[i][0]),
-				AlternateContactType: contactType,
+				AlternateContactType: accounttypes.AlternateContactType(rows[i][1]),
 				EmailAddress:         cliutil.Ptr(c.EmailAddress),
 				Name:                 cliutil.Ptr(c.Name),
 				PhoneNumber:          cliutil.Ptr(c.PhoneNumber),
 				Title:                cliutil.Ptr(c.Title),
 			})
 			if putErr != nil {
-				rows[i][6] = "failed: " + awstbxaws.FormatUserError(putErr)
+				rows[i][7] = "failed: " + awstbxaws.FormatUserError(putErr)
 				continue
 			}
-			rows[i][6] = "updated"
+			rows[i][7] = "updated"
 		}
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "contact_type", "email", "name", "title", "phone", "action"}, rows)
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
 }
 
 func readImportCSV(path string) ([]importRow, error) {
@@ -237,38 +288,57 @@ func ensureUser(ctx context.Context, identityClient IdentityStoreAPI, storeID st
 	return cliutil.PointerToString(createOut.UserId), "created-user", nil
 }
 
-func loadContacts(path string) (map[accounttypes.AlternateContactType]contact, error) {
+func loadContacts(path string) (alternateContacts, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("read contacts file: %w", err)
+		return alternateContacts{}, fmt.Errorf("read contacts file: %w", err)
 	}
 	var payload contactsPayload
 	if err := json.Unmarshal(b, &payload); err != nil {
-		return nil, fmt.Errorf("parse contacts file: %w", err)
+		return alternateContacts{}, fmt.Errorf("parse contacts file: %w", err)
+	}
+
+	defaults := payload.contactsByType()
+	for contactType, c := range defaults {
+		if strings.TrimSpace(c.Name) == "" || strings.TrimSpace(c.Title) == "" || strings.TrimSpace(c.EmailAddress) == "" || strings.TrimSpace(c.PhoneNumber) == "" {
+			return alternateContacts{}, fmt.Errorf("contacts file missing required fields for %s contact", strings.ToLower(string(contactType)))
+		}
 	}
 
-	security := payload.Security
+	overrides := make(map[string]map[accounttypes.AlternateContactType]contact, len(payload.Accounts)+len(payload.AccountOverrides))
+	for _, accountPayloads := range []map[string]contactsPayload{payload.Accounts, payload.AccountOverrides} {
+		for accountID, override := range accountPayloads {
+			if !orgAccountIDPattern.MatchString(accountID) {
+				return alternateContacts{}, fmt.Errorf("contacts file override key %q is not a 12-digit account ID", accountID)
+			}
+			if _, ok := overrides[accountID]; ok {
+				return alternateContacts{}, fmt.Errorf("contacts file overrides account %s under both accounts and accountOverrides", accountID)
+			}
+			overrides[accountID] = override.contactsByType()
+		}
+	}
+
+	return alternateContacts{defaults: defaults, overrides: overrides}, nil
+}
+
+// contactsByType resolves the short and long contact keys into one contact per type.
+func (p contactsPayload) contactsByType() map[accounttypes.AlternateContactType]contact {
+	security := p.Security
 	if security == (contact{}) {
-		security = payload.SecurityContact
+		security = p.SecurityContact
 	}
-	billing := payload.Billing
+	billing := p.Billing
 	if billing == (contact{}) {
-		billing = payload.BillingContact
+		billing = p.BillingContact
 	}
-	operations := payload.Operations
+	operations := p.Operations
 	if operations == (contact{}) {
-		operations = payload.OperationsContact
+		operations = p.OperationsContact
 	}
 
-	contacts := map[accounttypes.AlternateContactType]contact{
+	return map[accounttypes.AlternateContactType]contact{
 		accounttypes.AlternateContactTypeSecurity:   security,
 		accounttypes.AlternateContactTypeBilling:    billing,
 		accounttypes.AlternateContactTypeOperations: operations,
 	}
-	for contactType, c := range contacts {
-		if strings.TrimSpace(c.Name) == "" || strings.TrimSpace(c.Title) == "" || strings.TrimSpace(c.EmailAddress) == "" || strings.TrimSpace(c.PhoneNumber) == "" {
-			return nil, fmt.Errorf("contacts file missing required fields for %s contact", strings.ToLower(string(contactType)))
-		}
-	}
-	return contacts, nil
 }
@@ -10,17 +10,58 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/identitystore"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/ssoadmin"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
 type mockOrganizationsClient struct {
-	describeAccountFn func(context.Context, *organizations.DescribeAccountInput, ...func(*organizations.Options)) (*organizations.DescribeAccountOutput, error)
-	describeOUFn      func(context.Context, *organizations.DescribeOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.DescribeOrganizationalUnitOutput, error)
-	listAccountsFn    func(context.Context, *organizations.ListAccountsInput, ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
-	listForParentFn   func(context.Context, *organizations.ListAccountsForParentInput, ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error)
-	listOUsFn         func(context.Context, *organizations.ListOrganizationalUnitsForParentInput, ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error)
-	listParentsFn     func(context.Context, *organizations.ListParentsInput, ...func(*organizations.Options)) (*organizations.ListParentsOutput, error)
-	listRootsFn       func(context.Context, *organizations.ListRootsInput, ...func(*organizations.Options)) (*organizations.ListRootsOutput, error)
-	listTagsFn        func(context.Context, *organizations.ListTagsForResourceInput, ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error)
+	attachPolicyFn      func(context.Context, *organizations.AttachPolicyInput, ...func(*organizations.Options)) (*organizations.AttachPolicyOutput, error)
+	closeAccountFn      func(context.Context, *organizations.CloseAccountInput, ...func(*organizations.Options)) (*organizations.CloseAccountOutput, error)
+	createOUFn          func(context.Context, *organizations.CreateOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.CreateOrganizationalUnitOutput, error)
+	deleteOUFn          func(context.Context, *organizations.DeleteOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.DeleteOrganizationalUnitOutput, error)
+	describeAccountFn   func(context.Context, *organizations.DescribeAccountInput, ...func(*organizations.Options)) (*organizations.DescribeAccountOutput, error)
+	describeEffectiveFn func(context.Context, *organizations.DescribeEffectivePolicyInput, ...func(*organizations.Options)) (*organizations.DescribeEffectivePolicyOutput, error)
+	describeOrgFn       func(context.Context, *organizations.DescribeOrganizationInput, ...func(*organizations.Options)) (*organizations.DescribeOrganizationOutput, error)
+	describeOUFn        func(context.Context, *organizations.DescribeOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.DescribeOrganizationalUnitOutput, error)
+	describePolicyFn    func(context.Context, *organizations.DescribePolicyInput, ...func(*organizations.Options)) (*organizations.DescribePolicyOutput, error)
+	detachPolicyFn      func(context.Context, *organizations.DetachPolicyInput, ...func(*organizations.Options)) (*organizations.DetachPolicyOutput, error)
+	listAccountsFn      func(context.Context, *organizations.ListAccountsInput, ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
+	listForParentFn     func(context.Context, *organizations.ListAccountsForParentInput, ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error)
+	listDelegatedFn     func(context.Context, *organizations.ListDelegatedAdministratorsInput, ...func(*organizations.Options)) (*organizations.ListDelegatedAdministratorsOutput, error)
+	listServicesFn      func(context.Context, *organizations.ListDelegatedServicesForAccountInput, ...func(*organizations.Options)) (*organizations.ListDelegatedServicesForAccountOutput, error)
+	listOUsFn           func(context.Context, *organizations.ListOrganizationalUnitsForParentInput, ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error)
+	listParentsFn       func(context.Context, *organizations.ListParentsInput, ...func(*organizations.Options)) (*organizations.ListParentsOutput, error)
+	listPoliciesFn      func(context.Context, *organizations.ListPoliciesInput, ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error)
+	listRootsFn         func(context.Context, *organizations.ListRootsInput, ...func(*organizations.Options)) (*organizations.ListRootsOutput, error)
+	listTagsFn          func(context.Context, *organizations.ListTagsForResourceInput, ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error)
+	moveAccountFn       func(context.Context, *organizations.MoveAccountInput, ...func(*organizations.Options)) (*organizations.MoveAccountOutput, error)
+}
+
+func (m *mockOrganizationsClient) AttachPolicy(ctx context.Context, in *organizations.AttachPolicyInput, optFns ...func(*organizations.Options)) (*organizations.AttachPolicyOutput, error) {
+	if m.attachPolicyFn == nil {
+		return nil, errors.New("AttachPolicy not mocked")
+	}
+	return m.attachPolicyFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) CloseAccount(ctx context.Context, in *organizations.CloseAccountInput, optFns ...func(*organizations.Options)) (*organizations.CloseAccountOutput, error) {
+	if m.closeAccountFn == nil {
+		return nil, errors.New("CloseAccount not mocked")
+	}
+	return m.closeAccountFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) CreateOrganizationalUnit(ctx context.Context, in *organizations.CreateOrganizationalUnitInput, optFns ...func(*organizations.Options)) (*organizations.CreateOrganizationalUnitOutput, error) {
+	if m.createOUFn == nil {
+		return nil, errors.New("CreateOrganizationalUnit not mocked")
+	}
+	return m.createOUFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) DeleteOrganizationalUnit(ctx context.Context, in *organizations.DeleteOrganizationalUnitInput, optFns ...func(*organizations.Options)) (*organizations.DeleteOrganizationalUnitOutput, error) {
+	if m.deleteOUFn == nil {
+		return nil, errors.New("DeleteOrganizationalUnit not mocked")
+	}
+	return m.deleteOUFn(ctx, in, optFns...)
 }
 
 func (m *mockOrganizationsClient) DescribeAccount(ctx context.Context, in *organizations.DescribeAccountInput, optFns ...func(*organizations.Options)) (*organizations.DescribeAccountOutput, error) {
@@ -30,6 +71,20 @@ func (m *mockOrganizationsClient) DescribeAccount(ctx context.Context, in *organ
 	return m.describeAccountFn(ctx, in, optFns...)
 }
 
+func (m *mockOrganizationsClient) DescribeEffectivePolicy(ctx context.Context, in *organizations.DescribeEffectivePolicyInput, optFns ...func(*organizations.Options)) (*organizations.DescribeEffectivePolicyOutput, error) {
+	if m.describeEffectiveFn == nil {
+		return nil, errors.New("DescribeEffectivePolicy not mocked")
+	}
+	return m.describeEffectiveFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) DescribeOrganization(ctx context.Context, in *organizations.DescribeOrganizationInput, optFns ...func(*organizations.Options)) (*organizations.DescribeOrganizationOutput, error) {
+	if m.describeOrgFn == nil {
+		return nil, errors.New("DescribeOrganization not mocked")
+	}
+	return m.describeOrgFn(ctx, in, optFns...)
+}
+
 func (m *mockOrganizationsClient) DescribeOrganizationalUnit(ctx context.Context, in *organizations.DescribeOrganizationalUnitInput, optFns ...func(*organizations.Options)) (*organizations.DescribeOrganizationalUnitOutput, error) {
 	if m.describeOUFn == nil {
 		return nil, errors.New("DescribeOrganizationalUnit not mocked")
@@ -37,6 +92,20 @@ func (m *mockOrganizationsClient) DescribeOrganizationalUnit(ctx context.Context
 	return m.describeOUFn(ctx, in, optFns...)
 }
 
+func (m *mockOrganizationsClient) DescribePolicy(ctx context.Context, in *organizations.DescribePolicyInput, optFns ...func(*organizations.Options)) (*organizations.DescribePolicyOutput, error) {
+	if m.describePolicyFn == nil {
+		return nil, errors.New("DescribePolicy not mocked")
+	}
+	return m.describePolicyFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) DetachPolicy(ctx context.Context, in *organizations.DetachPolicyInput, optFns ...func(*organizations.Options)) (*organizations.DetachPolicyOutput, error) {
+	if m.detachPolicyFn == nil {
+		return nil, errors.New("DetachPolicy not mocked")
+	}
+	return m.detachPolicyFn(ctx, in, optFns...)
+}
+
 func (m *mockOrganizationsClient) ListAccounts(ctx context.Context, in *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
 	if m.listAccountsFn == nil {
 		return nil, errors.New("ListAccounts not mocked")
@@ -51,6 +120,20 @@ func (m *mockOrganizationsClient) ListAccountsForParent(ctx context.Context, in
 	return m.listForParentFn(ctx, in, optFns...)
 }
 
+func (m *mockOrganizationsClient) ListDelegatedAdministrators(ctx context.Context, in *organizations.ListDelegatedAdministratorsInput, optFns ...func(*organizations.Options)) (*organizations.ListDelegatedAdministratorsOutput, error) {
+	if m.listDelegatedFn == nil {
+		return nil, errors.New("ListDelegatedAdministrators not mocked")
+	}
+	return m.listDelegatedFn(ctx, in, optFns...)
+}
+
+func (m *mockOrganizationsClient) ListDelegatedServicesForAccount(ctx context.Context, in *organizations.ListDelegatedServicesForAccountInput, optFns ...func(*organizations.Options)) (*organizations.ListDelegatedServicesForAccountOutput, error) {
+	if m.listServicesFn == nil {
+		return nil, errors.New("ListDelegatedServicesForAccount not mocked")
+	}
+	return m.listServicesFn(ctx, in, optFns...)
+}
+
 func (m *mockOrganizationsClient) ListOrganizationalUnitsForParent(ctx context.Context, in *organizations.ListOrganizationalUnitsForParentInput, optFns ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
 	if m.listOUsFn == nil {
 		return nil, errors.New("ListOrganizationalUnitsForParent not mocked")
@@ -65,6 +148,13 @@ func (m *mockOrganizationsClient) ListParents(ctx context.Context, in *organizat
 	return m.listParentsFn(ctx, in, optFns...)
 }
 
+func (m *mockOrganizationsClient) ListPolicies(ctx context.Context, in *organizations.ListPoliciesInput, optFns ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error) {
+	if m.listPoliciesFn == nil {
+		return nil, errors.New("ListPolicies not mocked")
+	}
+	return m.listPoliciesFn(ctx, in, optFns...)
+}
+
 func (m *mockOrganizationsClient) ListRoots(ctx context.Context, in *organizations.ListRootsInput, optFns ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
 	if m.listRootsFn == nil {
 		return nil, errors.New("ListRoots not mocked")
@@ -79,6 +169,13 @@ func (m *mockOrganizationsClient) ListTagsForResource(ctx context.Context, in *o
 	return m.listTagsFn(ctx, in, optFns...)
 }
 
+func (m *mockOrganizationsClient) MoveAccount(ctx context.Context, in *organizations.MoveAccountInput, optFns ...func(*organizations.Options)) (*organizations.MoveAccountOutput, error) {
+	if m.moveAccountFn == nil {
+		return nil, errors.New("MoveAccount not mocked")
+	}
+	return m.moveAccountFn(ctx, in, optFns...)
+}
+
 type mockSSOAdminClient struct {
 	createAssignmentFn       func(context.Context, *ssoadmin.CreateAccountAssignmentInput, ...func(*ssoadmin.Options)) (*ssoadmin.CreateAccountAssignmentOutput, error)
 	deleteAssignmentFn       func(context.Context, *ssoadmin.DeleteAccountAssignmentInput, ...func(*ssoadmin.Options)) (*ssoadmin.DeleteAccountAssignmentOutput, error)
@@ -206,9 +303,17 @@ func (m *mockIdentityStoreClient) ListUsers(ctx context.Context, in *identitysto
 }
 
 type mockAccountClient struct {
+	getAlternateContactFn func(context.Context, *account.GetAlternateContactInput, ...func(*account.Options)) (*account.GetAlternateContactOutput, error)
 	putAlternateContactFn func(context.Context, *account.PutAlternateContactInput, ...func(*account.Options)) (*account.PutAlternateContactOutput, error)
 }
 
+func (m *mockAccountClient) GetAlternateContact(ctx context.Context, in *account.GetAlternateContactInput, optFns ...func(*account.Options)) (*account.GetAlternateContactOutput, error) {
+	if m.getAlternateContactFn == nil {
+		return nil, errors.New("GetAlternateContact not mocked")
+	}
+	return m.getAlternateContactFn(ctx, in, optFns...)
+}
+
 func (m *mockAccountClient) PutAlternateContact(ctx context.Context, in *account.PutAlternateContactInput, optFns ...func(*account.Options)) (*account.PutAlternateContactOutput, error) {
 	if m.putAlternateContactFn == nil {
 		return nil, errors.New("PutAlternateContact not mocked")
@@ -224,12 +329,15 @@ func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, erro
 	oldSSO := newSSOAdminClient
 	oldIdentity := newIdentityStoreClient
 	oldAccount := newAccountClient
+	oldCacheDir := cliutil.CompletionCacheDir
 
+	cacheDir := t.TempDir()
 	loadAWSConfig = loader
 	newOrganizationsClient = orgFactory
 	newSSOAdminClient = ssoFactory
 	newIdentityStoreClient = identityFactory
 	newAccountClient = accountFactory
+	cliutil.CompletionCacheDir = func() (string, error) { return cacheDir, nil }
 
 	t.Cleanup(func() {
 		loadAWSConfig = oldLoader
@@ -237,5 +345,6 @@ func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, erro
 		newSSOAdminClient = oldSSO
 		newIdentityStoreClient = oldIdentity
 		newAccountClient = oldAccount
+		cliutil.CompletionCacheDir = oldCacheDir
 	})
 }
@@ -20,14 +20,26 @@ import (
 )
 
 type OrganizationsAPI interface {
+	AttachPolicy(context.Context, *organizations.AttachPolicyInput, ...func(*organizations.Options)) (*organizations.AttachPolicyOutput, error)
+	CloseAccount(context.Context, *organizations.CloseAccountInput, ...func(*organizations.Options)) (*organizations.CloseAccountOutput, error)
+	CreateOrganizationalUnit(context.Context, *organizations.CreateOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.CreateOrganizationalUnitOutput, error)
+	DeleteOrganizationalUnit(context.Context, *organizations.DeleteOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.DeleteOrganizationalUnitOutput, error)
 	DescribeAccount(context.Context, *organizations.DescribeAccountInput, ...func(*organizations.Options)) (*organizations.DescribeAccountOutput, error)
+	DescribeEffectivePolicy(context.Context, *organizations.DescribeEffectivePolicyInput, ...func(*organizations.Options)) (*organizations.DescribeEffectivePolicyOutput, error)
+	DescribeOrganization(context.Context, *organizations.DescribeOrganizationInput, ...func(*organizations.Options)) (*organizations.DescribeOrganizationOutput, error)
 	DescribeOrganizationalUnit(context.Context, *organizations.DescribeOrganizationalUnitInput, ...func(*organizations.Options)) (*organizations.DescribeOrganizationalUnitOutput, error)
+	DescribePolicy(context.Context, *organizations.DescribePolicyInput, ...func(*organizations.Options)) (*organizations.DescribePolicyOutput, error)
+	DetachPolicy(context.Context, *organizations.DetachPolicyInput, ...func(*organizations.Options)) (*organizations.DetachPolicyOutput, error)
 	ListAccounts(context.Context, *organizations.ListAccountsInput, ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
 	ListAccountsForParent(context.Context, *organizations.ListAccountsForParentInput, ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error)
+	ListDelegatedAdministrators(context.Context, *organizations.ListDelegatedAdministratorsInput, ...func(*organizations.Options)) (*organizations.ListDelegatedAdministratorsOutput, error)
+	ListDelegatedServicesForAccount(context.Context, *organizations.ListDelegatedServicesForAccountInput, ...func(*organizations.Options)) (*organizations.ListDelegatedServicesForAccountOutput, error)
 	ListOrganizationalUnitsForParent(context.Context, *organizations.ListOrganizationalUnitsForParentInput, ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error)
 	ListParents(context.Context, *organizations.ListParentsInput, ...func(*organizations.Options)) (*organizations.ListParentsOutput, error)
+	ListPolicies(context.Context, *organizations.ListPoliciesInput, ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error)
 	ListRoots(context.Context, *organizations.ListRootsInput, ...func(*organizations.Options)) (*organizations.ListRootsOutput, error)
 	ListTagsForResource(context.Context, *organizations.ListTagsForResourceInput, ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error)
+	MoveAccount(context.Context, *organizations.MoveAccountInput, ...func(*organizations.Options)) (*organizations.MoveAccountOutput, error)
 }
 
 type SSOAdminAPI interface {
@@ -52,6 +64,7 @@ type IdentityStoreAPI interface {
 }
 
 type AccountAPI interface {
+	GetAlternateContact(context.Context, *account.GetAlternateContactInput, ...func(*account.Options)) (*account.GetAlternateContactOutput, error)
 	PutAlternateContact(context.Context, *account.PutAlternateContactInput, ...func(*account.Options)) (*account.PutAlternateContactOutput, error)
 }
 
@@ -73,13 +86,24 @@ func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("org", "Manage Organizations resources")
 
 	cmd.AddCommand(newAssignSSOAccessCommand())
+	cmd.AddCommand(newAttachSCPCommand())
+	cmd.AddCommand(newAuditRootUsageCommand())
+	cmd.AddCommand(newCloseAccountCommand())
+	cmd.AddCommand(newCreateOUCommand())
+	cmd.AddCommand(newDeleteOUCommand())
+	cmd.AddCommand(newDetachSCPCommand())
 	cmd.AddCommand(newGenerateDiagramCommand())
 	cmd.AddCommand(newGetAccountCommand())
 	cmd.AddCommand(newImportSSOUsersCommand())
 	cmd.AddCommand(newListAccountsCommand())
+	cmd.AddCommand(newListDelegatedAdminsCommand())
+	cmd.AddCommand(newListSCPsCommand())
 	cmd.AddCommand(newListSSOAssignmentsCommand())
+	cmd.AddCommand(newMoveAccountCommand())
 	cmd.AddCommand(newRemoveSSOAccessCommand())
+	cmd.AddCommand(newSecurityBaselineReportCommand())
 	cmd.AddCommand(newSetAlternateContactCommand())
+	cmd.AddCommand(newTagComplianceCommand())
 
 	return cmd
 }
@@ -88,13 +112,13 @@ func newAssignSSOAccessCommand() *cobra.Command {
 	var principalName string
 	var principalType string
 	var permissionSetName string
-	var ouName string
+	var selector ssoAccountSelector
 
 	cmd := &cobra.Command{
 		Use:   "assign-sso-access",
-		Short: "Assign an SSO permission set to accounts in an OU",
+		Short: "Assign an SSO permission set to accounts in an OU, a list, or the whole organization",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runAssignSSOAccess(cmd, principalName, principalType, permissionSetName, ouName)
+			return runAssignSSOAccess(cmd, principalName, principalType, permissionSetName, selector)
 		},
 		SilenceUsage: true,
 	}
@@ -102,23 +126,122 @@ func newAssignSSOAccessCommand() *cobra.Command {
 	cmd.Flags().StringVar(&principalName, "principal-name", "", "Identity Center principal name")
 	cmd.Flags().StringVar(&principalType, "principal-type", "GROUP", "Principal type: USER or GROUP")
 	cmd.Flags().StringVar(&permissionSetName, "permission-set-name", "", "Identity Center permission set name")
-	cmd.Flags().StringVar(&ouName, "ou-name", "", "Organizational unit name")
+	addSSOAccountSelectorFlags(cmd, &selector)
+
+	return cmd
+}
+
+func newAttachSCPCommand() *cobra.Command {
+	var policyName string
+	var target string
+
+	cmd := &cobra.Command{
+		Use:   "attach-scp",
+		Short: "Attach a service control policy to an account, OU or the root",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAttachSCP(cmd, policyName, target)
+		},
+		SilenceUsage: true,
+	}
+	addSCPTargetFlags(cmd, &policyName, &target)
+
+	return cmd
+}
+
+func newAuditRootUsageCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit-root-usage",
+		Short: "Audit root-account governance across organization accounts",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditRootUsage(cmd)
+		},
+		SilenceUsage: true,
+	}
+}
+
+func newCloseAccountCommand() *cobra.Command {
+	var accountID string
+
+	cmd := &cobra.Command{
+		Use:   "close-account",
+		Short: "Close a member account of the organization",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCloseAccount(cmd, accountID)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&accountID, "account-id", "", "12-digit AWS account ID")
+
+	return cmd
+}
+
+func newCreateOUCommand() *cobra.Command {
+	var name string
+	var parent string
+
+	cmd := &cobra.Command{
+		Use:   "create-ou",
+		Short: "Create an organizational unit under the root or a named OU",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCreateOU(cmd, name, parent)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the new organizational unit")
+	cmd.Flags().StringVar(&parent, "parent", rootParentName, "Parent: root or an existing OU name")
+	_ = cmd.RegisterFlagCompletionFunc("parent", cliutil.CachedCompletion(ouNameCompletionCache))
+
+	return cmd
+}
+
+func newDeleteOUCommand() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "delete-ou",
+		Short: "Delete an empty organizational unit",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteOU(cmd, name)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the organizational unit to delete")
+	_ = cmd.RegisterFlagCompletionFunc("name", cliutil.CachedCompletion(ouNameCompletionCache))
+
+	return cmd
+}
+
+func newDetachSCPCommand() *cobra.Command {
+	var policyName string
+	var target string
+
+	cmd := &cobra.Command{
+		Use:   "detach-scp",
+		Short: "Detach a service control policy from an account, OU or the root",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDetachSCP(cmd, policyName, target)
+		},
+		SilenceUsage: true,
+	}
+	addSCPTargetFlags(cmd, &policyName, &target)
 
 	return cmd
 }
 
 func newGenerateDiagramCommand() *cobra.Command {
 	var maxAccountsPerOU int
+	var format string
 
 	cmd := &cobra.Command{
 		Use:   "generate-diagram",
-		Short: "Generate a Mermaid diagram for organization structure",
+		Short: "Generate a diagram of the organization structure",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runGenerateDiagram(cmd, maxAccountsPerOU)
+			return runGenerateDiagram(cmd, maxAccountsPerOU, format)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().IntVar(&maxAccountsPerOU, "max-accounts-per-ou", 6, "Maximum accounts to render under each OU")
+	cmd.Flags().StringVar(&format, "format", "mermaid", "Diagram format: mermaid|dot|json")
 
 	return cmd
 }
@@ -157,32 +280,86 @@ func newImportSSOUsersCommand() *cobra.Command {
 
 func newListAccountsCommand() *cobra.Command {
 	var ouNames []string
+	var orphans bool
+	var statuses []string
 
 	cmd := &cobra.Command{
 		Use:   "list-accounts",
 		Short: "List organization accounts",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runListAccounts(cmd, ouNames)
+			return runListAccounts(cmd, ouNames, orphans, statuses)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringSliceVar(&ouNames, "ou-name", nil, "Filter by one or more OU names")
+	_ = cmd.RegisterFlagCompletionFunc("ou-name", cliutil.CachedCompletion(ouNameCompletionCache))
+	cmd.Flags().BoolVar(&orphans, "orphans", false, "Only list accounts placed directly under the root instead of an OU")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only list accounts in this status: ACTIVE, SUSPENDED or PENDING_CLOSURE (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("ou-name", "orphans")
+
+	return cmd
+}
+
+func newListDelegatedAdminsCommand() *cobra.Command {
+	var servicePrincipal string
+
+	cmd := &cobra.Command{
+		Use:   "list-delegated-admins",
+		Short: "List delegated administrator accounts and their services",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListDelegatedAdmins(cmd, servicePrincipal)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&servicePrincipal, "service-principal", "", "Only list delegated administrators for this service principal (e.g. guardduty.amazonaws.com)")
 
 	return cmd
 }
 
+func newListSCPsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-scps",
+		Short: "List service control policies",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListSCPs(cmd)
+		},
+		SilenceUsage: true,
+	}
+}
+
 func newListSSOAssignmentsCommand() *cobra.Command {
 	var accountID string
+	var resolveNames bool
 
 	cmd := &cobra.Command{
 		Use:   "list-sso-assignments",
 		Short: "List Identity Center assignments for accounts",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runListSSOAssignments(cmd, accountID)
+			return runListSSOAssignments(cmd, accountID, resolveNames)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&accountID, "account-id", "", "Optional 12-digit account ID filter")
+	cmd.Flags().BoolVar(&resolveNames, "resolve-names", true, "Look up principal and permission set names")
+
+	return cmd
+}
+
+func newMoveAccountCommand() *cobra.Command {
+	var accountID string
+	var destOUName string
+
+	cmd := &cobra.Command{
+		Use:   "move-account",
+		Short: "Move an account to another organizational unit",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMoveAccount(cmd, accountID, destOUName)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&accountID, "account-id", "", "12-digit account ID to move")
+	cmd.Flags().StringVar(&destOUName, "dest-ou-name", "", "Name of the destination organizational unit")
+	_ = cmd.RegisterFlagCompletionFunc("dest-ou-name", cliutil.CachedCompletion(ouNameCompletionCache))
 
 	return cmd
 }
@@ -191,24 +368,35 @@ func newRemoveSSOAccessCommand() *cobra.Command {
 	var principalName string
 	var principalType string
 	var permissionSetName string
-	var ouName string
+	var selector ssoAccountSelector
 
 	cmd := &cobra.Command{
 		Use:   "remove-sso-access",
-		Short: "Remove an SSO permission set from accounts in an OU",
+		Short: "Remove an SSO permission set from accounts in an OU, a list, or the whole organization",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runRemoveSSOAccess(cmd, principalName, principalType, permissionSetName, ouName)
+			return runRemoveSSOAccess(cmd, principalName, principalType, permissionSetName, selector)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&principalName, "principal-name", "", "Identity Center principal name")
 	cmd.Flags().StringVar(&principalType, "principal-type", "GROUP", "Principal type: USER or GROUP")
 	cmd.Flags().StringVar(&permissionSetName, "permission-set-name", "", "Identity Center permission set name")
-	cmd.Flags().StringVar(&ouName, "ou-name", "", "Organizational unit name")
+	addSSOAccountSelectorFlags(cmd, &selector)
 
 	return cmd
 }
 
+func newSecurityBaselineReportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "security-baseline-report",
+		Short: "Report organization security posture checks with pass/fail results",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSecurityBaselineReport(cmd)
+		},
+		SilenceUsage: true,
+	}
+}
+
 func newSetAlternateContactCommand() *cobra.Command {
 	var inputFile string
 
@@ -220,7 +408,23 @@ func newSetAlternateContactCommand() *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
-	cmd.Flags().StringVar(&inputFile, "input-file", "", "JSON file with security/billing/operations contact details")
+	cmd.Flags().StringVar(&inputFile, "input-file", "", "JSON file with security/billing/operations contact details and optional per-account overrides")
+
+	return cmd
+}
+
+func newTagComplianceCommand() *cobra.Command {
+	var policyID string
+
+	cmd := &cobra.Command{
+		Use:   "tag-compliance",
+		Short: "Report accounts whose tags violate a tag policy",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTagCompliance(cmd, policyID)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&policyID, "policy-id", "", "Evaluate every account against this tag policy instead of its effective tag policy")
 
 	return cmd
 }
@@ -252,3 +456,17 @@ func identityStoreFilterForPrincipal(principalName string, principalType ssoadmi
 		AttributeValue: cliutil.Ptr(principalName),
 	}
 }
+
+func addSSOAccountSelectorFlags(cmd *cobra.Command, selector *ssoAccountSelector) {
+	cmd.Flags().StringVar(&selector.OUName, "ou-name", "", "Organizational unit name")
+	_ = cmd.RegisterFlagCompletionFunc("ou-name", cliutil.CachedCompletion(ouNameCompletionCache))
+	cmd.Flags().StringSliceVar(&selector.AccountIDs, "account-ids", nil, "Comma-separated 12-digit account IDs")
+	cmd.Flags().BoolVar(&selector.AllAccounts, "all-accounts", false, "Target every active account in the organization")
+	cmd.MarkFlagsMutuallyExclusive("ou-name", "account-ids", "all-accounts")
+}
+
+func addSCPTargetFlags(cmd *cobra.Command, policyName, target *string) {
+	cmd.Flags().StringVar(policyName, "policy-name", "", "Service control policy name")
+	cmd.Flags().StringVar(target, "target", "", "12-digit account ID, OU ID (ou-...), OU name, or root")
+	_ = cmd.RegisterFlagCompletionFunc("target", cliutil.CachedCompletion(ouNameCompletionCache))
+}
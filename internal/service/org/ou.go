@@ -0,0 +1,145 @@
+package org
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const rootParentName = "root"
+
+func runCreateOU(cmd *cobra.Command, name, parent string) error {
+	name = strings.TrimSpace(name)
+	parent = strings.TrimSpace(parent)
+	if name == "" {
+		return fmt.Errorf("--name is required")
+	}
+	if parent == "" {
+		parent = rootParentName
+	}
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	rootID, _, err := getRoot(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
+	}
+	parentID := rootID
+	if !strings.EqualFold(parent, rootParentName) {
+		parentOU, findErr := findOUByName(ctx, orgClient, rootID, parent)
+		if findErr != nil {
+			return findErr
+		}
+		parentID = cliutil.PointerToString(parentOU.Id)
+	}
+
+	siblings, err := listOUsForParent(ctx, orgClient, parentID)
+	if err != nil {
+		return fmt.Errorf("list organizational units for %s: %w", parent, awstbxaws.WrapUserError(err))
+	}
+	for _, sibling := range siblings {
+		if strings.EqualFold(cliutil.PointerToString(sibling.Name), name) {
+			return fmt.Errorf("organizational unit %q already exists under %s (%s)", name, parent, cliutil.PointerToString(sibling.Id))
+		}
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = "would-create"
+	}
+	rows := [][]string{{name, parent, parentID, "", action}}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"ou_name", "parent", "parent_id", "ou_id", "action"},
+		Rows:          rows,
+		ActionColumn:  4,
+		ConfirmPrompt: fmt.Sprintf("Create organizational unit %q under %s", name, parent),
+		Execute: func(int) string {
+			out, createErr := orgClient.CreateOrganizationalUnit(ctx, &organizations.CreateOrganizationalUnitInput{
+				Name:     cliutil.Ptr(name),
+				ParentId: cliutil.Ptr(parentID),
+			})
+			if createErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(createErr))
+			}
+			if out.OrganizationalUnit != nil {
+				rows[0][3] = cliutil.PointerToString(out.OrganizationalUnit.Id)
+			}
+			return "created"
+		},
+	})
+}
+
+// runDeleteOU deletes an empty OU. AWS refuses to delete an OU that still
+// holds accounts or child OUs, so those are reported up front instead.
+func runDeleteOU(cmd *cobra.Command, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	rootID, _, err := getRoot(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
+	}
+	ou, err := findOUByName(ctx, orgClient, rootID, name)
+	if err != nil {
+		return err
+	}
+	ouID := cliutil.PointerToString(ou.Id)
+
+	accounts, err := listAccountsForParent(ctx, orgClient, ouID)
+	if err != nil {
+		return fmt.Errorf("list accounts for OU %q: %w", name, awstbxaws.WrapUserError(err))
+	}
+	children, err := listOUsForParent(ctx, orgClient, ouID)
+	if err != nil {
+		return fmt.Errorf("list organizational units for %s: %w", name, awstbxaws.WrapUserError(err))
+	}
+
+	blockers := make([]string, 0, len(accounts)+len(children))
+	for _, account := range accounts {
+		blockers = append(blockers, fmt.Sprintf("account:%s (%s)", cliutil.PointerToString(account.Id), cliutil.PointerToString(account.Name)))
+	}
+	for _, child := range children {
+		blockers = append(blockers, fmt.Sprintf("ou:%s (%s)", cliutil.PointerToString(child.Id), cliutil.PointerToString(child.Name)))
+	}
+
+	headers := []string{"ou_name", "ou_id", "blocked_by", "action"}
+	if len(blockers) > 0 {
+		rows := [][]string{{cliutil.PointerToString(ou.Name), ouID, strings.Join(blockers, "; "), cliutil.SkippedActionMessage("not-empty")}}
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       headers,
+		Rows:          [][]string{{cliutil.PointerToString(ou.Name), ouID, "", action}},
+		ActionColumn:  3,
+		ConfirmPrompt: fmt.Sprintf("Delete organizational unit %q (%s)", name, ouID),
+		Execute: func(int) string {
+			_, deleteErr := orgClient.DeleteOrganizationalUnit(ctx, &organizations.DeleteOrganizationalUnitInput{OrganizationalUnitId: cliutil.Ptr(ouID)})
+			if deleteErr != nil {
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+func runListSCPs(cmd *cobra.Command) error {
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+
+	policies, err := listPolicies(cmd.Context(), orgClient, organizationtypes.PolicyTypeServiceControlPolicy)
+	if err != nil {
+		return fmt.Errorf("list service control policies: %w", awstbxaws.WrapUserError(err))
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return cliutil.PointerToString(policies[i].Name) < cliutil.PointerToString(policies[j].Name)
+	})
+
+	rows := make([][]string, 0, len(policies))
+	for _, policy := range policies {
+		rows = append(rows, []string{
+			cliutil.PointerToString(policy.Id),
+			cliutil.PointerToString(policy.Name),
+			fmt.Sprintf("%t", policy.AwsManaged),
+			cliutil.PointerToString(policy.Description),
+		})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"policy_id", "policy_name", "aws_managed", "description"}, rows)
+}
+
+func runAttachSCP(cmd *cobra.Command, policyName, target string) error {
+	return runSCPAttachmentChange(cmd, policyName, target, true)
+}
+
+func runDetachSCP(cmd *cobra.Command, policyName, target string) error {
+	return runSCPAttachmentChange(cmd, policyName, target, false)
+}
+
+// runSCPAttachmentChange attaches or detaches a service control policy on an
+// account, an OU or the root. An attachment that is already in the requested
+// state is reported as skipped rather than failed.
+func runSCPAttachmentChange(cmd *cobra.Command, policyName, target string, attach bool) error {
+	policyName = strings.TrimSpace(policyName)
+	if policyName == "" {
+		return fmt.Errorf("--policy-name is required")
+	}
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("--target is required")
+	}
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	policyID, err := resolveSCPID(ctx, orgClient, policyName)
+	if err != nil {
+		return err
+	}
+	targetType, targetID, err := resolvePolicyTarget(ctx, orgClient, target)
+	if err != nil {
+		return err
+	}
+
+	actionWould, actionDone, verb := "would-detach", "detached", "Detach"
+	if attach {
+		actionWould, actionDone, verb = "would-attach", "attached", "Attach"
+	}
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = actionWould
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"policy_name", "policy_id", "target_type", "target", "target_id", "action"},
+		Rows:          [][]string{{policyName, policyID, targetType, target, targetID, action}},
+		ActionColumn:  5,
+		ConfirmPrompt: fmt.Sprintf("%s service control policy %s on %s %s", verb, policyName, targetType, targetID),
+		Execute: func(int) string {
+			var changeErr error
+			if attach {
+				_, changeErr = orgClient.AttachPolicy(ctx, &organizations.AttachPolicyInput{PolicyId: cliutil.Ptr(policyID), TargetId: cliutil.Ptr(targetID)})
+			} else {
+				_, changeErr = orgClient.DetachPolicy(ctx, &organizations.DetachPolicyInput{PolicyId: cliutil.Ptr(policyID), TargetId: cliutil.Ptr(targetID)})
+			}
+
+			var duplicateErr *organizationtypes.DuplicatePolicyAttachmentException
+			var notAttachedErr *organizationtypes.PolicyNotAttachedException
+			switch {
+			case changeErr == nil:
+				return actionDone
+			case errors.As(changeErr, &duplicateErr):
+				return cliutil.SkippedActionMessage("already-attached")
+			case errors.As(changeErr, &notAttachedErr):
+				return cliutil.SkippedActionMessage("not-attached")
+			default:
+				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(changeErr))
+			}
+		},
+	})
+}
+
+func resolveSCPID(ctx context.Context, orgClient OrganizationsAPI, policyName string) (string, error) {
+	policies, err := listPolicies(ctx, orgClient, organizationtypes.PolicyTypeServiceControlPolicy)
+	if err != nil {
+		return "", fmt.Errorf("list service control policies: %w", awstbxaws.WrapUserError(err))
+	}
+	for _, policy := range policies {
+		if cliutil.PointerToString(policy.Name) == policyName {
+			return cliutil.PointerToString(policy.Id), nil
+		}
+	}
+	return "", fmt.Errorf("service control policy not found: %s", policyName)
+}
+
+// resolvePolicyTarget turns --target into the ID a policy attaches to: a
+// 12-digit account ID, "root", an OU ID, or the name of an OU anywhere in the
+// tree. An OU name shared by several OUs is rejected rather than guessed.
+func resolvePolicyTarget(ctx context.Context, orgClient OrganizationsAPI, target string) (string, string, error) {
+	if orgAccountIDPattern.MatchString(target) {
+		return "account", target, nil
+	}
+	if strings.HasPrefix(target, "ou-") {
+		return "ou", target, nil
+	}
+
+	rootID, _, err := getRoot(ctx, orgClient)
+	if err != nil {
+		return "", "", fmt.Errorf("resolve organization root: %w", awstbxaws.WrapUserError(err))
+	}
+	if strings.EqualFold(target, rootParentName) {
+		return "root", rootID, nil
+	}
+	ou, _, err := findUniqueOU(ctx, orgClient, rootID, target)
+	if err != nil {
+		return "", "", err
+	}
+	return "ou", cliutil.PointerToString(ou.Id), nil
+}
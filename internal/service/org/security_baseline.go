@@ -0,0 +1,184 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const (
+	baselinePass = "pass"
+	baselineFail = "fail"
+)
+
+// baselineSecurityServices are the service principals expected to have a
+// delegated administrator outside the management account.
+var baselineSecurityServices = []string{
+	"access-analyzer.amazonaws.com",
+	"guardduty.amazonaws.com",
+	"inspector2.amazonaws.com",
+	"securityhub.amazonaws.com",
+}
+
+// baselinePolicyTypes are the policy types that must be enabled on the root
+// and have at least one customer-managed policy.
+var baselinePolicyTypes = []organizationtypes.PolicyType{
+	organizationtypes.PolicyTypeServiceControlPolicy,
+	organizationtypes.PolicyTypeTagPolicy,
+}
+
+func runSecurityBaselineReport(cmd *cobra.Command) error {
+	runtime, orgClient, _, _, accountClient, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	org, err := orgClient.DescribeOrganization(ctx, &organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return fmt.Errorf("describe organization: %w", awstbxaws.WrapUserError(err))
+	}
+	orgID := ""
+	featureSet := ""
+	managementAccountID := ""
+	if org.Organization != nil {
+		orgID = cliutil.PointerToString(org.Organization.Id)
+		featureSet = string(org.Organization.FeatureSet)
+		managementAccountID = cliutil.PointerToString(org.Organization.MasterAccountId)
+	}
+
+	rows := [][]string{{"organization", orgID, "feature-set", featureSet, baselineResult(featureSet == string(organizationtypes.OrganizationFeatureSetAll))}}
+
+	delegated, err := listDelegatedServices(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("list delegated administrators: %w", awstbxaws.WrapUserError(err))
+	}
+	for _, service := range baselineSecurityServices {
+		admins := delegated[service]
+		value := "none"
+		if len(admins) > 0 {
+			value = strings.Join(admins, ",")
+		}
+		rows = append(rows, []string{"service", service, "delegated-admin", value, baselineResult(len(admins) > 0)})
+	}
+
+	policyRows, err := policyTypeBaselineRows(ctx, orgClient)
+	if err != nil {
+		return err
+	}
+	rows = append(rows, policyRows...)
+
+	accounts, err := listAccounts(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(err))
+	}
+	sortAccountsByID(accounts)
+	for _, acct := range accounts {
+		id := cliutil.PointerToString(acct.Id)
+		hasContact, contactErr := hasSecurityContact(ctx, accountClient, id, managementAccountID)
+		switch {
+		case contactErr != nil:
+			rows = append(rows, []string{"account", id, "security-contact", "unknown", cliutil.FailedActionMessage(awstbxaws.FormatUserError(contactErr))})
+		case hasContact:
+			rows = append(rows, []string{"account", id, "security-contact", "present", baselinePass})
+		default:
+			rows = append(rows, []string{"account", id, "security-contact", "missing", baselineFail})
+		}
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"scope", "subject", "check", "value", "result"}, rows)
+}
+
+// listDelegatedServices maps each service principal to the accounts registered
+// as its delegated administrator.
+func listDelegatedServices(ctx context.Context, orgClient OrganizationsAPI) (map[string][]string, error) {
+	admins, err := listDelegatedAdministrators(ctx, orgClient, "")
+	if err != nil {
+		return nil, err
+	}
+
+	delegated := make(map[string][]string)
+	for _, admin := range admins {
+		accountID := cliutil.PointerToString(admin.Id)
+		services, err := listDelegatedServicesForAccount(ctx, orgClient, accountID)
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			principal := cliutil.PointerToString(service.ServicePrincipal)
+			delegated[principal] = append(delegated[principal], accountID)
+		}
+	}
+	for principal := range delegated {
+		sort.Strings(delegated[principal])
+	}
+
+	return delegated, nil
+}
+
+// policyTypeBaselineRows checks that each baseline policy type is enabled on
+// the root and that at least one customer-managed policy of that type exists.
+func policyTypeBaselineRows(ctx context.Context, orgClient OrganizationsAPI) ([][]string, error) {
+	roots, err := orgClient.ListRoots(ctx, &organizations.ListRootsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("list roots: %w", awstbxaws.WrapUserError(err))
+	}
+	enabled := make(map[organizationtypes.PolicyType]bool)
+	for _, root := range roots.Roots {
+		for _, policyType := range root.PolicyTypes {
+			if policyType.Status == organizationtypes.PolicyTypeStatusEnabled {
+				enabled[policyType.Type] = true
+			}
+		}
+	}
+
+	rows := make([][]string, 0, len(baselinePolicyTypes))
+	for _, policyType := range baselinePolicyTypes {
+		check := strings.ToLower(strings.ReplaceAll(string(policyType), "_", "-"))
+		if !enabled[policyType] {
+			rows = append(rows, []string{"policy", check, "enforcement", "disabled", baselineFail})
+			continue
+		}
+
+		policies, err := listPolicies(ctx, orgClient, policyType)
+		if err != nil {
+			return nil, fmt.Errorf("list %s policies: %w", check, awstbxaws.WrapUserError(err))
+		}
+		customerManaged := 0
+		for _, policy := range policies {
+			if !policy.AwsManaged {
+				customerManaged++
+			}
+		}
+		rows = append(rows, []string{"policy", check, "enforcement", fmt.Sprintf("enabled (%d customer-managed)", customerManaged), baselineResult(customerManaged > 0)})
+	}
+
+	return rows, nil
+}
+
+func listPolicies(ctx context.Context, orgClient OrganizationsAPI, policyType organizationtypes.PolicyType) ([]organizationtypes.PolicySummary, error) {
+	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[organizationtypes.PolicySummary], error) {
+		out, err := orgClient.ListPolicies(callCtx, &organizations.ListPoliciesInput{Filter: policyType, NextToken: nextToken})
+		if err != nil {
+			return awstbxaws.PageResult[organizationtypes.PolicySummary]{}, err
+		}
+		return awstbxaws.PageResult[organizationtypes.PolicySummary]{
+			Items:     out.Policies,
+			NextToken: out.NextToken,
+		}, nil
+	})
+}
+
+func baselineResult(ok bool) string {
+	if ok {
+		return baselinePass
+	}
+	return baselineFail
+}
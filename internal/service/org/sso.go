@@ -3,6 +3,7 @@ package org
 import (
 	"context"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
@@ -23,25 +24,38 @@ type ssoInstance struct {
 	IdentityStoreID string
 }
 
+// ssoAccountSelector names the accounts an SSO access change targets: the
+// accounts in an OU, an explicit list, or every active account.
+type ssoAccountSelector struct {
+	OUName      string
+	AccountIDs  []string
+	AllAccounts bool
+}
+
 const assignmentStatusPollInterval = 2 * time.Second
 
-func runAssignSSOAccess(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName, ouName string) error {
-	return runSSOAccessChange(cmd, principalName, principalTypeRaw, permissionSetName, ouName, true)
+func runAssignSSOAccess(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName string, selector ssoAccountSelector) error {
+	return runSSOAccessChange(cmd, principalName, principalTypeRaw, permissionSetName, selector, true)
 }
 
-func runRemoveSSOAccess(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName, ouName string) error {
-	return runSSOAccessChange(cmd, principalName, principalTypeRaw, permissionSetName, ouName, false)
+func runRemoveSSOAccess(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName string, selector ssoAccountSelector) error {
+	return runSSOAccessChange(cmd, principalName, principalTypeRaw, permissionSetName, selector, false)
 }
 
-func runSSOAccessChange(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName, ouName string, assign bool) error {
+func runSSOAccessChange(cmd *cobra.Command, principalName, principalTypeRaw, permissionSetName string, selector ssoAccountSelector, assign bool) error {
 	if strings.TrimSpace(principalName) == "" {
 		return fmt.Errorf("--principal-name is required")
 	}
 	if strings.TrimSpace(permissionSetName) == "" {
 		return fmt.Errorf("--permission-set-name is required")
 	}
-	if strings.TrimSpace(ouName) == "" {
-		return fmt.Errorf("--ou-name is required")
+	if strings.TrimSpace(selector.OUName) == "" && len(selector.AccountIDs) == 0 && !selector.AllAccounts {
+		return fmt.Errorf("one of --ou-name, --account-ids or --all-accounts is required")
+	}
+	for _, id := range selector.AccountIDs {
+		if !orgAccountIDPattern.MatchString(strings.TrimSpace(id)) {
+			return fmt.Errorf("--account-ids must list 12-digit AWS account IDs, got %q", id)
+		}
 	}
 
 	principalType, err := ssoPrincipalTypeFromString(principalTypeRaw)
@@ -67,11 +81,10 @@ func runSSOAccessChange(cmd *cobra.Command, principalName, principalTypeRaw, per
 	if err != nil {
 		return err
 	}
-	accountIDs, err := listAccountIDsByOU(ctx, orgClient, ouName)
+	accountIDs, err := resolveSSOTargetAccounts(ctx, orgClient, selector)
 	if err != nil {
 		return err
 	}
-	sort.Strings(accountIDs)
 
 	actionWould := "would-remove"
 	actionDone := "removed"
@@ -147,14 +160,55 @@ func runSSOAccessChange(cmd *cobra.Command, principalName, principalTypeRaw, per
 	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "principal_type", "principal_name", "permission_set", "action"}, rows)
 }
 
-func runListSSOAssignments(cmd *cobra.Command, accountID string) error {
+// resolveSSOTargetAccounts returns the sorted, de-duplicated account IDs the
+// selector names.
+func resolveSSOTargetAccounts(ctx context.Context, orgClient OrganizationsAPI, selector ssoAccountSelector) ([]string, error) {
+	var ids []string
+	switch {
+	case selector.AllAccounts:
+		accounts, err := listAccounts(ctx, orgClient)
+		if err != nil {
+			return nil, fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(err))
+		}
+		for _, account := range accounts {
+			if id := cliutil.PointerToString(account.Id); id != "" && account.Status == organizationtypes.AccountStatusActive {
+				ids = append(ids, id)
+			}
+		}
+	case len(selector.AccountIDs) > 0:
+		for _, id := range selector.AccountIDs {
+			ids = append(ids, strings.TrimSpace(id))
+		}
+	default:
+		var err error
+		ids, err = listAccountIDsByOU(ctx, orgClient, selector.OUName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(ids)
+	unique := make([]string, 0, len(ids))
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		unique = append(unique, id)
+	}
+	return unique, nil
+}
+
+// runListSSOAssignments lists assignments per account and permission set.
+// With resolveNames, principal and permission set names are added next to
+// their IDs; each is looked up once however many accounts it appears in.
+func runListSSOAssignments(cmd *cobra.Command, accountID string, resolveNames bool) error {
 	if accountID != "" {
 		if err := validateAccountID(accountID); err != nil {
 			return err
 		}
 	}
 
-	runtime, orgClient, ssoClient, _, _, err := runtimeClients(cmd)
+	runtime, orgClient, ssoClient, identityClient, _, err := runtimeClients(cmd)
 	if err != nil {
 		return err
 	}
@@ -166,20 +220,21 @@ func runListSSOAssignments(cmd *cobra.Command, accountID string) error {
 	}
 	permissionSets, err := listPermissionSets(ctx, ssoClient, instance.InstanceARN)
 	if err != nil {
-		return fmt.Errorf("list permission sets: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list permission sets: %w", awstbxaws.WrapUserError(err))
 	}
 	sort.Strings(permissionSets)
+	names := newSSONameResolver(ssoClient, identityClient, instance, cmd.ErrOrStderr())
 
 	accounts := make([]organizationtypes.Account, 0)
 	if accountID == "" {
 		accounts, err = listAccounts(ctx, orgClient)
 		if err != nil {
-			return fmt.Errorf("list accounts: %s", awstbxaws.FormatUserError(err))
+			return fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(err))
 		}
 	} else {
 		out, describeErr := orgClient.DescribeAccount(ctx, &organizations.DescribeAccountInput{AccountId: cliutil.Ptr(accountID)})
 		if describeErr != nil {
-			return fmt.Errorf("describe account %s: %s", accountID, awstbxaws.FormatUserError(describeErr))
+			return fmt.Errorf("describe account %s: %w", accountID, awstbxaws.WrapUserError(describeErr))
 		}
 		if out.Account != nil {
 			accounts = append(accounts, *out.Account)
@@ -193,22 +248,107 @@ func runListSSOAssignments(cmd *cobra.Command, accountID string) error {
 		for _, psArn := range permissionSets {
 			assignments, listErr := listAssignments(ctx, ssoClient, instance.InstanceARN, id, psArn)
 			if listErr != nil {
-				return fmt.Errorf("list assignments for account %s: %s", id, awstbxaws.FormatUserError(listErr))
+				return fmt.Errorf("list assignments for account %s: %w", id, awstbxaws.WrapUserError(listErr))
 			}
 			for _, assignment := range assignments {
-				rows = append(rows, []string{id, cliutil.PointerToString(acct.Name), string(assignment.PrincipalType), cliutil.PointerToString(assignment.PrincipalId), psArn})
+				principalID := cliutil.PointerToString(assignment.PrincipalId)
+				if !resolveNames {
+					rows = append(rows, []string{id, cliutil.PointerToString(acct.Name), string(assignment.PrincipalType), principalID, psArn})
+					continue
+				}
+				rows = append(rows, []string{
+					id,
+					cliutil.PointerToString(acct.Name),
+					string(assignment.PrincipalType),
+					principalID,
+					psArn,
+					names.principalName(ctx, assignment.PrincipalType, principalID),
+					names.permissionSetName(ctx, psArn),
+				})
 			}
 		}
 	}
 	sort.Slice(rows, func(i, j int) bool { return strings.Join(rows[i], "\x00") < strings.Join(rows[j], "\x00") })
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "account_name", "principal_type", "principal_id", "permission_set_arn"}, rows)
+	headers := []string{"account_id", "account_name", "principal_type", "principal_id", "permission_set_arn"}
+	if resolveNames {
+		headers = append(headers, "principal_name", "permission_set_name")
+	}
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
+}
+
+// ssoNameResolver caches principal and permission set names. A failed lookup
+// is cached as an empty name so the listing still completes, and is reported
+// once on warnings so the blank column is explained.
+type ssoNameResolver struct {
+	ssoClient          SSOAdminAPI
+	identityClient     IdentityStoreAPI
+	instance           ssoInstance
+	warnings           io.Writer
+	principalNames     map[string]string
+	permissionSetNames map[string]string
+}
+
+func newSSONameResolver(ssoClient SSOAdminAPI, identityClient IdentityStoreAPI, instance ssoInstance, warnings io.Writer) *ssoNameResolver {
+	return &ssoNameResolver{
+		ssoClient:          ssoClient,
+		identityClient:     identityClient,
+		instance:           instance,
+		warnings:           warnings,
+		principalNames:     make(map[string]string),
+		permissionSetNames: make(map[string]string),
+	}
+}
+
+func (r *ssoNameResolver) principalName(ctx context.Context, principalType ssoadmintypes.PrincipalType, principalID string) string {
+	key := string(principalType) + "/" + principalID
+	if name, ok := r.principalNames[key]; ok {
+		return name
+	}
+
+	name := ""
+	var err error
+	switch principalType {
+	case ssoadmintypes.PrincipalTypeGroup:
+		var out *identitystore.DescribeGroupOutput
+		out, err = r.identityClient.DescribeGroup(ctx, &identitystore.DescribeGroupInput{IdentityStoreId: cliutil.Ptr(r.instance.IdentityStoreID), GroupId: cliutil.Ptr(principalID)})
+		if err == nil {
+			name = cliutil.PointerToString(out.DisplayName)
+		}
+	case ssoadmintypes.PrincipalTypeUser:
+		var out *identitystore.DescribeUserOutput
+		out, err = r.identityClient.DescribeUser(ctx, &identitystore.DescribeUserInput{IdentityStoreId: cliutil.Ptr(r.instance.IdentityStoreID), UserId: cliutil.Ptr(principalID)})
+		if err == nil {
+			name = cliutil.PointerToString(out.UserName)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(r.warnings, "warning: resolve %s %s: %s\n", strings.ToLower(string(principalType)), principalID, awstbxaws.FormatUserError(err))
+	}
+	r.principalNames[key] = name
+	return name
+}
+
+func (r *ssoNameResolver) permissionSetName(ctx context.Context, permissionSetARN string) string {
+	if name, ok := r.permissionSetNames[permissionSetARN]; ok {
+		return name
+	}
+
+	name := ""
+	out, err := r.ssoClient.DescribePermissionSet(ctx, &ssoadmin.DescribePermissionSetInput{InstanceArn: cliutil.Ptr(r.instance.InstanceARN), PermissionSetArn: cliutil.Ptr(permissionSetARN)})
+	if err != nil {
+		fmt.Fprintf(r.warnings, "warning: resolve permission set %s: %s\n", permissionSetARN, awstbxaws.FormatUserError(err))
+	} else if out.PermissionSet != nil {
+		name = cliutil.PointerToString(out.PermissionSet.Name)
+	}
+	r.permissionSetNames[permissionSetARN] = name
+	return name
 }
 
 func resolveSSOInstance(ctx context.Context, ssoClient SSOAdminAPI) (ssoInstance, error) {
 	out, err := ssoClient.ListInstances(ctx, &ssoadmin.ListInstancesInput{})
 	if err != nil {
-		return ssoInstance{}, fmt.Errorf("list SSO instances: %s", awstbxaws.FormatUserError(err))
+		return ssoInstance{}, fmt.Errorf("list SSO instances: %w", awstbxaws.WrapUserError(err))
 	}
 	if len(out.Instances) == 0 {
 		return ssoInstance{}, fmt.Errorf("no IAM Identity Center instances found")
@@ -221,7 +361,7 @@ func resolvePrincipalID(ctx context.Context, identityClient IdentityStoreAPI, st
 	if principalType == ssoadmintypes.PrincipalTypeUser {
 		out, err := identityClient.ListUsers(ctx, &identitystore.ListUsersInput{IdentityStoreId: cliutil.Ptr(storeID), Filters: []identitystoretypes.Filter{filter}})
 		if err != nil {
-			return "", fmt.Errorf("lookup user %q: %s", principalName, awstbxaws.FormatUserError(err))
+			return "", fmt.Errorf("lookup user %q: %w", principalName, awstbxaws.WrapUserError(err))
 		}
 		if len(out.Users) == 0 {
 			return "", fmt.Errorf("principal not found: %s", principalName)
@@ -230,7 +370,7 @@ func resolvePrincipalID(ctx context.Context, identityClient IdentityStoreAPI, st
 	}
 	out, err := identityClient.ListGroups(ctx, &identitystore.ListGroupsInput{IdentityStoreId: cliutil.Ptr(storeID), Filters: []identitystoretypes.Filter{filter}})
 	if err != nil {
-		return "", fmt.Errorf("lookup group %q: %s", principalName, awstbxaws.FormatUserError(err))
+		return "", fmt.Errorf("lookup group %q: %w", principalName, awstbxaws.WrapUserError(err))
 	}
 	if len(out.Groups) == 0 {
 		return "", fmt.Errorf("principal not found: %s", principalName)
@@ -254,7 +394,7 @@ func listPermissionSets(ctx context.Context, ssoClient SSOAdminAPI, instanceARN
 func resolvePermissionSetARN(ctx context.Context, ssoClient SSOAdminAPI, instanceARN, permissionSetName string) (string, error) {
 	arns, err := listPermissionSets(ctx, ssoClient, instanceARN)
 	if err != nil {
-		return "", fmt.Errorf("list permission sets: %s", awstbxaws.FormatUserError(err))
+		return "", fmt.Errorf("list permission sets: %w", awstbxaws.WrapUserError(err))
 	}
 	for _, arn := range arns {
 		out, describeErr := ssoClient.DescribePermissionSet(ctx, &ssoadmin.DescribePermissionSetInput{InstanceArn: cliutil.Ptr(instanceARN), PermissionSetArn: cliutil.Ptr(arn)})
@@ -0,0 +1,207 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const (
+	tagComplianceCompliant    = "compliant"
+	tagComplianceNonCompliant = "non-compliant"
+	tagComplianceNoPolicy     = "no-policy"
+)
+
+// tagPolicyRule is one tag defined by a tag policy: the key with its required
+// capitalization and, optionally, the values it may take.
+type tagPolicyRule struct {
+	Key    string
+	Values []string
+}
+
+func runTagCompliance(cmd *cobra.Command, policyID string) error {
+	policyID = strings.TrimSpace(policyID)
+
+	runtime, orgClient, _, _, _, err := runtimeClients(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	var policyRules []tagPolicyRule
+	if policyID != "" {
+		out, describeErr := orgClient.DescribePolicy(ctx, &organizations.DescribePolicyInput{PolicyId: cliutil.Ptr(policyID)})
+		if describeErr != nil {
+			return fmt.Errorf("describe policy %s: %w", policyID, awstbxaws.WrapUserError(describeErr))
+		}
+		if out.Policy == nil || out.Policy.PolicySummary == nil || out.Policy.PolicySummary.Type != organizationtypes.PolicyTypeTagPolicy {
+			return fmt.Errorf("policy %s is not a tag policy", policyID)
+		}
+		policyRules, err = parseTagPolicy(cliutil.PointerToString(out.Policy.Content))
+		if err != nil {
+			return fmt.Errorf("parse policy %s: %w", policyID, err)
+		}
+	}
+
+	accounts, err := listAccounts(ctx, orgClient)
+	if err != nil {
+		return fmt.Errorf("list accounts: %w", awstbxaws.WrapUserError(err))
+	}
+	sortAccountsByID(accounts)
+
+	resolver := tagResolver(orgClient)
+	rows := make([][]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountID := cliutil.PointerToString(account.Id)
+		if accountID == "" {
+			continue
+		}
+
+		rules := policyRules
+		if policyID == "" {
+			rules, err = effectiveTagPolicyRules(ctx, orgClient, accountID)
+			if err != nil {
+				return err
+			}
+		}
+		if len(rules) == 0 {
+			rows = append(rows, []string{accountID, cliutil.PointerToString(account.Name), tagComplianceNoPolicy, ""})
+			continue
+		}
+
+		tags, tagErr := resolver.ResourceTags(ctx, accountID)
+		if tagErr != nil {
+			return fmt.Errorf("list tags for account %s: %w", accountID, awstbxaws.WrapUserError(tagErr))
+		}
+
+		status := tagComplianceCompliant
+		violations := tagPolicyViolations(rules, tags)
+		if len(violations) > 0 {
+			status = tagComplianceNonCompliant
+		}
+		rows = append(rows, []string{accountID, cliutil.PointerToString(account.Name), status, strings.Join(violations, "; ")})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"account_id", "account_name", "status", "violations"}, rows)
+}
+
+// effectiveTagPolicyRules returns the rules of the tag policy in effect for an
+// account, or none when no tag policy applies to it.
+func effectiveTagPolicyRules(ctx context.Context, orgClient OrganizationsAPI, accountID string) ([]tagPolicyRule, error) {
+	out, err := orgClient.DescribeEffectivePolicy(ctx, &organizations.DescribeEffectivePolicyInput{
+		PolicyType: organizationtypes.EffectivePolicyTypeTagPolicy,
+		TargetId:   cliutil.Ptr(accountID),
+	})
+	if err != nil {
+		var notFound *organizationtypes.EffectivePolicyNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("describe effective tag policy for account %s: %w", accountID, awstbxaws.WrapUserError(err))
+	}
+	if out.EffectivePolicy == nil {
+		return nil, nil
+	}
+
+	rules, err := parseTagPolicy(cliutil.PointerToString(out.EffectivePolicy.PolicyContent))
+	if err != nil {
+		return nil, fmt.Errorf("parse effective tag policy for account %s: %w", accountID, err)
+	}
+	return rules, nil
+}
+
+// parseTagPolicy reads the tags section of a tag policy. It accepts both the
+// authored syntax, where settings sit under "@@assign", and the effective
+// policy syntax, where they are plain values.
+func parseTagPolicy(content string) ([]tagPolicyRule, error) {
+	var policy struct {
+		Tags map[string]struct {
+			TagKey   json.RawMessage `json:"tag_key"`
+			TagValue json.RawMessage `json:"tag_value"`
+		} `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(content), &policy); err != nil {
+		return nil, err
+	}
+
+	rules := make([]tagPolicyRule, 0, len(policy.Tags))
+	for name, tag := range policy.Tags {
+		rule := tagPolicyRule{Key: name}
+		if len(tag.TagKey) > 0 {
+			var key string
+			if err := unmarshalPolicySetting(tag.TagKey, &key); err != nil {
+				return nil, fmt.Errorf("tag %s: tag_key: %w", name, err)
+			}
+			if key != "" {
+				rule.Key = key
+			}
+		}
+		if len(tag.TagValue) > 0 {
+			if err := unmarshalPolicySetting(tag.TagValue, &rule.Values); err != nil {
+				return nil, fmt.Errorf("tag %s: tag_value: %w", name, err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Key < rules[j].Key
+	})
+	return rules, nil
+}
+
+func unmarshalPolicySetting(data json.RawMessage, out any) error {
+	var assigned struct {
+		Assign json.RawMessage `json:"@@assign"`
+	}
+	if err := json.Unmarshal(data, &assigned); err == nil && len(assigned.Assign) > 0 {
+		data = assigned.Assign
+	}
+	return json.Unmarshal(data, out)
+}
+
+// tagPolicyViolations lists how tags break the rules: a missing key, a key
+// with the wrong capitalization, or a value outside the allowed list. Allowed
+// values may end in "*" to match any suffix, as in tag policies.
+func tagPolicyViolations(rules []tagPolicyRule, tags map[string]string) []string {
+	violations := make([]string, 0)
+	for _, rule := range rules {
+		value, ok := tags[rule.Key]
+		if !ok {
+			violation := rule.Key + ": missing"
+			for key := range tags {
+				if strings.EqualFold(key, rule.Key) {
+					violation = fmt.Sprintf("%s: key must be capitalized as %s", key, rule.Key)
+					break
+				}
+			}
+			violations = append(violations, violation)
+			continue
+		}
+		if len(rule.Values) > 0 && !tagValueAllowed(rule.Values, value) {
+			violations = append(violations, fmt.Sprintf("%s=%s: value not allowed", rule.Key, value))
+		}
+	}
+	return violations
+}
+
+func tagValueAllowed(allowed []string, value string) bool {
+	for _, pattern := range allowed {
+		if pattern == value {
+			return true
+		}
+		if prefix, wildcard := strings.CutSuffix(pattern, "*"); wildcard && strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
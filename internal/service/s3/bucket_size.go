@@ -0,0 +1,93 @@
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+type prefixTotals struct {
+	objects int
+	bytes   int64
+}
+
+// runBucketSize totals object count and bytes, optionally per group of the
+// first depth key segments. Totals are accumulated page by page, so memory
+// grows with the number of groups rather than the number of objects.
+func runBucketSize(cmd *cobra.Command, bucketName, prefix string, depth int) error {
+	bucketName = strings.TrimSpace(bucketName)
+	if bucketName == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+	if depth < 0 {
+		return fmt.Errorf("--group-by-prefix-depth must be >= 0")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	region, err := resolveBucketRegion(cmd.Context(), client, s3types.Bucket{Name: cliutil.Ptr(bucketName)})
+	if err != nil {
+		return err
+	}
+
+	totals := make(map[string]*prefixTotals)
+	err = walkObjects(cmd.Context(), newRegionalClients(cfg, client).forRegion(region), bucketName, prefix, func(page []s3types.Object) {
+		for _, object := range page {
+			group := prefix
+			if depth > 0 {
+				group = keyPrefixGroup(objectKey(object), depth)
+			}
+			if totals[group] == nil {
+				totals[group] = &prefixTotals{}
+			}
+			totals[group].objects++
+			totals[group].bytes += objectSize(object)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
+	}
+	if depth == 0 && totals[prefix] == nil {
+		totals[prefix] = &prefixTotals{}
+	}
+
+	groups := make([]string, 0, len(totals))
+	for group := range totals {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	rows := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		rows = append(rows, []string{
+			bucketName,
+			group,
+			strconv.Itoa(totals[group].objects),
+			strconv.FormatInt(totals[group].bytes, 10),
+			formatByteSize(totals[group].bytes),
+		})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "prefix", "object_count", "total_bytes", "size"}, rows)
+}
+
+// keyPrefixGroup returns the first depth "/"-separated segments of key with a
+// trailing slash. Objects with fewer segments are grouped under their own
+// parent prefix, which is empty at the bucket root.
+func keyPrefixGroup(key string, depth int) string {
+	segments := strings.Split(key, "/")
+	keep := min(depth, len(segments)-1)
+	if keep == 0 {
+		return ""
+	}
+	return strings.Join(segments[:keep], "/") + "/"
+}
@@ -0,0 +1,170 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+type bucketInventory struct {
+	name        string
+	region      string
+	createdAt   string
+	versioning  string
+	objectCount int
+	sizeBytes   int64
+}
+
+func runListBuckets(cmd *cobra.Command, withSize bool, concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	buckets, err := listBuckets(cmd.Context(), client)
+	if err != nil {
+		return fmt.Errorf("list buckets: %w", awstbxaws.WrapUserError(err))
+	}
+
+	inventory, err := describeBuckets(cmd.Context(), cfg, client, buckets, withSize, concurrency)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"bucket", "region", "creation_date", "versioning"}
+	if withSize {
+		headers = append(headers, "object_count", "size_bytes")
+	}
+
+	rows := make([][]string, 0, len(inventory))
+	for _, bucket := range inventory {
+		row := []string{bucket.name, bucket.region, bucket.createdAt, bucket.versioning}
+		if withSize {
+			row = append(row, fmt.Sprintf("%d", bucket.objectCount), fmt.Sprintf("%d", bucket.sizeBytes))
+		}
+		rows = append(rows, row)
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, headers, rows)
+}
+
+// describeBuckets enriches each bucket with its region, versioning state and,
+// optionally, object totals. At most concurrency buckets are queried at once;
+// the first error aborts the inventory.
+func describeBuckets(ctx context.Context, cfg awssdk.Config, client API, buckets []s3types.Bucket, withSize bool, concurrency int) ([]bucketInventory, error) {
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		results  = make([]bucketInventory, len(buckets))
+		limit    = make(chan struct{}, concurrency)
+		clients  = newRegionalClients(cfg, client)
+	)
+
+	for i, bucket := range buckets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			inventory, err := describeBucket(ctx, clients, bucket, withSize)
+			if err != nil {
+				errOnce.Do(func() { firstErr = err })
+				return
+			}
+			results[i] = inventory
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].name < results[j].name
+	})
+
+	return results, nil
+}
+
+func describeBucket(ctx context.Context, clients *regionalClients, bucket s3types.Bucket, withSize bool) (bucketInventory, error) {
+	name := cliutil.PointerToString(bucket.Name)
+	inventory := bucketInventory{name: name, versioning: "Disabled"}
+	if bucket.CreationDate != nil {
+		inventory.createdAt = bucket.CreationDate.UTC().Format(time.RFC3339)
+	}
+
+	inventory.region = cliutil.PointerToString(bucket.BucketRegion)
+	if inventory.region == "" {
+		location, err := clients.base.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: cliutil.Ptr(name)})
+		if err != nil {
+			return inventory, fmt.Errorf("get bucket location for %s: %w", name, awstbxaws.WrapUserError(err))
+		}
+		inventory.region = bucketRegion(location.LocationConstraint)
+	}
+
+	regionalClient := clients.forRegion(inventory.region)
+	versioning, err := regionalClient.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: cliutil.Ptr(name)})
+	if err != nil {
+		return inventory, fmt.Errorf("get bucket versioning for %s: %w", name, awstbxaws.WrapUserError(err))
+	}
+	if versioning.Status != "" {
+		inventory.versioning = string(versioning.Status)
+	}
+
+	if withSize {
+		err := walkObjects(ctx, regionalClient, name, "", func(page []s3types.Object) {
+			inventory.objectCount += len(page)
+			for _, object := range page {
+				inventory.sizeBytes += objectSize(object)
+			}
+		})
+		if err != nil {
+			return inventory, fmt.Errorf("list objects for %s: %w", name, awstbxaws.WrapUserError(err))
+		}
+	}
+
+	return inventory, nil
+}
+
+// regionalClients hands out one client per bucket region, reusing the base
+// client for the configured region.
+type regionalClients struct {
+	mu      sync.Mutex
+	cfg     awssdk.Config
+	base    API
+	clients map[string]API
+}
+
+func newRegionalClients(cfg awssdk.Config, base API) *regionalClients {
+	return &regionalClients{cfg: cfg, base: base, clients: map[string]API{cfg.Region: base}}
+}
+
+func (r *regionalClients) forRegion(region string) API {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[region]; ok {
+		return client
+	}
+	regionalCfg := r.cfg
+	regionalCfg.Region = region
+	client := newClient(regionalCfg)
+	r.clients[region] = client
+	return client
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/spf13/cobra"
@@ -14,23 +16,43 @@ import (
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
-func runDeleteBuckets(cmd *cobra.Command, emptyOnly bool, filterNameContains string) error {
+// runDeleteBuckets deletes matching buckets. With allRegions each bucket is
+// checked, emptied and deleted through a client for its home region, since
+// S3 redirects calls made against any other region.
+func runDeleteBuckets(cmd *cobra.Command, emptyOnly bool, filterNameContains string, abortUploads, forceVersioned, allRegions bool) error {
 	filterNameContains = strings.TrimSpace(filterNameContains)
 	if !emptyOnly && filterNameContains == "" {
 		return fmt.Errorf("set --empty or --filter-name-contains")
 	}
+	if forceVersioned && !emptyOnly {
+		return fmt.Errorf("--force-versioned requires --empty")
+	}
 
-	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
 	buckets, err := listBuckets(cmd.Context(), client)
 	if err != nil {
-		return fmt.Errorf("list buckets: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list buckets: %w", awstbxaws.WrapUserError(err))
+	}
+
+	clients := newRegionalClients(cfg, client)
+	bucketRegions := make(map[string]string)
+	clientFor := func(name string) API {
+		if !allRegions {
+			return client
+		}
+		return clients.forRegion(bucketRegions[name])
 	}
 
 	targets := make([]string, 0, len(buckets))
+	// versioned records the targets whose old versions and delete markers
+	// --force-versioned will purge; hasObjects records the buckets it
+	// reports as skipped because they still hold current objects.
+	versioned := make(map[string]bool)
+	hasObjects := make(map[string]bool)
 	for _, bucket := range buckets {
 		name := cliutil.PointerToString(bucket.Name)
 		if name == "" {
@@ -39,8 +61,26 @@ func runDeleteBuckets(cmd *cobra.Command, emptyOnly bool, filterNameContains str
 		if filterNameContains != "" && !strings.Contains(name, filterNameContains) {
 			continue
 		}
-		if emptyOnly {
-			ok, checkErr := isBucketEmptyAndUnversioned(cmd.Context(), client, name)
+		if allRegions {
+			region, regionErr := resolveBucketRegion(cmd.Context(), client, bucket)
+			if regionErr != nil {
+				return regionErr
+			}
+			bucketRegions[name] = region
+		}
+		if forceVersioned {
+			empty, status, checkErr := bucketCurrentObjectsAndVersioning(cmd.Context(), clientFor(name), name)
+			if checkErr != nil {
+				return checkErr
+			}
+			if !empty {
+				hasObjects[name] = true
+				targets = append(targets, name)
+				continue
+			}
+			versioned[name] = status != ""
+		} else if emptyOnly {
+			ok, checkErr := isBucketEmptyAndUnversioned(cmd.Context(), clientFor(name), name)
 			if checkErr != nil {
 				return checkErr
 			}
@@ -55,25 +95,74 @@ func runDeleteBuckets(cmd *cobra.Command, emptyOnly bool, filterNameContains str
 	sort.Strings(targets)
 
 	rows := make([][]string, 0, len(targets))
+	deletable := 0
 	for _, name := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
-		rows = append(rows, []string{name, action})
+		if hasObjects[name] {
+			action = cliutil.SkippedActionMessage("has-current-objects")
+		} else {
+			deletable++
+		}
+		row := []string{name}
+		if allRegions {
+			row = append(row, bucketRegions[name])
+		}
+		row = append(row, action)
+		if abortUploads {
+			row = append(row, "")
+		}
+		if forceVersioned {
+			note := ""
+			if versioned[name] {
+				note = "versioned contents will be purged"
+			}
+			row = append(row, note)
+		}
+		rows = append(rows, row)
+	}
+
+	baseHeaders := []string{"bucket"}
+	if allRegions {
+		baseHeaders = append(baseHeaders, "region")
+	}
+	actionColumn := len(baseHeaders)
+	baseHeaders = append(baseHeaders, "action")
+	if abortUploads {
+		baseHeaders = append(baseHeaders, "aborted_uploads")
+	}
+	if forceVersioned {
+		baseHeaders = append(baseHeaders, "note")
+	}
+	headers, rows, err := cliutil.AppendTagColumns(cmd.Context(), bucketTagResolver(clientFor), runtime.Options.IncludeTags, baseHeaders, rows, 0)
+	if err != nil {
+		return fmt.Errorf("include tags: %w", awstbxaws.WrapUserError(err))
+	}
+	if deletable == 0 {
+		return cliutil.WriteDataset(cmd, runtime, headers, rows)
 	}
 
 	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
-		Headers:       []string{"bucket", "action"},
+		Headers:       headers,
 		Rows:          rows,
-		ActionColumn:  1,
-		ConfirmPrompt: fmt.Sprintf("Delete %d S3 bucket(s)", len(rows)),
+		ActionColumn:  actionColumn,
+		ConfirmPrompt: fmt.Sprintf("Delete %d S3 bucket(s)", deletable),
 		Execute: func(rowIndex int) string {
 			bucket := rows[rowIndex][0]
-			if clearErr := deleteAllObjectsFromBucket(cmd.Context(), client, bucket); clearErr != nil {
+			if hasObjects[bucket] {
+				return ""
+			}
+			bucketClient := clientFor(bucket)
+			aborted, clearErr := deleteAllObjectsFromBucket(cmd.Context(), bucketClient, bucket, abortUploads)
+			if abortUploads {
+				rows[rowIndex][actionColumn+1] = fmt.Sprintf("%d", aborted)
+			}
+			if clearErr != nil {
 				return cliutil.FailedAction(clearErr)
 			}
-			_, deleteErr := client.DeleteBucket(cmd.Context(), &s3.DeleteBucketInput{Bucket: cliutil.Ptr(bucket)})
+			_, deleteErr := bucketClient.DeleteBucket(cmd.Context(), &s3.DeleteBucketInput{Bucket: cliutil.Ptr(bucket)})
 			if deleteErr != nil {
 				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
 			}
@@ -82,13 +171,21 @@ func runDeleteBuckets(cmd *cobra.Command, emptyOnly bool, filterNameContains str
 	})
 }
 
-func runDownloadBucket(cmd *cobra.Command, bucket, prefix, outputDir string) error {
+// runDownloadBucket downloads up to concurrency objects at once. Target paths
+// are validated for every key before any download starts, and rows stay in
+// key order whatever order the downloads finish in. With skipExisting, files
+// already on disk with the object's size are left alone so an interrupted
+// download can be resumed; otherwise every object replaces its local file.
+func runDownloadBucket(cmd *cobra.Command, bucket, prefix, outputDir string, concurrency int, skipExisting bool) error {
 	if strings.TrimSpace(bucket) == "" {
 		return fmt.Errorf("--bucket-name is required")
 	}
 	if strings.TrimSpace(prefix) == "" {
 		return fmt.Errorf("--prefix is required")
 	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
@@ -97,11 +194,12 @@ func runDownloadBucket(cmd *cobra.Command, bucket, prefix, outputDir string) err
 
 	objects, err := listObjects(cmd.Context(), client, bucket, prefix)
 	if err != nil {
-		return fmt.Errorf("list objects: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
 	}
 	sortObjectsByKey(objects)
 
 	rows := make([][]string, 0, len(objects))
+	pending := make([]int, 0, len(objects))
 	for _, object := range objects {
 		key := objectKey(object)
 		relativeKey := strings.TrimPrefix(key, prefix)
@@ -115,30 +213,58 @@ func runDownloadBucket(cmd *cobra.Command, bucket, prefix, outputDir string) err
 			continue
 		}
 
-		action := "would-download"
-		if runtime.Options.DryRun {
-			rows = append(rows, []string{bucket, key, targetPath, action})
+		if skipExisting && localFileMatchesSize(targetPath, object) {
+			rows = append(rows, []string{bucket, key, targetPath, cliutil.SkippedActionMessage("already-present")})
 			continue
 		}
 
-		if err := downloadObject(cmd.Context(), client, bucket, key, targetPath); err != nil {
-			action = cliutil.FailedAction(err)
-		} else {
-			action = "downloaded"
+		rows = append(rows, []string{bucket, key, targetPath, "would-download"})
+		if !runtime.Options.DryRun {
+			pending = append(pending, len(rows)-1)
 		}
-		rows = append(rows, []string{bucket, key, targetPath, action})
 	}
 
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, concurrency)
+	for _, rowIndex := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			row := rows[rowIndex]
+			if err := downloadObject(cmd.Context(), client, bucket, row[1], row[2]); err != nil {
+				row[3] = cliutil.FailedAction(err)
+				return
+			}
+			row[3] = "downloaded"
+		}()
+	}
+	wg.Wait()
+
 	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "key", "target_path", "action"}, rows)
 }
 
-func runListOldFiles(cmd *cobra.Command, bucket, prefix string, olderThanDays int) error {
+func runListOldFiles(cmd *cobra.Command, bucket, prefix string, olderThanDays int, olderThan string, minSizeBytes int64, storageClass string) error {
 	if strings.TrimSpace(bucket) == "" {
 		return fmt.Errorf("--bucket-name is required")
 	}
 	if olderThanDays < 0 {
 		return fmt.Errorf("--older-than-days must be >= 0")
 	}
+	if minSizeBytes < 0 {
+		return fmt.Errorf("--min-size-bytes must be >= 0")
+	}
+	storageClass = strings.TrimSpace(storageClass)
+	spec := fmt.Sprintf("%dd", olderThanDays)
+	if strings.TrimSpace(olderThan) != "" {
+		spec = olderThan
+	}
+	cutoff, err := cliutil.ParseTimeSpec(spec)
+	if err != nil {
+		return fmt.Errorf("--older-than: %w", err)
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
@@ -147,7 +273,7 @@ func runListOldFiles(cmd *cobra.Command, bucket, prefix string, olderThanDays in
 
 	objects, err := listObjects(cmd.Context(), client, bucket, prefix)
 	if err != nil {
-		return fmt.Errorf("list objects: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
 	}
 	sortObjectsByKey(objects)
 
@@ -158,10 +284,16 @@ func runListOldFiles(cmd *cobra.Command, bucket, prefix string, olderThanDays in
 		if lastModified.IsZero() {
 			continue
 		}
-		ageDays := int(now.Sub(lastModified).Hours() / 24)
-		if ageDays < olderThanDays {
+		if lastModified.After(cutoff) {
+			continue
+		}
+		if objectSize(object) < minSizeBytes {
+			continue
+		}
+		if storageClass != "" && !strings.EqualFold(string(objectStorageClass(object)), storageClass) {
 			continue
 		}
+		ageDays := int(now.Sub(lastModified).Hours() / 24)
 		rows = append(rows, []string{
 			bucket,
 			objectKey(object),
@@ -171,27 +303,58 @@ func runListOldFiles(cmd *cobra.Command, bucket, prefix string, olderThanDays in
 		})
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "key", "last_modified", "age_days", "size_bytes"}, rows)
+	return cliutil.WriteLimitedDataset(cmd, runtime, []string{"bucket", "key", "last_modified", "age_days", "size_bytes"}, rows, len(rows))
 }
 
-func runSearchObjects(cmd *cobra.Command, bucket, prefix string, keys []string) error {
+func runSearchObjects(cmd *cobra.Command, bucket, prefix string, keys []string, matchMode string, stream bool) error {
 	if strings.TrimSpace(bucket) == "" {
 		return fmt.Errorf("--bucket-name is required")
 	}
 
-	queries := normalizeKeyQueries(keys)
+	matchMode = strings.ToLower(strings.TrimSpace(matchMode))
+	var queries []string
+	switch matchMode {
+	case keyMatchExact:
+		queries = normalizeKeyQueries(keys)
+	case keyMatchGlob, keyMatchRegex:
+		// Patterns such as {a,b} or x{1,3} contain commas, so each --keys
+		// value is one pattern.
+		queries = normalizeKeyPatterns(keys)
+	default:
+		return fmt.Errorf("--match-mode must be one of: exact, glob, regex")
+	}
 	if strings.TrimSpace(prefix) == "" && len(queries) == 0 {
 		return fmt.Errorf("set --prefix and/or --keys")
 	}
+	var matchers []func(string) bool
+	if matchMode != keyMatchExact {
+		var err error
+		matchers, err = compileKeyPatterns(queries, matchMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	// --stream always writes JSON lines, so an explicit non-json --output
+	// would be silently ignored.
+	if stream && cmd.Flags().Changed("output") {
+		if format, _ := cmd.Flags().GetString("output"); strings.ToLower(strings.TrimSpace(format)) != "json" {
+			return fmt.Errorf("--stream writes JSON lines; use --output json or omit --output")
+		}
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
 		return err
 	}
 
+	if stream {
+		return streamSearchObjects(cmd, client, bucket, prefix, queries, matchers, runtime.Options.Limit)
+	}
+
 	objects, err := listObjects(cmd.Context(), client, bucket, prefix)
 	if err != nil {
-		return fmt.Errorf("list objects: %s", awstbxaws.FormatUserError(err))
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
 	}
 	sortObjectsByKey(objects)
 
@@ -209,6 +372,10 @@ func runSearchObjects(cmd *cobra.Command, bucket, prefix string, keys []string)
 		return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "key", "exists", "last_modified", "size_bytes"}, rows)
 	}
 
+	if matchers != nil {
+		return cliutil.WriteDataset(cmd, runtime, searchResultHeaders, patternSearchRows(bucket, queries, matchers, objects))
+	}
+
 	objectByKey := make(map[string]s3types.Object, len(objects))
 	for _, object := range objects {
 		objectByKey[objectKey(object)] = object
@@ -227,28 +394,45 @@ func runSearchObjects(cmd *cobra.Command, bucket, prefix string, keys []string)
 			}
 		}
 
-		lastModified := ""
-		size := "0"
-		exists := "false"
-		if ok {
-			exists = "true"
-			if ts := objectLastModified(object); !ts.IsZero() {
-				lastModified = ts.Format(time.RFC3339)
-			}
-			size = fmt.Sprintf("%d", objectSize(object))
+		if !ok {
+			rows = append(rows, []string{bucket, query, matchedKey, "false", "", "0"})
+			continue
 		}
+		rows = append(rows, searchResultRow(bucket, query, matchedKey, object))
+	}
 
-		rows = append(rows, []string{
-			bucket,
-			query,
-			matchedKey,
-			exists,
-			lastModified,
-			size,
-		})
+	return cliutil.WriteDataset(cmd, runtime, searchResultHeaders, rows)
+}
+
+var searchResultHeaders = []string{"bucket", "query_key", "matched_key", "exists", "last_modified", "size_bytes"}
+
+func searchResultRow(bucket, query, matchedKey string, object s3types.Object) []string {
+	lastModified := ""
+	if ts := objectLastModified(object); !ts.IsZero() {
+		lastModified = ts.Format(time.RFC3339)
 	}
+	return []string{bucket, query, matchedKey, "true", lastModified, fmt.Sprintf("%d", objectSize(object))}
+}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "query_key", "matched_key", "exists", "last_modified", "size_bytes"}, rows)
+// patternSearchRows reports every listed object matching each pattern, or a
+// single exists=false row for a pattern that matched nothing.
+func patternSearchRows(bucket string, queries []string, matchers []func(string) bool, objects []s3types.Object) [][]string {
+	rows := make([][]string, 0, len(queries))
+	for i, query := range queries {
+		matched := false
+		for _, object := range objects {
+			key := objectKey(object)
+			if !matchers[i](key) {
+				continue
+			}
+			matched = true
+			rows = append(rows, searchResultRow(bucket, query, key, object))
+		}
+		if !matched {
+			rows = append(rows, []string{bucket, query, "", "false", "", "0"})
+		}
+	}
+	return rows
 }
 
 func listBuckets(ctx context.Context, client API) ([]s3types.Bucket, error) {
@@ -261,8 +445,28 @@ func listBuckets(ctx context.Context, client API) ([]s3types.Bucket, error) {
 
 func listObjects(ctx context.Context, client API, bucket, prefix string) ([]s3types.Object, error) {
 	objects := make([]s3types.Object, 0)
-	var continuationToken *string
+	err := walkObjects(ctx, client, bucket, prefix, func(page []s3types.Object) {
+		objects = append(objects, page...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// walkObjects calls visit with each ListObjectsV2 page, so callers that only
+// aggregate do not have to hold every object in memory.
+func walkObjects(ctx context.Context, client API, bucket, prefix string, visit func([]s3types.Object)) error {
+	return walkObjectsUntil(ctx, client, bucket, prefix, func(page []s3types.Object) bool {
+		visit(page)
+		return true
+	})
+}
 
+// walkObjectsUntil is walkObjects for callers that can stop early: listing
+// ends without requesting further pages once visit returns false.
+func walkObjectsUntil(ctx context.Context, client API, bucket, prefix string, visit func([]s3types.Object) bool) error {
+	var continuationToken *string
 	for {
 		input := &s3.ListObjectsV2Input{
 			Bucket:            cliutil.Ptr(bucket),
@@ -274,40 +478,75 @@ func listObjects(ctx context.Context, client API, bucket, prefix string) ([]s3ty
 
 		out, err := client.ListObjectsV2(ctx, input)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		objects = append(objects, out.Contents...)
+		if !visit(out.Contents) {
+			return nil
+		}
 		if out.NextContinuationToken == nil || cliutil.PointerToString(out.NextContinuationToken) == "" {
-			break
+			return nil
 		}
 		continuationToken = out.NextContinuationToken
 	}
-
-	return objects, nil
 }
 
 func isBucketEmptyAndUnversioned(ctx context.Context, client API, bucket string) (bool, error) {
+	empty, status, err := bucketCurrentObjectsAndVersioning(ctx, client, bucket)
+	if err != nil || !empty {
+		return false, err
+	}
+	return status != s3types.BucketVersioningStatusEnabled, nil
+}
+
+// bucketCurrentObjectsAndVersioning reports whether the bucket has no current
+// objects and, if so, its versioning status. Old versions and delete markers
+// do not count as current objects.
+func bucketCurrentObjectsAndVersioning(ctx context.Context, client API, bucket string) (bool, s3types.BucketVersioningStatus, error) {
 	objects, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
 		Bucket:  cliutil.Ptr(bucket),
 		MaxKeys: cliutil.Ptr(int32(1)),
 	})
 	if err != nil {
-		return false, fmt.Errorf("list objects for bucket %s: %s", bucket, awstbxaws.FormatUserError(err))
+		return false, "", fmt.Errorf("list objects for bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
 	}
 	if len(objects.Contents) > 0 {
-		return false, nil
+		return false, "", nil
 	}
 
 	versioning, err := client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: cliutil.Ptr(bucket)})
 	if err != nil {
-		return false, fmt.Errorf("get versioning for bucket %s: %s", bucket, awstbxaws.FormatUserError(err))
+		return false, "", fmt.Errorf("get versioning for bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
 	}
+	return true, versioning.Status, nil
+}
 
-	return versioning.Status != s3types.BucketVersioningStatusEnabled, nil
+// EmptyBucket removes every object, version, delete marker and incomplete
+// multipart upload from a bucket in cfg's region, so other services can clear
+// a bucket before deleting what owns it.
+func EmptyBucket(ctx context.Context, cfg awssdk.Config, bucket string) error {
+	_, err := deleteAllObjectsFromBucket(ctx, newClient(cfg), bucket, true)
+	return err
 }
 
-func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string) error {
+// deleteAllObjectsFromBucket empties the bucket, including versions and delete
+// markers. Keys that DeleteObjects reports as individually failed do not stop
+// the sweep; they are collected and returned as one error naming the objects
+// that will block the bucket deletion. With abortUploads, incomplete multipart
+// uploads are aborted first, since they also keep DeleteBucket failing with
+// BucketNotEmpty; the number aborted is returned.
+func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string, abortUploads bool) (int, error) {
+	aborted := 0
+	if abortUploads {
+		var err error
+		aborted, err = abortMultipartUploads(ctx, client, bucket)
+		if err != nil {
+			return aborted, err
+		}
+	}
+
+	var failures []s3types.Error
+
 	// Delete regular objects first.
 	var continuationToken *string
 	for {
@@ -316,7 +555,7 @@ func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string)
 			ContinuationToken: continuationToken,
 		})
 		if err != nil {
-			return fmt.Errorf("list objects for bucket %s: %s", bucket, awstbxaws.FormatUserError(err))
+			return aborted, fmt.Errorf("list objects for bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
 		}
 
 		batch := make([]s3types.ObjectIdentifier, 0, len(page.Contents))
@@ -326,9 +565,11 @@ func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string)
 			}
 			batch = append(batch, s3types.ObjectIdentifier{Key: object.Key})
 		}
-		if err := deleteObjectBatch(ctx, client, bucket, batch); err != nil {
-			return err
+		keyErrs, err := deleteObjectBatch(ctx, client, bucket, batch)
+		if err != nil {
+			return aborted, err
 		}
+		failures = append(failures, keyErrs...)
 
 		if page.NextContinuationToken == nil || cliutil.PointerToString(page.NextContinuationToken) == "" {
 			break
@@ -348,7 +589,7 @@ func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string)
 			VersionIdMarker: versionIDMarker,
 		})
 		if err != nil {
-			return fmt.Errorf("list object versions for bucket %s: %s", bucket, awstbxaws.FormatUserError(err))
+			return aborted, fmt.Errorf("list object versions for bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
 		}
 
 		batch := make([]s3types.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
@@ -364,9 +605,11 @@ func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string)
 			}
 			batch = append(batch, s3types.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
 		}
-		if err := deleteObjectBatch(ctx, client, bucket, batch); err != nil {
-			return err
+		keyErrs, err := deleteObjectBatch(ctx, client, bucket, batch)
+		if err != nil {
+			return aborted, err
 		}
+		failures = append(failures, keyErrs...)
 
 		if (page.NextKeyMarker == nil || cliutil.PointerToString(page.NextKeyMarker) == "") &&
 			(page.NextVersionIdMarker == nil || cliutil.PointerToString(page.NextVersionIdMarker) == "") {
@@ -377,5 +620,70 @@ func deleteAllObjectsFromBucket(ctx context.Context, client API, bucket string)
 		versionIDMarker = page.NextVersionIdMarker
 	}
 
-	return nil
+	if len(failures) > 0 {
+		return aborted, objectDeleteFailuresError(failures)
+	}
+
+	return aborted, nil
+}
+
+// abortMultipartUploads aborts every incomplete multipart upload in the bucket
+// and returns how many were aborted.
+func abortMultipartUploads(ctx context.Context, client API, bucket string) (int, error) {
+	aborted := 0
+
+	// Like ListObjectVersions, ListMultipartUploads pages with two markers.
+	var keyMarker *string
+	var uploadIDMarker *string
+	for {
+		page, err := client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
+			Bucket:         cliutil.Ptr(bucket),
+			KeyMarker:      keyMarker,
+			UploadIdMarker: uploadIDMarker,
+		})
+		if err != nil {
+			return aborted, fmt.Errorf("list multipart uploads for bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
+		}
+
+		for _, upload := range page.Uploads {
+			if upload.Key == nil || upload.UploadId == nil {
+				continue
+			}
+			_, err := client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   cliutil.Ptr(bucket),
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			if err != nil {
+				return aborted, fmt.Errorf("abort multipart upload %s for key %s: %w", cliutil.PointerToString(upload.UploadId), cliutil.PointerToString(upload.Key), awstbxaws.WrapUserError(err))
+			}
+			aborted++
+		}
+
+		if !awssdk.ToBool(page.IsTruncated) {
+			break
+		}
+		keyMarker = page.NextKeyMarker
+		uploadIDMarker = page.NextUploadIdMarker
+	}
+
+	return aborted, nil
+}
+
+// maxReportedDeleteFailures bounds how many failed keys are named in the error.
+const maxReportedDeleteFailures = 5
+
+func objectDeleteFailuresError(failures []s3types.Error) error {
+	described := make([]string, 0, min(len(failures), maxReportedDeleteFailures))
+	for _, failure := range failures[:min(len(failures), maxReportedDeleteFailures)] {
+		name := cliutil.PointerToString(failure.Key)
+		if versionID := cliutil.PointerToString(failure.VersionId); versionID != "" {
+			name += "@" + versionID
+		}
+		described = append(described, fmt.Sprintf("%s (%s)", name, deleteErrorMessage(failure)))
+	}
+	if extra := len(failures) - len(described); extra > 0 {
+		described = append(described, fmt.Sprintf("and %d more", extra))
+	}
+	return fmt.Errorf("%d object(s) could not be deleted: %s", len(failures), strings.Join(described, ", "))
 }
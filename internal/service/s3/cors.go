@@ -0,0 +1,263 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const corsNotConfigured = "not-configured"
+
+// corsUnsafeMethods are the methods that change state, so allowing them from
+// any origin lets any website write to or delete from the bucket.
+var corsUnsafeMethods = []string{"DELETE", "POST", "PUT"}
+
+// corsConfigFile is the JSON accepted by set-cors; it matches the
+// --cors-configuration format of `aws s3api put-bucket-cors`.
+type corsConfigFile struct {
+	CORSRules []struct {
+		ID             string   `json:"ID"`
+		AllowedHeaders []string `json:"AllowedHeaders"`
+		AllowedMethods []string `json:"AllowedMethods"`
+		AllowedOrigins []string `json:"AllowedOrigins"`
+		ExposeHeaders  []string `json:"ExposeHeaders"`
+		MaxAgeSeconds  *int32   `json:"MaxAgeSeconds"`
+	} `json:"CORSRules"`
+}
+
+func runAuditCORS(cmd *cobra.Command, bucketName string) error {
+	bucketName = strings.TrimSpace(bucketName)
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	buckets := []s3types.Bucket{{Name: cliutil.Ptr(bucketName)}}
+	if bucketName == "" {
+		buckets, err = listBuckets(cmd.Context(), client)
+		if err != nil {
+			return fmt.Errorf("list buckets: %w", awstbxaws.WrapUserError(err))
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return cliutil.PointerToString(buckets[i].Name) < cliutil.PointerToString(buckets[j].Name)
+	})
+
+	clients := newRegionalClients(cfg, client)
+	rows := make([][]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		name := cliutil.PointerToString(bucket.Name)
+		region, regionErr := resolveBucketRegion(cmd.Context(), client, bucket)
+		if regionErr != nil {
+			return regionErr
+		}
+
+		rules, corsErr := bucketCORSRules(cmd.Context(), clients.forRegion(region), name)
+		if corsErr != nil {
+			return fmt.Errorf("get bucket cors for %s: %w", name, awstbxaws.WrapUserError(corsErr))
+		}
+		if rules == nil {
+			rows = append(rows, []string{name, region, "", "", "", "", corsNotConfigured})
+			continue
+		}
+
+		for i, rule := range rules {
+			finding := "ok"
+			if problems := corsRuleFindings(rule); len(problems) > 0 {
+				finding = strings.Join(problems, "; ")
+			}
+			rows = append(rows, []string{
+				name,
+				region,
+				corsRuleLabel(rule, i),
+				strings.Join(rule.AllowedOrigins, ","),
+				strings.Join(rule.AllowedMethods, ","),
+				strings.Join(rule.AllowedHeaders, ","),
+				finding,
+			})
+		}
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "region", "rule", "allowed_origins", "allowed_methods", "allowed_headers", "finding"}, rows)
+}
+
+func runSetCORS(cmd *cobra.Command, bucketName, configPath string) error {
+	bucketName = strings.TrimSpace(bucketName)
+	if bucketName == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+	rules, err := loadCORSConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	region, err := resolveBucketRegion(cmd.Context(), client, s3types.Bucket{Name: cliutil.Ptr(bucketName)})
+	if err != nil {
+		return err
+	}
+	regionalClient := newRegionalClients(cfg, client).forRegion(region)
+
+	pending := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		pending = "would-apply"
+	}
+
+	rows := make([][]string, 0, len(rules))
+	for i, rule := range rules {
+		maxAge := ""
+		if rule.MaxAgeSeconds != nil {
+			maxAge = strconv.Itoa(int(*rule.MaxAgeSeconds))
+		}
+		rows = append(rows, []string{
+			bucketName,
+			corsRuleLabel(rule, i),
+			strings.Join(rule.AllowedOrigins, ","),
+			strings.Join(rule.AllowedMethods, ","),
+			strings.Join(rule.AllowedHeaders, ","),
+			strings.Join(rule.ExposeHeaders, ","),
+			maxAge,
+			pending,
+		})
+	}
+
+	// The rules are applied in one PutBucketCors call, so every row shares its
+	// outcome.
+	var outcome string
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"bucket", "rule", "allowed_origins", "allowed_methods", "allowed_headers", "expose_headers", "max_age_seconds", "action"},
+		Rows:          rows,
+		ActionColumn:  7,
+		ConfirmPrompt: fmt.Sprintf("Replace the CORS configuration of %s with %d rule(s)", bucketName, len(rules)),
+		Execute: func(int) string {
+			if outcome != "" {
+				return outcome
+			}
+			_, putErr := regionalClient.PutBucketCors(cmd.Context(), &s3.PutBucketCorsInput{
+				Bucket:            cliutil.Ptr(bucketName),
+				CORSConfiguration: &s3types.CORSConfiguration{CORSRules: rules},
+			})
+			outcome = "applied"
+			if putErr != nil {
+				outcome = cliutil.FailedActionMessage(awstbxaws.FormatUserError(putErr))
+			}
+			return outcome
+		},
+	})
+}
+
+// bucketCORSRules returns the bucket's CORS rules, or nil when the bucket has
+// no CORS configuration.
+func bucketCORSRules(ctx context.Context, client API, bucket string) ([]s3types.CORSRule, error) {
+	out, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: cliutil.Ptr(bucket)})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchCORSConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if out.CORSRules == nil {
+		return []s3types.CORSRule{}, nil
+	}
+	return out.CORSRules, nil
+}
+
+// corsRuleFindings flags a rule that lets any origin change objects or send
+// arbitrary headers, such as Authorization, with its requests.
+func corsRuleFindings(rule s3types.CORSRule) []string {
+	if !slices.Contains(rule.AllowedOrigins, "*") {
+		return nil
+	}
+
+	findings := make([]string, 0)
+	unsafe := make([]string, 0)
+	for _, method := range corsUnsafeMethods {
+		if slices.ContainsFunc(rule.AllowedMethods, func(allowed string) bool { return strings.EqualFold(allowed, method) }) {
+			unsafe = append(unsafe, method)
+		}
+	}
+	if len(unsafe) > 0 {
+		findings = append(findings, "wildcard origin allows "+strings.Join(unsafe, ","))
+	}
+	if slices.Contains(rule.AllowedHeaders, "*") {
+		findings = append(findings, "wildcard origin allows any request header")
+	}
+	return findings
+}
+
+func corsRuleLabel(rule s3types.CORSRule, index int) string {
+	if id := cliutil.PointerToString(rule.ID); id != "" {
+		return id
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+func loadCORSConfig(path string) ([]s3types.CORSRule, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("--config is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read --config: %w", err)
+	}
+
+	var config corsConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse --config %s: %w", path, err)
+	}
+	if len(config.CORSRules) == 0 {
+		return nil, fmt.Errorf("--config %s has no CORSRules", path)
+	}
+
+	rules := make([]s3types.CORSRule, 0, len(config.CORSRules))
+	for i, rule := range config.CORSRules {
+		if len(rule.AllowedMethods) == 0 || len(rule.AllowedOrigins) == 0 {
+			return nil, fmt.Errorf("--config %s: rule %d needs AllowedMethods and AllowedOrigins", path, i+1)
+		}
+		converted := s3types.CORSRule{
+			AllowedHeaders: rule.AllowedHeaders,
+			AllowedMethods: rule.AllowedMethods,
+			AllowedOrigins: rule.AllowedOrigins,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  rule.MaxAgeSeconds,
+		}
+		if rule.ID != "" {
+			converted.ID = cliutil.Ptr(rule.ID)
+		}
+		rules = append(rules, converted)
+	}
+	return rules, nil
+}
+
+// resolveBucketRegion returns the bucket's region from ListBuckets when it was
+// reported there, falling back to GetBucketLocation.
+func resolveBucketRegion(ctx context.Context, client API, bucket s3types.Bucket) (string, error) {
+	if region := cliutil.PointerToString(bucket.BucketRegion); region != "" {
+		return region, nil
+	}
+	name := cliutil.PointerToString(bucket.Name)
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: cliutil.Ptr(name)})
+	if err != nil {
+		return "", fmt.Errorf("get bucket location for %s: %w", name, awstbxaws.WrapUserError(err))
+	}
+	return bucketRegion(location.LocationConstraint), nil
+}
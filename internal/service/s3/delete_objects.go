@@ -0,0 +1,154 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// maxDeleteObjectsKeys is the DeleteObjects per-request key limit.
+const maxDeleteObjectsKeys = 1000
+
+type deleteBatch struct {
+	objects  []s3types.ObjectIdentifier
+	executed bool
+	err      error
+	failures map[string]string
+}
+
+func runDeleteObjects(cmd *cobra.Command, bucket, keysFile, versionIDsFile string) error {
+	if strings.TrimSpace(bucket) == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+	if strings.TrimSpace(keysFile) == "" {
+		return fmt.Errorf("--keys-file is required")
+	}
+
+	keys, err := readObjectListFile(keysFile)
+	if err != nil {
+		return fmt.Errorf("read keys file: %w", err)
+	}
+
+	var versionIDs []string
+	if strings.TrimSpace(versionIDsFile) != "" {
+		versionIDs, err = readObjectListFile(versionIDsFile)
+		if err != nil {
+			return fmt.Errorf("read version ids file: %w", err)
+		}
+		if len(versionIDs) != len(keys) {
+			return fmt.Errorf("--version-ids-file has %d entries but --keys-file has %d; they are paired line by line", len(versionIDs), len(keys))
+		}
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = cliutil.ActionWouldDelete
+	}
+
+	rows := make([][]string, 0, len(keys))
+	batches := make([]*deleteBatch, 0, (len(keys)+maxDeleteObjectsKeys-1)/maxDeleteObjectsKeys)
+	for i, key := range keys {
+		identifier := s3types.ObjectIdentifier{Key: cliutil.Ptr(key)}
+		versionID := ""
+		if versionIDs != nil {
+			versionID = versionIDs[i]
+			identifier.VersionId = cliutil.Ptr(versionID)
+		}
+		rows = append(rows, []string{bucket, key, versionID, action})
+
+		if i%maxDeleteObjectsKeys == 0 {
+			batches = append(batches, &deleteBatch{})
+		}
+		batch := batches[len(batches)-1]
+		batch.objects = append(batch.objects, identifier)
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"bucket", "key", "version_id", "action"},
+		Rows:          rows,
+		ActionColumn:  3,
+		ConfirmPrompt: fmt.Sprintf("Delete %d object(s) from bucket %s in %d DeleteObjects call(s)", len(rows), bucket, len(batches)),
+		Execute: func(rowIndex int) string {
+			batch := batches[rowIndex/maxDeleteObjectsKeys]
+			if !batch.executed {
+				batch.executed = true
+				errs, deleteErr := deleteObjectBatch(cmd.Context(), client, bucket, batch.objects)
+				batch.err = deleteErr
+				batch.failures = make(map[string]string, len(errs))
+				for _, keyErr := range errs {
+					batch.failures[objectVersionKey(keyErr.Key, keyErr.VersionId)] = deleteErrorMessage(keyErr)
+				}
+			}
+			if batch.err != nil {
+				return cliutil.FailedAction(batch.err)
+			}
+			if reason, failed := batch.failures[objectVersionKey(cliutil.Ptr(rows[rowIndex][1]), cliutil.Ptr(rows[rowIndex][2]))]; failed {
+				return cliutil.FailedActionMessage(reason)
+			}
+			return cliutil.ActionDeleted
+		},
+	})
+}
+
+// readObjectListFile reads a JSON array of strings or one entry per line,
+// skipping blank lines. Entries are not trimmed because S3 keys may contain
+// leading or trailing spaces.
+func readObjectListFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("parse JSON array: %w", err)
+		}
+		for i, entry := range entries {
+			if entry == "" {
+				return nil, fmt.Errorf("entry %d is empty", i+1)
+			}
+		}
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			entries = append(entries, line)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s contains no entries", path)
+	}
+	return entries, nil
+}
+
+func objectVersionKey(key, versionID *string) string {
+	return cliutil.PointerToString(key) + "\x00" + cliutil.PointerToString(versionID)
+}
+
+func deleteErrorMessage(keyErr s3types.Error) string {
+	code := cliutil.PointerToString(keyErr.Code)
+	message := cliutil.PointerToString(keyErr.Message)
+	switch {
+	case code != "" && message != "":
+		return code + ": " + message
+	case code != "":
+		return code
+	default:
+		return message
+	}
+}
@@ -0,0 +1,115 @@
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// listingSnapshot is the saved form of a bucket listing, compact enough to
+// keep for large buckets.
+type listingSnapshot struct {
+	Bucket     string                    `json:"bucket"`
+	Prefix     string                    `json:"prefix,omitempty"`
+	CapturedAt string                    `json:"captured_at"`
+	Objects    map[string]snapshotObject `json:"objects"`
+}
+
+type snapshotObject struct {
+	Size int64  `json:"s"`
+	ETag string `json:"e"`
+}
+
+func runDiffListing(cmd *cobra.Command, bucket, prefix, baselinePath, savePath string) error {
+	if strings.TrimSpace(bucket) == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+	if strings.TrimSpace(baselinePath) == "" && strings.TrimSpace(savePath) == "" {
+		return fmt.Errorf("set --baseline and/or --save-listing")
+	}
+
+	var baseline listingSnapshot
+	if strings.TrimSpace(baselinePath) != "" {
+		data, err := os.ReadFile(baselinePath)
+		if err != nil {
+			return fmt.Errorf("read baseline: %w", err)
+		}
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			return fmt.Errorf("parse baseline: %w", err)
+		}
+		if baseline.Bucket != "" && baseline.Bucket != bucket {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: baseline was captured from bucket %s, comparing against %s\n", baseline.Bucket, bucket)
+		}
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	objects, err := listObjects(cmd.Context(), client, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
+	}
+
+	current := listingSnapshot{
+		Bucket:     bucket,
+		Prefix:     prefix,
+		CapturedAt: time.Now().UTC().Format(time.RFC3339),
+		Objects:    make(map[string]snapshotObject, len(objects)),
+	}
+	for _, object := range objects {
+		current.Objects[objectKey(object)] = snapshotObject{Size: objectSize(object), ETag: cliutil.PointerToString(object.ETag)}
+	}
+
+	if strings.TrimSpace(savePath) != "" {
+		data, marshalErr := json.Marshal(current)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if writeErr := os.WriteFile(savePath, data, 0o644); writeErr != nil {
+			return fmt.Errorf("write listing: %w", writeErr)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "saved listing of %d object(s) to %s\n", len(current.Objects), savePath)
+	}
+
+	if strings.TrimSpace(baselinePath) == "" {
+		return nil
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"key", "change", "baseline_size", "current_size"}, diffListings(baseline, current))
+}
+
+// diffListings reports objects added, removed, resized, or rewritten with the
+// same size (ETag change) between two snapshots, sorted by key.
+func diffListings(baseline, current listingSnapshot) [][]string {
+	rows := make([][]string, 0)
+	for key, before := range baseline.Objects {
+		after, ok := current.Objects[key]
+		switch {
+		case !ok:
+			rows = append(rows, []string{key, "removed", fmt.Sprintf("%d", before.Size), ""})
+		case after.Size != before.Size:
+			rows = append(rows, []string{key, "size-changed", fmt.Sprintf("%d", before.Size), fmt.Sprintf("%d", after.Size)})
+		case after.ETag != before.ETag:
+			rows = append(rows, []string{key, "modified", fmt.Sprintf("%d", before.Size), fmt.Sprintf("%d", after.Size)})
+		}
+	}
+	for key, after := range current.Objects {
+		if _, ok := baseline.Objects[key]; !ok {
+			rows = append(rows, []string{key, "added", "", fmt.Sprintf("%d", after.Size)})
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+	return rows
+}
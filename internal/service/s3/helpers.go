@@ -2,27 +2,33 @@ package s3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
-func deleteObjectBatch(ctx context.Context, client API, bucket string, objects []s3types.ObjectIdentifier) error {
+// deleteObjectBatch deletes up to 1000 objects in one DeleteObjects call. A
+// call can succeed while individual keys fail (for example object-locked
+// versions), so per-key errors are returned alongside the call error.
+func deleteObjectBatch(ctx context.Context, client API, bucket string, objects []s3types.ObjectIdentifier) ([]s3types.Error, error) {
 	if len(objects) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	_, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+	out, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: cliutil.Ptr(bucket),
 		Delete: &s3types.Delete{
 			Objects: objects,
@@ -30,10 +36,10 @@ func deleteObjectBatch(ctx context.Context, client API, bucket string, objects [
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("delete objects from bucket %s: %s", bucket, awstbxaws.FormatUserError(err))
+		return nil, fmt.Errorf("delete objects from bucket %s: %w", bucket, awstbxaws.WrapUserError(err))
 	}
 
-	return nil
+	return out.Errors, nil
 }
 
 func downloadObject(ctx context.Context, client API, bucket, key, targetPath string) error {
@@ -42,7 +48,7 @@ func downloadObject(ctx context.Context, client API, bucket, key, targetPath str
 		Key:    cliutil.Ptr(key),
 	})
 	if err != nil {
-		return fmt.Errorf("download %s: %s", key, awstbxaws.FormatUserError(err))
+		return fmt.Errorf("download %s: %w", key, awstbxaws.WrapUserError(err))
 	}
 	defer out.Body.Close()
 
@@ -105,6 +111,57 @@ func normalizeKeyQueries(raw []string) []string {
 	return queries
 }
 
+// normalizeKeyPatterns trims and de-duplicates glob or regex patterns without
+// splitting them on commas.
+func normalizeKeyPatterns(raw []string) []string {
+	patterns := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, item := range raw {
+		pattern := strings.TrimSpace(item)
+		if pattern == "" {
+			continue
+		}
+		if _, ok := seen[pattern]; ok {
+			continue
+		}
+		seen[pattern] = struct{}{}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// Key match modes accepted by search-objects --match-mode.
+const (
+	keyMatchExact = "exact"
+	keyMatchGlob  = "glob"
+	keyMatchRegex = "regex"
+)
+
+// compileKeyPatterns turns each query into a matcher against full object keys,
+// using path.Match semantics for glob and RE2 syntax for regex.
+func compileKeyPatterns(queries []string, mode string) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, 0, len(queries))
+	for _, query := range queries {
+		if mode == keyMatchRegex {
+			pattern, err := regexp.Compile(query)
+			if err != nil {
+				return nil, fmt.Errorf("--keys: invalid regex %q: %w", query, err)
+			}
+			matchers = append(matchers, pattern.MatchString)
+			continue
+		}
+
+		if _, err := path.Match(query, ""); err != nil {
+			return nil, fmt.Errorf("--keys: invalid glob %q: %w", query, err)
+		}
+		matchers = append(matchers, func(key string) bool {
+			matched, _ := path.Match(query, key)
+			return matched
+		})
+	}
+	return matchers, nil
+}
+
 func sortObjectsByKey(objects []s3types.Object) {
 	sort.Slice(objects, func(i, j int) bool {
 		return objectKey(objects[i]) < objectKey(objects[j])
@@ -128,3 +185,69 @@ func objectSize(object s3types.Object) int64 {
 	}
 	return *object.Size
 }
+
+// objectStorageClass returns the object's storage class. ListObjectsV2 omits
+// it for STANDARD objects.
+func objectStorageClass(object s3types.Object) s3types.ObjectStorageClass {
+	if object.StorageClass == "" {
+		return s3types.ObjectStorageClassStandard
+	}
+	return object.StorageClass
+}
+
+// localFileMatchesSize reports whether a regular file exists at path with the
+// object's size. Objects without a reported size never match.
+func localFileMatchesSize(path string, object s3types.Object) bool {
+	if object.Size == nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return info.Size() == *object.Size
+}
+
+// formatByteSize renders a byte count in binary units, such as 1.5 GiB.
+func formatByteSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	i := -1
+	for value >= unit && i < len(units)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, units[i])
+}
+
+// tagResolver resolves bucket tags via GetBucketTagging. Buckets without a tag
+// set resolve to no tags instead of an error.
+func tagResolver(client API) cliutil.TagResolver {
+	return bucketTagResolver(func(string) API { return client })
+}
+
+// bucketTagResolver is tagResolver for buckets spread over regions: clientFor
+// returns the client for each bucket's home region, since S3 redirects
+// GetBucketTagging made against any other region.
+func bucketTagResolver(clientFor func(bucket string) API) cliutil.TagResolver {
+	return cliutil.TagResolverFunc(func(ctx context.Context, bucket string) (map[string]string, error) {
+		out, err := clientFor(bucket).GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: cliutil.Ptr(bucket)})
+		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+				return map[string]string{}, nil
+			}
+			return nil, err
+		}
+
+		values := make(map[string]string, len(out.TagSet))
+		for _, tag := range out.TagSet {
+			values[cliutil.PointerToString(tag.Key)] = cliutil.PointerToString(tag.Value)
+		}
+		return values, nil
+	})
+}
@@ -0,0 +1,117 @@
+package s3
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// objectSizeHeap is a min-heap by size, so the smallest of the kept objects
+// is the one evicted when a larger object arrives.
+type objectSizeHeap []s3types.Object
+
+func (h objectSizeHeap) Len() int           { return len(h) }
+func (h objectSizeHeap) Less(i, j int) bool { return objectSize(h[i]) < objectSize(h[j]) }
+func (h objectSizeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *objectSizeHeap) Push(x any)        { *h = append(*h, x.(s3types.Object)) }
+func (h *objectSizeHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+func runLargestObjects(cmd *cobra.Command, bucketName, prefix string, top int, allBuckets bool) error {
+	bucketName = strings.TrimSpace(bucketName)
+	if bucketName == "" && !allBuckets {
+		return fmt.Errorf("set --bucket-name or --all-buckets")
+	}
+	if top < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	buckets := []s3types.Bucket{{Name: cliutil.Ptr(bucketName)}}
+	if allBuckets {
+		buckets, err = listBuckets(cmd.Context(), client)
+		if err != nil {
+			return fmt.Errorf("list buckets: %w", awstbxaws.WrapUserError(err))
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return cliutil.PointerToString(buckets[i].Name) < cliutil.PointerToString(buckets[j].Name)
+	})
+
+	clients := newRegionalClients(cfg, client)
+	rows := make([][]string, 0)
+	for _, bucket := range buckets {
+		name := cliutil.PointerToString(bucket.Name)
+		region, regionErr := resolveBucketRegion(cmd.Context(), client, bucket)
+		if regionErr != nil {
+			return regionErr
+		}
+
+		largest, listErr := largestObjects(cmd.Context(), clients.forRegion(region), name, prefix, top)
+		if listErr != nil {
+			return fmt.Errorf("list objects for %s: %w", name, awstbxaws.WrapUserError(listErr))
+		}
+		for _, object := range largest {
+			lastModified := ""
+			if object.LastModified != nil {
+				lastModified = object.LastModified.UTC().Format(time.RFC3339)
+			}
+			rows = append(rows, []string{
+				name,
+				objectKey(object),
+				strconv.FormatInt(objectSize(object), 10),
+				string(objectStorageClass(object)),
+				lastModified,
+			})
+		}
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "key", "size_bytes", "storage_class", "last_modified"}, rows)
+}
+
+// largestObjects pages through the bucket keeping only the top largest objects
+// in a bounded heap, so memory stays constant however many objects there are.
+// The result is ordered largest first, ties broken by key.
+func largestObjects(ctx context.Context, client API, bucket, prefix string, top int) ([]s3types.Object, error) {
+	kept := make(objectSizeHeap, 0, top)
+	err := walkObjects(ctx, client, bucket, prefix, func(page []s3types.Object) {
+		for _, object := range page {
+			if kept.Len() < top {
+				heap.Push(&kept, object)
+				continue
+			}
+			if objectSize(object) > objectSize(kept[0]) {
+				kept[0] = object
+				heap.Fix(&kept, 0)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		if objectSize(kept[i]) == objectSize(kept[j]) {
+			return objectKey(kept[i]) < objectKey(kept[j])
+		}
+		return objectSize(kept[i]) > objectSize(kept[j])
+	})
+	return kept, nil
+}
@@ -0,0 +1,69 @@
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runAuditMFADelete reports versioned buckets without MFA Delete. Only the root
+// user can enable it, with an MFA code, so the command prints the AWS CLI call
+// to run as root instead of changing anything.
+func runAuditMFADelete(cmd *cobra.Command, bucketName string) error {
+	bucketName = strings.TrimSpace(bucketName)
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	buckets := []s3types.Bucket{{Name: cliutil.Ptr(bucketName)}}
+	if bucketName == "" {
+		buckets, err = listBuckets(cmd.Context(), client)
+		if err != nil {
+			return fmt.Errorf("list buckets: %w", awstbxaws.WrapUserError(err))
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return cliutil.PointerToString(buckets[i].Name) < cliutil.PointerToString(buckets[j].Name)
+	})
+
+	clients := newRegionalClients(cfg, client)
+	rows := make([][]string, 0)
+	for _, bucket := range buckets {
+		name := cliutil.PointerToString(bucket.Name)
+		region, regionErr := resolveBucketRegion(cmd.Context(), client, bucket)
+		if regionErr != nil {
+			return regionErr
+		}
+
+		versioning, versioningErr := clients.forRegion(region).GetBucketVersioning(cmd.Context(), &s3.GetBucketVersioningInput{Bucket: cliutil.Ptr(name)})
+		if versioningErr != nil {
+			return fmt.Errorf("get bucket versioning for %s: %w", name, awstbxaws.WrapUserError(versioningErr))
+		}
+		if versioning.Status != s3types.BucketVersioningStatusEnabled || versioning.MFADelete == s3types.MFADeleteStatusEnabled {
+			continue
+		}
+
+		mfaDelete := string(versioning.MFADelete)
+		if mfaDelete == "" {
+			mfaDelete = string(s3types.MFADeleteStatusDisabled)
+		}
+		rows = append(rows, []string{name, region, string(versioning.Status), mfaDelete, mfaDeleteEnableCommand(name, region)})
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"bucket", "region", "versioning", "mfa_delete", "enable_command"}, rows)
+}
+
+func mfaDeleteEnableCommand(bucket, region string) string {
+	return fmt.Sprintf(
+		`aws s3api put-bucket-versioning --bucket %s --region %s --versioning-configuration Status=Enabled,MFADelete=Enabled --mfa "arn:aws:iam::<account-id>:mfa/root-account-mfa-device <mfa-code>"`,
+		bucket, region,
+	)
+}
@@ -0,0 +1,283 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+type replicationBucket struct {
+	name      string
+	region    string
+	client    API
+	versioned bool
+}
+
+// runSetupReplication enables versioning where needed and adds a replication
+// rule from source to dest. Rules already configured on the source bucket are
+// kept; the configuration is only replaced with force when it cannot be merged.
+// Every rule of the resulting configuration is reported on its own row.
+func runSetupReplication(cmd *cobra.Command, source, dest, roleARN string, force bool) error {
+	source = strings.TrimSpace(source)
+	dest = strings.TrimSpace(dest)
+	roleARN = strings.TrimSpace(roleARN)
+	if source == "" {
+		return fmt.Errorf("--source is required")
+	}
+	if dest == "" {
+		return fmt.Errorf("--dest is required")
+	}
+	if source == dest {
+		return fmt.Errorf("--source and --dest must be different buckets")
+	}
+	role, err := arn.Parse(roleARN)
+	if err != nil || role.Service != "iam" {
+		return fmt.Errorf("--role-arn must be an IAM role ARN")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	buckets := make([]*replicationBucket, 0, 2)
+	for _, name := range []string{source, dest} {
+		bucket, resolveErr := resolveReplicationBucket(cmd.Context(), cfg, client, name)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	existing, err := bucketReplicationConfiguration(cmd.Context(), buckets[0].client, source)
+	if err != nil {
+		return fmt.Errorf("get bucket replication for %s: %w", source, awstbxaws.WrapUserError(err))
+	}
+	rule := replicationRule(role.Partition, source, dest)
+	config, steps, err := mergeReplicationConfiguration(existing, rule, roleARN, force)
+	if err != nil {
+		return fmt.Errorf("bucket %s: %w", source, err)
+	}
+
+	pending := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		pending = "would-apply"
+	}
+
+	rows := make([][]string, 0, 2+len(steps))
+	prerequisites := make(map[int]*replicationBucket)
+	for _, bucket := range buckets {
+		if bucket.versioned {
+			continue
+		}
+		prerequisites[len(rows)] = bucket
+		rows = append(rows, []string{"enable-versioning", bucket.name, bucket.region, "Status=Enabled", pending})
+	}
+
+	firstRuleRow := len(rows)
+	for _, step := range steps {
+		rows = append(rows, []string{
+			step.Step,
+			source,
+			buckets[0].region,
+			fmt.Sprintf("rule=%s priority=%d destination=%s role=%s status=%s",
+				cliutil.PointerToString(step.Rule.ID), awssdk.ToInt32(step.Rule.Priority), replicationDestination(step.Rule), roleARN, step.Rule.Status),
+			pending,
+		})
+	}
+
+	prompt := fmt.Sprintf("Configure replication from %s to %s", source, dest)
+	if len(prerequisites) > 0 {
+		prompt = fmt.Sprintf("Enable versioning on %d bucket(s) and configure replication from %s to %s", len(prerequisites), source, dest)
+	}
+
+	// The whole configuration is written with one PutBucketReplication call,
+	// whose outcome is reported on every rule row.
+	versioningFailed := false
+	replicationResult := ""
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"step", "bucket", "region", "detail", "action"},
+		Rows:          rows,
+		ActionColumn:  4,
+		ConfirmPrompt: prompt,
+		Execute: func(rowIndex int) string {
+			if bucket, ok := prerequisites[rowIndex]; ok {
+				_, putErr := bucket.client.PutBucketVersioning(cmd.Context(), &s3.PutBucketVersioningInput{
+					Bucket:                  cliutil.Ptr(bucket.name),
+					VersioningConfiguration: &s3types.VersioningConfiguration{Status: s3types.BucketVersioningStatusEnabled},
+				})
+				if putErr != nil {
+					versioningFailed = true
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(putErr))
+				}
+				return "enabled"
+			}
+			if rowIndex < firstRuleRow {
+				return ""
+			}
+			if replicationResult != "" {
+				return replicationResult
+			}
+			if versioningFailed {
+				replicationResult = cliutil.SkippedActionMessage("versioning-not-enabled")
+				return replicationResult
+			}
+			_, putErr := buckets[0].client.PutBucketReplication(cmd.Context(), &s3.PutBucketReplicationInput{
+				Bucket:                   cliutil.Ptr(source),
+				ReplicationConfiguration: config,
+			})
+			if putErr != nil {
+				replicationResult = cliutil.FailedActionMessage(awstbxaws.FormatUserError(putErr))
+				return replicationResult
+			}
+			replicationResult = "applied"
+			return replicationResult
+		},
+	})
+}
+
+// replicationStep is a rule of the configuration that setup-replication
+// writes, or one it drops, together with what happens to it.
+type replicationStep struct {
+	Step string
+	Rule s3types.ReplicationRule
+}
+
+// mergeReplicationConfiguration adds rule to the source bucket's existing
+// configuration, replacing a rule with the same ID and keeping the others.
+// A configuration that uses another role or legacy prefix-only rules cannot
+// be merged with rule; it is rejected unless force, in which case it is
+// replaced and its rules are reported as dropped.
+func mergeReplicationConfiguration(existing *s3types.ReplicationConfiguration, rule s3types.ReplicationRule, roleARN string, force bool) (*s3types.ReplicationConfiguration, []replicationStep, error) {
+	config := &s3types.ReplicationConfiguration{Role: cliutil.Ptr(roleARN)}
+	steps := make([]replicationStep, 0)
+	if existing == nil || len(existing.Rules) == 0 {
+		config.Rules = []s3types.ReplicationRule{rule}
+		return config, append(steps, replicationStep{Step: "add-rule", Rule: rule}), nil
+	}
+
+	conflict := ""
+	if existingRole := cliutil.PointerToString(existing.Role); existingRole != roleARN {
+		conflict = fmt.Sprintf("replication already uses role %s", existingRole)
+	}
+	for _, existingRule := range existing.Rules {
+		if conflict == "" && existingRule.Filter == nil {
+			conflict = fmt.Sprintf("replication rule %s uses the legacy prefix format", cliutil.PointerToString(existingRule.ID))
+		}
+	}
+	if conflict != "" {
+		if !force {
+			return nil, nil, fmt.Errorf("%s and cannot be merged; rerun with --force to replace the existing configuration", conflict)
+		}
+		for _, existingRule := range existing.Rules {
+			steps = append(steps, replicationStep{Step: "drop-rule", Rule: existingRule})
+		}
+		config.Rules = []s3types.ReplicationRule{rule}
+		return config, append(steps, replicationStep{Step: "add-rule", Rule: rule}), nil
+	}
+
+	step := "add-rule"
+	var maxPriority int32
+	for _, existingRule := range existing.Rules {
+		if cliutil.PointerToString(existingRule.ID) == cliutil.PointerToString(rule.ID) {
+			step = "replace-rule"
+			rule.Priority = existingRule.Priority
+			continue
+		}
+		maxPriority = max(maxPriority, awssdk.ToInt32(existingRule.Priority))
+		config.Rules = append(config.Rules, existingRule)
+		steps = append(steps, replicationStep{Step: "keep-rule", Rule: existingRule})
+	}
+	if step == "add-rule" {
+		rule.Priority = cliutil.Ptr(maxPriority + 1)
+	}
+	config.Rules = append(config.Rules, rule)
+
+	return config, append(steps, replicationStep{Step: step, Rule: rule}), nil
+}
+
+// bucketReplicationConfiguration returns the bucket's replication
+// configuration, or nil when it has none.
+func bucketReplicationConfiguration(ctx context.Context, client API, bucket string) (*s3types.ReplicationConfiguration, error) {
+	out, err := client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{Bucket: cliutil.Ptr(bucket)})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ReplicationConfigurationNotFoundError" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return out.ReplicationConfiguration, nil
+}
+
+func replicationDestination(rule s3types.ReplicationRule) string {
+	if rule.Destination == nil {
+		return ""
+	}
+	return cliutil.PointerToString(rule.Destination.Bucket)
+}
+
+// resolveReplicationBucket looks up the bucket's region and versioning state,
+// using a client pinned to that region so cross-region buckets are reachable.
+func resolveReplicationBucket(ctx context.Context, cfg awssdk.Config, client API, name string) (*replicationBucket, error) {
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: cliutil.Ptr(name)})
+	if err != nil {
+		return nil, fmt.Errorf("get bucket location for %s: %w", name, awstbxaws.WrapUserError(err))
+	}
+	region := bucketRegion(location.LocationConstraint)
+
+	regionalClient := client
+	if region != cfg.Region {
+		regionalCfg := cfg
+		regionalCfg.Region = region
+		regionalClient = newClient(regionalCfg)
+	}
+
+	versioning, err := regionalClient.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: cliutil.Ptr(name)})
+	if err != nil {
+		return nil, fmt.Errorf("get bucket versioning for %s: %w", name, awstbxaws.WrapUserError(err))
+	}
+
+	return &replicationBucket{
+		name:      name,
+		region:    region,
+		client:    regionalClient,
+		versioned: versioning.Status == s3types.BucketVersioningStatusEnabled,
+	}, nil
+}
+
+// bucketRegion maps a GetBucketLocation constraint to a region name; an empty
+// constraint means us-east-1 and the legacy "EU" value means eu-west-1.
+func bucketRegion(constraint s3types.BucketLocationConstraint) string {
+	switch constraint {
+	case "":
+		return "us-east-1"
+	case s3types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return string(constraint)
+	}
+}
+
+// replicationRule builds the rule from source to dest. The destination ARN
+// uses the role's partition, since replication cannot cross partitions.
+func replicationRule(partition, source, dest string) s3types.ReplicationRule {
+	return s3types.ReplicationRule{
+		ID:                      cliutil.Ptr(fmt.Sprintf("awstbx-%s-to-%s", source, dest)),
+		Status:                  s3types.ReplicationRuleStatusEnabled,
+		Priority:                cliutil.Ptr(int32(1)),
+		Filter:                  &s3types.ReplicationRuleFilter{Prefix: cliutil.Ptr("")},
+		DeleteMarkerReplication: &s3types.DeleteMarkerReplication{Status: s3types.DeleteMarkerReplicationStatusDisabled},
+		Destination:             &s3types.Destination{Bucket: cliutil.Ptr(fmt.Sprintf("arn:%s:s3:::%s", partition, dest))},
+	}
+}
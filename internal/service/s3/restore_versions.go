@@ -0,0 +1,202 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// objectVersionEntry is one version or delete marker of a key.
+type objectVersionEntry struct {
+	versionID    string
+	lastModified time.Time
+	isLatest     bool
+	deleteMarker bool
+}
+
+// deletedObject is a key whose current version is a delete marker, with the
+// markers to remove so that restoredVersionID becomes current again.
+type deletedObject struct {
+	key               string
+	markerVersionIDs  []string
+	restoredVersionID string
+}
+
+// runRestoreVersions undoes deletions in a versioned bucket by removing the
+// delete markers stacked on top of each key's newest real version.
+func runRestoreVersions(cmd *cobra.Command, bucketName, prefix string) error {
+	bucketName = strings.TrimSpace(bucketName)
+	if bucketName == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	region, err := resolveBucketRegion(cmd.Context(), client, s3types.Bucket{Name: cliutil.Ptr(bucketName)})
+	if err != nil {
+		return err
+	}
+	regionalClient := newRegionalClients(cfg, client).forRegion(region)
+
+	deleted, err := listDeletedObjects(cmd.Context(), regionalClient, bucketName, prefix)
+	if err != nil {
+		return fmt.Errorf("list object versions for bucket %s: %w", bucketName, awstbxaws.WrapUserError(err))
+	}
+
+	action := cliutil.ActionPending
+	if runtime.Options.DryRun {
+		action = "would-restore"
+	}
+	rows := make([][]string, 0, len(deleted))
+	for _, object := range deleted {
+		rows = append(rows, []string{bucketName, object.key, strings.Join(object.markerVersionIDs, ","), object.restoredVersionID, action})
+	}
+
+	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
+		Headers:       []string{"bucket", "key", "delete_marker_version_ids", "restored_version_id", "action"},
+		Rows:          rows,
+		ActionColumn:  4,
+		ConfirmPrompt: fmt.Sprintf("Restore %d deleted object(s) in %s by removing their delete markers", len(rows), bucketName),
+		Execute: func(rowIndex int) string {
+			object := deleted[rowIndex]
+			markers := make([]s3types.ObjectIdentifier, 0, len(object.markerVersionIDs))
+			for _, versionID := range object.markerVersionIDs {
+				markers = append(markers, s3types.ObjectIdentifier{Key: cliutil.Ptr(object.key), VersionId: cliutil.Ptr(versionID)})
+			}
+			keyErrs, deleteErr := deleteObjectBatch(cmd.Context(), regionalClient, bucketName, markers)
+			if deleteErr != nil {
+				return cliutil.FailedAction(deleteErr)
+			}
+			if len(keyErrs) > 0 {
+				return cliutil.FailedActionMessage(deleteErrorMessage(keyErrs[0]))
+			}
+			return "restored"
+		},
+	})
+}
+
+// listDeletedObjects walks every version under prefix and returns, sorted by
+// key, the keys whose latest entry is a delete marker and that still have a
+// real version underneath. Keys with only delete markers are skipped since
+// there is nothing to restore.
+//
+// ListObjectVersions returns each key's versions and markers contiguously, so
+// a key is decided as soon as the listing moves past it and only the deleted
+// objects are kept.
+func listDeletedObjects(ctx context.Context, client API, bucket, prefix string) ([]deletedObject, error) {
+	deleted := make([]deletedObject, 0)
+	currentKey := ""
+	var current []objectVersionEntry
+	flush := func() {
+		if object, ok := deletedObjectFromVersions(currentKey, current); ok {
+			deleted = append(deleted, object)
+		}
+		current = current[:0]
+	}
+
+	// ListObjectVersions pages on two markers, so this mirrors the loop in
+	// deleteAllObjectsFromBucket rather than using CollectAllPages.
+	var keyMarker *string
+	var versionIDMarker *string
+	for {
+		page, err := client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+			Bucket:          cliutil.Ptr(bucket),
+			Prefix:          cliutil.Ptr(prefix),
+			KeyMarker:       keyMarker,
+			VersionIdMarker: versionIDMarker,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Versions and delete markers arrive as two key-ordered lists; merge
+		// them so each key's entries are seen together.
+		type keyedEntry struct {
+			key   string
+			entry objectVersionEntry
+		}
+		pageEntries := make([]keyedEntry, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, version := range page.Versions {
+			if version.Key == nil {
+				continue
+			}
+			pageEntries = append(pageEntries, keyedEntry{key: *version.Key, entry: objectVersionEntry{
+				versionID:    cliutil.PointerToString(version.VersionId),
+				lastModified: awssdk.ToTime(version.LastModified),
+				isLatest:     awssdk.ToBool(version.IsLatest),
+			}})
+		}
+		for _, marker := range page.DeleteMarkers {
+			if marker.Key == nil {
+				continue
+			}
+			pageEntries = append(pageEntries, keyedEntry{key: *marker.Key, entry: objectVersionEntry{
+				versionID:    cliutil.PointerToString(marker.VersionId),
+				lastModified: awssdk.ToTime(marker.LastModified),
+				isLatest:     awssdk.ToBool(marker.IsLatest),
+				deleteMarker: true,
+			}})
+		}
+		sort.SliceStable(pageEntries, func(i, j int) bool {
+			return pageEntries[i].key < pageEntries[j].key
+		})
+
+		for _, entry := range pageEntries {
+			if entry.key != currentKey {
+				flush()
+				currentKey = entry.key
+			}
+			current = append(current, entry.entry)
+		}
+
+		if (page.NextKeyMarker == nil || cliutil.PointerToString(page.NextKeyMarker) == "") &&
+			(page.NextVersionIdMarker == nil || cliutil.PointerToString(page.NextVersionIdMarker) == "") {
+			break
+		}
+		keyMarker = page.NextKeyMarker
+		versionIDMarker = page.NextVersionIdMarker
+	}
+	flush()
+
+	return deleted, nil
+}
+
+// deletedObjectFromVersions decides a single key from all of its versions and
+// delete markers. It reports false unless the latest entry is a delete marker
+// with a real version underneath.
+func deletedObjectFromVersions(key string, versions []objectVersionEntry) (deletedObject, bool) {
+	if len(versions) == 0 {
+		return deletedObject{}, false
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].isLatest != versions[j].isLatest {
+			return versions[i].isLatest
+		}
+		return versions[i].lastModified.After(versions[j].lastModified)
+	})
+	if !versions[0].deleteMarker {
+		return deletedObject{}, false
+	}
+
+	object := deletedObject{key: key}
+	for _, version := range versions {
+		if !version.deleteMarker {
+			object.restoredVersionID = version.versionID
+			break
+		}
+		object.markerVersionIDs = append(object.markerVersionIDs, version.versionID)
+	}
+	return object, object.restoredVersionID != ""
+}
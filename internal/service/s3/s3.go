@@ -12,13 +12,22 @@ import (
 
 // API is the subset of the S3 client used by this package.
 type API interface {
+	AbortMultipartUpload(context.Context, *s3.AbortMultipartUploadInput, ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
 	DeleteBucket(context.Context, *s3.DeleteBucketInput, ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
 	DeleteObjects(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	GetBucketCors(context.Context, *s3.GetBucketCorsInput, ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error)
+	GetBucketLocation(context.Context, *s3.GetBucketLocationInput, ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketReplication(context.Context, *s3.GetBucketReplicationInput, ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error)
+	GetBucketTagging(context.Context, *s3.GetBucketTaggingInput, ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
 	GetBucketVersioning(context.Context, *s3.GetBucketVersioningInput, ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
 	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	ListBuckets(context.Context, *s3.ListBucketsInput, ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	ListMultipartUploads(context.Context, *s3.ListMultipartUploadsInput, ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
 	ListObjectVersions(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
 	ListObjectsV2(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	PutBucketCors(context.Context, *s3.PutBucketCorsInput, ...func(*s3.Options)) (*s3.PutBucketCorsOutput, error)
+	PutBucketReplication(context.Context, *s3.PutBucketReplicationInput, ...func(*s3.Options)) (*s3.PutBucketReplicationOutput, error)
+	PutBucketVersioning(context.Context, *s3.PutBucketVersioningInput, ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error)
 }
 
 var loadAWSConfig = awstbxaws.LoadAWSConfig
@@ -30,10 +39,73 @@ var newClient = func(cfg awssdk.Config) API {
 func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("s3", "Manage S3 resources")
 
+	cmd.AddCommand(newAuditCORSCommand())
+	cmd.AddCommand(newAuditMFADeleteCommand())
+	cmd.AddCommand(newBucketSizeCommand())
 	cmd.AddCommand(newDeleteBucketsCommand())
+	cmd.AddCommand(newDeleteObjectsCommand())
+	cmd.AddCommand(newDiffListingCommand())
 	cmd.AddCommand(newDownloadBucketCommand())
+	cmd.AddCommand(newLargestObjectsCommand())
+	cmd.AddCommand(newListBucketsCommand())
 	cmd.AddCommand(newListOldFilesCommand())
+	cmd.AddCommand(newRestoreVersionsCommand())
 	cmd.AddCommand(newSearchObjectsCommand())
+	cmd.AddCommand(newSetCORSCommand())
+	cmd.AddCommand(newSetupReplicationCommand())
+	cmd.AddCommand(newTieringReportCommand())
+
+	return cmd
+}
+
+func newAuditCORSCommand() *cobra.Command {
+	var bucketName string
+
+	cmd := &cobra.Command{
+		Use:   "audit-cors",
+		Short: "Report bucket CORS rules and flag overly permissive ones",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditCORS(cmd, bucketName)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Only audit this bucket (default: all buckets)")
+
+	return cmd
+}
+
+func newAuditMFADeleteCommand() *cobra.Command {
+	var bucketName string
+
+	cmd := &cobra.Command{
+		Use:   "audit-mfa-delete",
+		Short: "Report versioned buckets without MFA Delete and how to enable it",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAuditMFADelete(cmd, bucketName)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Only audit this bucket (default: all buckets)")
+
+	return cmd
+}
+
+func newBucketSizeCommand() *cobra.Command {
+	var bucketName string
+	var prefix string
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "bucket-size",
+		Short: "Summarize object count and total bytes, optionally per key prefix",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBucketSize(cmd, bucketName, prefix, depth)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix")
+	cmd.Flags().IntVar(&depth, "group-by-prefix-depth", 0, "Group totals by the first N key path segments (0 = one total)")
 
 	return cmd
 }
@@ -41,17 +113,66 @@ func NewCommand() *cobra.Command {
 func newDeleteBucketsCommand() *cobra.Command {
 	var emptyOnly bool
 	var filterNameContains string
+	var abortUploads bool
+	var forceVersioned bool
+	var allRegions bool
 
 	cmd := &cobra.Command{
 		Use:   "delete-buckets",
 		Short: "Delete S3 buckets by emptiness and/or name match",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteBuckets(cmd, emptyOnly, filterNameContains)
+			return runDeleteBuckets(cmd, emptyOnly, filterNameContains, abortUploads, forceVersioned, allRegions)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().BoolVar(&emptyOnly, "empty", false, "Only target empty buckets with versioning disabled")
 	cmd.Flags().StringVar(&filterNameContains, "filter-name-contains", "", "Only target buckets containing this text")
+	cmd.Flags().BoolVar(&abortUploads, "abort-uploads", false, "Abort incomplete multipart uploads before deleting each bucket")
+	cmd.Flags().BoolVar(&forceVersioned, "force-versioned", false, "With --empty, also target versioned buckets that hold only old versions and delete markers, purging them first; buckets with current objects are reported as skipped")
+	cmd.Flags().BoolVar(&allRegions, "all-regions", false, "Resolve each bucket's home region and check and delete it through a client for that region")
+	cliutil.AddInteractiveFlag(cmd)
+
+	return cmd
+}
+
+func newDeleteObjectsCommand() *cobra.Command {
+	var bucketName string
+	var keysFile string
+	var versionIDsFile string
+
+	cmd := &cobra.Command{
+		Use:   "delete-objects",
+		Short: "Delete an explicit list of S3 objects in batches",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteObjects(cmd, bucketName, keysFile, versionIDsFile)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&keysFile, "keys-file", "", "File with object keys, one per line or a JSON array")
+	cmd.Flags().StringVar(&versionIDsFile, "version-ids-file", "", "Optional file with version IDs paired line by line with --keys-file")
+
+	return cmd
+}
+
+func newDiffListingCommand() *cobra.Command {
+	var bucketName string
+	var prefix string
+	var baseline string
+	var saveListing string
+
+	cmd := &cobra.Command{
+		Use:   "diff-listing",
+		Short: "Compare a bucket listing against a saved snapshot",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDiffListing(cmd, bucketName, prefix, baseline, saveListing)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix")
+	cmd.Flags().StringVar(&baseline, "baseline", "", "Snapshot file from an earlier --save-listing to compare against")
+	cmd.Flags().StringVar(&saveListing, "save-listing", "", "Write the current listing to this snapshot file")
 
 	return cmd
 }
@@ -60,18 +181,63 @@ func newDownloadBucketCommand() *cobra.Command {
 	var bucketName string
 	var prefix string
 	var outputDir string
+	var concurrency int
+	var skipExisting bool
 
 	cmd := &cobra.Command{
 		Use:   "download-bucket",
 		Short: "Download S3 objects from a bucket prefix",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDownloadBucket(cmd, bucketName, prefix, outputDir)
+			return runDownloadBucket(cmd, bucketName, prefix, outputDir, concurrency, skipExisting)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Object key prefix to download")
 	cmd.Flags().StringVar(&outputDir, "output-dir", ".", "Local directory for downloaded files")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 5, "Number of objects to download in parallel")
+	cmd.Flags().BoolVar(&skipExisting, "skip-existing", false, "Skip objects whose local file already exists with the same size (by default every object is downloaded and replaces the local file)")
+
+	return cmd
+}
+
+func newLargestObjectsCommand() *cobra.Command {
+	var bucketName string
+	var prefix string
+	var top int
+	var allBuckets bool
+
+	cmd := &cobra.Command{
+		Use:   "largest-objects",
+		Short: "Report the largest objects in a bucket or in every bucket",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runLargestObjects(cmd, bucketName, prefix, top, allBuckets)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix")
+	cmd.Flags().IntVar(&top, "top", 20, "Number of objects to report per bucket")
+	cmd.Flags().BoolVar(&allBuckets, "all-buckets", false, "Report the largest objects of every bucket")
+	cmd.MarkFlagsMutuallyExclusive("bucket-name", "all-buckets")
+
+	return cmd
+}
+
+func newListBucketsCommand() *cobra.Command {
+	var withSize bool
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "list-buckets",
+		Short: "List buckets with region, creation date, and versioning status",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListBuckets(cmd, withSize, concurrency)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&withSize, "with-size", false, "Include object count and total size (lists every object)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "Number of buckets to inspect in parallel")
 
 	return cmd
 }
@@ -80,18 +246,43 @@ func newListOldFilesCommand() *cobra.Command {
 	var bucketName string
 	var prefix string
 	var olderThanDays int
+	var olderThan string
+	var minSizeBytes int64
+	var storageClass string
 
 	cmd := &cobra.Command{
 		Use:   "list-old-files",
 		Short: "List objects older than a threshold",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runListOldFiles(cmd, bucketName, prefix, olderThanDays)
+			return runListOldFiles(cmd, bucketName, prefix, olderThanDays, olderThan, minSizeBytes, storageClass)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix")
 	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 60, "Only show files older than this many days")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only show files last modified before this time (RFC3339, 2006-01-02, or relative like 90d, 12h, 2w)")
+	cmd.Flags().Int64Var(&minSizeBytes, "min-size-bytes", 0, "Only show files at least this many bytes")
+	cmd.Flags().StringVar(&storageClass, "storage-class", "", "Only show files in this storage class, such as STANDARD or GLACIER")
+	cmd.MarkFlagsMutuallyExclusive("older-than-days", "older-than")
+
+	return cmd
+}
+
+func newRestoreVersionsCommand() *cobra.Command {
+	var bucketName string
+	var prefix string
+
+	cmd := &cobra.Command{
+		Use:   "restore-versions",
+		Short: "Undo object deletions in a versioned bucket by removing delete markers",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRestoreVersions(cmd, bucketName, prefix)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Versioned bucket name")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only restore keys under this prefix")
 
 	return cmd
 }
@@ -100,18 +291,82 @@ func newSearchObjectsCommand() *cobra.Command {
 	var bucketName string
 	var prefix string
 	var keys []string
+	var matchMode string
+	var stream bool
 
 	cmd := &cobra.Command{
 		Use:   "search-objects",
 		Short: "Search S3 objects by prefix and/or key list",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runSearchObjects(cmd, bucketName, prefix, keys)
+			return runSearchObjects(cmd, bucketName, prefix, keys, matchMode, stream)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix filter")
-	cmd.Flags().StringSliceVar(&keys, "keys", nil, "Comma-separated keys to search for")
+	cmd.Flags().StringArrayVar(&keys, "keys", nil, "Key or pattern to search for (repeatable; exact keys may also be comma-separated)")
+	cmd.Flags().StringVar(&matchMode, "match-mode", keyMatchExact, "How --keys match object keys: exact, glob, or regex")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write each result as a JSON line as it is found instead of buffering all rows (JSON output only; stops at --limit)")
+
+	return cmd
+}
+
+func newSetCORSCommand() *cobra.Command {
+	var bucketName string
+	var configPath string
+
+	cmd := &cobra.Command{
+		Use:   "set-cors",
+		Short: "Replace a bucket's CORS configuration from a JSON file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSetCORS(cmd, bucketName, configPath)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&configPath, "config", "", "CORS configuration JSON file in the put-bucket-cors format ({\"CORSRules\": [...]})")
+
+	return cmd
+}
+
+func newSetupReplicationCommand() *cobra.Command {
+	var source string
+	var dest string
+	var roleARN string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "setup-replication",
+		Short: "Enable versioning and configure replication between two buckets",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSetupReplication(cmd, source, dest, roleARN, force)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&source, "source", "", "Source bucket name")
+	cmd.Flags().StringVar(&dest, "dest", "", "Destination bucket name")
+	cmd.Flags().StringVar(&roleARN, "role-arn", "", "IAM role ARN that S3 assumes to replicate objects")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing replication configuration that uses another role or legacy prefix rules instead of failing")
+
+	return cmd
+}
+
+func newTieringReportCommand() *cobra.Command {
+	var bucketName string
+	var prefix string
+	var olderThanDays int
+
+	cmd := &cobra.Command{
+		Use:   "tiering-report",
+		Short: "Estimate savings from tiering old STANDARD objects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTieringReport(cmd, bucketName, prefix, olderThanDays)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&bucketName, "bucket-name", "", "Bucket name")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Optional key prefix")
+	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 30, "Only report STANDARD objects older than this many days")
 
 	return cmd
 }
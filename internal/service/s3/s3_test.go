@@ -3,28 +3,49 @@ package s3
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
 type mockClient struct {
-	deleteBucketFn        func(context.Context, *s3.DeleteBucketInput, ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
-	deleteObjectsFn       func(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
-	getBucketVersioningFn func(context.Context, *s3.GetBucketVersioningInput, ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
-	getObjectFn           func(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
-	listBucketsFn         func(context.Context, *s3.ListBucketsInput, ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
-	listObjectVersionsFn  func(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
-	listObjectsV2Fn       func(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	abortMultipartUploadFn func(context.Context, *s3.AbortMultipartUploadInput, ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	deleteBucketFn         func(context.Context, *s3.DeleteBucketInput, ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+	deleteObjectsFn        func(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	getBucketCorsFn        func(context.Context, *s3.GetBucketCorsInput, ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error)
+	getBucketLocationFn    func(context.Context, *s3.GetBucketLocationInput, ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	getBucketReplicationFn func(context.Context, *s3.GetBucketReplicationInput, ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error)
+	getBucketTaggingFn     func(context.Context, *s3.GetBucketTaggingInput, ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+	getBucketVersioningFn  func(context.Context, *s3.GetBucketVersioningInput, ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
+	getObjectFn            func(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	listBucketsFn          func(context.Context, *s3.ListBucketsInput, ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	listMultipartUploadsFn func(context.Context, *s3.ListMultipartUploadsInput, ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+	listObjectVersionsFn   func(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	listObjectsV2Fn        func(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	putBucketCorsFn        func(context.Context, *s3.PutBucketCorsInput, ...func(*s3.Options)) (*s3.PutBucketCorsOutput, error)
+	putBucketReplicationFn func(context.Context, *s3.PutBucketReplicationInput, ...func(*s3.Options)) (*s3.PutBucketReplicationOutput, error)
+	putBucketVersioningFn  func(context.Context, *s3.PutBucketVersioningInput, ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error)
+}
+
+func (m *mockClient) AbortMultipartUpload(ctx context.Context, in *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	if m.abortMultipartUploadFn == nil {
+		return nil, errors.New("AbortMultipartUpload not mocked")
+	}
+	return m.abortMultipartUploadFn(ctx, in, optFns...)
 }
 
 func (m *mockClient) DeleteBucket(ctx context.Context, in *s3.DeleteBucketInput, optFns ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
@@ -41,6 +62,27 @@ func (m *mockClient) DeleteObjects(ctx context.Context, in *s3.DeleteObjectsInpu
 	return m.deleteObjectsFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) GetBucketCors(ctx context.Context, in *s3.GetBucketCorsInput, optFns ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error) {
+	if m.getBucketCorsFn == nil {
+		return nil, errors.New("GetBucketCors not mocked")
+	}
+	return m.getBucketCorsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetBucketLocation(ctx context.Context, in *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	if m.getBucketLocationFn == nil {
+		return nil, errors.New("GetBucketLocation not mocked")
+	}
+	return m.getBucketLocationFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetBucketTagging(ctx context.Context, in *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	if m.getBucketTaggingFn == nil {
+		return nil, errors.New("GetBucketTagging not mocked")
+	}
+	return m.getBucketTaggingFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) GetBucketVersioning(ctx context.Context, in *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
 	if m.getBucketVersioningFn == nil {
 		return nil, errors.New("GetBucketVersioning not mocked")
@@ -62,6 +104,13 @@ func (m *mockClient) ListBuckets(ctx context.Context, in *s3.ListBucketsInput, o
 	return m.listBucketsFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) ListMultipartUploads(ctx context.Context, in *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+	if m.listMultipartUploadsFn == nil {
+		return nil, errors.New("ListMultipartUploads not mocked")
+	}
+	return m.listMultipartUploadsFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) ListObjectVersions(ctx context.Context, in *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
 	if m.listObjectVersionsFn == nil {
 		return nil, errors.New("ListObjectVersions not mocked")
@@ -76,6 +125,34 @@ func (m *mockClient) ListObjectsV2(ctx context.Context, in *s3.ListObjectsV2Inpu
 	return m.listObjectsV2Fn(ctx, in, optFns...)
 }
 
+func (m *mockClient) GetBucketReplication(ctx context.Context, in *s3.GetBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	if m.getBucketReplicationFn == nil {
+		return nil, errors.New("GetBucketReplication not mocked")
+	}
+	return m.getBucketReplicationFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) PutBucketCors(ctx context.Context, in *s3.PutBucketCorsInput, optFns ...func(*s3.Options)) (*s3.PutBucketCorsOutput, error) {
+	if m.putBucketCorsFn == nil {
+		return nil, errors.New("PutBucketCors not mocked")
+	}
+	return m.putBucketCorsFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) PutBucketReplication(ctx context.Context, in *s3.PutBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+	if m.putBucketReplicationFn == nil {
+		return nil, errors.New("PutBucketReplication not mocked")
+	}
+	return m.putBucketReplicationFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) PutBucketVersioning(ctx context.Context, in *s3.PutBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error) {
+	if m.putBucketVersioningFn == nil {
+		return nil, errors.New("PutBucketVersioning not mocked")
+	}
+	return m.putBucketVersioningFn(ctx, in, optFns...)
+}
+
 func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, error), factory func(awssdk.Config) API) {
 	t.Helper()
 
@@ -138,6 +215,146 @@ func TestSearchObjectsSupportsMultipleKeys(t *testing.T) {
 	}
 }
 
+func TestSearchObjectsGlobAndRegexMatchModes(t *testing.T) {
+	listCalls := 0
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			listCalls++
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+				{Key: cliutil.Ptr("logs/2026/app.log"), Size: cliutil.Ptr(int64(10))},
+				{Key: cliutil.Ptr("logs/app.log"), Size: cliutil.Ptr(int64(20))},
+				{Key: cliutil.Ptr("logs/db.log"), Size: cliutil.Ptr(int64(30))},
+			}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "search-objects", "--bucket-name", "b", "--keys", "logs/*.log", "--keys", "*.csv", "--match-mode", "glob")
+	if err != nil {
+		t.Fatalf("execute glob search: %v", err)
+	}
+	expected := strings.Join([]string{
+		"bucket=b query_key=logs/*.log matched_key=logs/app.log exists=true last_modified= size_bytes=20",
+		"bucket=b query_key=logs/*.log matched_key=logs/db.log exists=true last_modified= size_bytes=30",
+		"bucket=b query_key=*.csv matched_key= exists=false last_modified= size_bytes=0",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected glob output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "s3", "search-objects", "--bucket-name", "b", "--keys", `^logs/\d+/`, "--match-mode", "regex")
+	if err != nil {
+		t.Fatalf("execute regex search: %v", err)
+	}
+	if strings.TrimSpace(output) != "bucket=b query_key=^logs/\\d+/ matched_key=logs/2026/app.log exists=true last_modified= size_bytes=10" {
+		t.Fatalf("unexpected regex output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "s3", "search-objects", "--bucket-name", "b", "--keys", `^logs/\d{1,4}/`, "--match-mode", "regex")
+	if err != nil {
+		t.Fatalf("execute regex search with a comma: %v", err)
+	}
+	if strings.TrimSpace(output) != "bucket=b query_key=^logs/\\d{1,4}/ matched_key=logs/2026/app.log exists=true last_modified= size_bytes=10" {
+		t.Fatalf("expected the regex to be kept whole:\n%s", output)
+	}
+
+	calls := listCalls
+	if _, err := executeCommand(t, "s3", "search-objects", "--bucket-name", "b", "--keys", "logs/(", "--match-mode", "regex"); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Fatalf("expected invalid regex error, got %v", err)
+	}
+	if listCalls != calls {
+		t.Fatal("expected invalid regex to fail before listing objects")
+	}
+}
+
+func TestSearchObjectsStreamHonoursLimitAndRejectsNonJSONOutput(t *testing.T) {
+	listCalls := 0
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			listCalls++
+			if in.ContinuationToken == nil {
+				return &s3.ListObjectsV2Output{
+					Contents:              []s3types.Object{{Key: cliutil.Ptr("data/a.csv")}, {Key: cliutil.Ptr("data/b.csv")}},
+					NextContinuationToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("data/c.csv")}}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	_, err := executeCommand(t, "--output", "table", "s3", "search-objects", "--bucket-name", "b", "--prefix", "data/", "--stream")
+	if err == nil || !strings.Contains(err.Error(), "--stream writes JSON lines") {
+		t.Fatalf("expected non-json output to be rejected, got %v", err)
+	}
+	if listCalls != 0 {
+		t.Fatal("expected the output check to run before listing objects")
+	}
+
+	output, err := executeCommand(t, "--output", "json", "--limit", "1", "s3", "search-objects", "--bucket-name", "b", "--prefix", "data/", "--stream")
+	if err != nil {
+		t.Fatalf("execute limited stream: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"key":"data/a.csv"`) {
+		t.Fatalf("expected a single NDJSON line, got: %s", output)
+	}
+	if listCalls != 1 {
+		t.Fatalf("expected listing to stop once the limit was reached, got %d calls", listCalls)
+	}
+}
+
+func TestSearchObjectsStreamWritesNDJSONPerPage(t *testing.T) {
+	failSecondPage := true
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if in.ContinuationToken == nil {
+				return &s3.ListObjectsV2Output{
+					Contents:              []s3types.Object{{Key: cliutil.Ptr("data/a.csv"), Size: cliutil.Ptr(int64(1))}},
+					NextContinuationToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			if failSecondPage {
+				return nil, errors.New("throttled")
+			}
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("data/b.csv"), Size: cliutil.Ptr(int64(2))}}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "s3", "search-objects", "--bucket-name", "b", "--prefix", "data/", "--stream")
+	if err == nil || !strings.Contains(err.Error(), "list objects") {
+		t.Fatalf("expected list error, got %v", err)
+	}
+	var first map[string]string
+	if jsonErr := json.Unmarshal([]byte(strings.SplitN(output, "\n", 2)[0]), &first); jsonErr != nil || first["key"] != "data/a.csv" {
+		t.Fatalf("expected first page streamed before the error, got %q (%v)", output, jsonErr)
+	}
+
+	failSecondPage = false
+	output, err = executeCommand(t, "s3", "search-objects", "--bucket-name", "b", "--prefix", "data/", "--keys", "b.csv,missing.csv", "--stream")
+	if err != nil {
+		t.Fatalf("execute keyed stream: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two NDJSON lines, got: %s", output)
+	}
+	var found, missing map[string]string
+	if err := json.Unmarshal([]byte(lines[0]), &found); err != nil {
+		t.Fatalf("decode line 1: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &missing); err != nil {
+		t.Fatalf("decode line 2: %v", err)
+	}
+	if found["query_key"] != "b.csv" || found["matched_key"] != "data/b.csv" || found["exists"] != "true" || found["size_bytes"] != "2" {
+		t.Fatalf("unexpected match line: %v", found)
+	}
+	if missing["query_key"] != "missing.csv" || missing["exists"] != "false" {
+		t.Fatalf("unexpected missing line: %v", missing)
+	}
+}
+
 func TestSearchObjectsRequiresBucket(t *testing.T) {
 	output, err := executeCommand(t, "s3", "search-objects", "--keys", "foo")
 	if err == nil {
@@ -462,6 +679,46 @@ func TestDeleteBucketsClearObjectsError(t *testing.T) {
 	}
 }
 
+func TestDeleteBucketsReportsObjectsThatFailedToDelete(t *testing.T) {
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{
+				Buckets: []s3types.Bucket{{Name: cliutil.Ptr("locked-bucket")}},
+			}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("a.txt")}}}, nil
+		},
+		listObjectVersionsFn: func(_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			return &s3.ListObjectVersionsOutput{Versions: []s3types.ObjectVersion{{Key: cliutil.Ptr("a.txt"), VersionId: cliutil.Ptr("v1")}}}, nil
+		},
+		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			if in.Delete.Objects[0].VersionId == nil {
+				return &s3.DeleteObjectsOutput{}, nil
+			}
+			return &s3.DeleteObjectsOutput{Errors: []s3types.Error{{
+				Key:       cliutil.Ptr("a.txt"),
+				VersionId: cliutil.Ptr("v1"),
+				Code:      cliutil.Ptr("AccessDenied"),
+			}}}, nil
+		},
+		deleteBucketFn: func(_ context.Context, _ *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			t.Fatal("DeleteBucket should not be called when objects remain")
+			return nil, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--filter-name-contains", "locked")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(output, "action=failed:1 object(s) could not be deleted: a.txt@v1 (AccessDenied)") {
+		t.Fatalf("expected blocking object in output: %s", output)
+	}
+}
+
 func TestDeleteBucketsSkipsNilNameBuckets(t *testing.T) {
 	client := &mockClient{
 		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
@@ -539,7 +796,7 @@ func TestDeleteAllObjectsFromBucketWithVersions(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -559,7 +816,7 @@ func TestDeleteAllObjectsFromBucketListVersionsError(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -580,7 +837,7 @@ func TestDeleteAllObjectsFromBucketDeleteObjectsError(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -604,7 +861,7 @@ func TestDeleteAllObjectsFromBucketDeleteVersionsError(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -645,7 +902,7 @@ func TestDeleteAllObjectsFromBucketPagination(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -664,7 +921,7 @@ func TestDeleteAllObjectsFromBucketListObjectsError(t *testing.T) {
 		},
 	}
 
-	err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket")
+	_, err := deleteAllObjectsFromBucket(context.Background(), client, "my-bucket", false)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -788,6 +1045,128 @@ func TestDownloadBucketDownloadError(t *testing.T) {
 	}
 }
 
+func TestDownloadBucketConcurrentKeepsKeyOrderAndIsolatesFailures(t *testing.T) {
+	tmpDir := t.TempDir()
+	keys := []string{"prefix/e.txt", "prefix/b.txt", "prefix/d.txt", "prefix/a.txt", "prefix/c.txt"}
+	var mu sync.Mutex
+	active, peak := 0, 0
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			objects := make([]s3types.Object, 0, len(keys))
+			for _, key := range keys {
+				objects = append(objects, s3types.Object{Key: cliutil.Ptr(key)})
+			}
+			return &s3.ListObjectsV2Output{Contents: objects}, nil
+		},
+		getObjectFn: func(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			mu.Lock()
+			active++
+			peak = max(peak, active)
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+
+			if cliutil.PointerToString(in.Key) == "prefix/b.txt" {
+				return nil, errors.New("download failed")
+			}
+			return &s3.GetObjectOutput{Body: nopReadCloser{bytes.NewReader([]byte("data"))}}, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "download-bucket", "--bucket-name", "my-bucket", "--prefix", "prefix/", "--output-dir", tmpDir, "--concurrency", "2")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != len(keys) {
+		t.Fatalf("expected %d rows, got: %s", len(keys), output)
+	}
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		if !strings.Contains(lines[i], "key=prefix/"+name+".txt ") {
+			t.Fatalf("expected row %d for %s.txt, got: %s", i, name, output)
+		}
+		expected := "action=downloaded"
+		if name == "b" {
+			expected = "action=failed:download prefix/b.txt: download failed"
+		}
+		if !strings.Contains(lines[i], expected) {
+			t.Fatalf("expected %q in row %d: %s", expected, i, output)
+		}
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent downloads, got %d", peak)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "e.txt")); err != nil {
+		t.Fatalf("expected e.txt downloaded: %v", err)
+	}
+
+	if _, err := executeCommand(t, "s3", "download-bucket", "--bucket-name", "my-bucket", "--prefix", "prefix/", "--concurrency", "0"); err == nil {
+		t.Fatal("expected error for --concurrency 0")
+	}
+}
+
+func TestDownloadBucketSkipExistingResumes(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "done.txt"), []byte("12345"), 0o600); err != nil {
+		t.Fatalf("write done.txt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "partial.txt"), []byte("12"), 0o600); err != nil {
+		t.Fatalf("write partial.txt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "unsized.txt"), []byte("12345"), 0o600); err != nil {
+		t.Fatalf("write unsized.txt: %v", err)
+	}
+
+	var mu sync.Mutex
+	downloaded := make([]string, 0)
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+				{Key: cliutil.Ptr("prefix/done.txt"), Size: cliutil.Ptr(int64(5))},
+				{Key: cliutil.Ptr("prefix/partial.txt"), Size: cliutil.Ptr(int64(5))},
+				{Key: cliutil.Ptr("prefix/unsized.txt")},
+			}}, nil
+		},
+		getObjectFn: func(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			mu.Lock()
+			downloaded = append(downloaded, cliutil.PointerToString(in.Key))
+			mu.Unlock()
+			return &s3.GetObjectOutput{Body: nopReadCloser{bytes.NewReader([]byte("12345"))}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "download-bucket", "--bucket-name", "my-bucket", "--prefix", "prefix/", "--output-dir", tmpDir, "--skip-existing")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	for _, expected := range []string{
+		"key=prefix/done.txt target_path=" + filepath.Join(tmpDir, "done.txt") + " action=skipped:already-present",
+		"key=prefix/partial.txt target_path=" + filepath.Join(tmpDir, "partial.txt") + " action=downloaded",
+		"key=prefix/unsized.txt target_path=" + filepath.Join(tmpDir, "unsized.txt") + " action=downloaded",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	sort.Strings(downloaded)
+	if strings.Join(downloaded, ",") != "prefix/partial.txt,prefix/unsized.txt" {
+		t.Fatalf("unexpected downloads: %v", downloaded)
+	}
+
+	downloaded = downloaded[:0]
+	if _, err := executeCommand(t, "--output", "text", "s3", "download-bucket", "--bucket-name", "my-bucket", "--prefix", "prefix/", "--output-dir", tmpDir); err != nil {
+		t.Fatalf("execute without --skip-existing: %v", err)
+	}
+	if len(downloaded) != 3 {
+		t.Fatalf("expected every object to be downloaded without --skip-existing, got %v", downloaded)
+	}
+}
+
 func TestDownloadBucketRejectsPathTraversal(t *testing.T) {
 	now := time.Now().UTC()
 	tmpDir := t.TempDir()
@@ -1277,7 +1656,7 @@ func TestSortObjectsByKey(t *testing.T) {
 
 func TestDeleteObjectBatchEmpty(t *testing.T) {
 	client := &mockClient{}
-	err := deleteObjectBatch(context.Background(), client, "my-bucket", nil)
+	_, err := deleteObjectBatch(context.Background(), client, "my-bucket", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1290,7 +1669,7 @@ func TestDeleteObjectBatchError(t *testing.T) {
 		},
 	}
 
-	err := deleteObjectBatch(context.Background(), client, "my-bucket", []s3types.ObjectIdentifier{
+	_, err := deleteObjectBatch(context.Background(), client, "my-bucket", []s3types.ObjectIdentifier{
 		{Key: cliutil.Ptr("obj1")},
 	})
 	if err == nil {
@@ -1301,6 +1680,36 @@ func TestDeleteObjectBatchError(t *testing.T) {
 	}
 }
 
+func TestDeleteObjectBatchReturnsPerKeyErrors(t *testing.T) {
+	client := &mockClient{
+		deleteObjectsFn: func(_ context.Context, _ *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			return &s3.DeleteObjectsOutput{Errors: []s3types.Error{{Key: cliutil.Ptr("obj2"), Code: cliutil.Ptr("AccessDenied")}}}, nil
+		},
+	}
+
+	keyErrs, err := deleteObjectBatch(context.Background(), client, "my-bucket", []s3types.ObjectIdentifier{
+		{Key: cliutil.Ptr("obj1")},
+		{Key: cliutil.Ptr("obj2")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keyErrs) != 1 || cliutil.PointerToString(keyErrs[0].Key) != "obj2" {
+		t.Fatalf("unexpected per-key errors: %#v", keyErrs)
+	}
+}
+
+func TestObjectDeleteFailuresErrorTruncates(t *testing.T) {
+	failures := make([]s3types.Error, 7)
+	for i := range failures {
+		failures[i] = s3types.Error{Key: cliutil.Ptr(fmt.Sprintf("k%d", i)), Code: cliutil.Ptr("AccessDenied")}
+	}
+	got := objectDeleteFailuresError(failures).Error()
+	if !strings.HasPrefix(got, "7 object(s) could not be deleted: k0 (AccessDenied)") || !strings.HasSuffix(got, "k4 (AccessDenied), and 2 more") {
+		t.Fatalf("unexpected error: %s", got)
+	}
+}
+
 func TestDeleteObjectBatchSuccess(t *testing.T) {
 	client := &mockClient{
 		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
@@ -1311,7 +1720,7 @@ func TestDeleteObjectBatchSuccess(t *testing.T) {
 		},
 	}
 
-	err := deleteObjectBatch(context.Background(), client, "my-bucket", []s3types.ObjectIdentifier{
+	_, err := deleteObjectBatch(context.Background(), client, "my-bucket", []s3types.ObjectIdentifier{
 		{Key: cliutil.Ptr("obj1")},
 		{Key: cliutil.Ptr("obj2")},
 	})
@@ -1521,3 +1930,1057 @@ func TestIsBucketEmptyAndUnversionedFalseWhenVersioned(t *testing.T) {
 		t.Fatal("expected false for versioned bucket")
 	}
 }
+
+func TestTieringReportEstimatesSavings(t *testing.T) {
+	oldDate := time.Now().UTC().AddDate(0, 0, -90)
+	recentDate := time.Now().UTC().AddDate(0, 0, -5)
+
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{
+				Contents: []s3types.Object{
+					{Key: cliutil.Ptr("old-standard.bin"), LastModified: &oldDate, Size: cliutil.Ptr(int64(1024 * 1024 * 1024)), StorageClass: s3types.ObjectStorageClassStandard},
+					{Key: cliutil.Ptr("old-default.bin"), LastModified: &oldDate, Size: nil},
+					{Key: cliutil.Ptr("old-glacier.bin"), LastModified: &oldDate, Size: cliutil.Ptr(int64(100)), StorageClass: s3types.ObjectStorageClassGlacier},
+					{Key: cliutil.Ptr("recent.bin"), LastModified: &recentDate, Size: cliutil.Ptr(int64(100))},
+				},
+			}, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "json", "s3", "tiering-report", "--bucket-name", "my-bucket", "--older-than-days", "30")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	for _, want := range []string{`"key": "old-standard.bin"`, `"key": "old-default.bin"`, `"savings_standard_ia_usd": "0.0105"`, `"savings_glacier_usd": "0.0194"`, "total: 2 object(s), 1073741824 bytes, savings_standard_ia_usd=0.0105 savings_glacier_usd=0.0194"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %s in output: %s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"old-glacier.bin", "recent.bin", "TOTAL"} {
+		if strings.Contains(output, unwanted) {
+			t.Fatalf("%s should not appear: %s", unwanted, output)
+		}
+	}
+}
+
+func TestTieringReportRequiresBucket(t *testing.T) {
+	_, err := executeCommand(t, "s3", "tiering-report")
+	if err == nil || !strings.Contains(err.Error(), "--bucket-name is required") {
+		t.Fatalf("expected bucket validation error, got %v", err)
+	}
+}
+
+func TestListOldFilesOlderThanSpec(t *testing.T) {
+	oldDate := time.Now().UTC().Add(-72 * time.Hour)
+	recentDate := time.Now().UTC().Add(-1 * time.Hour)
+
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{
+				Contents: []s3types.Object{
+					{Key: cliutil.Ptr("three-days.txt"), LastModified: &oldDate, Size: cliutil.Ptr(int64(1))},
+					{Key: cliutil.Ptr("one-hour.txt"), LastModified: &recentDate, Size: cliutil.Ptr(int64(1))},
+				},
+			}, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "json", "s3", "list-old-files", "--bucket-name", "my-bucket", "--older-than", "12h")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(output, "three-days.txt") || strings.Contains(output, "one-hour.txt") {
+		t.Fatalf("unexpected output for --older-than 12h: %s", output)
+	}
+
+	if _, err := executeCommand(t, "s3", "list-old-files", "--bucket-name", "my-bucket", "--older-than", "soon"); err == nil || !strings.Contains(err.Error(), "invalid time") {
+		t.Fatalf("expected invalid time error, got %v", err)
+	}
+}
+
+func TestListOldFilesFiltersBySizeAndStorageClass(t *testing.T) {
+	oldDate := time.Now().UTC().Add(-90 * 24 * time.Hour)
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{
+				Contents: []s3types.Object{
+					{Key: cliutil.Ptr("big-standard.bin"), LastModified: &oldDate, Size: cliutil.Ptr(int64(5000))},
+					{Key: cliutil.Ptr("big-glacier.bin"), LastModified: &oldDate, Size: cliutil.Ptr(int64(5000)), StorageClass: s3types.ObjectStorageClassGlacier},
+					{Key: cliutil.Ptr("small-standard.bin"), LastModified: &oldDate, Size: cliutil.Ptr(int64(10)), StorageClass: s3types.ObjectStorageClassStandard},
+					{Key: cliutil.Ptr("big-undated.bin"), Size: cliutil.Ptr(int64(5000))},
+				},
+			}, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "list-old-files", "--bucket-name", "my-bucket", "--min-size-bytes", "1000", "--storage-class", "standard")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if strings.TrimSpace(output) == "" || strings.Count(strings.TrimSpace(output), "\n") != 0 || !strings.Contains(output, "key=big-standard.bin") {
+		t.Fatalf("expected only big-standard.bin: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "s3", "list-old-files", "--bucket-name", "my-bucket", "--storage-class", "GLACIER")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(output, "key=big-glacier.bin") || strings.Contains(output, "standard.bin") {
+		t.Fatalf("expected only big-glacier.bin: %s", output)
+	}
+}
+
+func TestLargestObjectsKeepsTopNAcrossPages(t *testing.T) {
+	sizes := []int64{7, 3, 42, 0, 19, 42, 5, 100, 1, 8}
+	client := &mockClient{
+		getBucketLocationFn: func(_ context.Context, _ *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			start, end := 0, 5
+			var next *string
+			if in.ContinuationToken == nil {
+				next = cliutil.Ptr("page-2")
+			} else {
+				start, end = 5, len(sizes)
+			}
+			objects := make([]s3types.Object, 0, end-start)
+			for i := start; i < end; i++ {
+				object := s3types.Object{Key: cliutil.Ptr(fmt.Sprintf("obj-%02d", i))}
+				if sizes[i] > 0 {
+					object.Size = cliutil.Ptr(sizes[i])
+				}
+				objects = append(objects, object)
+			}
+			return &s3.ListObjectsV2Output{Contents: objects, NextContinuationToken: next}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "largest-objects", "--bucket-name", "my-bucket", "--top", "3")
+	if err != nil {
+		t.Fatalf("execute largest-objects: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	want := []string{
+		"bucket=my-bucket key=obj-07 size_bytes=100 storage_class=STANDARD",
+		"bucket=my-bucket key=obj-02 size_bytes=42 storage_class=STANDARD",
+		"bucket=my-bucket key=obj-05 size_bytes=42 storage_class=STANDARD",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d rows, got: %s", len(want), output)
+	}
+	for i := range want {
+		if !strings.HasPrefix(lines[i], want[i]) {
+			t.Fatalf("row %d: expected prefix %q, got %q", i, want[i], lines[i])
+		}
+	}
+
+	if _, err := executeCommand(t, "s3", "largest-objects"); err == nil {
+		t.Fatal("expected error without --bucket-name or --all-buckets")
+	}
+}
+
+func TestBucketSizeGroupsByPrefixDepth(t *testing.T) {
+	client := &mockClient{
+		getBucketLocationFn: func(_ context.Context, _ *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if in.ContinuationToken == nil {
+				return &s3.ListObjectsV2Output{
+					Contents: []s3types.Object{
+						{Key: cliutil.Ptr("README.md"), Size: cliutil.Ptr(int64(100))},
+						{Key: cliutil.Ptr("logs/2026/01/a.log"), Size: cliutil.Ptr(int64(1024))},
+					},
+					NextContinuationToken: cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+				{Key: cliutil.Ptr("logs/2026/02/b.log"), Size: cliutil.Ptr(int64(2048))},
+				{Key: cliutil.Ptr("logs/index.json")},
+				{Key: cliutil.Ptr("media/video.mp4"), Size: cliutil.Ptr(int64(3 * 1024 * 1024))},
+			}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "bucket-size", "--bucket-name", "b", "--group-by-prefix-depth", "2")
+	if err != nil {
+		t.Fatalf("execute bucket-size: %v", err)
+	}
+	expected := strings.Join([]string{
+		"bucket=b prefix= object_count=1 total_bytes=100 size=100 B",
+		"bucket=b prefix=logs/ object_count=1 total_bytes=0 size=0 B",
+		"bucket=b prefix=logs/2026/ object_count=2 total_bytes=3072 size=3.0 KiB",
+		"bucket=b prefix=media/ object_count=1 total_bytes=3145728 size=3.0 MiB",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "s3", "bucket-size", "--bucket-name", "b")
+	if err != nil {
+		t.Fatalf("execute bucket-size: %v", err)
+	}
+	if strings.TrimSpace(output) != "bucket=b prefix= object_count=5 total_bytes=3148900 size=3.0 MiB" {
+		t.Fatalf("unexpected total output:\n%s", output)
+	}
+}
+
+func replicationMock(versioned map[string]bool) (*mockClient, *[]string, **s3.PutBucketReplicationInput) {
+	var enabled []string
+	var replication *s3.PutBucketReplicationInput
+	client := &mockClient{
+		getBucketLocationFn: func(_ context.Context, in *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			if awssdk.ToString(in.Bucket) == "dest-bucket" {
+				return &s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintEuWest1}, nil
+			}
+			return &s3.GetBucketLocationOutput{}, nil
+		},
+		getBucketVersioningFn: func(_ context.Context, in *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+			if versioned[awssdk.ToString(in.Bucket)] {
+				return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+			}
+			return &s3.GetBucketVersioningOutput{}, nil
+		},
+		putBucketVersioningFn: func(_ context.Context, in *s3.PutBucketVersioningInput, _ ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error) {
+			enabled = append(enabled, awssdk.ToString(in.Bucket))
+			return &s3.PutBucketVersioningOutput{}, nil
+		},
+		getBucketReplicationFn: func(_ context.Context, _ *s3.GetBucketReplicationInput, _ ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "ReplicationConfigurationNotFoundError", Message: "not found"}
+		},
+		putBucketReplicationFn: func(_ context.Context, in *s3.PutBucketReplicationInput, _ ...func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+			replication = in
+			return &s3.PutBucketReplicationOutput{}, nil
+		},
+	}
+	return client, &enabled, &replication
+}
+
+func TestSetupReplicationMergesExistingRules(t *testing.T) {
+	client, _, replication := replicationMock(map[string]bool{"source-bucket": true, "dest-bucket": true})
+	existingRole := "arn:aws:iam::123456789012:role/replication"
+	client.getBucketReplicationFn = func(_ context.Context, _ *s3.GetBucketReplicationInput, _ ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+		return &s3.GetBucketReplicationOutput{ReplicationConfiguration: &s3types.ReplicationConfiguration{
+			Role: cliutil.Ptr(existingRole),
+			Rules: []s3types.ReplicationRule{{
+				ID: cliutil.Ptr("archive"), Priority: cliutil.Ptr(int32(4)), Status: s3types.ReplicationRuleStatusEnabled,
+				Filter:      &s3types.ReplicationRuleFilter{Prefix: cliutil.Ptr("logs/")},
+				Destination: &s3types.Destination{Bucket: cliutil.Ptr("arn:aws:s3:::archive-bucket")},
+			}},
+		}}, nil
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", existingRole)
+	if err != nil {
+		t.Fatalf("execute dry-run: %v", err)
+	}
+	for _, expected := range []string{
+		"step=keep-rule bucket=source-bucket region=us-east-1 detail=rule=archive priority=4 destination=arn:aws:s3:::archive-bucket",
+		"step=add-rule bucket=source-bucket region=us-east-1 detail=rule=awstbx-source-bucket-to-dest-bucket priority=5 destination=arn:aws:s3:::dest-bucket",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in dry-run output: %s", expected, output)
+		}
+	}
+	if *replication != nil {
+		t.Fatal("dry-run must not write replication")
+	}
+
+	if _, err := executeCommand(t, "--no-confirm", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", existingRole); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	rules := (*replication).ReplicationConfiguration.Rules
+	if len(rules) != 2 || awssdk.ToString(rules[0].ID) != "archive" || awssdk.ToInt32(rules[1].Priority) != 5 {
+		t.Fatalf("expected existing rule to be kept, got %#v", rules)
+	}
+
+	otherRole := "arn:aws:iam::123456789012:role/other"
+	*replication = nil
+	if _, err := executeCommand(t, "--no-confirm", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", otherRole); err == nil || !strings.Contains(err.Error(), "rerun with --force") {
+		t.Fatalf("expected role conflict error, got %v", err)
+	}
+	if *replication != nil {
+		t.Fatal("conflicting configuration must not be overwritten without --force")
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", otherRole, "--force")
+	if err != nil {
+		t.Fatalf("execute --force: %v", err)
+	}
+	if rules := (*replication).ReplicationConfiguration.Rules; len(rules) != 1 || !strings.Contains(output, "step=drop-rule bucket=source-bucket region=us-east-1 detail=rule=archive") {
+		t.Fatalf("expected configuration to be replaced, got rules %#v output %s", rules, output)
+	}
+}
+
+func TestSetupReplicationAppliesConfig(t *testing.T) {
+	client, enabled, replication := replicationMock(map[string]bool{"source-bucket": true})
+	regions := make([]string, 0)
+	withMockDeps(t, mockLoader, func(cfg awssdk.Config) API {
+		regions = append(regions, cfg.Region)
+		return client
+	})
+
+	output, err := executeCommand(t, "--output", "json", "--no-confirm", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", "arn:aws:iam::123456789012:role/replication")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if strings.Join(*enabled, ",") != "dest-bucket" {
+		t.Fatalf("expected versioning enabled only on dest-bucket, got %v", *enabled)
+	}
+	if strings.Join(regions, ",") != "us-east-1,eu-west-1" {
+		t.Fatalf("expected a regional client for the destination bucket, got %v", regions)
+	}
+	in := *replication
+	if in == nil {
+		t.Fatal("expected PutBucketReplication to be called")
+	}
+	if awssdk.ToString(in.Bucket) != "source-bucket" {
+		t.Fatalf("unexpected replication bucket: %s", awssdk.ToString(in.Bucket))
+	}
+	if awssdk.ToString(in.ReplicationConfiguration.Role) != "arn:aws:iam::123456789012:role/replication" {
+		t.Fatalf("unexpected role: %s", awssdk.ToString(in.ReplicationConfiguration.Role))
+	}
+	if got := awssdk.ToString(in.ReplicationConfiguration.Rules[0].Destination.Bucket); got != "arn:aws:s3:::dest-bucket" {
+		t.Fatalf("unexpected destination: %s", got)
+	}
+	for _, expected := range []string{`"step": "enable-versioning"`, `"action": "enabled"`, `"action": "applied"`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %s in output: %s", expected, output)
+		}
+	}
+}
+
+func TestSetupReplicationDryRun(t *testing.T) {
+	client, enabled, replication := replicationMock(map[string]bool{})
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "json", "--dry-run", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", "arn:aws:iam::123456789012:role/replication")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if len(*enabled) != 0 || *replication != nil {
+		t.Fatalf("dry-run must not mutate buckets")
+	}
+	if strings.Count(output, `"step": "enable-versioning"`) != 2 || !strings.Contains(output, "destination=arn:aws:s3:::dest-bucket") || !strings.Contains(output, `"action": "would-apply"`) {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+}
+
+func TestSetupReplicationUsesRolePartition(t *testing.T) {
+	client, _, _ := replicationMock(map[string]bool{"source-bucket": true, "dest-bucket": true})
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", "arn:aws-cn:iam::123456789012:role/replication")
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !strings.Contains(output, "destination=arn:aws-cn:s3:::dest-bucket") {
+		t.Fatalf("expected aws-cn destination ARN: %s", output)
+	}
+
+	if _, err := executeCommand(t, "s3", "setup-replication",
+		"--source", "source-bucket", "--dest", "dest-bucket", "--role-arn", "arn:aws:s3:::not-a-role"); err == nil || !strings.Contains(err.Error(), "--role-arn must be an IAM role ARN") {
+		t.Fatalf("expected role ARN validation error, got %v", err)
+	}
+}
+
+func TestListBucketsEnrichesRegionAndVersioning(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var (
+		mu            sync.Mutex
+		regionClients []string
+	)
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{
+				{Name: cliutil.Ptr("logs"), CreationDate: &created},
+				{Name: cliutil.Ptr("assets"), CreationDate: &created, BucketRegion: cliutil.Ptr("us-east-1")},
+			}}, nil
+		},
+		getBucketLocationFn: func(_ context.Context, in *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			if cliutil.PointerToString(in.Bucket) != "logs" {
+				t.Fatalf("unexpected location lookup for %s", cliutil.PointerToString(in.Bucket))
+			}
+			return &s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintEuWest1}, nil
+		},
+		getBucketVersioningFn: func(_ context.Context, in *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+			if cliutil.PointerToString(in.Bucket) == "assets" {
+				return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+			}
+			return &s3.GetBucketVersioningOutput{}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if in.ContinuationToken == nil {
+				return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("a"), Size: awssdk.Int64(10)}}, NextContinuationToken: cliutil.Ptr("page-2")}, nil
+			}
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("b"), Size: awssdk.Int64(32)}}}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(cfg awssdk.Config) API {
+			mu.Lock()
+			regionClients = append(regionClients, cfg.Region)
+			mu.Unlock()
+			return client
+		},
+	)
+
+	output, err := executeCommand(t, "--output", "text", "s3", "list-buckets", "--with-size", "--concurrency", "2")
+	if err != nil {
+		t.Fatalf("execute list-buckets: %v", err)
+	}
+	for _, expected := range []string{
+		"bucket=assets region=us-east-1 creation_date=2024-03-01T12:00:00Z versioning=Enabled object_count=2 size_bytes=42",
+		"bucket=logs region=eu-west-1 creation_date=2024-03-01T12:00:00Z versioning=Disabled object_count=2 size_bytes=42",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Join(regionClients, ",") != "us-east-1,eu-west-1" {
+		t.Fatalf("expected a single extra eu-west-1 client, got %v", regionClients)
+	}
+}
+
+func TestListBucketsValidatesConcurrency(t *testing.T) {
+	_, err := executeCommand(t, "s3", "list-buckets", "--concurrency", "0")
+	if err == nil || !strings.Contains(err.Error(), "--concurrency must be at least 1") {
+		t.Fatalf("expected concurrency validation error, got %v", err)
+	}
+}
+
+func TestDeleteObjectsReportsPerKeyErrors(t *testing.T) {
+	dir := t.TempDir()
+	keysFile := filepath.Join(dir, "keys.json")
+	versionsFile := filepath.Join(dir, "versions.txt")
+	if err := os.WriteFile(keysFile, []byte(`["a.txt", "locked.txt", "b.txt"]`), 0o600); err != nil {
+		t.Fatalf("write keys file: %v", err)
+	}
+	if err := os.WriteFile(versionsFile, []byte("v1\nv2\n\nv3\n"), 0o600); err != nil {
+		t.Fatalf("write versions file: %v", err)
+	}
+
+	calls := 0
+	client := &mockClient{
+		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			calls++
+			if len(in.Delete.Objects) != 3 || cliutil.PointerToString(in.Delete.Objects[1].VersionId) != "v2" {
+				t.Fatalf("unexpected delete request: %#v", in.Delete.Objects)
+			}
+			return &s3.DeleteObjectsOutput{Errors: []s3types.Error{{
+				Key:       cliutil.Ptr("locked.txt"),
+				VersionId: cliutil.Ptr("v2"),
+				Code:      cliutil.Ptr("AccessDenied"),
+				Message:   cliutil.Ptr("object is WORM protected"),
+			}}}, nil
+		},
+	}
+	withMockDeps(t, func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil }, func(awssdk.Config) API { return client })
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-objects", "--bucket-name", "my-bucket", "--keys-file", keysFile, "--version-ids-file", versionsFile)
+	if err != nil {
+		t.Fatalf("execute delete-objects: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 DeleteObjects call, got %d", calls)
+	}
+	for _, expected := range []string{
+		"key=a.txt version_id=v1 action=deleted",
+		"key=locked.txt version_id=v2 action=failed:AccessDenied: object is WORM protected",
+		"key=b.txt version_id=v3 action=deleted",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestDeleteObjectsDryRunAndValidation(t *testing.T) {
+	dir := t.TempDir()
+	keysFile := filepath.Join(dir, "keys.txt")
+	if err := os.WriteFile(keysFile, []byte("logs/one.log\nlogs/two.log\n"), 0o600); err != nil {
+		t.Fatalf("write keys file: %v", err)
+	}
+	versionsFile := filepath.Join(dir, "versions.txt")
+	if err := os.WriteFile(versionsFile, []byte("v1\n"), 0o600); err != nil {
+		t.Fatalf("write versions file: %v", err)
+	}
+
+	client := &mockClient{
+		deleteObjectsFn: func(_ context.Context, _ *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			t.Fatal("DeleteObjects should not be called")
+			return nil, nil
+		},
+	}
+	withMockDeps(t, func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil }, func(awssdk.Config) API { return client })
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "delete-objects", "--bucket-name", "my-bucket", "--keys-file", keysFile)
+	if err != nil {
+		t.Fatalf("execute delete-objects dry-run: %v", err)
+	}
+	if !strings.Contains(output, "key=logs/one.log version_id= action=would-delete") || !strings.Contains(output, "key=logs/two.log version_id= action=would-delete") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	_, err = executeCommand(t, "s3", "delete-objects", "--bucket-name", "my-bucket", "--keys-file", keysFile, "--version-ids-file", versionsFile)
+	if err == nil || !strings.Contains(err.Error(), "paired line by line") {
+		t.Fatalf("expected version count mismatch error, got %v", err)
+	}
+}
+
+func TestDiffListingAgainstBaseline(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	baseline := `{"bucket":"my-bucket","captured_at":"2026-01-01T00:00:00Z","objects":{
+	  "keep.txt":{"s":10,"e":"\"a\""},
+	  "gone.txt":{"s":5,"e":"\"b\""},
+	  "grown.txt":{"s":1,"e":"\"c\""},
+	  "rewritten.txt":{"s":3,"e":"\"d\""}
+	}}`
+	if err := os.WriteFile(baselinePath, []byte(baseline), 0o600); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+
+	client := &mockClient{
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{
+				{Key: cliutil.Ptr("keep.txt"), Size: cliutil.Ptr(int64(10)), ETag: cliutil.Ptr(`"a"`)},
+				{Key: cliutil.Ptr("grown.txt"), Size: cliutil.Ptr(int64(100)), ETag: cliutil.Ptr(`"c2"`)},
+				{Key: cliutil.Ptr("rewritten.txt"), Size: cliutil.Ptr(int64(3)), ETag: cliutil.Ptr(`"d2"`)},
+				{Key: cliutil.Ptr("new.txt"), Size: cliutil.Ptr(int64(7)), ETag: cliutil.Ptr(`"e"`)},
+			}}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	savePath := filepath.Join(dir, "current.json")
+	output, err := executeCommand(t, "--output", "text", "s3", "diff-listing", "--bucket-name", "my-bucket", "--baseline", baselinePath, "--save-listing", savePath)
+	if err != nil {
+		t.Fatalf("execute diff-listing: %v", err)
+	}
+	for _, expected := range []string{
+		"key=gone.txt change=removed baseline_size=5 current_size=",
+		"key=grown.txt change=size-changed baseline_size=1 current_size=100",
+		"key=new.txt change=added baseline_size= current_size=7",
+		"key=rewritten.txt change=modified baseline_size=3 current_size=3",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "key=keep.txt") {
+		t.Fatalf("did not expect unchanged object in output: %s", output)
+	}
+
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		t.Fatalf("read saved listing: %v", err)
+	}
+	var saved listingSnapshot
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("parse saved listing: %v", err)
+	}
+	if saved.Bucket != "my-bucket" || len(saved.Objects) != 4 || saved.Objects["new.txt"].Size != 7 {
+		t.Fatalf("unexpected saved listing: %+v", saved)
+	}
+}
+
+func TestDeleteBucketsAbortUploadsClearsInProgressUpload(t *testing.T) {
+	uploadInProgress := true
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{{Name: cliutil.Ptr("test-uploads")}}}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{}, nil
+		},
+		listObjectVersionsFn: func(_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			return &s3.ListObjectVersionsOutput{}, nil
+		},
+		listMultipartUploadsFn: func(_ context.Context, _ *s3.ListMultipartUploadsInput, _ ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+			if !uploadInProgress {
+				return &s3.ListMultipartUploadsOutput{}, nil
+			}
+			return &s3.ListMultipartUploadsOutput{Uploads: []s3types.MultipartUpload{{Key: cliutil.Ptr("big.iso"), UploadId: cliutil.Ptr("upload-1")}}}, nil
+		},
+		abortMultipartUploadFn: func(_ context.Context, in *s3.AbortMultipartUploadInput, _ ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+			if cliutil.PointerToString(in.Key) != "big.iso" || cliutil.PointerToString(in.UploadId) != "upload-1" {
+				t.Fatalf("unexpected abort input: %+v", in)
+			}
+			uploadInProgress = false
+			return &s3.AbortMultipartUploadOutput{}, nil
+		},
+		deleteBucketFn: func(_ context.Context, _ *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			if uploadInProgress {
+				return nil, &smithy.GenericAPIError{Code: "BucketNotEmpty", Message: "The bucket you tried to delete is not empty"}
+			}
+			return &s3.DeleteBucketOutput{}, nil
+		},
+	}
+
+	withMockDeps(t, mockLoader, mockFactory(client))
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--filter-name-contains", "test")
+	if err != nil {
+		t.Fatalf("execute delete-buckets: %v", err)
+	}
+	if !strings.Contains(output, "action=failed:The bucket you tried to delete is not empty (BucketNotEmpty)") {
+		t.Fatalf("expected in-progress upload to block deletion: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--filter-name-contains", "test", "--abort-uploads")
+	if err != nil {
+		t.Fatalf("execute delete-buckets --abort-uploads: %v", err)
+	}
+	if !strings.Contains(output, "bucket=test-uploads action=deleted aborted_uploads=1") {
+		t.Fatalf("expected aborted upload and deletion: %s", output)
+	}
+}
+
+func TestDeleteBucketsForceVersionedPurgesVersionsAndDeleteMarkers(t *testing.T) {
+	deletedBuckets := make([]string, 0)
+	purged := make([]string, 0)
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{{Name: cliutil.Ptr("live")}, {Name: cliutil.Ptr("versioned")}}}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if cliutil.PointerToString(in.Bucket) == "live" {
+				return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("current.txt")}}}, nil
+			}
+			return &s3.ListObjectsV2Output{}, nil
+		},
+		getBucketVersioningFn: func(_ context.Context, _ *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+			return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+		},
+		listObjectVersionsFn: func(_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			return &s3.ListObjectVersionsOutput{
+				Versions:      []s3types.ObjectVersion{{Key: cliutil.Ptr("a.txt"), VersionId: cliutil.Ptr("v1")}},
+				DeleteMarkers: []s3types.DeleteMarkerEntry{{Key: cliutil.Ptr("a.txt"), VersionId: cliutil.Ptr("v2")}},
+			}, nil
+		},
+		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			for _, object := range in.Delete.Objects {
+				purged = append(purged, cliutil.PointerToString(object.Key)+"@"+cliutil.PointerToString(object.VersionId))
+			}
+			return &s3.DeleteObjectsOutput{}, nil
+		},
+		deleteBucketFn: func(_ context.Context, in *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			deletedBuckets = append(deletedBuckets, cliutil.PointerToString(in.Bucket))
+			return &s3.DeleteBucketOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	if _, err := executeCommand(t, "s3", "delete-buckets", "--filter-name-contains", "v", "--force-versioned"); err == nil || !strings.Contains(err.Error(), "--force-versioned requires --empty") {
+		t.Fatalf("expected --empty requirement error, got %v", err)
+	}
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "delete-buckets", "--empty", "--force-versioned")
+	if err != nil {
+		t.Fatalf("execute dry-run: %v", err)
+	}
+	if !strings.Contains(output, "bucket=versioned action=would-delete note=versioned contents will be purged") || !strings.Contains(output, "bucket=live action=skipped:has-current-objects") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+	if len(deletedBuckets) != 0 || len(purged) != 0 {
+		t.Fatalf("dry-run should not delete, got buckets=%v objects=%v", deletedBuckets, purged)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--empty", "--force-versioned")
+	if err != nil {
+		t.Fatalf("execute delete-buckets: %v", err)
+	}
+	if !strings.Contains(output, "bucket=versioned action=deleted") || !strings.Contains(output, "bucket=live action=skipped:has-current-objects") {
+		t.Fatalf("expected versioned bucket deleted and live bucket skipped: %s", output)
+	}
+	if strings.Join(deletedBuckets, ",") != "versioned" || strings.Join(purged, ",") != "a.txt@v1,a.txt@v2" {
+		t.Fatalf("unexpected deletes: buckets=%v objects=%v", deletedBuckets, purged)
+	}
+}
+
+func TestDeleteBucketsClearsSuspendedBucketWithLiveObjects(t *testing.T) {
+	purged := make([]string, 0)
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{{Name: cliutil.Ptr("suspended")}}}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("live.txt")}}}, nil
+		},
+		listObjectVersionsFn: func(_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			return &s3.ListObjectVersionsOutput{Versions: []s3types.ObjectVersion{{Key: cliutil.Ptr("old.txt"), VersionId: cliutil.Ptr("v1")}}}, nil
+		},
+		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			for _, object := range in.Delete.Objects {
+				purged = append(purged, cliutil.PointerToString(object.Key))
+			}
+			return &s3.DeleteObjectsOutput{}, nil
+		},
+		deleteBucketFn: func(_ context.Context, _ *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			return &s3.DeleteBucketOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--filter-name-contains", "suspended")
+	if err != nil {
+		t.Fatalf("execute delete-buckets: %v", err)
+	}
+	if !strings.Contains(output, "bucket=suspended action=deleted") || strings.Join(purged, ",") != "live.txt,old.txt" {
+		t.Fatalf("expected live objects and old versions purged, got %v: %s", purged, output)
+	}
+}
+
+func TestDeleteBucketsForceVersionedSkipsSuspendedBucketWithLiveObjects(t *testing.T) {
+	deleted := false
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{{Name: cliutil.Ptr("suspended")}}}, nil
+		},
+		listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			return &s3.ListObjectsV2Output{Contents: []s3types.Object{{Key: cliutil.Ptr("live.txt")}}}, nil
+		},
+		getBucketVersioningFn: func(_ context.Context, _ *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+			return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusSuspended}, nil
+		},
+		deleteObjectsFn: func(_ context.Context, _ *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			deleted = true
+			return &s3.DeleteObjectsOutput{}, nil
+		},
+		deleteBucketFn: func(_ context.Context, _ *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+			deleted = true
+			return &s3.DeleteBucketOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--empty", "--force-versioned")
+	if err != nil {
+		t.Fatalf("execute delete-buckets: %v", err)
+	}
+	if !strings.Contains(output, "bucket=suspended action=skipped:has-current-objects") || strings.Contains(output, "Delete") {
+		t.Fatalf("expected suspended bucket skipped without a prompt: %s", output)
+	}
+	if deleted {
+		t.Fatal("bucket with current objects should not be touched")
+	}
+}
+
+func TestListDeletedObjectsJoinsKeySplitAcrossPages(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	client := &mockClient{
+		listObjectVersionsFn: func(_ context.Context, in *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			if in.KeyMarker == nil {
+				return &s3.ListObjectVersionsOutput{
+					DeleteMarkers: []s3types.DeleteMarkerEntry{
+						{Key: cliutil.Ptr("a.txt"), VersionId: cliutil.Ptr("a-dm"), LastModified: &newer, IsLatest: cliutil.Ptr(true)},
+					},
+					NextKeyMarker:       cliutil.Ptr("a.txt"),
+					NextVersionIdMarker: cliutil.Ptr("a-dm"),
+				}, nil
+			}
+			return &s3.ListObjectVersionsOutput{
+				Versions: []s3types.ObjectVersion{
+					{Key: cliutil.Ptr("a.txt"), VersionId: cliutil.Ptr("a-v1"), LastModified: &older},
+					{Key: cliutil.Ptr("b.txt"), VersionId: cliutil.Ptr("b-v1"), LastModified: &older, IsLatest: cliutil.Ptr(true)},
+				},
+			}, nil
+		},
+	}
+
+	deleted, err := listDeletedObjects(context.Background(), client, "bucket", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].key != "a.txt" || deleted[0].restoredVersionID != "a-v1" || strings.Join(deleted[0].markerVersionIDs, ",") != "a-dm" {
+		t.Fatalf("expected a.txt restored across pages, got %#v", deleted)
+	}
+}
+
+func TestRestoreVersionsRemovesDeleteMarkers(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	newest := newer.Add(time.Hour)
+	removed := make([]string, 0)
+	client := &mockClient{
+		getBucketLocationFn: func(_ context.Context, _ *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{}, nil
+		},
+		listObjectVersionsFn: func(_ context.Context, in *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+			if cliutil.PointerToString(in.Prefix) != "docs/" {
+				t.Fatalf("unexpected prefix: %q", cliutil.PointerToString(in.Prefix))
+			}
+			if in.KeyMarker == nil {
+				return &s3.ListObjectVersionsOutput{
+					Versions: []s3types.ObjectVersion{
+						{Key: cliutil.Ptr("docs/a.txt"), VersionId: cliutil.Ptr("a-v1"), LastModified: &older},
+						{Key: cliutil.Ptr("docs/a.txt"), VersionId: cliutil.Ptr("a-v2"), LastModified: &newer},
+						{Key: cliutil.Ptr("docs/live.txt"), VersionId: cliutil.Ptr("live-v1"), LastModified: &older, IsLatest: cliutil.Ptr(true)},
+					},
+					DeleteMarkers: []s3types.DeleteMarkerEntry{
+						{Key: cliutil.Ptr("docs/a.txt"), VersionId: cliutil.Ptr("a-dm"), LastModified: &newest, IsLatest: cliutil.Ptr(true)},
+					},
+					NextKeyMarker:       cliutil.Ptr("docs/live.txt"),
+					NextVersionIdMarker: cliutil.Ptr("live-v1"),
+				}, nil
+			}
+			return &s3.ListObjectVersionsOutput{
+				Versions: []s3types.ObjectVersion{
+					{Key: cliutil.Ptr("docs/b.txt"), VersionId: cliutil.Ptr("b-v1"), LastModified: &older},
+				},
+				DeleteMarkers: []s3types.DeleteMarkerEntry{
+					{Key: cliutil.Ptr("docs/b.txt"), VersionId: cliutil.Ptr("b-dm2"), LastModified: &newest, IsLatest: cliutil.Ptr(true)},
+					{Key: cliutil.Ptr("docs/b.txt"), VersionId: cliutil.Ptr("b-dm1"), LastModified: &newer},
+					{Key: cliutil.Ptr("docs/gone.txt"), VersionId: cliutil.Ptr("gone-dm"), LastModified: &newer, IsLatest: cliutil.Ptr(true)},
+				},
+			}, nil
+		},
+		deleteObjectsFn: func(_ context.Context, in *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+			for _, object := range in.Delete.Objects {
+				removed = append(removed, cliutil.PointerToString(object.Key)+"@"+cliutil.PointerToString(object.VersionId))
+			}
+			return &s3.DeleteObjectsOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "restore-versions", "--bucket-name", "docs-bucket", "--prefix", "docs/")
+	if err != nil {
+		t.Fatalf("execute dry-run: %v", err)
+	}
+	expected := "bucket=docs-bucket key=docs/a.txt delete_marker_version_ids=a-dm restored_version_id=a-v2 action=would-restore\n" +
+		"bucket=docs-bucket key=docs/b.txt delete_marker_version_ids=b-dm2,b-dm1 restored_version_id=b-v1 action=would-restore"
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected dry-run output:\n%s", output)
+	}
+	if len(removed) != 0 {
+		t.Fatalf("dry-run should not delete markers, got %v", removed)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "restore-versions", "--bucket-name", "docs-bucket", "--prefix", "docs/")
+	if err != nil {
+		t.Fatalf("execute restore-versions: %v", err)
+	}
+	if strings.Count(output, "action=restored") != 2 {
+		t.Fatalf("expected two restored keys: %s", output)
+	}
+	if strings.Join(removed, ",") != "docs/a.txt@a-dm,docs/b.txt@b-dm2,docs/b.txt@b-dm1" {
+		t.Fatalf("unexpected removed markers: %v", removed)
+	}
+}
+
+func TestDeleteBucketsAllRegionsUsesHomeRegionClient(t *testing.T) {
+	deletedIn := make(map[string]string)
+	regionalClient := func(region string) *mockClient {
+		return &mockClient{
+			listObjectsV2Fn: func(_ context.Context, _ *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+				return &s3.ListObjectsV2Output{}, nil
+			},
+			getBucketVersioningFn: func(_ context.Context, _ *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+				return &s3.GetBucketVersioningOutput{}, nil
+			},
+			listObjectVersionsFn: func(_ context.Context, _ *s3.ListObjectVersionsInput, _ ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error) {
+				return &s3.ListObjectVersionsOutput{}, nil
+			},
+			deleteBucketFn: func(_ context.Context, in *s3.DeleteBucketInput, _ ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+				deletedIn[cliutil.PointerToString(in.Bucket)] = region
+				return &s3.DeleteBucketOutput{}, nil
+			},
+			getBucketTaggingFn: func(_ context.Context, in *s3.GetBucketTaggingInput, _ ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+				bucket := cliutil.PointerToString(in.Bucket)
+				if (bucket == "tmp-eu") != (region == "eu-west-1") {
+					return nil, &smithy.GenericAPIError{Code: "PermanentRedirect", Message: "wrong region"}
+				}
+				return &s3.GetBucketTaggingOutput{TagSet: []s3types.Tag{{Key: cliutil.Ptr("env"), Value: cliutil.Ptr(region)}}}, nil
+			},
+		}
+	}
+	base := regionalClient("us-east-1")
+	base.listBucketsFn = func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+		return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{
+			{Name: cliutil.Ptr("tmp-eu")},
+			{Name: cliutil.Ptr("tmp-us"), BucketRegion: cliutil.Ptr("us-east-1")},
+		}}, nil
+	}
+	base.getBucketLocationFn = func(_ context.Context, in *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+		if cliutil.PointerToString(in.Bucket) != "tmp-eu" {
+			t.Fatalf("unexpected location lookup for %s", cliutil.PointerToString(in.Bucket))
+		}
+		return &s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintEuWest1}, nil
+	}
+	euClient := regionalClient("eu-west-1")
+	withMockDeps(t, mockLoader, func(cfg awssdk.Config) API {
+		if cfg.Region == "eu-west-1" {
+			return euClient
+		}
+		return base
+	})
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "--include-tags", "env", "s3", "delete-buckets", "--empty", "--all-regions")
+	if err != nil {
+		t.Fatalf("execute delete-buckets --all-regions --include-tags: %v", err)
+	}
+	for _, expected := range []string{
+		"bucket=tmp-eu region=eu-west-1 action=would-delete tag:env=eu-west-1",
+		"bucket=tmp-us region=us-east-1 action=would-delete tag:env=us-east-1",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "delete-buckets", "--empty", "--all-regions")
+	if err != nil {
+		t.Fatalf("execute delete-buckets --all-regions: %v", err)
+	}
+	for _, expected := range []string{
+		"bucket=tmp-eu region=eu-west-1 action=deleted",
+		"bucket=tmp-us region=us-east-1 action=deleted",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+	if deletedIn["tmp-eu"] != "eu-west-1" || deletedIn["tmp-us"] != "us-east-1" {
+		t.Fatalf("expected deletes in home regions, got %v", deletedIn)
+	}
+}
+
+func TestAuditCORSFlagsWildcardOriginPut(t *testing.T) {
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{
+				{Name: cliutil.Ptr("uploads"), BucketRegion: cliutil.Ptr("us-east-1")},
+				{Name: cliutil.Ptr("plain"), BucketRegion: cliutil.Ptr("us-east-1")},
+				{Name: cliutil.Ptr("site"), BucketRegion: cliutil.Ptr("us-east-1")},
+			}}, nil
+		},
+		getBucketCorsFn: func(_ context.Context, in *s3.GetBucketCorsInput, _ ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error) {
+			switch cliutil.PointerToString(in.Bucket) {
+			case "uploads":
+				return &s3.GetBucketCorsOutput{CORSRules: []s3types.CORSRule{{
+					ID: cliutil.Ptr("browser-upload"), AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "PUT"}, AllowedHeaders: []string{"*"},
+				}}}, nil
+			case "site":
+				return &s3.GetBucketCorsOutput{CORSRules: []s3types.CORSRule{{
+					AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"GET"},
+				}}}, nil
+			}
+			return nil, &smithy.GenericAPIError{Code: "NoSuchCORSConfiguration", Message: "The CORS configuration does not exist"}
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "audit-cors")
+	if err != nil {
+		t.Fatalf("execute audit-cors: %v", err)
+	}
+	for _, expected := range []string{
+		"bucket=plain region=us-east-1 rule= allowed_origins= allowed_methods= allowed_headers= finding=not-configured",
+		"bucket=site region=us-east-1 rule=#1 allowed_origins=https://example.com allowed_methods=GET allowed_headers= finding=ok",
+		"rule=browser-upload allowed_origins=* allowed_methods=GET,PUT allowed_headers=* finding=wildcard origin allows PUT; wildcard origin allows any request header",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestAuditMFADeleteReportsVersionedBucketsWithoutIt(t *testing.T) {
+	client := &mockClient{
+		listBucketsFn: func(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []s3types.Bucket{
+				{Name: cliutil.Ptr("logs"), BucketRegion: cliutil.Ptr("eu-west-1")},
+				{Name: cliutil.Ptr("protected"), BucketRegion: cliutil.Ptr("us-east-1")},
+				{Name: cliutil.Ptr("unversioned"), BucketRegion: cliutil.Ptr("us-east-1")},
+			}}, nil
+		},
+		getBucketVersioningFn: func(_ context.Context, in *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+			switch cliutil.PointerToString(in.Bucket) {
+			case "logs":
+				return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+			case "protected":
+				return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled, MFADelete: s3types.MFADeleteStatusEnabled}, nil
+			}
+			return &s3.GetBucketVersioningOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "s3", "audit-mfa-delete")
+	if err != nil {
+		t.Fatalf("execute audit-mfa-delete: %v", err)
+	}
+	if !strings.Contains(output, "bucket=logs region=eu-west-1 versioning=Enabled mfa_delete=Disabled enable_command=aws s3api put-bucket-versioning --bucket logs --region eu-west-1 --versioning-configuration Status=Enabled,MFADelete=Enabled --mfa") {
+		t.Fatalf("expected logs bucket with enable command: %s", output)
+	}
+	if strings.Contains(output, "bucket=protected") || strings.Contains(output, "bucket=unversioned") {
+		t.Fatalf("expected only unprotected versioned buckets: %s", output)
+	}
+}
+
+func TestSetCORSAppliesConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "cors.json")
+	config := `{"CORSRules": [{"ID": "site", "AllowedOrigins": ["https://example.com"], "AllowedMethods": ["GET", "HEAD"], "MaxAgeSeconds": 3000}]}`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var applied *s3types.CORSConfiguration
+	client := &mockClient{
+		getBucketLocationFn: func(_ context.Context, _ *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{}, nil
+		},
+		putBucketCorsFn: func(_ context.Context, in *s3.PutBucketCorsInput, _ ...func(*s3.Options)) (*s3.PutBucketCorsOutput, error) {
+			applied = in.CORSConfiguration
+			return &s3.PutBucketCorsOutput{}, nil
+		},
+	}
+	withMockDeps(t, mockLoader, mockFactory(client))
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "s3", "set-cors", "--bucket-name", "site-bucket", "--config", configPath)
+	if err != nil {
+		t.Fatalf("execute set-cors --dry-run: %v", err)
+	}
+	if applied != nil || !strings.Contains(output, "rule=site allowed_origins=https://example.com allowed_methods=GET,HEAD allowed_headers= expose_headers= max_age_seconds=3000 action=would-apply") {
+		t.Fatalf("unexpected dry-run output: %s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "s3", "set-cors", "--bucket-name", "site-bucket", "--config", configPath)
+	if err != nil {
+		t.Fatalf("execute set-cors: %v", err)
+	}
+	if applied == nil || len(applied.CORSRules) != 1 || cliutil.PointerToString(applied.CORSRules[0].ID) != "site" || !strings.Contains(output, "action=applied") {
+		t.Fatalf("unexpected apply result %#v: %s", applied, output)
+	}
+
+	if err := os.WriteFile(configPath, []byte(`{"CORSRules": [{"AllowedOrigins": ["*"]}]}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := executeCommand(t, "s3", "set-cors", "--bucket-name", "site-bucket", "--config", configPath); err == nil || !strings.Contains(err.Error(), "needs AllowedMethods") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+)
+
+// streamSearchObjects writes search-objects results as one JSON object per
+// line while ListObjectsV2 pages arrive, so memory does not grow with the
+// number of matches. Keys are streamed in listing order; queries that matched
+// nothing are written last with exists=false. Rows already written stay on
+// stdout when a later page fails, and the error is still returned. A limit
+// above zero stops the stream, and the listing, after that many lines.
+func streamSearchObjects(cmd *cobra.Command, client API, bucket, prefix string, queries []string, matchers []func(string) bool, limit int) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	exactMode := matchers == nil
+	if exactMode {
+		matchers = exactKeyMatchers(queries, prefix)
+	}
+	matched := make([]bool, len(queries))
+
+	written := 0
+	limitReached := func() bool { return limit > 0 && written >= limit }
+	writeLine := func(headers, row []string) error {
+		if err := writeSearchLine(encoder, headers, row); err != nil {
+			return err
+		}
+		written++
+		return nil
+	}
+
+	var writeErr error
+	listErr := walkObjectsUntil(cmd.Context(), client, bucket, prefix, func(page []s3types.Object) bool {
+		for _, object := range page {
+			if writeErr != nil || limitReached() {
+				return false
+			}
+			key := objectKey(object)
+			if len(queries) == 0 {
+				writeErr = writeLine([]string{"bucket", "key", "exists", "last_modified", "size_bytes"},
+					[]string{bucket, key, "true", objectLastModified(object).Format(time.RFC3339), fmt.Sprintf("%d", objectSize(object))})
+				continue
+			}
+			for i, query := range queries {
+				// An exact query names a single object, so only its first
+				// match is reported.
+				if (exactMode && matched[i]) || !matchers[i](key) {
+					continue
+				}
+				matched[i] = true
+				if writeErr = writeLine(searchResultHeaders, searchResultRow(bucket, query, key, object)); writeErr != nil || limitReached() {
+					return false
+				}
+			}
+		}
+		return writeErr == nil && !limitReached()
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if listErr != nil {
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(listErr))
+	}
+
+	for i, query := range queries {
+		if matched[i] {
+			continue
+		}
+		if limitReached() {
+			return nil
+		}
+		if err := writeLine(searchResultHeaders, []string{bucket, query, "", "false", "", "0"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exactKeyMatchers matches each query as a full key or, as the buffered
+// search does, as a key relative to prefix.
+func exactKeyMatchers(queries []string, prefix string) []func(string) bool {
+	matchers := make([]func(string) bool, 0, len(queries))
+	for _, query := range queries {
+		candidate := ""
+		if strings.TrimSpace(prefix) != "" {
+			candidate = strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(query, "/")
+		}
+		matchers = append(matchers, func(key string) bool {
+			return key == query || (candidate != "" && key == candidate)
+		})
+	}
+	return matchers
+}
+
+func writeSearchLine(encoder *json.Encoder, headers, row []string) error {
+	line := make(map[string]string, len(headers))
+	for i, header := range headers {
+		line[header] = row[i]
+	}
+	if err := encoder.Encode(line); err != nil {
+		return fmt.Errorf("write result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// storagePricePerGBMonth is a static USD price table (us-east-1 list prices)
+// used to estimate tiering savings. It is intentionally approximate.
+var storagePricePerGBMonth = map[s3types.ObjectStorageClass]float64{
+	s3types.ObjectStorageClassStandard:   0.023,
+	s3types.ObjectStorageClassStandardIa: 0.0125,
+	s3types.ObjectStorageClassGlacier:    0.0036,
+}
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+func runTieringReport(cmd *cobra.Command, bucket, prefix string, olderThanDays int) error {
+	if strings.TrimSpace(bucket) == "" {
+		return fmt.Errorf("--bucket-name is required")
+	}
+	if olderThanDays < 0 {
+		return fmt.Errorf("--older-than-days must be >= 0")
+	}
+
+	cutoff, err := cliutil.ParseTimeSpec(fmt.Sprintf("%dd", olderThanDays))
+	if err != nil {
+		return err
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	objects, err := listObjects(cmd.Context(), client, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("list objects: %w", awstbxaws.WrapUserError(err))
+	}
+	sortObjectsByKey(objects)
+
+	now := time.Now().UTC()
+	var totalBytes int64
+	var totalIA, totalGlacier float64
+	rows := make([][]string, 0, len(objects))
+	for _, object := range objects {
+		storageClass := object.StorageClass
+		if storageClass == "" {
+			storageClass = s3types.ObjectStorageClassStandard
+		}
+		if storageClass != s3types.ObjectStorageClassStandard {
+			continue
+		}
+
+		lastModified := objectLastModified(object)
+		if lastModified.IsZero() {
+			continue
+		}
+		if lastModified.After(cutoff) {
+			continue
+		}
+		ageDays := int(now.Sub(lastModified).Hours() / 24)
+
+		size := objectSize(object)
+		savingsIA := estimateMonthlySavings(size, s3types.ObjectStorageClassStandardIa)
+		savingsGlacier := estimateMonthlySavings(size, s3types.ObjectStorageClassGlacier)
+		totalBytes += size
+		totalIA += savingsIA
+		totalGlacier += savingsGlacier
+
+		rows = append(rows, []string{
+			objectKey(object),
+			fmt.Sprintf("%d", size),
+			fmt.Sprintf("%d", ageDays),
+			string(storageClass),
+			formatUSD(savingsIA),
+			formatUSD(savingsGlacier),
+		})
+	}
+
+	if err := cliutil.WriteDataset(cmd, runtime, []string{"key", "size_bytes", "age_days", "storage_class", "savings_standard_ia_usd", "savings_glacier_usd"}, rows); err != nil {
+		return err
+	}
+	// Totals go to stderr so every stdout row stays one object.
+	if len(rows) > 0 && !runtime.Options.Quiet {
+		fmt.Fprintf(cmd.ErrOrStderr(), "total: %d object(s), %d bytes, savings_standard_ia_usd=%s savings_glacier_usd=%s\n", len(rows), totalBytes, formatUSD(totalIA), formatUSD(totalGlacier))
+	}
+	return nil
+}
+
+func estimateMonthlySavings(sizeBytes int64, target s3types.ObjectStorageClass) float64 {
+	gb := float64(sizeBytes) / bytesPerGB
+	return gb * (storagePricePerGBMonth[s3types.ObjectStorageClassStandard] - storagePricePerGBMonth[target])
+}
+
+func formatUSD(value float64) string {
+	return fmt.Sprintf("%.4f", value)
+}
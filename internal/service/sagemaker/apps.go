@@ -0,0 +1,73 @@
+package sagemaker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runListApps lists the Studio apps that have not been deleted, across every
+// domain unless one is given. Running apps bill by the hour for their
+// instance type, so both are shown to make forgotten apps easy to spot.
+func runListApps(cmd *cobra.Command, domainID, userProfile string) error {
+	domain := strings.TrimSpace(domainID)
+	profile := strings.TrimSpace(userProfile)
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	domainIDs := []string{domain}
+	if domain == "" {
+		domainIDs, err = listDomainIDs(cmd.Context(), client)
+		if err != nil {
+			return fmt.Errorf("list SageMaker domains: %w", awstbxaws.WrapUserError(err))
+		}
+	}
+
+	rows := make([][]string, 0)
+	for _, currentDomainID := range domainIDs {
+		apps, listErr := listApps(cmd.Context(), client, currentDomainID, profile, true)
+		if listErr != nil {
+			return fmt.Errorf("list apps for domain %s: %w", currentDomainID, awstbxaws.WrapUserError(listErr))
+		}
+
+		for _, app := range apps {
+			instanceType := ""
+			if app.ResourceSpec != nil {
+				instanceType = string(app.ResourceSpec.InstanceType)
+			}
+			created := ""
+			if app.CreationTime != nil {
+				created = app.CreationTime.UTC().Format(time.RFC3339)
+			}
+			rows = append(rows, []string{
+				currentDomainID,
+				cliutil.PointerToString(app.UserProfileName),
+				cliutil.PointerToString(app.SpaceName),
+				cliutil.PointerToString(app.AppName),
+				string(app.AppType),
+				string(app.Status),
+				instanceType,
+				created,
+			})
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, column := range []int{0, 1, 2, 3} {
+			if rows[i][column] != rows[j][column] {
+				return rows[i][column] < rows[j][column]
+			}
+		}
+		return false
+	})
+
+	return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "space_name", "app_name", "app_type", "status", "instance_type", "created"}, rows)
+}
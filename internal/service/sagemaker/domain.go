@@ -0,0 +1,202 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	sagemakertypes "github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+// runDeleteDomain tears a domain down in the order SageMaker requires: apps
+// that run in spaces, each user profile with its apps and owned spaces, the
+// spaces no profile owns, and the domain once all of them are gone.
+func runDeleteDomain(cmd *cobra.Command, domainID string) error {
+	domain := strings.TrimSpace(domainID)
+	if domain == "" {
+		return fmt.Errorf("--domain-id is required")
+	}
+
+	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	profiles, err := listUserProfileNames(ctx, client, domain, false)
+	if err != nil {
+		return fmt.Errorf("list user profiles for domain %s: %w", domain, awstbxaws.WrapUserError(err))
+	}
+
+	plan := &sageMakerDeletePlan{dryRun: runtime.Options.DryRun}
+
+	// Apps that run in a space rather than under a profile go first, since a
+	// space cannot be deleted while its apps run.
+	apps, err := listApps(ctx, client, domain, "", false)
+	if err != nil {
+		return fmt.Errorf("list apps for domain %s: %w", domain, awstbxaws.WrapUserError(err))
+	}
+	spaceApps := make([]sageMakerDeleteOperation, 0)
+	for _, app := range apps {
+		if cliutil.PointerToString(app.SpaceName) == "" || cliutil.PointerToString(app.UserProfileName) != "" || cliutil.PointerToString(app.AppName) == "" {
+			continue
+		}
+		spaceApps = append(spaceApps, plan.addAppDeletion(client, domain, "", app))
+	}
+
+	profileDeletions := make([]sageMakerProfileDeletion, 0, len(profiles))
+	plannedSpaces := make(map[string]bool)
+	for _, profile := range profiles {
+		deletion, planErr := plan.addUserProfile(ctx, client, domain, profile)
+		if planErr != nil {
+			return planErr
+		}
+		for _, dependency := range deletion.dependencies {
+			if dependency.step == "space" {
+				plannedSpaces[dependency.resource] = true
+			}
+		}
+		profileDeletions = append(profileDeletions, deletion)
+	}
+
+	// Shared spaces belong to no profile and would otherwise keep the domain
+	// from being deleted.
+	sharedSpaces := make([]sageMakerDeleteOperation, 0)
+	spaces, err := listSpaces(ctx, client, domain)
+	if err != nil {
+		return fmt.Errorf("list spaces for domain %s: %w", domain, awstbxaws.WrapUserError(err))
+	}
+	for _, space := range spaces {
+		spaceName := cliutil.PointerToString(space.SpaceName)
+		status := string(space.Status)
+		if spaceName == "" || plannedSpaces[spaceName] || strings.EqualFold(status, "Deleting") || strings.EqualFold(status, "Deleted") {
+			continue
+		}
+		sharedSpaces = append(sharedSpaces, plan.addSpaceDeletion(client, domain, "", spaceName))
+	}
+
+	domainDeletion := plan.add(domain, "", "domain", domain, func(callCtx context.Context) error {
+		_, deleteErr := client.DeleteDomain(callCtx, &sagemaker.DeleteDomainInput{DomainId: cliutil.Ptr(domain)})
+		return deleteErr
+	})
+
+	if runtime.Options.DryRun {
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+	}
+
+	ok, confirmErr := runtime.Prompter.Confirm(
+		fmt.Sprintf("Delete SageMaker domain %s and %d dependency item(s)", domain, len(plan.rows)-1),
+		runtime.Options.NoConfirm,
+	)
+	if confirmErr != nil {
+		return confirmErr
+	}
+	if !ok {
+		plan.cancel()
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+	}
+
+	dependencyFailure := !plan.run(ctx, spaceApps)
+	for _, deletion := range profileDeletions {
+		if !plan.deleteUserProfile(ctx, client, deletion) {
+			dependencyFailure = true
+		}
+	}
+	if !plan.run(ctx, sharedSpaces) {
+		dependencyFailure = true
+	}
+
+	domainRow := plan.rows[domainDeletion.rowIndex]
+	if dependencyFailure {
+		domainRow[4] = cliutil.SkippedActionMessage("dependency cleanup failed")
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+	}
+	if waitErr := waitForDomainDependenciesDeleted(ctx, client, domain); waitErr != nil {
+		domainRow[4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+	}
+	plan.run(ctx, []sageMakerDeleteOperation{domainDeletion})
+
+	return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+}
+
+// waitForDomainDependenciesDeleted waits until the domain has no user
+// profiles, spaces or apps left, including ones still being deleted.
+func waitForDomainDependenciesDeleted(ctx context.Context, client API, domainID string) error {
+	const maxAttempts = 120
+	const pollInterval = 5 * time.Second
+
+	for range maxAttempts {
+		profiles, err := listUserProfileNames(ctx, client, domainID, true)
+		if err != nil {
+			return fmt.Errorf("list user profiles for domain %s: %w", domainID, err)
+		}
+		apps, err := listApps(ctx, client, domainID, "", true)
+		if err != nil {
+			return fmt.Errorf("list apps for domain %s: %w", domainID, err)
+		}
+		spaces, err := listSpaces(ctx, client, domainID)
+		if err != nil {
+			return fmt.Errorf("list spaces for domain %s: %w", domainID, err)
+		}
+		liveSpaces := 0
+		for _, space := range spaces {
+			if !strings.EqualFold(string(space.Status), "Deleted") {
+				liveSpaces++
+			}
+		}
+
+		if len(profiles) == 0 && len(apps) == 0 && liveSpaces == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			sleep(pollInterval)
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for dependencies to delete for domain %s", domainID)
+}
+
+func listUserProfileNames(ctx context.Context, client API, domainID string, includeDeleting bool) ([]string, error) {
+	profiles, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[sagemakertypes.UserProfileDetails], error) {
+		page, listErr := client.ListUserProfiles(callCtx, &sagemaker.ListUserProfilesInput{
+			DomainIdEquals: cliutil.Ptr(domainID),
+			NextToken:      nextToken,
+		})
+		if listErr != nil {
+			return awstbxaws.PageResult[sagemakertypes.UserProfileDetails]{}, listErr
+		}
+		return awstbxaws.PageResult[sagemakertypes.UserProfileDetails]{
+			Items:     page.UserProfiles,
+			NextToken: page.NextToken,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		name := cliutil.PointerToString(profile.UserProfileName)
+		status := string(profile.Status)
+		if name == "" || strings.EqualFold(status, "Deleted") {
+			continue
+		}
+		if !includeDeleting && strings.EqualFold(status, "Deleting") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
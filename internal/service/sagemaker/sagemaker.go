@@ -2,6 +2,7 @@ package sagemaker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -18,12 +19,15 @@ import (
 // API is the subset of the SageMaker client used by this package.
 type API interface {
 	DeleteApp(context.Context, *sagemaker.DeleteAppInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error)
+	DeleteDomain(context.Context, *sagemaker.DeleteDomainInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteDomainOutput, error)
 	DeleteSpace(context.Context, *sagemaker.DeleteSpaceInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error)
 	DeleteUserProfile(context.Context, *sagemaker.DeleteUserProfileInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error)
+	DescribeDomain(context.Context, *sagemaker.DescribeDomainInput, ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error)
 	DescribeSpace(context.Context, *sagemaker.DescribeSpaceInput, ...func(*sagemaker.Options)) (*sagemaker.DescribeSpaceOutput, error)
 	ListApps(context.Context, *sagemaker.ListAppsInput, ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error)
 	ListDomains(context.Context, *sagemaker.ListDomainsInput, ...func(*sagemaker.Options)) (*sagemaker.ListDomainsOutput, error)
 	ListSpaces(context.Context, *sagemaker.ListSpacesInput, ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error)
+	ListUserProfiles(context.Context, *sagemaker.ListUserProfilesInput, ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error)
 }
 
 var loadAWSConfig = awstbxaws.LoadAWSConfig
@@ -32,12 +36,16 @@ var newClient = func(cfg awssdk.Config) API {
 }
 var sleep = time.Sleep
 
+const defaultSpaceDeleteTimeout = 30 * time.Minute
+
 // NewCommand returns the sagemaker service group command.
 func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("sagemaker", "Manage SageMaker resources")
 
 	cmd.AddCommand(newCleanupSpacesCommand())
+	cmd.AddCommand(newDeleteDomainCommand())
 	cmd.AddCommand(newDeleteUserProfileCommand())
+	cmd.AddCommand(newListAppsCommand())
 
 	return cmd
 }
@@ -45,35 +53,79 @@ func NewCommand() *cobra.Command {
 func newCleanupSpacesCommand() *cobra.Command {
 	var domainID string
 	var spaceNames []string
+	var olderThanDays int
+	var wait bool
+	var timeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "cleanup-spaces",
 		Short: "Delete SageMaker spaces",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runCleanupSpaces(cmd, domainID, spaceNames)
+			return runCleanupSpaces(cmd, domainID, spaceNames, olderThanDays, wait, timeout)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&domainID, "domain-id", "", "Optional SageMaker domain ID (defaults to all domains)")
 	cmd.Flags().StringSliceVar(&spaceNames, "spaces", nil, "Optional comma-separated list of space names (requires --domain-id)")
+	cmd.Flags().IntVar(&olderThanDays, "older-than-days", 0, "Only delete spaces created more than this many days ago (0 disables the filter)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until every deleted space is gone")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultSpaceDeleteTimeout, "Give up waiting on the deleted spaces after this long when --wait is set")
+
+	return cmd
+}
+
+func newDeleteDomainCommand() *cobra.Command {
+	var domainID string
+
+	cmd := &cobra.Command{
+		Use:   "delete-domain",
+		Short: "Delete a SageMaker domain with its user profiles, spaces, and apps",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDeleteDomain(cmd, domainID)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&domainID, "domain-id", "", "SageMaker domain ID")
 
 	return cmd
 }
 
 func newDeleteUserProfileCommand() *cobra.Command {
 	var domainID string
-	var userProfile string
+	var userProfiles []string
+	var all bool
+	var warnEFS bool
 
 	cmd := &cobra.Command{
 		Use:   "delete-user-profile",
-		Short: "Delete a SageMaker user profile and dependencies",
+		Short: "Delete SageMaker user profiles and dependencies",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteUserProfile(cmd, domainID, userProfile)
+			return runDeleteUserProfile(cmd, domainID, userProfiles, all, warnEFS)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&domainID, "domain-id", "", "SageMaker domain ID")
-	cmd.Flags().StringVar(&userProfile, "user-profile", "", "SageMaker user profile name")
+	cmd.Flags().StringSliceVar(&userProfiles, "user-profile", nil, "Comma-separated list of SageMaker user profile names")
+	cmd.Flags().BoolVar(&all, "all", false, "Delete every user profile in the domain")
+	cmd.Flags().BoolVar(&warnEFS, "warn-efs", true, "Report the domain EFS file system that still holds the user's home directory")
+
+	return cmd
+}
+
+func newListAppsCommand() *cobra.Command {
+	var domainID string
+	var userProfile string
+
+	cmd := &cobra.Command{
+		Use:   "list-apps",
+		Short: "List SageMaker Studio apps with their status and instance type",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runListApps(cmd, domainID, userProfile)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&domainID, "domain-id", "", "Optional SageMaker domain ID (defaults to all domains)")
+	cmd.Flags().StringVar(&userProfile, "user-profile", "", "Optional user profile name")
 
 	return cmd
 }
@@ -82,6 +134,7 @@ type sageMakerSpaceTarget struct {
 	domainID  string
 	spaceName string
 	status    string
+	skip      string
 }
 
 type sageMakerDeleteOperation struct {
@@ -91,11 +144,21 @@ type sageMakerDeleteOperation struct {
 	rowIndex int
 }
 
-func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string) error {
+func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string, olderThanDays int, wait bool, timeout time.Duration) error {
 	domain := strings.TrimSpace(domainID)
 	if len(spaceNames) > 0 && domain == "" {
 		return fmt.Errorf("--domain-id is required when --spaces is set")
 	}
+	if olderThanDays < 0 {
+		return fmt.Errorf("--older-than-days must be >= 0")
+	}
+	if wait && timeout <= 0 {
+		return fmt.Errorf("--timeout must be > 0")
+	}
+	var cutoff time.Time
+	if olderThanDays > 0 {
+		cutoff = time.Now().UTC().AddDate(0, 0, -olderThanDays)
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
@@ -108,7 +171,7 @@ func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string)
 	} else {
 		domainIDs, err = listDomainIDs(cmd.Context(), client)
 		if err != nil {
-			return fmt.Errorf("list SageMaker domains: %s", awstbxaws.FormatUserError(err))
+			return fmt.Errorf("list SageMaker domains: %w", awstbxaws.WrapUserError(err))
 		}
 	}
 
@@ -124,7 +187,7 @@ func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string)
 	for _, currentDomainID := range domainIDs {
 		spaces, listErr := listSpaces(cmd.Context(), client, currentDomainID)
 		if listErr != nil {
-			return fmt.Errorf("list spaces for domain %s: %s", currentDomainID, awstbxaws.FormatUserError(listErr))
+			return fmt.Errorf("list spaces for domain %s: %w", currentDomainID, awstbxaws.WrapUserError(listErr))
 		}
 
 		for _, space := range spaces {
@@ -143,7 +206,18 @@ func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string)
 				continue
 			}
 
-			targets = append(targets, sageMakerSpaceTarget{domainID: currentDomainID, spaceName: spaceName, status: status})
+			// Without a creation time the age is unknown, so the space is
+			// reported but never deleted under an age filter.
+			skip := ""
+			if !cutoff.IsZero() {
+				if space.CreationTime == nil {
+					skip = cliutil.SkippedActionMessage("no-creation-time")
+				} else if !space.CreationTime.Before(cutoff) {
+					continue
+				}
+			}
+
+			targets = append(targets, sageMakerSpaceTarget{domainID: currentDomainID, spaceName: spaceName, status: status, skip: skip})
 		}
 	}
 
@@ -155,33 +229,44 @@ func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string)
 	})
 
 	rows := make([][]string, 0, len(targets))
+	deletable := 0
 	for _, target := range targets {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
+		if target.skip != "" {
+			action = target.skip
+		} else {
+			deletable++
+		}
 		rows = append(rows, []string{target.domainID, target.spaceName, target.status, action})
 	}
 
-	if len(targets) == 0 || runtime.Options.DryRun {
+	if deletable == 0 || runtime.Options.DryRun {
 		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "space_name", "status", "action"}, rows)
 	}
 
 	ok, confirmErr := runtime.Prompter.Confirm(
-		fmt.Sprintf("Delete %d SageMaker space(s)", len(targets)),
+		fmt.Sprintf("Delete %d SageMaker space(s)", deletable),
 		runtime.Options.NoConfirm,
 	)
 	if confirmErr != nil {
 		return confirmErr
 	}
 	if !ok {
-		for i := range rows {
-			rows[i][3] = cliutil.ActionCancelled
+		for i, target := range targets {
+			if target.skip == "" {
+				rows[i][3] = cliutil.ActionCancelled
+			}
 		}
 		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "space_name", "status", "action"}, rows)
 	}
 
 	for i, target := range targets {
+		if target.skip != "" {
+			continue
+		}
 		_, deleteErr := client.DeleteSpace(cmd.Context(), &sagemaker.DeleteSpaceInput{
 			DomainId:  cliutil.Ptr(target.domainID),
 			SpaceName: cliutil.Ptr(target.spaceName),
@@ -193,17 +278,85 @@ func runCleanupSpaces(cmd *cobra.Command, domainID string, spaceNames []string)
 		rows[i][3] = cliutil.ActionDeleted
 	}
 
+	if wait {
+		// One deadline covers all spaces, since they delete in parallel.
+		waitCtx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+		for i, target := range targets {
+			if rows[i][3] != cliutil.ActionDeleted {
+				continue
+			}
+			waitErr := waitForSpaceDeleted(waitCtx, client, target.domainID, target.spaceName)
+			if errors.Is(waitErr, context.DeadlineExceeded) {
+				rows[i][3] = cliutil.FailedActionMessage("timed out")
+				continue
+			}
+			if waitErr != nil {
+				rows[i][3] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
+			}
+		}
+	}
+
 	return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "space_name", "status", "action"}, rows)
 }
 
-func runDeleteUserProfile(cmd *cobra.Command, domainID, userProfile string) error {
+// waitForSpaceDeleted polls a space until DescribeSpace no longer finds it.
+// The caller's deadline bounds the wait.
+func waitForSpaceDeleted(ctx context.Context, client API, domainID, spaceName string) error {
+	const pollInterval = 5 * time.Second
+	for {
+		out, err := client.DescribeSpace(ctx, &sagemaker.DescribeSpaceInput{
+			DomainId:  cliutil.Ptr(domainID),
+			SpaceName: cliutil.Ptr(spaceName),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if awstbxaws.ClassifyError(err).Kind == awstbxaws.ErrorKindNotFound {
+				return nil
+			}
+			return err
+		}
+		if out.Status == sagemakertypes.SpaceStatusDeleteFailed {
+			reason := strings.TrimSpace(cliutil.PointerToString(out.FailureReason))
+			if reason != "" {
+				return fmt.Errorf("space %s: %s", out.Status, reason)
+			}
+			return fmt.Errorf("space %s", out.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			sleep(pollInterval)
+		}
+	}
+}
+
+// runDeleteUserProfile deletes each profile with its dependencies in turn. A
+// profile that fails does not stop the remaining ones; its rows report why.
+func runDeleteUserProfile(cmd *cobra.Command, domainID string, userProfiles []string, all, warnEFS bool) error {
 	domain := strings.TrimSpace(domainID)
-	profile := strings.TrimSpace(userProfile)
 	if domain == "" {
 		return fmt.Errorf("--domain-id is required")
 	}
-	if profile == "" {
-		return fmt.Errorf("--user-profile is required")
+	profiles := make([]string, 0, len(userProfiles))
+	seen := make(map[string]bool, len(userProfiles))
+	for _, raw := range userProfiles {
+		profile := strings.TrimSpace(raw)
+		if profile == "" || seen[profile] {
+			continue
+		}
+		seen[profile] = true
+		profiles = append(profiles, profile)
+	}
+	if all && len(profiles) > 0 {
+		return fmt.Errorf("--user-profile and --all are mutually exclusive")
+	}
+	if !all && len(profiles) == 0 {
+		return fmt.Errorf("--user-profile or --all is required")
 	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
@@ -211,123 +364,199 @@ func runDeleteUserProfile(cmd *cobra.Command, domainID, userProfile string) erro
 		return err
 	}
 
-	rows := make([][]string, 0)
-	operations := make([]sageMakerDeleteOperation, 0)
-	addOperation := func(step, resource string, execute func(context.Context) error) {
-		action := cliutil.ActionWouldDelete
-		if !runtime.Options.DryRun {
-			action = cliutil.ActionPending
+	if all {
+		profiles, err = listUserProfileNames(cmd.Context(), client, domain, false)
+		if err != nil {
+			return fmt.Errorf("list user profiles for domain %s: %w", domain, awstbxaws.WrapUserError(err))
 		}
-		rows = append(rows, []string{domain, profile, step, resource, action})
-		operations = append(operations, sageMakerDeleteOperation{step: step, resource: resource, execute: execute, rowIndex: len(rows) - 1})
 	}
 
-	apps, err := listUserProfileApps(cmd.Context(), client, domain, profile, false)
-	if err != nil {
-		return fmt.Errorf("list apps for user profile %s: %s", profile, awstbxaws.FormatUserError(err))
-	}
-	for _, app := range apps {
-		appName := cliutil.PointerToString(app.AppName)
-		if appName == "" {
-			continue
+	plan := &sageMakerDeletePlan{dryRun: runtime.Options.DryRun}
+	profileDeletions := make([]sageMakerProfileDeletion, 0, len(profiles))
+	dependencyCount := 0
+	for _, profile := range profiles {
+		profileDeletion, planErr := plan.addUserProfile(cmd.Context(), client, domain, profile)
+		if planErr != nil {
+			return planErr
 		}
-		appType := app.AppType
-		resource := appName + " (" + string(appType) + ")"
-		addOperation("app", resource, func(callCtx context.Context) error {
-			_, deleteErr := client.DeleteApp(callCtx, &sagemaker.DeleteAppInput{
-				AppName:         cliutil.Ptr(appName),
-				AppType:         appType,
-				DomainId:        cliutil.Ptr(domain),
-				UserProfileName: cliutil.Ptr(profile),
-			})
-			return deleteErr
-		})
+		profileDeletions = append(profileDeletions, profileDeletion)
+		dependencyCount += len(profileDeletion.dependencies)
 	}
 
-	spaces, err := listUserProfileSpaces(cmd.Context(), client, domain, profile, false)
-	if err != nil {
-		return fmt.Errorf("list spaces for user profile %s: %s", profile, awstbxaws.FormatUserError(err))
-	}
-	for _, spaceName := range spaces {
-		currentSpaceName := spaceName
-		addOperation("space", currentSpaceName, func(callCtx context.Context) error {
-			_, deleteErr := client.DeleteSpace(callCtx, &sagemaker.DeleteSpaceInput{
-				DomainId:  cliutil.Ptr(domain),
-				SpaceName: cliutil.Ptr(currentSpaceName),
-			})
-			return deleteErr
-		})
+	if runtime.Options.DryRun || len(profileDeletions) == 0 {
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
 	}
 
-	addOperation("user-profile", profile, func(callCtx context.Context) error {
-		_, deleteErr := client.DeleteUserProfile(callCtx, &sagemaker.DeleteUserProfileInput{
-			DomainId:        cliutil.Ptr(domain),
-			UserProfileName: cliutil.Ptr(profile),
-		})
-		return deleteErr
-	})
-
-	if runtime.Options.DryRun {
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+	prompt := fmt.Sprintf("Delete %d SageMaker user profile(s) and %d dependency item(s)", len(profileDeletions), dependencyCount)
+	if len(profileDeletions) == 1 {
+		prompt = fmt.Sprintf("Delete SageMaker user profile %q and %d dependency item(s)", profiles[0], dependencyCount)
 	}
-
-	ok, confirmErr := runtime.Prompter.Confirm(
-		fmt.Sprintf("Delete SageMaker user profile %q and %d dependency item(s)", profile, len(operations)-1),
-		runtime.Options.NoConfirm,
-	)
+	ok, confirmErr := runtime.Prompter.Confirm(prompt, runtime.Options.NoConfirm)
 	if confirmErr != nil {
 		return confirmErr
 	}
 	if !ok {
-		for i := range rows {
-			if rows[i][4] == cliutil.ActionPending {
-				rows[i][4] = cliutil.ActionCancelled
-			}
+		plan.cancel()
+		return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+	}
+
+	for _, profileDeletion := range profileDeletions {
+		if plan.deleteUserProfile(cmd.Context(), client, profileDeletion) && warnEFS {
+			plan.rows = append(plan.rows, homeEFSRow(cmd, client, domain, profileDeletion.userProfile))
+		}
+	}
+
+	return cliutil.WriteDataset(cmd, runtime, sageMakerDeleteHeaders, plan.rows)
+}
+
+var sageMakerDeleteHeaders = []string{"domain_id", "user_profile", "step", "resource", "action"}
+
+// sageMakerDeletePlan collects the step rows of a teardown so that
+// delete-user-profile and delete-domain report the same way.
+type sageMakerDeletePlan struct {
+	dryRun bool
+	rows   [][]string
+}
+
+// sageMakerProfileDeletion is one user profile's dependencies, its apps and
+// the spaces it owns, followed by the profile itself.
+type sageMakerProfileDeletion struct {
+	domainID     string
+	userProfile  string
+	dependencies []sageMakerDeleteOperation
+	profile      sageMakerDeleteOperation
+}
+
+func (p *sageMakerDeletePlan) add(domainID, userProfile, step, resource string, execute func(context.Context) error) sageMakerDeleteOperation {
+	action := cliutil.ActionWouldDelete
+	if !p.dryRun {
+		action = cliutil.ActionPending
+	}
+	p.rows = append(p.rows, []string{domainID, userProfile, step, resource, action})
+	return sageMakerDeleteOperation{step: step, resource: resource, execute: execute, rowIndex: len(p.rows) - 1}
+}
+
+func (p *sageMakerDeletePlan) cancel() {
+	for i := range p.rows {
+		if p.rows[i][4] == cliutil.ActionPending {
+			p.rows[i][4] = cliutil.ActionCancelled
 		}
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
 	}
+}
 
-	var dependencyFailure bool
-	var userProfileOperation *sageMakerDeleteOperation
-	for i := range operations {
-		operation := &operations[i]
-		if operation.step == "user-profile" {
-			userProfileOperation = operation
+// run executes the operations in order and reports whether all succeeded.
+func (p *sageMakerDeletePlan) run(ctx context.Context, operations []sageMakerDeleteOperation) bool {
+	succeeded := true
+	for _, operation := range operations {
+		if err := operation.execute(ctx); err != nil {
+			p.rows[operation.rowIndex][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(err))
+			succeeded = false
 			continue
 		}
+		p.rows[operation.rowIndex][4] = cliutil.ActionDeleted
+	}
+	return succeeded
+}
+
+// addAppDeletion plans deleting an app of a user profile or of a space.
+func (p *sageMakerDeletePlan) addAppDeletion(client API, domainID, userProfile string, app sagemakertypes.AppDetails) sageMakerDeleteOperation {
+	appName := cliutil.PointerToString(app.AppName)
+	appType := app.AppType
+	input := &sagemaker.DeleteAppInput{
+		AppName:  cliutil.Ptr(appName),
+		AppType:  appType,
+		DomainId: cliutil.Ptr(domainID),
+	}
+	// DeleteApp takes either the space or the user profile the app runs in.
+	if space := cliutil.PointerToString(app.SpaceName); space != "" {
+		input.SpaceName = cliutil.Ptr(space)
+	} else if owner := cliutil.PointerToString(app.UserProfileName); owner != "" {
+		input.UserProfileName = cliutil.Ptr(owner)
+	} else {
+		input.UserProfileName = cliutil.Ptr(userProfile)
+	}
+	return p.add(domainID, userProfile, "app", appName+" ("+string(appType)+")", func(callCtx context.Context) error {
+		_, deleteErr := client.DeleteApp(callCtx, input)
+		return deleteErr
+	})
+}
+
+func (p *sageMakerDeletePlan) addSpaceDeletion(client API, domainID, userProfile, spaceName string) sageMakerDeleteOperation {
+	return p.add(domainID, userProfile, "space", spaceName, func(callCtx context.Context) error {
+		_, deleteErr := client.DeleteSpace(callCtx, &sagemaker.DeleteSpaceInput{
+			DomainId:  cliutil.Ptr(domainID),
+			SpaceName: cliutil.Ptr(spaceName),
+		})
+		return deleteErr
+	})
+}
+
+// addUserProfile plans deleting a user profile's apps, the spaces it owns and
+// then the profile.
+func (p *sageMakerDeletePlan) addUserProfile(ctx context.Context, client API, domainID, userProfile string) (sageMakerProfileDeletion, error) {
+	deletion := sageMakerProfileDeletion{domainID: domainID, userProfile: userProfile}
 
-		execErr := operation.execute(cmd.Context())
-		if execErr != nil {
-			rows[operation.rowIndex][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(execErr))
-			dependencyFailure = true
+	apps, err := listUserProfileApps(ctx, client, domainID, userProfile, false)
+	if err != nil {
+		return deletion, fmt.Errorf("list apps for user profile %s: %w", userProfile, awstbxaws.WrapUserError(err))
+	}
+	for _, app := range apps {
+		if cliutil.PointerToString(app.AppName) == "" {
 			continue
 		}
-		rows[operation.rowIndex][4] = cliutil.ActionDeleted
+		deletion.dependencies = append(deletion.dependencies, p.addAppDeletion(client, domainID, userProfile, app))
 	}
 
-	if userProfileOperation == nil {
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+	spaces, err := listUserProfileSpaces(ctx, client, domainID, userProfile, false)
+	if err != nil {
+		return deletion, fmt.Errorf("list spaces for user profile %s: %w", userProfile, awstbxaws.WrapUserError(err))
+	}
+	for _, spaceName := range spaces {
+		deletion.dependencies = append(deletion.dependencies, p.addSpaceDeletion(client, domainID, userProfile, spaceName))
+	}
+
+	deletion.profile = p.add(domainID, userProfile, "user-profile", userProfile, func(callCtx context.Context) error {
+		_, deleteErr := client.DeleteUserProfile(callCtx, &sagemaker.DeleteUserProfileInput{
+			DomainId:        cliutil.Ptr(domainID),
+			UserProfileName: cliutil.Ptr(userProfile),
+		})
+		return deleteErr
+	})
+	return deletion, nil
+}
+
+// deleteUserProfile deletes the profile's dependencies, waits for them to be
+// gone and deletes the profile. The profile is skipped when a dependency
+// fails, because SageMaker refuses to delete a profile that still has any.
+func (p *sageMakerDeletePlan) deleteUserProfile(ctx context.Context, client API, deletion sageMakerProfileDeletion) bool {
+	row := p.rows[deletion.profile.rowIndex]
+	if !p.run(ctx, deletion.dependencies) {
+		row[4] = cliutil.SkippedActionMessage("dependency cleanup failed")
+		return false
 	}
 
-	if dependencyFailure {
-		rows[userProfileOperation.rowIndex][4] = cliutil.SkippedActionMessage("dependency cleanup failed")
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+	if err := waitForUserProfileDependenciesDeleted(ctx, client, deletion.domainID, deletion.userProfile); err != nil {
+		row[4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(err))
+		return false
 	}
+	return p.run(ctx, []sageMakerDeleteOperation{deletion.profile})
+}
 
-	waitErr := waitForUserProfileDependenciesDeleted(cmd.Context(), client, domain, profile)
-	if waitErr != nil {
-		rows[userProfileOperation.rowIndex][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(waitErr))
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+// homeEFSRow reports the domain's EFS file system, which SageMaker keeps after
+// a user profile is deleted and which still holds that user's home directory.
+func homeEFSRow(cmd *cobra.Command, client API, domainID, userProfile string) []string {
+	out, err := client.DescribeDomain(cmd.Context(), &sagemaker.DescribeDomainInput{DomainId: cliutil.Ptr(domainID)})
+	if err != nil {
+		return []string{domainID, userProfile, "efs-home", "", cliutil.FailedActionMessage(awstbxaws.FormatUserError(err))}
 	}
 
-	execErr := userProfileOperation.execute(cmd.Context())
-	if execErr != nil {
-		rows[userProfileOperation.rowIndex][4] = cliutil.FailedActionMessage(awstbxaws.FormatUserError(execErr))
-		return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+	fileSystemID := cliutil.PointerToString(out.HomeEfsFileSystemId)
+	if fileSystemID == "" {
+		return []string{domainID, userProfile, "efs-home", "", cliutil.SkippedActionMessage("no-home-efs")}
 	}
-	rows[userProfileOperation.rowIndex][4] = cliutil.ActionDeleted
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"domain_id", "user_profile", "step", "resource", "action"}, rows)
+	fmt.Fprintf(cmd.ErrOrStderr(), "warning: EFS file system %s still holds the home directory data of user profile %s\n", fileSystemID, userProfile)
+	return []string{domainID, userProfile, "efs-home", fileSystemID, "retained"}
 }
 
 func waitForUserProfileDependenciesDeleted(ctx context.Context, client API, domainID, userProfile string) error {
@@ -401,12 +630,20 @@ func listSpaces(ctx context.Context, client API, domainID string) ([]sagemakerty
 }
 
 func listUserProfileApps(ctx context.Context, client API, domainID, userProfile string, includeDeleting bool) ([]sagemakertypes.AppDetails, error) {
+	return listApps(ctx, client, domainID, userProfile, includeDeleting)
+}
+
+// listApps lists a domain's apps that are not deleted, optionally only those
+// of one user profile when userProfile is set.
+func listApps(ctx context.Context, client API, domainID, userProfile string, includeDeleting bool) ([]sagemakertypes.AppDetails, error) {
+	input := &sagemaker.ListAppsInput{DomainIdEquals: cliutil.Ptr(domainID)}
+	if userProfile != "" {
+		input.UserProfileNameEquals = cliutil.Ptr(userProfile)
+	}
 	apps, err := awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[sagemakertypes.AppDetails], error) {
-		page, listErr := client.ListApps(callCtx, &sagemaker.ListAppsInput{
-			DomainIdEquals:        cliutil.Ptr(domainID),
-			UserProfileNameEquals: cliutil.Ptr(userProfile),
-			NextToken:             nextToken,
-		})
+		pageInput := *input
+		pageInput.NextToken = nextToken
+		page, listErr := client.ListApps(callCtx, &pageInput)
 		if listErr != nil {
 			return awstbxaws.PageResult[sagemakertypes.AppDetails]{}, listErr
 		}
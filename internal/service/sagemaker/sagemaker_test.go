@@ -11,17 +11,21 @@ import (
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
 	sagemakertypes "github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+	"github.com/aws/smithy-go"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
 type mockClient struct {
 	deleteAppFn         func(context.Context, *sagemaker.DeleteAppInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error)
+	deleteDomainFn      func(context.Context, *sagemaker.DeleteDomainInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteDomainOutput, error)
 	deleteSpaceFn       func(context.Context, *sagemaker.DeleteSpaceInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error)
 	deleteUserProfileFn func(context.Context, *sagemaker.DeleteUserProfileInput, ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error)
+	describeDomainFn    func(context.Context, *sagemaker.DescribeDomainInput, ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error)
 	describeSpaceFn     func(context.Context, *sagemaker.DescribeSpaceInput, ...func(*sagemaker.Options)) (*sagemaker.DescribeSpaceOutput, error)
 	listAppsFn          func(context.Context, *sagemaker.ListAppsInput, ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error)
 	listDomainsFn       func(context.Context, *sagemaker.ListDomainsInput, ...func(*sagemaker.Options)) (*sagemaker.ListDomainsOutput, error)
 	listSpacesFn        func(context.Context, *sagemaker.ListSpacesInput, ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error)
+	listUserProfilesFn  func(context.Context, *sagemaker.ListUserProfilesInput, ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error)
 }
 
 func (m *mockClient) DeleteApp(ctx context.Context, in *sagemaker.DeleteAppInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error) {
@@ -31,6 +35,13 @@ func (m *mockClient) DeleteApp(ctx context.Context, in *sagemaker.DeleteAppInput
 	return m.deleteAppFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DeleteDomain(ctx context.Context, in *sagemaker.DeleteDomainInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteDomainOutput, error) {
+	if m.deleteDomainFn == nil {
+		return nil, errors.New("DeleteDomain not mocked")
+	}
+	return m.deleteDomainFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DeleteSpace(ctx context.Context, in *sagemaker.DeleteSpaceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error) {
 	if m.deleteSpaceFn == nil {
 		return nil, errors.New("DeleteSpace not mocked")
@@ -45,6 +56,13 @@ func (m *mockClient) DeleteUserProfile(ctx context.Context, in *sagemaker.Delete
 	return m.deleteUserProfileFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DescribeDomain(ctx context.Context, in *sagemaker.DescribeDomainInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error) {
+	if m.describeDomainFn == nil {
+		return nil, errors.New("DescribeDomain not mocked")
+	}
+	return m.describeDomainFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) DescribeSpace(ctx context.Context, in *sagemaker.DescribeSpaceInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeSpaceOutput, error) {
 	if m.describeSpaceFn == nil {
 		return nil, errors.New("DescribeSpace not mocked")
@@ -73,6 +91,13 @@ func (m *mockClient) ListSpaces(ctx context.Context, in *sagemaker.ListSpacesInp
 	return m.listSpacesFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) ListUserProfiles(ctx context.Context, in *sagemaker.ListUserProfilesInput, optFns ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error) {
+	if m.listUserProfilesFn == nil {
+		return nil, errors.New("ListUserProfiles not mocked")
+	}
+	return m.listUserProfilesFn(ctx, in, optFns...)
+}
+
 func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, error), nc func(awssdk.Config) API) {
 	t.Helper()
 
@@ -229,9 +254,14 @@ func TestDeleteUserProfileRequiredFlags(t *testing.T) {
 
 func TestDeleteUserProfileMissingUserProfile(t *testing.T) {
 	_, err := executeCommand(t, "sagemaker", "delete-user-profile", "--domain-id", "d-123")
-	if err == nil || !strings.Contains(err.Error(), "--user-profile is required") {
+	if err == nil || !strings.Contains(err.Error(), "--user-profile or --all is required") {
 		t.Fatalf("expected --user-profile required error, got %v", err)
 	}
+
+	_, err = executeCommand(t, "sagemaker", "delete-user-profile", "--domain-id", "d-123", "--user-profile", "alice", "--all")
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
 }
 
 func TestCleanupSpacesSpacesWithoutDomainID(t *testing.T) {
@@ -727,6 +757,115 @@ func TestDeleteUserProfileNoDependenciesSuccess(t *testing.T) {
 	}
 }
 
+func TestDeleteUserProfileReportsHomeEFS(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		describeCall bool
+		want         []string
+		notWant      []string
+	}{
+		{
+			name:         "default warns",
+			describeCall: true,
+			want:         []string{"\"step\": \"efs-home\"", "\"resource\": \"fs-0123\"", "\"action\": \"retained\"", "warning: EFS file system fs-0123"},
+		},
+		{
+			name:    "warn-efs disabled",
+			args:    []string{"--warn-efs=false"},
+			notWant: []string{"efs-home", "fs-0123"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			describeCalled := false
+			client := &mockClient{
+				listAppsFn: func(_ context.Context, _ *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+					return &sagemaker.ListAppsOutput{}, nil
+				},
+				listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+					return &sagemaker.ListSpacesOutput{}, nil
+				},
+				deleteUserProfileFn: func(_ context.Context, _ *sagemaker.DeleteUserProfileInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error) {
+					return &sagemaker.DeleteUserProfileOutput{}, nil
+				},
+				describeDomainFn: func(_ context.Context, in *sagemaker.DescribeDomainInput, _ ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error) {
+					describeCalled = true
+					if cliutil.PointerToString(in.DomainId) != "d-123" {
+						t.Fatalf("unexpected domain id: %s", cliutil.PointerToString(in.DomainId))
+					}
+					return &sagemaker.DescribeDomainOutput{HomeEfsFileSystemId: cliutil.Ptr("fs-0123")}, nil
+				},
+			}
+
+			withMockDeps(
+				t,
+				func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+				func(awssdk.Config) API { return client },
+			)
+
+			oldSleep := sleep
+			sleep = func(_ time.Duration) {}
+			t.Cleanup(func() { sleep = oldSleep })
+
+			args := append([]string{"--output", "json", "--no-confirm", "sagemaker", "delete-user-profile", "--domain-id", "d-123", "--user-profile", "alice"}, tc.args...)
+			output, err := executeCommand(t, args...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if describeCalled != tc.describeCall {
+				t.Fatalf("expected DescribeDomain called=%t, got %t", tc.describeCall, describeCalled)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(output, want) {
+					t.Fatalf("expected %q in output: %s", want, output)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(output, notWant) {
+					t.Fatalf("did not expect %q in output: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteUserProfileHomeEFSDescribeError(t *testing.T) {
+	client := &mockClient{
+		listAppsFn: func(_ context.Context, _ *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			return &sagemaker.ListAppsOutput{}, nil
+		},
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			return &sagemaker.ListSpacesOutput{}, nil
+		},
+		deleteUserProfileFn: func(_ context.Context, _ *sagemaker.DeleteUserProfileInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error) {
+			return &sagemaker.DeleteUserProfileOutput{}, nil
+		},
+		describeDomainFn: func(_ context.Context, _ *sagemaker.DescribeDomainInput, _ ...func(*sagemaker.Options)) (*sagemaker.DescribeDomainOutput, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	oldSleep := sleep
+	sleep = func(_ time.Duration) {}
+	t.Cleanup(func() { sleep = oldSleep })
+
+	output, err := executeCommand(t, "--output", "json", "--no-confirm", "sagemaker", "delete-user-profile", "--domain-id", "d-123", "--user-profile", "alice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "\"action\": \"deleted\"") || !strings.Contains(output, "failed:access denied") {
+		t.Fatalf("expected profile deletion and efs lookup failure: %s", output)
+	}
+}
+
 func TestDeleteUserProfileWithSpaceDependency(t *testing.T) {
 	describeSpaceCalls := 0
 	deleteSpaceCalled := false
@@ -1418,3 +1557,406 @@ func TestWaitForUserProfileDependenciesContextCancelled(t *testing.T) {
 		t.Fatalf("expected context canceled error, got: %v", err)
 	}
 }
+
+func TestCleanupSpacesOlderThanDays(t *testing.T) {
+	old := time.Now().UTC().AddDate(0, 0, -45)
+	recent := time.Now().UTC().AddDate(0, 0, -2)
+	deleted := make([]string, 0)
+	client := &mockClient{
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			return &sagemaker.ListSpacesOutput{Spaces: []sagemakertypes.SpaceDetails{
+				{SpaceName: cliutil.Ptr("stale"), Status: sagemakertypes.SpaceStatusInService, CreationTime: &old},
+				{SpaceName: cliutil.Ptr("fresh"), Status: sagemakertypes.SpaceStatusInService, CreationTime: &recent},
+				{SpaceName: cliutil.Ptr("unknown"), Status: sagemakertypes.SpaceStatusInService},
+			}}, nil
+		},
+		deleteSpaceFn: func(_ context.Context, in *sagemaker.DeleteSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error) {
+			deleted = append(deleted, cliutil.PointerToString(in.SpaceName))
+			return &sagemaker.DeleteSpaceOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "cleanup-spaces", "--domain-id", "d-123", "--older-than-days", "30")
+	if err != nil {
+		t.Fatalf("execute sagemaker cleanup-spaces --older-than-days: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "stale" {
+		t.Fatalf("expected only the stale space deleted, got %v", deleted)
+	}
+	expected := strings.Join([]string{
+		"domain_id=d-123 space_name=stale status=InService action=deleted",
+		"domain_id=d-123 space_name=unknown status=InService action=skipped:no-creation-time",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestListAppsAcrossDomains(t *testing.T) {
+	created := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	client := &mockClient{
+		listDomainsFn: func(_ context.Context, _ *sagemaker.ListDomainsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListDomainsOutput, error) {
+			return &sagemaker.ListDomainsOutput{Domains: []sagemakertypes.DomainDetails{{DomainId: cliutil.Ptr("d-2")}, {DomainId: cliutil.Ptr("d-1")}}}, nil
+		},
+		listAppsFn: func(_ context.Context, in *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			if in.UserProfileNameEquals != nil {
+				t.Fatalf("unexpected user profile filter: %s", cliutil.PointerToString(in.UserProfileNameEquals))
+			}
+			if cliutil.PointerToString(in.DomainIdEquals) == "d-1" {
+				return &sagemaker.ListAppsOutput{Apps: []sagemakertypes.AppDetails{
+					{
+						AppName:         cliutil.Ptr("default"),
+						AppType:         sagemakertypes.AppTypeJupyterServer,
+						Status:          sagemakertypes.AppStatusInService,
+						UserProfileName: cliutil.Ptr("alice"),
+						CreationTime:    &created,
+						ResourceSpec:    &sagemakertypes.ResourceSpec{InstanceType: sagemakertypes.AppInstanceTypeSystem},
+					},
+					{AppName: cliutil.Ptr("old"), AppType: sagemakertypes.AppTypeKernelGateway, Status: sagemakertypes.AppStatusDeleted, UserProfileName: cliutil.Ptr("alice")},
+				}}, nil
+			}
+			return &sagemaker.ListAppsOutput{Apps: []sagemakertypes.AppDetails{
+				{
+					AppName:      cliutil.Ptr("datascience-1"),
+					AppType:      sagemakertypes.AppTypeKernelGateway,
+					Status:       sagemakertypes.AppStatusInService,
+					SpaceName:    cliutil.Ptr("team-space"),
+					ResourceSpec: &sagemakertypes.ResourceSpec{InstanceType: sagemakertypes.AppInstanceTypeMlG4dnXlarge},
+				},
+			}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "sagemaker", "list-apps")
+	if err != nil {
+		t.Fatalf("execute sagemaker list-apps: %v", err)
+	}
+	expected := strings.Join([]string{
+		"domain_id=d-1 user_profile=alice space_name= app_name=default app_type=JupyterServer status=InService instance_type=system created=2026-09-01T08:00:00Z",
+		"domain_id=d-2 user_profile= space_name=team-space app_name=datascience-1 app_type=KernelGateway status=InService instance_type=ml.g4dn.xlarge created=",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestListAppsFiltersByUserProfile(t *testing.T) {
+	client := &mockClient{
+		listAppsFn: func(_ context.Context, in *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			if cliutil.PointerToString(in.DomainIdEquals) != "d-1" || cliutil.PointerToString(in.UserProfileNameEquals) != "alice" {
+				t.Fatalf("unexpected filters: domain=%s profile=%s", cliutil.PointerToString(in.DomainIdEquals), cliutil.PointerToString(in.UserProfileNameEquals))
+			}
+			return &sagemaker.ListAppsOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	if _, err := executeCommand(t, "sagemaker", "list-apps", "--domain-id", "d-1", "--user-profile", "alice"); err != nil {
+		t.Fatalf("execute sagemaker list-apps --user-profile: %v", err)
+	}
+}
+
+// domainTeardownClient simulates a domain with one user profile owning a
+// private space and one shared space running an app. Deleting a resource
+// removes it from the listings.
+func domainTeardownClient(deleted *[]string) *mockClient {
+	gone := func(name string) bool {
+		for _, item := range *deleted {
+			if item == name {
+				return true
+			}
+		}
+		return false
+	}
+	return &mockClient{
+		listUserProfilesFn: func(_ context.Context, _ *sagemaker.ListUserProfilesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error) {
+			if gone("user-profile:alice") {
+				return &sagemaker.ListUserProfilesOutput{}, nil
+			}
+			return &sagemaker.ListUserProfilesOutput{UserProfiles: []sagemakertypes.UserProfileDetails{{UserProfileName: cliutil.Ptr("alice"), Status: sagemakertypes.UserProfileStatusInService}}}, nil
+		},
+		listAppsFn: func(_ context.Context, in *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			apps := make([]sagemakertypes.AppDetails, 0)
+			if !gone("app:default") && cliutil.PointerToString(in.UserProfileNameEquals) != "bob" {
+				apps = append(apps, sagemakertypes.AppDetails{AppName: cliutil.Ptr("default"), AppType: sagemakertypes.AppTypeJupyterServer, UserProfileName: cliutil.Ptr("alice"), Status: sagemakertypes.AppStatusInService})
+			}
+			if !gone("app:shared-kernel") && in.UserProfileNameEquals == nil {
+				apps = append(apps, sagemakertypes.AppDetails{AppName: cliutil.Ptr("shared-kernel"), AppType: sagemakertypes.AppTypeKernelGateway, SpaceName: cliutil.Ptr("team"), Status: sagemakertypes.AppStatusInService})
+			}
+			return &sagemaker.ListAppsOutput{Apps: apps}, nil
+		},
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			spaces := make([]sagemakertypes.SpaceDetails, 0)
+			for _, name := range []string{"alice-private", "team"} {
+				if !gone("space:" + name) {
+					spaces = append(spaces, sagemakertypes.SpaceDetails{SpaceName: cliutil.Ptr(name), Status: sagemakertypes.SpaceStatusInService})
+				}
+			}
+			return &sagemaker.ListSpacesOutput{Spaces: spaces}, nil
+		},
+		describeSpaceFn: func(_ context.Context, in *sagemaker.DescribeSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DescribeSpaceOutput, error) {
+			out := &sagemaker.DescribeSpaceOutput{SpaceName: in.SpaceName, Status: sagemakertypes.SpaceStatusInService}
+			if cliutil.PointerToString(in.SpaceName) == "alice-private" {
+				out.OwnershipSettings = &sagemakertypes.OwnershipSettings{OwnerUserProfileName: cliutil.Ptr("alice")}
+			}
+			return out, nil
+		},
+		deleteAppFn: func(_ context.Context, in *sagemaker.DeleteAppInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteAppOutput, error) {
+			if cliutil.PointerToString(in.AppName) == "shared-kernel" && (cliutil.PointerToString(in.SpaceName) != "team" || in.UserProfileName != nil) {
+				return nil, errors.New("space app must be deleted by space name")
+			}
+			*deleted = append(*deleted, "app:"+cliutil.PointerToString(in.AppName))
+			return &sagemaker.DeleteAppOutput{}, nil
+		},
+		deleteSpaceFn: func(_ context.Context, in *sagemaker.DeleteSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error) {
+			*deleted = append(*deleted, "space:"+cliutil.PointerToString(in.SpaceName))
+			return &sagemaker.DeleteSpaceOutput{}, nil
+		},
+		deleteUserProfileFn: func(_ context.Context, in *sagemaker.DeleteUserProfileInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error) {
+			*deleted = append(*deleted, "user-profile:"+cliutil.PointerToString(in.UserProfileName))
+			return &sagemaker.DeleteUserProfileOutput{}, nil
+		},
+		deleteDomainFn: func(_ context.Context, in *sagemaker.DeleteDomainInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteDomainOutput, error) {
+			*deleted = append(*deleted, "domain:"+cliutil.PointerToString(in.DomainId))
+			return &sagemaker.DeleteDomainOutput{}, nil
+		},
+	}
+}
+
+func TestDeleteDomainCascades(t *testing.T) {
+	deleted := make([]string, 0)
+	client := domainTeardownClient(&deleted)
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "sagemaker", "delete-domain", "--domain-id", "d-123")
+	if err != nil {
+		t.Fatalf("execute sagemaker delete-domain --dry-run: %v", err)
+	}
+	expected := strings.Join([]string{
+		"domain_id=d-123 user_profile= step=app resource=shared-kernel (KernelGateway) action=would-delete",
+		"domain_id=d-123 user_profile=alice step=app resource=default (JupyterServer) action=would-delete",
+		"domain_id=d-123 user_profile=alice step=space resource=alice-private action=would-delete",
+		"domain_id=d-123 user_profile=alice step=user-profile resource=alice action=would-delete",
+		"domain_id=d-123 user_profile= step=space resource=team action=would-delete",
+		"domain_id=d-123 user_profile= step=domain resource=d-123 action=would-delete",
+	}, "\n")
+	if strings.TrimSpace(output) != expected || len(deleted) != 0 {
+		t.Fatalf("unexpected dry-run output:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "delete-domain", "--domain-id", "d-123")
+	if err != nil {
+		t.Fatalf("execute sagemaker delete-domain: %v", err)
+	}
+	want := []string{"app:shared-kernel", "app:default", "space:alice-private", "user-profile:alice", "space:team", "domain:d-123"}
+	if strings.Join(deleted, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected deletion order: %v", deleted)
+	}
+	if strings.Count(output, "action=deleted") != 6 {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func TestDeleteDomainSkipsDomainWhenDependencyFails(t *testing.T) {
+	deleted := make([]string, 0)
+	client := domainTeardownClient(&deleted)
+	client.deleteSpaceFn = func(_ context.Context, in *sagemaker.DeleteSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error) {
+		if cliutil.PointerToString(in.SpaceName) == "team" {
+			return nil, errors.New("resource in use")
+		}
+		deleted = append(deleted, "space:"+cliutil.PointerToString(in.SpaceName))
+		return &sagemaker.DeleteSpaceOutput{}, nil
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "delete-domain", "--domain-id", "d-123")
+	if err != nil {
+		t.Fatalf("execute sagemaker delete-domain: %v", err)
+	}
+	if !strings.Contains(output, "step=space resource=team action=failed:resource in use") || !strings.Contains(output, "step=domain resource=d-123 action=skipped:dependency cleanup failed") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	for _, item := range deleted {
+		if strings.HasPrefix(item, "domain:") {
+			t.Fatalf("domain must not be deleted after a failed dependency: %v", deleted)
+		}
+	}
+}
+
+func TestDeleteUserProfileBatchContinuesAfterFailure(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		listAppsFn: func(_ context.Context, _ *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			return &sagemaker.ListAppsOutput{Apps: []sagemakertypes.AppDetails{}}, nil
+		},
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			return &sagemaker.ListSpacesOutput{Spaces: []sagemakertypes.SpaceDetails{}}, nil
+		},
+		deleteUserProfileFn: func(_ context.Context, in *sagemaker.DeleteUserProfileInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteUserProfileOutput, error) {
+			name := cliutil.PointerToString(in.UserProfileName)
+			if name == "alice" {
+				return nil, errors.New("profile in use")
+			}
+			deleted = append(deleted, name)
+			return &sagemaker.DeleteUserProfileOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "delete-user-profile", "--domain-id", "d-123", "--user-profile", "alice,bob,alice", "--warn-efs=false")
+	if err != nil {
+		t.Fatalf("execute sagemaker delete-user-profile: %v", err)
+	}
+	if !strings.Contains(output, "user_profile=alice step=user-profile resource=alice action=failed:profile in use") ||
+		!strings.Contains(output, "user_profile=bob step=user-profile resource=bob action=deleted") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "bob" {
+		t.Fatalf("unexpected deleted profiles: %v", deleted)
+	}
+}
+
+func TestDeleteUserProfileAll(t *testing.T) {
+	client := &mockClient{
+		listUserProfilesFn: func(_ context.Context, in *sagemaker.ListUserProfilesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListUserProfilesOutput, error) {
+			if cliutil.PointerToString(in.DomainIdEquals) != "d-123" {
+				t.Fatalf("unexpected domain filter: %v", in.DomainIdEquals)
+			}
+			return &sagemaker.ListUserProfilesOutput{UserProfiles: []sagemakertypes.UserProfileDetails{
+				{UserProfileName: cliutil.Ptr("bob"), Status: sagemakertypes.UserProfileStatusInService},
+				{UserProfileName: cliutil.Ptr("carol"), Status: sagemakertypes.UserProfileStatusDeleting},
+				{UserProfileName: cliutil.Ptr("alice"), Status: sagemakertypes.UserProfileStatusInService},
+			}}, nil
+		},
+		listAppsFn: func(_ context.Context, _ *sagemaker.ListAppsInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListAppsOutput, error) {
+			return &sagemaker.ListAppsOutput{Apps: []sagemakertypes.AppDetails{}}, nil
+		},
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			return &sagemaker.ListSpacesOutput{Spaces: []sagemakertypes.SpaceDetails{}}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--dry-run", "sagemaker", "delete-user-profile", "--domain-id", "d-123", "--all")
+	if err != nil {
+		t.Fatalf("execute sagemaker delete-user-profile --all: %v", err)
+	}
+	expected := strings.Join([]string{
+		"domain_id=d-123 user_profile=alice step=user-profile resource=alice action=would-delete",
+		"domain_id=d-123 user_profile=bob step=user-profile resource=bob action=would-delete",
+	}, "\n")
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+}
+
+func cleanupSpacesWaitClient(describeSpace func(string, int) (*sagemaker.DescribeSpaceOutput, error)) *mockClient {
+	describeCalls := make(map[string]int)
+	return &mockClient{
+		listSpacesFn: func(_ context.Context, _ *sagemaker.ListSpacesInput, _ ...func(*sagemaker.Options)) (*sagemaker.ListSpacesOutput, error) {
+			return &sagemaker.ListSpacesOutput{Spaces: []sagemakertypes.SpaceDetails{
+				{SpaceName: cliutil.Ptr("space-a"), Status: sagemakertypes.SpaceStatusInService},
+				{SpaceName: cliutil.Ptr("space-b"), Status: sagemakertypes.SpaceStatusInService},
+			}}, nil
+		},
+		deleteSpaceFn: func(_ context.Context, _ *sagemaker.DeleteSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DeleteSpaceOutput, error) {
+			return &sagemaker.DeleteSpaceOutput{}, nil
+		},
+		describeSpaceFn: func(_ context.Context, in *sagemaker.DescribeSpaceInput, _ ...func(*sagemaker.Options)) (*sagemaker.DescribeSpaceOutput, error) {
+			name := cliutil.PointerToString(in.SpaceName)
+			describeCalls[name]++
+			return describeSpace(name, describeCalls[name])
+		},
+	}
+}
+
+func TestCleanupSpacesWaitUntilDeleted(t *testing.T) {
+	client := cleanupSpacesWaitClient(func(name string, call int) (*sagemaker.DescribeSpaceOutput, error) {
+		if name == "space-b" {
+			return &sagemaker.DescribeSpaceOutput{Status: sagemakertypes.SpaceStatusDeleteFailed, FailureReason: cliutil.Ptr("EFS busy")}, nil
+		}
+		if call < 3 {
+			return &sagemaker.DescribeSpaceOutput{Status: sagemakertypes.SpaceStatusDeleting}, nil
+		}
+		return nil, &smithy.GenericAPIError{Code: "ResourceNotFound", Message: "space not found"}
+	})
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	sleeps := 0
+	oldSleep := sleep
+	sleep = func(_ time.Duration) { sleeps++ }
+	t.Cleanup(func() { sleep = oldSleep })
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "cleanup-spaces", "--domain-id", "d-123", "--wait")
+	if err != nil {
+		t.Fatalf("execute sagemaker cleanup-spaces --wait: %v", err)
+	}
+	if !strings.Contains(output, "space_name=space-a status=InService action=deleted") ||
+		!strings.Contains(output, "space_name=space-b status=InService action=failed:space Delete_Failed: EFS busy") {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if sleeps != 2 {
+		t.Fatalf("expected 2 polls to sleep, got %d", sleeps)
+	}
+}
+
+func TestCleanupSpacesWaitTimeout(t *testing.T) {
+	client := cleanupSpacesWaitClient(func(string, int) (*sagemaker.DescribeSpaceOutput, error) {
+		return &sagemaker.DescribeSpaceOutput{Status: sagemakertypes.SpaceStatusDeleting}, nil
+	})
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	oldSleep := sleep
+	sleep = func(_ time.Duration) {}
+	t.Cleanup(func() { sleep = oldSleep })
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "sagemaker", "cleanup-spaces", "--domain-id", "d-123", "--wait", "--timeout", "1ns")
+	if err != nil {
+		t.Fatalf("execute sagemaker cleanup-spaces --wait: %v", err)
+	}
+	if strings.Count(output, "action=failed:timed out") != 2 {
+		t.Fatalf("expected both spaces to time out:\n%s", output)
+	}
+
+	if _, err := executeCommand(t, "sagemaker", "cleanup-spaces", "--wait", "--timeout", "0s"); err == nil || !strings.Contains(err.Error(), "--timeout must be > 0") {
+		t.Fatalf("expected --timeout validation error, got %v", err)
+	}
+}
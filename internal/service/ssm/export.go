@@ -0,0 +1,238 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+var (
+	envKeyInvalidChars   = regexp.MustCompile(`[^A-Z0-9_]`)
+	tfvarKeyInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+	envSafeValue         = regexp.MustCompile(`^[A-Za-z0-9_./:@,+=-]*$`)
+)
+
+type exportedParameter struct {
+	Name  string `json:"Name"`
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+	// ValueOmitted marks a SecureString exported without --with-decryption,
+	// so import-parameters skips it rather than writing an empty value.
+	ValueOmitted bool `json:"ValueOmitted,omitempty"`
+}
+
+type exportedParameterEnvelope struct {
+	Parameters []exportedParameter `json:"Parameters"`
+}
+
+// runExportParameters writes the parameters under path to stdout or outPath.
+// JSON output uses the Name/Type/Value records import-parameters reads, so an
+// export can be imported again.
+func runExportParameters(cmd *cobra.Command, path string, recursive, withDecryption bool, format, outPath string, envelope bool) error {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return fmt.Errorf("--path is required")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "json" && format != "env" && format != "tfvars" {
+		return fmt.Errorf("--format must be one of: json, env, tfvars")
+	}
+	if envelope && format != "json" {
+		return fmt.Errorf("--envelope requires --format json")
+	}
+
+	_, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := listParametersByPath(cmd.Context(), client, path, recursive, withDecryption)
+	if err != nil {
+		return fmt.Errorf("get parameters by path: %w", awstbxaws.WrapUserError(err))
+	}
+
+	exported := make([]exportedParameter, 0, len(parameters))
+	for _, parameter := range parameters {
+		name := cliutil.PointerToString(parameter.Name)
+		value := cliutil.PointerToString(parameter.Value)
+		omitted := false
+		if parameter.Type == ssmtypes.ParameterTypeSecureString && !withDecryption {
+			// JSON keeps the record so the file lists every parameter; the
+			// flat formats have no way to mark a value as missing.
+			if format != "json" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: skipping SecureString %s: use --with-decryption to export it\n", name)
+				continue
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: exporting SecureString %s with an empty value: use --with-decryption to include it\n", name)
+			value = ""
+			omitted = true
+		}
+		exported = append(exported, exportedParameter{Name: name, Type: string(parameter.Type), Value: value, ValueOmitted: omitted})
+	}
+	sort.Slice(exported, func(i, j int) bool {
+		return exported[i].Name < exported[j].Name
+	})
+
+	var rendered string
+	switch format {
+	case "env":
+		rendered, err = renderEnv(exported)
+	case "tfvars":
+		rendered, err = renderTFVars(exported)
+	default:
+		var document any = exported
+		if envelope {
+			document = exportedParameterEnvelope{Parameters: exported}
+		}
+		var data []byte
+		data, err = json.MarshalIndent(document, "", "  ")
+		rendered = string(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	outPath = strings.TrimSpace(outPath)
+	if outPath == "" {
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), rendered)
+		return err
+	}
+	// Decrypted values may be in the file, so only the owner can read it.
+	if err := os.WriteFile(outPath, []byte(rendered+"\n"), 0o600); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "wrote %d parameter(s) to %s\n", len(exported), outPath)
+	return nil
+}
+
+func listParametersByPath(ctx context.Context, client API, path string, recursive, withDecryption bool) ([]ssmtypes.Parameter, error) {
+	return awstbxaws.CollectAllPages(ctx, func(callCtx context.Context, nextToken *string) (awstbxaws.PageResult[ssmtypes.Parameter], error) {
+		page, err := client.GetParametersByPath(callCtx, &ssm.GetParametersByPathInput{
+			Path:           cliutil.Ptr(path),
+			Recursive:      cliutil.Ptr(recursive),
+			WithDecryption: cliutil.Ptr(withDecryption),
+			NextToken:      nextToken,
+		})
+		if err != nil {
+			return awstbxaws.PageResult[ssmtypes.Parameter]{}, err
+		}
+		return awstbxaws.PageResult[ssmtypes.Parameter]{
+			Items:     page.Parameters,
+			NextToken: page.NextToken,
+		}, nil
+	})
+}
+
+// renderEnv flattens parameter names to their uppercased last path segment
+// and renders KEY=value lines. Values with characters outside envSafeValue
+// are single-quoted, which both POSIX shells and dotenv loaders read
+// literally, so $ and backslashes are never expanded.
+func renderEnv(parameters []exportedParameter) (string, error) {
+	keys, err := flattenParameterKeys(parameters, func(segment string) string {
+		return envKeyInvalidChars.ReplaceAllString(strings.ToUpper(segment), "_")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(parameters))
+	for i, parameter := range parameters {
+		value := parameter.Value
+		if !envSafeValue.MatchString(value) {
+			value = envQuote(value)
+		}
+		lines = append(lines, keys[i]+"="+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// renderTFVars renders parameters as Terraform variable assignments; StringList
+// parameters become lists.
+func renderTFVars(parameters []exportedParameter) (string, error) {
+	keys, err := flattenParameterKeys(parameters, func(segment string) string {
+		return tfvarKeyInvalidChars.ReplaceAllString(segment, "_")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(parameters))
+	for i, parameter := range parameters {
+		value := hclString(parameter.Value)
+		if parameter.Type == string(ssmtypes.ParameterTypeStringList) {
+			items := strings.Split(parameter.Value, ",")
+			quoted := make([]string, 0, len(items))
+			for _, item := range items {
+				quoted = append(quoted, hclString(item))
+			}
+			value = "[" + strings.Join(quoted, ", ") + "]"
+		}
+		lines = append(lines, keys[i]+" = "+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// flattenParameterKeys maps each parameter's last path segment through
+// normalize and reports an error when two parameters collapse to one key.
+func flattenParameterKeys(parameters []exportedParameter, normalize func(string) string) ([]string, error) {
+	keys := make([]string, 0, len(parameters))
+	owners := make(map[string]string, len(parameters))
+	for _, parameter := range parameters {
+		segment := parameter.Name[strings.LastIndex(parameter.Name, "/")+1:]
+		key := normalize(segment)
+		if key == "" {
+			return nil, fmt.Errorf("parameter %s does not produce a usable key", parameter.Name)
+		}
+		if owner, exists := owners[key]; exists {
+			return nil, fmt.Errorf("key conflict: %s and %s both map to %s", owner, parameter.Name, key)
+		}
+		owners[key] = parameter.Name
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// envQuote wraps value in single quotes; an embedded single quote closes the
+// quoting, adds an escaped quote and reopens it.
+func envQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// hclString renders value as an HCL quoted string. HCL only knows the \\, \",
+// \n, \r, \t and \u escapes, so other control characters use \u, and ${ and
+// %{ are doubled so Terraform does not read them as template sequences.
+func hclString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	quoted := strings.ReplaceAll(b.String(), "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
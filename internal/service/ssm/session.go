@@ -0,0 +1,163 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/spf13/cobra"
+	awstbxaws "github.com/towardsthecloud/aws-toolbox/internal/aws"
+	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
+)
+
+const sessionManagerPlugin = "session-manager-plugin"
+
+var lookPath = exec.LookPath
+
+// runSessionPlugin hands the terminal over to the session manager plugin until
+// the session ends.
+var runSessionPlugin = func(cmd *cobra.Command, binary string, args []string) error {
+	plugin := exec.CommandContext(cmd.Context(), binary, args...)
+	plugin.Stdin = cmd.InOrStdin()
+	plugin.Stdout = cmd.OutOrStdout()
+	plugin.Stderr = cmd.ErrOrStderr()
+	return plugin.Run()
+}
+
+func runStartSession(cmd *cobra.Command, target, tag string) error {
+	target = strings.TrimSpace(target)
+	tag = strings.TrimSpace(tag)
+	if target == "" && tag == "" {
+		return fmt.Errorf("one of --target or --tag is required")
+	}
+
+	filter := ssmtypes.InstanceInformationStringFilter{Key: cliutil.Ptr("InstanceIds"), Values: []string{target}}
+	if tag != "" {
+		key, value, err := cliutil.ParseTagFilter(tag)
+		if err != nil || key == "" || value == "" {
+			return fmt.Errorf("--tag must use KEY=VALUE format")
+		}
+		filter = ssmtypes.InstanceInformationStringFilter{Key: cliutil.Ptr("tag:" + key), Values: []string{value}}
+	}
+
+	runtime, cfg, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
+	if err != nil {
+		return err
+	}
+
+	instance, err := resolveManagedInstance(cmd.Context(), client, filter, target, tag)
+	if err != nil {
+		return err
+	}
+	instanceID := cliutil.PointerToString(instance.InstanceId)
+
+	headers := []string{"instance_id", "computer_name", "platform", "ping_status", "region", "action"}
+	row := []string{instanceID, cliutil.PointerToString(instance.ComputerName), string(instance.PlatformType), string(instance.PingStatus), cfg.Region, "would-start-session"}
+	if runtime.Options.DryRun {
+		return cliutil.WriteDataset(cmd, runtime, headers, [][]string{row})
+	}
+
+	session, err := client.StartSession(cmd.Context(), &ssm.StartSessionInput{Target: cliutil.Ptr(instanceID)})
+	if err != nil {
+		return fmt.Errorf("start session: %w", awstbxaws.WrapUserError(err))
+	}
+
+	pluginPath, lookErr := lookPath(sessionManagerPlugin)
+	if lookErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s not found on PATH; install it to connect with the parameters below\n", sessionManagerPlugin)
+		return cliutil.WriteDataset(cmd, runtime, []string{"instance_id", "session_id", "stream_url", "token_value", "region"}, [][]string{{
+			instanceID,
+			cliutil.PointerToString(session.SessionId),
+			cliutil.PointerToString(session.StreamUrl),
+			cliutil.PointerToString(session.TokenValue),
+			cfg.Region,
+		}})
+	}
+
+	sessionJSON, err := json.Marshal(map[string]string{
+		"SessionId":  cliutil.PointerToString(session.SessionId),
+		"StreamUrl":  cliutil.PointerToString(session.StreamUrl),
+		"TokenValue": cliutil.PointerToString(session.TokenValue),
+	})
+	if err != nil {
+		return err
+	}
+	inputJSON, err := json.Marshal(map[string]string{"Target": instanceID})
+	if err != nil {
+		return err
+	}
+
+	// The plugin takes the StartSession response, region, operation, profile,
+	// request parameters, and endpoint as positional arguments.
+	args := []string{string(sessionJSON), cfg.Region, "StartSession", runtime.Options.Profile, string(inputJSON), sessionEndpoint(cmd.Context(), cfg)}
+	if err := runSessionPlugin(cmd, pluginPath, args); err != nil {
+		return fmt.Errorf("%s: %w", sessionManagerPlugin, err)
+	}
+
+	return nil
+}
+
+// resolveManagedInstance returns the single SSM-managed instance matching the
+// filter, with errors that point at the usual agent or instance-profile gaps.
+func resolveManagedInstance(ctx context.Context, client API, filter ssmtypes.InstanceInformationStringFilter, target, tag string) (ssmtypes.InstanceInformation, error) {
+	instances := make([]ssmtypes.InstanceInformation, 0)
+	var nextToken *string
+
+	for {
+		page, err := client.DescribeInstanceInformation(ctx, &ssm.DescribeInstanceInformationInput{
+			Filters:   []ssmtypes.InstanceInformationStringFilter{filter},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return ssmtypes.InstanceInformation{}, fmt.Errorf("describe instance information: %w", awstbxaws.WrapUserError(err))
+		}
+
+		instances = append(instances, page.InstanceInformationList...)
+		if page.NextToken == nil || *page.NextToken == "" {
+			break
+		}
+		nextToken = page.NextToken
+	}
+
+	selector := target
+	if tag != "" {
+		selector = "tag " + tag
+	}
+
+	if len(instances) == 0 {
+		return ssmtypes.InstanceInformation{}, fmt.Errorf("no SSM-managed instance matches %s: check that the SSM agent is running and the instance profile grants AmazonSSMManagedInstanceCore", selector)
+	}
+	if len(instances) > 1 {
+		ids := make([]string, 0, len(instances))
+		for _, instance := range instances {
+			ids = append(ids, cliutil.PointerToString(instance.InstanceId))
+		}
+		return ssmtypes.InstanceInformation{}, fmt.Errorf("%s matches %d managed instances (%s); use --target to pick one", selector, len(instances), strings.Join(ids, ", "))
+	}
+
+	instance := instances[0]
+	if instance.PingStatus != ssmtypes.PingStatusOnline {
+		return ssmtypes.InstanceInformation{}, fmt.Errorf("instance %s is not reachable through SSM (ping status %s): check that the SSM agent is running", cliutil.PointerToString(instance.InstanceId), instance.PingStatus)
+	}
+
+	return instance, nil
+}
+
+// sessionEndpoint resolves the SSM endpoint the client uses for the region, so
+// the plugin talks to the right partition. An empty string lets the plugin
+// resolve the endpoint itself.
+func sessionEndpoint(ctx context.Context, cfg awssdk.Config) string {
+	endpoint, err := ssm.NewDefaultEndpointResolverV2().ResolveEndpoint(ctx, ssm.EndpointParameters{
+		Region:   cliutil.Ptr(cfg.Region),
+		Endpoint: cfg.BaseEndpoint,
+	})
+	if err != nil {
+		return ""
+	}
+	return endpoint.URI.String()
+}
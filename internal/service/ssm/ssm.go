@@ -19,20 +19,43 @@ import (
 // API is the subset of the SSM client used by this package.
 type API interface {
 	DeleteParameter(context.Context, *ssm.DeleteParameterInput, ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	DeleteParameters(context.Context, *ssm.DeleteParametersInput, ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	DescribeInstanceInformation(context.Context, *ssm.DescribeInstanceInformationInput, ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error)
+	GetParametersByPath(context.Context, *ssm.GetParametersByPathInput, ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	LabelParameterVersion(context.Context, *ssm.LabelParameterVersionInput, ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error)
 	PutParameter(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	StartSession(context.Context, *ssm.StartSessionInput, ...func(*ssm.Options)) (*ssm.StartSessionOutput, error)
+}
+
+// maxDeleteParametersNames is the DeleteParameters per-request name limit.
+const maxDeleteParametersNames = 10
+
+type deleteParametersBatch struct {
+	names    []string
+	executed bool
+	err      error
+	deleted  map[string]bool
+	invalid  map[string]bool
 }
 
 type parameterFileRecord struct {
-	Name          string `json:"Name"`
-	NameLower     string `json:"name"`
-	Type          string `json:"Type"`
-	TypeLower     string `json:"type"`
-	Value         string `json:"Value"`
-	ValueLower    string `json:"value"`
-	Overwrite     *bool  `json:"Overwrite"`
-	OverwriteLow  *bool  `json:"overwrite"`
-	Description   string `json:"Description"`
-	DescriptionLo string `json:"description"`
+	Name          string          `json:"Name"`
+	NameLower     string          `json:"name"`
+	Type          string          `json:"Type"`
+	TypeLower     string          `json:"type"`
+	Value         string          `json:"Value"`
+	ValueLower    string          `json:"value"`
+	Overwrite     *bool           `json:"Overwrite"`
+	OverwriteLow  *bool           `json:"overwrite"`
+	Description   string          `json:"Description"`
+	DescriptionLo string          `json:"description"`
+	Labels        []string        `json:"Labels"`
+	LabelsLower   []string        `json:"labels"`
+	Tags          json.RawMessage `json:"Tags"`
+	TagsLower     json.RawMessage `json:"tags"`
+	KeyID         string          `json:"KeyId"`
+	KeyIDLower    string          `json:"keyId"`
+	ValueOmitted  bool            `json:"ValueOmitted"`
 }
 
 type parameterFileEnvelope struct {
@@ -46,6 +69,12 @@ type importParameter struct {
 	Value       string
 	Overwrite   bool
 	Description string
+	Labels      []string
+	Tags        []ssmtypes.Tag
+	KeyID       string
+	// ValueOmitted is set for SecureString records that export-parameters
+	// wrote without their value.
+	ValueOmitted bool
 }
 
 var loadAWSConfig = awstbxaws.LoadAWSConfig
@@ -58,51 +87,115 @@ func NewCommand() *cobra.Command {
 	cmd := cliutil.NewServiceGroupCommand("ssm", "Manage SSM resources")
 
 	cmd.AddCommand(newDeleteParametersCommand())
+	cmd.AddCommand(newExportParametersCommand())
 	cmd.AddCommand(newImportParametersCommand())
+	cmd.AddCommand(newStartSessionCommand())
 
 	return cmd
 }
 
 func newDeleteParametersCommand() *cobra.Command {
 	var inputFile string
+	var path string
+	var recursive bool
 
 	cmd := &cobra.Command{
 		Use:   "delete-parameters",
-		Short: "Delete SSM parameters listed in an input JSON file",
+		Short: "Delete SSM parameters listed in an input JSON file or under a path",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteParameters(cmd, inputFile)
+			return runDeleteParameters(cmd, inputFile, path, recursive)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&inputFile, "input-file", "", "Path to a JSON file containing parameter names")
+	cmd.Flags().StringVar(&path, "path", "", "Delete the parameters under this path prefix instead, e.g. /app/legacy")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Include parameters in nested paths (requires --path)")
+
+	return cmd
+}
+
+func newExportParametersCommand() *cobra.Command {
+	var path string
+	var recursive bool
+	var withDecryption bool
+	var format string
+	var outPath string
+	var envelope bool
+
+	cmd := &cobra.Command{
+		Use:   "export-parameters",
+		Short: "Export SSM parameters under a path as JSON, .env, or .tfvars",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runExportParameters(cmd, path, recursive, withDecryption, format, outPath, envelope)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&path, "path", "", "Parameter path prefix to export, e.g. /app/prod")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Include parameters in nested paths")
+	cmd.Flags().BoolVar(&withDecryption, "with-decryption", false, "Decrypt and include SecureString parameters")
+	cmd.Flags().StringVar(&format, "format", "json", "Export format: json|env|tfvars")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the export to this file instead of stdout")
+	cmd.Flags().BoolVar(&envelope, "envelope", false, "Wrap JSON records in a {\"Parameters\": [...]} object")
 
 	return cmd
 }
 
 func newImportParametersCommand() *cobra.Command {
 	var inputFile string
+	var overwriteAll bool
+	var noOverwrite bool
 
 	cmd := &cobra.Command{
 		Use:   "import-parameters",
 		Short: "Import SSM parameters from a JSON file",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runImportParameters(cmd, inputFile)
+			return runImportParameters(cmd, inputFile, overwriteAll, noOverwrite)
 		},
 		SilenceUsage: true,
 	}
 	cmd.Flags().StringVar(&inputFile, "input-file", "", "Path to a JSON file containing parameter records")
+	cmd.Flags().BoolVar(&overwriteAll, "overwrite-all", false, "Overwrite existing parameters regardless of each record's Overwrite field")
+	cmd.Flags().BoolVar(&noOverwrite, "no-overwrite", false, "Never overwrite; existing parameters are reported as failed regardless of each record's Overwrite field")
 
 	return cmd
 }
 
-func runDeleteParameters(cmd *cobra.Command, inputFile string) error {
-	if strings.TrimSpace(inputFile) == "" {
-		return fmt.Errorf("--input-file is required")
+func newStartSessionCommand() *cobra.Command {
+	var target string
+	var tag string
+
+	cmd := &cobra.Command{
+		Use:   "start-session",
+		Short: "Start a Session Manager session on an SSM-managed instance",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStartSession(cmd, target, tag)
+		},
+		SilenceUsage: true,
 	}
+	cmd.Flags().StringVar(&target, "target", "", "Instance ID to connect to")
+	cmd.Flags().StringVar(&tag, "tag", "", "Resolve the instance by tag in KEY=VALUE form")
+	cmd.MarkFlagsMutuallyExclusive("target", "tag")
 
-	names, err := readParameterNamesFile(inputFile)
-	if err != nil {
-		return err
+	return cmd
+}
+
+func runDeleteParameters(cmd *cobra.Command, inputFile, path string, recursive bool) error {
+	inputFile = strings.TrimSpace(inputFile)
+	path = strings.TrimSpace(path)
+	if (inputFile == "") == (path == "") {
+		return fmt.Errorf("exactly one of --input-file or --path is required")
+	}
+	if recursive && path == "" {
+		return fmt.Errorf("--recursive requires --path")
+	}
+
+	var names []string
+	if inputFile != "" {
+		var err error
+		names, err = readParameterNamesFile(inputFile)
+		if err != nil {
+			return err
+		}
 	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
@@ -110,42 +203,96 @@ func runDeleteParameters(cmd *cobra.Command, inputFile string) error {
 		return err
 	}
 
+	if path != "" {
+		parameters, listErr := listParametersByPath(cmd.Context(), client, path, recursive, false)
+		if listErr != nil {
+			return fmt.Errorf("get parameters by path: %w", awstbxaws.WrapUserError(listErr))
+		}
+		for _, parameter := range parameters {
+			if name := cliutil.PointerToString(parameter.Name); name != "" {
+				names = append(names, name)
+			}
+		}
+		names = uniqueStrings(names)
+	}
+
 	sort.Strings(names)
 	rows := make([][]string, 0, len(names))
-	for _, name := range names {
+	batches := make([]*deleteParametersBatch, 0, (len(names)+maxDeleteParametersNames-1)/maxDeleteParametersNames)
+	for i, name := range names {
 		action := cliutil.ActionWouldDelete
 		if !runtime.Options.DryRun {
 			action = cliutil.ActionPending
 		}
 		rows = append(rows, []string{name, action})
+
+		if i%maxDeleteParametersNames == 0 {
+			batches = append(batches, &deleteParametersBatch{})
+		}
+		batch := batches[len(batches)-1]
+		batch.names = append(batch.names, name)
 	}
 
 	return cliutil.RunDestructiveActionPlan(cmd, runtime, cliutil.DestructiveActionPlan{
 		Headers:       []string{"parameter_name", "action"},
 		Rows:          rows,
 		ActionColumn:  1,
-		ConfirmPrompt: fmt.Sprintf("Delete %d SSM parameter(s)", len(rows)),
+		ConfirmPrompt: fmt.Sprintf("Delete %d SSM parameter(s) in %d DeleteParameters call(s)", len(rows), len(batches)),
 		Execute: func(rowIndex int) string {
-			_, deleteErr := client.DeleteParameter(cmd.Context(), &ssm.DeleteParameterInput{
-				Name: cliutil.Ptr(rows[rowIndex][0]),
-			})
-			if deleteErr != nil {
-				return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+			name := rows[rowIndex][0]
+			batch := batches[rowIndex/maxDeleteParametersNames]
+			if !batch.executed {
+				batch.executed = true
+				out, deleteErr := client.DeleteParameters(cmd.Context(), &ssm.DeleteParametersInput{Names: batch.names})
+				batch.err = deleteErr
+				if deleteErr == nil {
+					batch.deleted = make(map[string]bool, len(out.DeletedParameters))
+					for _, deleted := range out.DeletedParameters {
+						batch.deleted[deleted] = true
+					}
+					batch.invalid = make(map[string]bool, len(out.InvalidParameters))
+					for _, invalid := range out.InvalidParameters {
+						batch.invalid[invalid] = true
+					}
+				}
+			}
+
+			switch {
+			case batch.err != nil:
+				// The whole call failed, so fall back to deleting this name
+				// alone; that still reports a per-parameter reason.
+				if _, deleteErr := client.DeleteParameter(cmd.Context(), &ssm.DeleteParameterInput{Name: cliutil.Ptr(name)}); deleteErr != nil {
+					return cliutil.FailedActionMessage(awstbxaws.FormatUserError(deleteErr))
+				}
+				return cliutil.ActionDeleted
+			case batch.invalid[name]:
+				return cliutil.FailedActionMessage("not-found")
+			case batch.deleted[name]:
+				return cliutil.ActionDeleted
+			default:
+				return cliutil.FailedActionMessage("not reported by DeleteParameters")
 			}
-			return cliutil.ActionDeleted
 		},
 	})
 }
 
-func runImportParameters(cmd *cobra.Command, inputFile string) error {
+func runImportParameters(cmd *cobra.Command, inputFile string, overwriteAll, noOverwrite bool) error {
 	if strings.TrimSpace(inputFile) == "" {
 		return fmt.Errorf("--input-file is required")
 	}
+	if overwriteAll && noOverwrite {
+		return fmt.Errorf("--overwrite-all and --no-overwrite are mutually exclusive")
+	}
 
 	parameters, err := readImportParametersFile(inputFile)
 	if err != nil {
 		return err
 	}
+	if overwriteAll || noOverwrite {
+		for i := range parameters {
+			parameters[i].Overwrite = overwriteAll
+		}
+	}
 
 	runtime, _, client, err := cliutil.NewServiceRuntime(cmd, loadAWSConfig, newClient)
 	if err != nil {
@@ -158,29 +305,101 @@ func runImportParameters(cmd *cobra.Command, inputFile string) error {
 
 	rows := make([][]string, 0, len(parameters))
 	for _, parameter := range parameters {
-		action := "would-import"
+		labels := strings.Join(parameter.Labels, ",")
+		row := func(version, action string) []string {
+			return []string{parameter.Name, string(parameter.Type), fmt.Sprintf("%t", parameter.Overwrite), labels, version, action}
+		}
+
+		if parameter.ValueOmitted {
+			rows = append(rows, row("", cliutil.SkippedActionMessage("value not exported; re-export with --with-decryption")))
+			continue
+		}
+		if labelErr := validateParameterLabels(parameter.Labels); labelErr != nil {
+			rows = append(rows, row("", cliutil.FailedAction(labelErr)))
+			continue
+		}
+		// SSM rejects tags on a PutParameter that overwrites, since tags of
+		// an existing parameter are managed with AddTagsToResource.
+		if len(parameter.Tags) > 0 && parameter.Overwrite {
+			rows = append(rows, row("", cliutil.FailedActionMessage("Tags cannot be combined with Overwrite")))
+			continue
+		}
+		if parameter.KeyID != "" && parameter.Type != ssmtypes.ParameterTypeSecureString {
+			rows = append(rows, row("", cliutil.FailedActionMessage("KeyId requires Type SecureString")))
+			continue
+		}
 		if runtime.Options.DryRun {
-			rows = append(rows, []string{parameter.Name, string(parameter.Type), fmt.Sprintf("%t", parameter.Overwrite), action})
+			rows = append(rows, row("", "would-import"))
 			continue
 		}
 
-		_, putErr := client.PutParameter(cmd.Context(), &ssm.PutParameterInput{
+		input := &ssm.PutParameterInput{
 			Name:        cliutil.Ptr(parameter.Name),
 			Type:        parameter.Type,
 			Value:       cliutil.Ptr(parameter.Value),
 			Overwrite:   cliutil.Ptr(parameter.Overwrite),
 			Description: cliutil.Ptr(parameter.Description),
-		})
+			Tags:        parameter.Tags,
+		}
+		if parameter.KeyID != "" {
+			input.KeyId = cliutil.Ptr(parameter.KeyID)
+		}
+		out, putErr := client.PutParameter(cmd.Context(), input)
 		if putErr != nil {
-			action = cliutil.FailedActionMessage(awstbxaws.FormatUserError(putErr))
-		} else {
-			action = "imported"
+			rows = append(rows, row("", cliutil.FailedActionMessage(awstbxaws.FormatUserError(putErr))))
+			continue
 		}
 
-		rows = append(rows, []string{parameter.Name, string(parameter.Type), fmt.Sprintf("%t", parameter.Overwrite), action})
+		version := fmt.Sprintf("%d", out.Version)
+		if len(parameter.Labels) == 0 {
+			rows = append(rows, row(version, "imported"))
+			continue
+		}
+
+		labelOut, labelErr := client.LabelParameterVersion(cmd.Context(), &ssm.LabelParameterVersionInput{
+			Name:             cliutil.Ptr(parameter.Name),
+			Labels:           parameter.Labels,
+			ParameterVersion: cliutil.Ptr(out.Version),
+		})
+		switch {
+		case labelErr != nil:
+			rows = append(rows, row(version, cliutil.FailedActionMessage("label: "+awstbxaws.FormatUserError(labelErr))))
+		case len(labelOut.InvalidLabels) > 0:
+			rows = append(rows, row(version, cliutil.FailedActionMessage("invalid labels: "+strings.Join(labelOut.InvalidLabels, ","))))
+		default:
+			rows = append(rows, row(version, "imported"))
+		}
 	}
 
-	return cliutil.WriteDataset(cmd, runtime, []string{"parameter_name", "type", "overwrite", "action"}, rows)
+	return cliutil.WriteDataset(cmd, runtime, []string{"parameter_name", "type", "overwrite", "labels", "version", "action"}, rows)
+}
+
+// validateParameterLabels applies the SSM label rules: at most 10 labels per
+// version, each up to 100 letters, digits, periods, hyphens or underscores,
+// not starting with a digit or with "aws"/"ssm".
+func validateParameterLabels(labels []string) error {
+	if len(labels) > 10 {
+		return fmt.Errorf("at most 10 labels per parameter version, got %d", len(labels))
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 100 {
+			return fmt.Errorf("invalid label %q: must be 1-100 characters", label)
+		}
+		lower := strings.ToLower(label)
+		if strings.HasPrefix(lower, "aws") || strings.HasPrefix(lower, "ssm") {
+			return fmt.Errorf("invalid label %q: cannot start with aws or ssm", label)
+		}
+		if label[0] >= '0' && label[0] <= '9' {
+			return fmt.Errorf("invalid label %q: cannot start with a number", label)
+		}
+		for _, r := range label {
+			isAllowed := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_'
+			if !isAllowed {
+				return fmt.Errorf("invalid label %q: only letters, numbers, periods, hyphens and underscores are allowed", label)
+			}
+		}
+	}
+	return nil
 }
 
 func readParameterNamesFile(path string) ([]string, error) {
@@ -242,6 +461,11 @@ func readImportParametersFile(path string) ([]importParameter, error) {
 			return nil, fmt.Errorf("input file entry %d: %w", i+1, err)
 		}
 
+		tags, err := parseParameterTags(record.Tags, record.TagsLower)
+		if err != nil {
+			return nil, fmt.Errorf("input file entry %d: %w", i+1, err)
+		}
+
 		overwrite := false
 		if record.Overwrite != nil {
 			overwrite = *record.Overwrite
@@ -251,11 +475,15 @@ func readImportParametersFile(path string) ([]importParameter, error) {
 		}
 
 		parameters = append(parameters, importParameter{
-			Name:        name,
-			Type:        typ,
-			Value:       firstNonEmpty(record.Value, record.ValueLower),
-			Overwrite:   overwrite,
-			Description: firstNonEmpty(record.Description, record.DescriptionLo),
+			Name:         name,
+			Type:         typ,
+			Value:        firstNonEmpty(record.Value, record.ValueLower),
+			Overwrite:    overwrite,
+			Description:  firstNonEmpty(record.Description, record.DescriptionLo),
+			Labels:       append(record.Labels, record.LabelsLower...),
+			Tags:         tags,
+			KeyID:        strings.TrimSpace(firstNonEmpty(record.KeyID, record.KeyIDLower)),
+			ValueOmitted: record.ValueOmitted,
 		})
 	}
 
@@ -281,6 +509,50 @@ func parseParameterRecords(data []byte) ([]parameterFileRecord, error) {
 	return nil, fmt.Errorf("parse input file: expected JSON array of parameter objects")
 }
 
+// parseParameterTags accepts tags either as a {"key": "value"} object or as a
+// list of {"Key": ..., "Value": ...} objects, and returns them sorted by key.
+func parseParameterTags(raws ...json.RawMessage) ([]ssmtypes.Tag, error) {
+	tags := make([]ssmtypes.Tag, 0)
+	for _, raw := range raws {
+		trimmed := strings.TrimSpace(string(raw))
+		if trimmed == "" || trimmed == "null" {
+			continue
+		}
+
+		var tagMap map[string]string
+		if err := json.Unmarshal(raw, &tagMap); err == nil {
+			for key, value := range tagMap {
+				tags = append(tags, ssmtypes.Tag{Key: cliutil.Ptr(key), Value: cliutil.Ptr(value)})
+			}
+			continue
+		}
+
+		var tagList []struct {
+			Key   string `json:"Key"`
+			Value string `json:"Value"`
+		}
+		if err := json.Unmarshal(raw, &tagList); err != nil {
+			return nil, fmt.Errorf("invalid Tags: expected an object or a list of Key/Value objects")
+		}
+		for _, tag := range tagList {
+			tags = append(tags, ssmtypes.Tag{Key: cliutil.Ptr(tag.Key), Value: cliutil.Ptr(tag.Value)})
+		}
+	}
+
+	for _, tag := range tags {
+		if strings.TrimSpace(cliutil.PointerToString(tag.Key)) == "" {
+			return nil, fmt.Errorf("invalid Tags: empty key")
+		}
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return cliutil.PointerToString(tags[i].Key) < cliutil.PointerToString(tags[j].Key)
+	})
+	return tags, nil
+}
+
 func parseParameterType(raw string) (ssmtypes.ParameterType, error) {
 	value := strings.TrimSpace(raw)
 	if value == "" {
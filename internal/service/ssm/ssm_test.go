@@ -3,7 +3,9 @@ package ssm
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,12 +14,19 @@ import (
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/aws/smithy-go"
+	"github.com/spf13/cobra"
 	"github.com/towardsthecloud/aws-toolbox/internal/cliutil"
 )
 
 type mockClient struct {
-	deleteParameterFn func(context.Context, *ssm.DeleteParameterInput, ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
-	putParameterFn    func(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	deleteParameterFn             func(context.Context, *ssm.DeleteParameterInput, ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error)
+	deleteParametersFn            func(context.Context, *ssm.DeleteParametersInput, ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error)
+	describeInstanceInformationFn func(context.Context, *ssm.DescribeInstanceInformationInput, ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error)
+	getParametersByPathFn         func(context.Context, *ssm.GetParametersByPathInput, ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
+	labelParameterVersionFn       func(context.Context, *ssm.LabelParameterVersionInput, ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error)
+	putParameterFn                func(context.Context, *ssm.PutParameterInput, ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
+	startSessionFn                func(context.Context, *ssm.StartSessionInput, ...func(*ssm.Options)) (*ssm.StartSessionOutput, error)
 }
 
 func (m *mockClient) DeleteParameter(ctx context.Context, in *ssm.DeleteParameterInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error) {
@@ -27,6 +36,34 @@ func (m *mockClient) DeleteParameter(ctx context.Context, in *ssm.DeleteParamete
 	return m.deleteParameterFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) DeleteParameters(ctx context.Context, in *ssm.DeleteParametersInput, optFns ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+	if m.deleteParametersFn == nil {
+		return nil, errors.New("DeleteParameters not mocked")
+	}
+	return m.deleteParametersFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) DescribeInstanceInformation(ctx context.Context, in *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+	if m.describeInstanceInformationFn == nil {
+		return nil, errors.New("DescribeInstanceInformation not mocked")
+	}
+	return m.describeInstanceInformationFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) GetParametersByPath(ctx context.Context, in *ssm.GetParametersByPathInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	if m.getParametersByPathFn == nil {
+		return nil, errors.New("GetParametersByPath not mocked")
+	}
+	return m.getParametersByPathFn(ctx, in, optFns...)
+}
+
+func (m *mockClient) LabelParameterVersion(ctx context.Context, in *ssm.LabelParameterVersionInput, optFns ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error) {
+	if m.labelParameterVersionFn == nil {
+		return nil, errors.New("LabelParameterVersion not mocked")
+	}
+	return m.labelParameterVersionFn(ctx, in, optFns...)
+}
+
 func (m *mockClient) PutParameter(ctx context.Context, in *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
 	if m.putParameterFn == nil {
 		return nil, errors.New("PutParameter not mocked")
@@ -34,6 +71,13 @@ func (m *mockClient) PutParameter(ctx context.Context, in *ssm.PutParameterInput
 	return m.putParameterFn(ctx, in, optFns...)
 }
 
+func (m *mockClient) StartSession(ctx context.Context, in *ssm.StartSessionInput, optFns ...func(*ssm.Options)) (*ssm.StartSessionOutput, error) {
+	if m.startSessionFn == nil {
+		return nil, errors.New("StartSession not mocked")
+	}
+	return m.startSessionFn(ctx, in, optFns...)
+}
+
 func withMockDeps(t *testing.T, loader func(string, string) (awssdk.Config, error), factory func(awssdk.Config) API) {
 	t.Helper()
 
@@ -107,6 +151,143 @@ func TestImportParametersReadsFromInputFile(t *testing.T) {
 	}
 }
 
+func TestImportParametersPassesTagsAndKeyID(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "params.json")
+	content := `{"Parameters":[
+  {"Name":"/service/list-tags","Type":"String","Value":"one","Tags":[{"Key":"team","Value":"core"}]},
+  {"Name":"/service/map-tags","Type":"String","Value":"two","tags":{"env":"prod","app":"api"}},
+  {"Name":"/service/secret","Type":"SecureString","Value":"three","KeyId":"alias/app"},
+  {"Name":"/service/overwrite-tags","Type":"String","Value":"four","Overwrite":true,"Tags":{"team":"core"}},
+  {"Name":"/service/plain-key","Type":"String","Value":"five","KeyId":"alias/app"}
+]}`
+	if err := os.WriteFile(inputPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write params file: %v", err)
+	}
+
+	inputs := make(map[string]*ssm.PutParameterInput)
+	client := &mockClient{
+		putParameterFn: func(_ context.Context, in *ssm.PutParameterInput, _ ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+			inputs[cliutil.PointerToString(in.Name)] = in
+			return &ssm.PutParameterOutput{Version: int64(1)}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ssm", "import-parameters", "--input-file", inputPath)
+	if err != nil {
+		t.Fatalf("execute ssm import-parameters: %v", err)
+	}
+
+	tagString := func(tags []ssmtypes.Tag) string {
+		pairs := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			pairs = append(pairs, cliutil.PointerToString(tag.Key)+"="+cliutil.PointerToString(tag.Value))
+		}
+		return strings.Join(pairs, ",")
+	}
+	if got := tagString(inputs["/service/list-tags"].Tags); got != "team=core" {
+		t.Fatalf("unexpected list tags: %s", got)
+	}
+	if got := tagString(inputs["/service/map-tags"].Tags); got != "app=api,env=prod" {
+		t.Fatalf("unexpected map tags: %s", got)
+	}
+	if got := cliutil.PointerToString(inputs["/service/secret"].KeyId); got != "alias/app" {
+		t.Fatalf("expected KeyId alias/app, got %q", got)
+	}
+	if inputs["/service/list-tags"].KeyId != nil {
+		t.Fatal("expected no KeyId when the record omits it")
+	}
+	if len(inputs) != 3 {
+		t.Fatalf("expected 3 put calls, got %d", len(inputs))
+	}
+	for _, expected := range []string{
+		"parameter_name=/service/overwrite-tags type=String overwrite=true labels= version= action=failed:Tags cannot be combined with Overwrite",
+		"parameter_name=/service/plain-key type=String overwrite=false labels= version= action=failed:KeyId requires Type SecureString",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected output to contain %q\n%s", expected, output)
+		}
+	}
+}
+
+func TestReadImportParametersFileRejectsInvalidTags(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(inputPath, []byte(`[{"Name":"/service/foo","Value":"one","Tags":"team=core"}]`), 0o600); err != nil {
+		t.Fatalf("write params file: %v", err)
+	}
+	if _, err := readImportParametersFile(inputPath); err == nil || !strings.Contains(err.Error(), "input file entry 1: invalid Tags") {
+		t.Fatalf("expected invalid Tags error, got %v", err)
+	}
+}
+
+func TestImportParametersOverwriteOverrides(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "params.json")
+	content := `[
+  {"Name":"/service/default","Value":"one"},
+  {"Name":"/service/false","Value":"two","Overwrite":false},
+  {"Name":"/service/true","Value":"three","Overwrite":true}
+]`
+	if err := os.WriteFile(inputPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write params file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		flag string
+		want map[string]bool
+	}{
+		{name: "per record", want: map[string]bool{"/service/default": false, "/service/false": false, "/service/true": true}},
+		{name: "overwrite all", flag: "--overwrite-all", want: map[string]bool{"/service/default": true, "/service/false": true, "/service/true": true}},
+		{name: "no overwrite", flag: "--no-overwrite", want: map[string]bool{"/service/default": false, "/service/false": false, "/service/true": false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]bool)
+			client := &mockClient{
+				putParameterFn: func(_ context.Context, in *ssm.PutParameterInput, _ ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+					got[cliutil.PointerToString(in.Name)] = awssdk.ToBool(in.Overwrite)
+					if !awssdk.ToBool(in.Overwrite) && cliutil.PointerToString(in.Name) == "/service/true" {
+						return nil, &smithy.GenericAPIError{Code: "ParameterAlreadyExists", Message: "The parameter already exists."}
+					}
+					return &ssm.PutParameterOutput{Version: int64(2)}, nil
+				},
+			}
+			withMockDeps(
+				t,
+				func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+				func(awssdk.Config) API { return client },
+			)
+
+			args := []string{"--output", "text", "ssm", "import-parameters", "--input-file", inputPath}
+			if tt.flag != "" {
+				args = append(args, tt.flag)
+			}
+			output, err := executeCommand(t, args...)
+			if err != nil {
+				t.Fatalf("execute ssm import-parameters: %v", err)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Fatalf("%s: expected Overwrite=%t, got %t", name, want, got[name])
+				}
+			}
+			alreadyExists := strings.Contains(output, "parameter_name=/service/true type=String overwrite=false labels= version= action=failed:The parameter already exists. (ParameterAlreadyExists)")
+			if alreadyExists != (tt.flag == "--no-overwrite") {
+				t.Fatalf("unexpected output:\n%s", output)
+			}
+		})
+	}
+
+	_, err := executeCommand(t, "ssm", "import-parameters", "--input-file", inputPath, "--overwrite-all", "--no-overwrite")
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+}
+
 func TestImportParametersRequiresInputFile(t *testing.T) {
 	output, err := executeCommand(t, "ssm", "import-parameters")
 	if err == nil {
@@ -161,15 +342,122 @@ func TestDeleteParametersRequiresInputFile(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for missing --input-file")
 	}
-	if !strings.Contains(err.Error(), "--input-file is required") {
+	if !strings.Contains(err.Error(), "exactly one of --input-file or --path is required") {
 		t.Fatalf("unexpected error: %v", err)
 	}
+
+	_, err = executeCommand(t, "ssm", "delete-parameters", "--input-file", "names.json", "--path", "/app")
+	if err == nil || !strings.Contains(err.Error(), "exactly one of --input-file or --path is required") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+
+	_, err = executeCommand(t, "ssm", "delete-parameters", "--input-file", "names.json", "--recursive")
+	if err == nil || !strings.Contains(err.Error(), "--recursive requires --path") {
+		t.Fatalf("expected --recursive error, got %v", err)
+	}
+}
+
+func TestDeleteParametersByPath(t *testing.T) {
+	deleted := make([]string, 0)
+	client := &mockClient{
+		getParametersByPathFn: func(_ context.Context, in *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+			if cliutil.PointerToString(in.Path) != "/app/legacy" || !awssdk.ToBool(in.Recursive) || awssdk.ToBool(in.WithDecryption) {
+				t.Fatalf("unexpected GetParametersByPath input: %#v", in)
+			}
+			if in.NextToken == nil {
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []ssmtypes.Parameter{{Name: cliutil.Ptr("/app/legacy/b")}, {Name: nil}},
+					NextToken:  cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &ssm.GetParametersByPathOutput{Parameters: []ssmtypes.Parameter{{Name: cliutil.Ptr("/app/legacy/nested/a")}, {Name: cliutil.Ptr("/app/legacy/b")}}}, nil
+		},
+		deleteParametersFn: func(_ context.Context, in *ssm.DeleteParametersInput, _ ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+			deleted = append(deleted, in.Names...)
+			return &ssm.DeleteParametersOutput{DeletedParameters: in.Names}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ssm", "delete-parameters", "--path", "/app/legacy", "--recursive")
+	if err != nil {
+		t.Fatalf("execute ssm delete-parameters --path: %v", err)
+	}
+	expected := "parameter_name=/app/legacy/b action=deleted\nparameter_name=/app/legacy/nested/a action=deleted"
+	if strings.TrimSpace(output) != expected {
+		t.Fatalf("unexpected output:\n%s", output)
+	}
+	if strings.Join(deleted, ",") != "/app/legacy/b,/app/legacy/nested/a" {
+		t.Fatalf("unexpected deleted parameters: %v", deleted)
+	}
 }
 
 // ---------------------------------------------------------------------------
 // delete-parameters: dry-run shows would-delete without calling API
 // ---------------------------------------------------------------------------
 
+func TestDeleteParametersBatchesNames(t *testing.T) {
+	names := make([]string, 0, 12)
+	for i := range 12 {
+		names = append(names, fmt.Sprintf("/app/p%02d", i))
+	}
+	inputPath := filepath.Join(t.TempDir(), "names.json")
+	data, _ := json.Marshal(names)
+	if err := os.WriteFile(inputPath, data, 0o600); err != nil {
+		t.Fatalf("write names file: %v", err)
+	}
+
+	batchSizes := make([]int, 0)
+	singleDeletes := make([]string, 0)
+	client := &mockClient{
+		deleteParametersFn: func(_ context.Context, in *ssm.DeleteParametersInput, _ ...func(*ssm.Options)) (*ssm.DeleteParametersOutput, error) {
+			batchSizes = append(batchSizes, len(in.Names))
+			if in.Names[0] == "/app/p10" {
+				return nil, errors.New("throttled")
+			}
+			return &ssm.DeleteParametersOutput{DeletedParameters: in.Names[1:], InvalidParameters: in.Names[:1]}, nil
+		},
+		deleteParameterFn: func(_ context.Context, in *ssm.DeleteParameterInput, _ ...func(*ssm.Options)) (*ssm.DeleteParameterOutput, error) {
+			singleDeletes = append(singleDeletes, cliutil.PointerToString(in.Name))
+			if cliutil.PointerToString(in.Name) == "/app/p11" {
+				return nil, errors.New("still throttled")
+			}
+			return &ssm.DeleteParameterOutput{}, nil
+		},
+	}
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "--no-confirm", "ssm", "delete-parameters", "--input-file", inputPath)
+	if err != nil {
+		t.Fatalf("execute ssm delete-parameters: %v", err)
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != 10 || batchSizes[1] != 2 {
+		t.Fatalf("expected batches of 10 and 2, got %v", batchSizes)
+	}
+	if strings.Join(singleDeletes, ",") != "/app/p10,/app/p11" {
+		t.Fatalf("expected single-delete fallback for the failed batch, got %v", singleDeletes)
+	}
+	for _, expected := range []string{
+		"parameter_name=/app/p00 action=failed:not-found",
+		"parameter_name=/app/p01 action=deleted",
+		"parameter_name=/app/p09 action=deleted",
+		"parameter_name=/app/p10 action=deleted",
+		"parameter_name=/app/p11 action=failed:still throttled",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected output to contain %q\n%s", expected, output)
+		}
+	}
+}
+
 func TestDeleteParametersDryRun(t *testing.T) {
 	inputPath := filepath.Join(t.TempDir(), "names.json")
 	content := `["/app/param-a", "/app/param-b"]`
@@ -1043,3 +1331,385 @@ func TestImportParametersEnvelopeLowercase(t *testing.T) {
 		t.Fatalf("expected SecureString, got %s", capturedType)
 	}
 }
+
+func exportFixture() []exportedParameter {
+	return []exportedParameter{
+		{Name: "/app/prod/db-host", Type: "String", Value: "db.internal"},
+		{Name: "/app/prod/greeting", Type: "String", Value: "hello world"},
+		{Name: "/app/prod/zones", Type: "StringList", Value: "a,b"},
+	}
+}
+
+func TestRenderEnv(t *testing.T) {
+	got, err := renderEnv(exportFixture())
+	if err != nil {
+		t.Fatalf("renderEnv: %v", err)
+	}
+	want := "DB_HOST=db.internal\nGREETING='hello world'\nZONES=a,b"
+	if got != want {
+		t.Fatalf("unexpected env output:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err = renderEnv([]exportedParameter{
+		{Name: "/app/home", Value: `$HOME\n`},
+		{Name: "/app/quote", Value: "it's"},
+	})
+	if err != nil {
+		t.Fatalf("renderEnv: %v", err)
+	}
+	want = `HOME='$HOME\n'` + "\n" + `QUOTE='it'\''s'`
+	if got != want {
+		t.Fatalf("unexpected escaped env output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderTFVars(t *testing.T) {
+	parameters := append(exportFixture(), exportedParameter{Name: "/app/prod/template", Type: "String", Value: "${var}"})
+	got, err := renderTFVars(parameters)
+	if err != nil {
+		t.Fatalf("renderTFVars: %v", err)
+	}
+	want := "db-host = \"db.internal\"\ngreeting = \"hello world\"\nzones = [\"a\", \"b\"]\ntemplate = \"$${var}\""
+	if got != want {
+		t.Fatalf("unexpected tfvars output:\n%s\nwant:\n%s", got, want)
+	}
+
+	for value, want := range map[string]string{
+		"line\nbreak": `"line\nbreak"`,
+		"bell\a":      `"bell\u0007"`,
+		`C:\dir "x"`:  `"C:\\dir \"x\""`,
+		"café %{if}":  `"café %%{if}"`,
+		`\${literal}`: `"\\$${literal}"`,
+	} {
+		if got := hclString(value); got != want {
+			t.Fatalf("hclString(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestRenderEnvKeyConflict(t *testing.T) {
+	_, err := renderEnv([]exportedParameter{
+		{Name: "/app/a/db-host", Value: "one"},
+		{Name: "/app/b/db_host", Value: "two"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "key conflict") {
+		t.Fatalf("expected key conflict error, got %v", err)
+	}
+}
+
+func TestExportParametersSkipsSecureStringWithoutDecryption(t *testing.T) {
+	client := &mockClient{
+		getParametersByPathFn: func(_ context.Context, in *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+			if awssdk.ToBool(in.WithDecryption) {
+				t.Fatal("expected WithDecryption=false")
+			}
+			return &ssm.GetParametersByPathOutput{Parameters: []ssmtypes.Parameter{
+				{Name: cliutil.Ptr("/app/prod/db-host"), Type: ssmtypes.ParameterTypeString, Value: cliutil.Ptr("db.internal")},
+				{Name: cliutil.Ptr("/app/prod/password"), Type: ssmtypes.ParameterTypeSecureString, Value: cliutil.Ptr("AQICAH...")},
+			}}, nil
+		},
+	}
+	withMockDeps(t, func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil }, func(awssdk.Config) API { return client })
+
+	output, err := executeCommand(t, "ssm", "export-parameters", "--path", "/app/prod", "--format", "env")
+	if err != nil {
+		t.Fatalf("execute export-parameters: %v", err)
+	}
+	if !strings.Contains(output, "DB_HOST=db.internal") {
+		t.Fatalf("expected DB_HOST in output: %s", output)
+	}
+	if strings.Contains(output, "PASSWORD=") || !strings.Contains(output, "warning: skipping SecureString /app/prod/password") {
+		t.Fatalf("SecureString should be skipped with a warning: %s", output)
+	}
+}
+
+func TestExportParametersRoundTripsThroughImportFile(t *testing.T) {
+	client := &mockClient{
+		getParametersByPathFn: func(_ context.Context, in *ssm.GetParametersByPathInput, _ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+			if !awssdk.ToBool(in.Recursive) {
+				t.Fatal("expected Recursive=true")
+			}
+			if in.NextToken == nil {
+				return &ssm.GetParametersByPathOutput{
+					Parameters: []ssmtypes.Parameter{{Name: cliutil.Ptr("/app/prod/zones"), Type: ssmtypes.ParameterTypeStringList, Value: cliutil.Ptr("a,b")}},
+					NextToken:  cliutil.Ptr("page-2"),
+				}, nil
+			}
+			return &ssm.GetParametersByPathOutput{Parameters: []ssmtypes.Parameter{
+				{Name: cliutil.Ptr("/app/prod/password"), Type: ssmtypes.ParameterTypeSecureString, Value: cliutil.Ptr("AQICAH...")},
+				{Name: cliutil.Ptr("/app/prod/db/host"), Type: ssmtypes.ParameterTypeString, Value: cliutil.Ptr("db.internal")},
+			}}, nil
+		},
+	}
+	withMockDeps(t, func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil }, func(awssdk.Config) API { return client })
+
+	outPath := filepath.Join(t.TempDir(), "params.json")
+	output, err := executeCommand(t, "ssm", "export-parameters", "--path", "/app/prod", "--recursive", "--envelope", "--out", outPath)
+	if err != nil {
+		t.Fatalf("execute export-parameters: %v", err)
+	}
+	if !strings.Contains(output, "warning: exporting SecureString /app/prod/password with an empty value") || !strings.Contains(output, "wrote 3 parameter(s) to "+outPath) {
+		t.Fatalf("unexpected output: %s", output)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "{\n  \"Parameters\": [") || strings.Contains(string(data), "AQICAH") {
+		t.Fatalf("unexpected export file:\n%s", data)
+	}
+	parameters, err := readImportParametersFile(outPath)
+	if err != nil {
+		t.Fatalf("read export as import file: %v", err)
+	}
+	want := []importParameter{
+		{Name: "/app/prod/db/host", Type: ssmtypes.ParameterTypeString, Value: "db.internal"},
+		{Name: "/app/prod/password", Type: ssmtypes.ParameterTypeSecureString, Value: "", ValueOmitted: true},
+		{Name: "/app/prod/zones", Type: ssmtypes.ParameterTypeStringList, Value: "a,b"},
+	}
+	if len(parameters) != len(want) {
+		t.Fatalf("unexpected round-trip records: %#v", parameters)
+	}
+	for i := range want {
+		if parameters[i].Name != want[i].Name || parameters[i].Type != want[i].Type || parameters[i].Value != want[i].Value || parameters[i].ValueOmitted != want[i].ValueOmitted {
+			t.Fatalf("record %d: got %#v, want %#v", i, parameters[i], want[i])
+		}
+	}
+
+	var put []string
+	client.putParameterFn = func(_ context.Context, in *ssm.PutParameterInput, _ ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+		put = append(put, cliutil.PointerToString(in.Name))
+		return &ssm.PutParameterOutput{Version: 1}, nil
+	}
+	output, err = executeCommand(t, "--output", "text", "--no-confirm", "ssm", "import-parameters", "--input-file", outPath)
+	if err != nil {
+		t.Fatalf("execute import-parameters: %v", err)
+	}
+	if !strings.Contains(output, "parameter_name=/app/prod/password type=SecureString overwrite=false labels= version= action=skipped:value not exported; re-export with --with-decryption") {
+		t.Fatalf("expected the omitted SecureString to be skipped: %s", output)
+	}
+	if strings.Join(put, ",") != "/app/prod/db/host,/app/prod/zones" {
+		t.Fatalf("expected only parameters with values to be written, got %v", put)
+	}
+
+	if _, err := executeCommand(t, "ssm", "export-parameters", "--path", "/app/prod", "--format", "env", "--envelope"); err == nil || !strings.Contains(err.Error(), "--envelope requires --format json") {
+		t.Fatalf("expected --envelope validation error, got %v", err)
+	}
+}
+
+func managedInstanceClient(t *testing.T, instances []ssmtypes.InstanceInformation, startCalls *int) *mockClient {
+	t.Helper()
+	return &mockClient{
+		describeInstanceInformationFn: func(_ context.Context, _ *ssm.DescribeInstanceInformationInput, _ ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+			return &ssm.DescribeInstanceInformationOutput{InstanceInformationList: instances}, nil
+		},
+		startSessionFn: func(_ context.Context, in *ssm.StartSessionInput, _ ...func(*ssm.Options)) (*ssm.StartSessionOutput, error) {
+			*startCalls++
+			return &ssm.StartSessionOutput{SessionId: cliutil.Ptr("sess-1"), StreamUrl: cliutil.Ptr("wss://stream"), TokenValue: cliutil.Ptr("token")}, nil
+		},
+	}
+}
+
+func TestStartSessionResolvesTagAndHandsOffToPlugin(t *testing.T) {
+	var filters []ssmtypes.InstanceInformationStringFilter
+	startCalls := 0
+	client := managedInstanceClient(t, []ssmtypes.InstanceInformation{{InstanceId: cliutil.Ptr("i-bastion"), PingStatus: ssmtypes.PingStatusOnline}}, &startCalls)
+	describe := client.describeInstanceInformationFn
+	client.describeInstanceInformationFn = func(ctx context.Context, in *ssm.DescribeInstanceInformationInput, optFns ...func(*ssm.Options)) (*ssm.DescribeInstanceInformationOutput, error) {
+		filters = in.Filters
+		return describe(ctx, in, optFns...)
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "eu-west-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	var pluginArgs []string
+	oldLookPath, oldRunPlugin := lookPath, runSessionPlugin
+	lookPath = func(string) (string, error) { return "/usr/local/bin/session-manager-plugin", nil }
+	runSessionPlugin = func(_ *cobra.Command, _ string, args []string) error {
+		pluginArgs = args
+		return nil
+	}
+	t.Cleanup(func() { lookPath, runSessionPlugin = oldLookPath, oldRunPlugin })
+
+	if _, err := executeCommand(t, "ssm", "start-session", "--tag", "Name=bastion"); err != nil {
+		t.Fatalf("execute start-session: %v", err)
+	}
+	if len(filters) != 1 || cliutil.PointerToString(filters[0].Key) != "tag:Name" || filters[0].Values[0] != "bastion" {
+		t.Fatalf("expected tag:Name filter, got %#v", filters)
+	}
+	if startCalls != 1 || len(pluginArgs) != 6 || !strings.Contains(pluginArgs[0], `"SessionId":"sess-1"`) || pluginArgs[1] != "eu-west-1" || pluginArgs[4] != `{"Target":"i-bastion"}` || pluginArgs[5] != "https://ssm.eu-west-1.amazonaws.com" {
+		t.Fatalf("unexpected plugin hand-off: calls=%d args=%v", startCalls, pluginArgs)
+	}
+}
+
+func TestSessionEndpointFollowsPartition(t *testing.T) {
+	for region, want := range map[string]string{
+		"eu-west-1":     "https://ssm.eu-west-1.amazonaws.com",
+		"cn-north-1":    "https://ssm.cn-north-1.amazonaws.com.cn",
+		"us-gov-west-1": "https://ssm.us-gov-west-1.amazonaws.com",
+	} {
+		if got := sessionEndpoint(context.Background(), awssdk.Config{Region: region}); got != want {
+			t.Fatalf("region %s: expected %s, got %s", region, want, got)
+		}
+	}
+	if got := sessionEndpoint(context.Background(), awssdk.Config{Region: "eu-west-1", BaseEndpoint: cliutil.Ptr("https://ssm.internal.example")}); got != "https://ssm.internal.example" {
+		t.Fatalf("expected custom endpoint, got %s", got)
+	}
+}
+
+func TestStartSessionDryRunAndValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		instances []ssmtypes.InstanceInformation
+		args      []string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "dry run reports target",
+			instances: []ssmtypes.InstanceInformation{{InstanceId: cliutil.Ptr("i-1"), PingStatus: ssmtypes.PingStatusOnline, PlatformType: ssmtypes.PlatformTypeLinux}},
+			args:      []string{"--output", "text", "--dry-run", "ssm", "start-session", "--target", "i-1"},
+			want:      "instance_id=i-1 computer_name= platform=Linux ping_status=Online region=us-east-1 action=would-start-session",
+		},
+		{
+			name:    "unmanaged instance",
+			args:    []string{"ssm", "start-session", "--target", "i-2"},
+			wantErr: "no SSM-managed instance matches i-2",
+		},
+		{
+			name:      "agent offline",
+			instances: []ssmtypes.InstanceInformation{{InstanceId: cliutil.Ptr("i-3"), PingStatus: ssmtypes.PingStatusConnectionLost}},
+			args:      []string{"ssm", "start-session", "--target", "i-3"},
+			wantErr:   "ping status ConnectionLost",
+		},
+		{
+			name:      "ambiguous tag",
+			instances: []ssmtypes.InstanceInformation{{InstanceId: cliutil.Ptr("i-4")}, {InstanceId: cliutil.Ptr("i-5")}},
+			args:      []string{"ssm", "start-session", "--tag", "Role=web"},
+			wantErr:   "matches 2 managed instances (i-4, i-5)",
+		},
+		{
+			name:    "missing target",
+			args:    []string{"ssm", "start-session"},
+			wantErr: "one of --target or --tag is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			startCalls := 0
+			client := managedInstanceClient(t, tc.instances, &startCalls)
+			withMockDeps(
+				t,
+				func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+				func(awssdk.Config) API { return client },
+			)
+
+			output, err := executeCommand(t, tc.args...)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected %q error, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if startCalls != 0 || !strings.Contains(output, tc.want) {
+				t.Fatalf("expected %q without starting a session, calls=%d output=%s", tc.want, startCalls, output)
+			}
+		})
+	}
+}
+
+func TestStartSessionPrintsParametersWithoutPlugin(t *testing.T) {
+	startCalls := 0
+	client := managedInstanceClient(t, []ssmtypes.InstanceInformation{{InstanceId: cliutil.Ptr("i-1"), PingStatus: ssmtypes.PingStatusOnline}}, &startCalls)
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	oldLookPath := lookPath
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { lookPath = oldLookPath })
+
+	output, err := executeCommand(t, "--output", "text", "ssm", "start-session", "--target", "i-1")
+	if err != nil {
+		t.Fatalf("execute start-session: %v", err)
+	}
+	if !strings.Contains(output, "session-manager-plugin not found") || !strings.Contains(output, "session_id=sess-1 stream_url=wss://stream token_value=token") {
+		t.Fatalf("expected connection parameters: %s", output)
+	}
+}
+
+func TestImportParametersAppliesLabelsToCreatedVersion(t *testing.T) {
+	inputPath := filepath.Join(t.TempDir(), "params.json")
+	content := `[
+  {"Name":"/service/foo","Value":"one","Overwrite":true,"Labels":["prod","latest-good"]},
+  {"Name":"/service/bad","Value":"two","Labels":["aws-reserved"]},
+  {"Name":"/service/plain","Value":"three"}
+]`
+	if err := os.WriteFile(inputPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("write params file: %v", err)
+	}
+
+	var labelCalls []*ssm.LabelParameterVersionInput
+	client := &mockClient{
+		putParameterFn: func(_ context.Context, in *ssm.PutParameterInput, _ ...func(*ssm.Options)) (*ssm.PutParameterOutput, error) {
+			if cliutil.PointerToString(in.Name) == "/service/bad" {
+				t.Fatal("parameters with invalid labels should not be imported")
+			}
+			return &ssm.PutParameterOutput{Version: int64(7)}, nil
+		},
+		labelParameterVersionFn: func(_ context.Context, in *ssm.LabelParameterVersionInput, _ ...func(*ssm.Options)) (*ssm.LabelParameterVersionOutput, error) {
+			labelCalls = append(labelCalls, in)
+			return &ssm.LabelParameterVersionOutput{ParameterVersion: 7}, nil
+		},
+	}
+
+	withMockDeps(
+		t,
+		func(_, _ string) (awssdk.Config, error) { return awssdk.Config{Region: "us-east-1"}, nil },
+		func(awssdk.Config) API { return client },
+	)
+
+	output, err := executeCommand(t, "--output", "text", "ssm", "import-parameters", "--input-file", inputPath)
+	if err != nil {
+		t.Fatalf("execute ssm import-parameters: %v", err)
+	}
+
+	if len(labelCalls) != 1 {
+		t.Fatalf("expected 1 LabelParameterVersion call, got %d", len(labelCalls))
+	}
+	call := labelCalls[0]
+	if cliutil.PointerToString(call.Name) != "/service/foo" || call.ParameterVersion == nil || *call.ParameterVersion != 7 || strings.Join(call.Labels, ",") != "prod,latest-good" {
+		t.Fatalf("unexpected label call: %+v", call)
+	}
+	for _, expected := range []string{
+		"parameter_name=/service/foo type=String overwrite=true labels=prod,latest-good version=7 action=imported",
+		`parameter_name=/service/bad type=String overwrite=false labels=aws-reserved version= action=failed:invalid label "aws-reserved"`,
+		"parameter_name=/service/plain type=String overwrite=false labels= version=7 action=imported",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected %q in output: %s", expected, output)
+		}
+	}
+}
+
+func TestValidateParameterLabels(t *testing.T) {
+	if err := validateParameterLabels([]string{"prod", "release_1.2-rc"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, labels := range [][]string{{"1st"}, {"SSM-x"}, {"has space"}, {""}, {strings.Repeat("a", 101)}, make([]string, 11)} {
+		if err := validateParameterLabels(labels); err == nil {
+			t.Fatalf("expected %q to be rejected", labels)
+		}
+	}
+}